@@ -26,6 +26,25 @@ const resolveTaskIDFromArgs = (args: unknown[]): string => {
     return BROWSER_MOCK_TASK_ID;
 };
 
+const createBrowserMockPhaseEstimate = (args: unknown[], phase: string) => ({
+    task_id: resolveTaskIDFromArgs(args),
+    phase,
+    provider: '',
+    model: '',
+    bulk_strategy: 'sync',
+    tokenizer: '',
+    requests: 0,
+    input_tokens: 0,
+    output_tokens: 0,
+    concurrency: 1,
+    estimated_seconds: 0,
+    rate_limited: false,
+    wall_clock_upper_bound: false,
+    cost_usd: 0,
+    priced: false,
+    provider_costs: [],
+});
+
 const toInt = (value: unknown, fallback: number): number => {
     if (typeof value === 'number' && Number.isFinite(value)) {
         return Math.floor(value);
@@ -129,8 +148,23 @@ const CONTROLLER_FALLBACKS: Record<string, ControllerMap> = {
     TaskController: {
         CancelTask: async () => undefined,
         DeleteTask: async () => undefined,
+        EstimateTranslationFlowMainTranslation: async (...args) => createBrowserMockPhaseEstimate(args, 'main_translation'),
+        EstimateTranslationFlowPersona: async (...args) => createBrowserMockPhaseEstimate(args, 'persona'),
+        EstimateTranslationFlowSummary: async (...args) => createBrowserMockPhaseEstimate(args, 'summary'),
+        EstimateTranslationFlowTerminology: async (...args) => createBrowserMockPhaseEstimate(args, 'terminology'),
         GetActiveTasks: async () => createBrowserMockTaskList(),
         GetAllTasks: async () => createBrowserMockTaskList(),
+        GetResponseCacheStats: async () => [],
+        GetTaskUsage: async (...args) => ({
+            task_id: resolveTaskIDFromArgs(args),
+            totals: [],
+            requests: 0,
+            prompt_tokens: 0,
+            completion_tokens: 0,
+            total_tokens: 0,
+            cost_usd: 0,
+            unpriced: false,
+        }),
         GetTranslationFlowTerminology: async (...args) => ({
             ...EMPTY_TERMINOLOGY_RESULT(),
             task_id: resolveTaskIDFromArgs(args),
@@ -191,7 +225,24 @@ const CONTROLLER_FALLBACKS: Record<string, ControllerMap> = {
                     files: [createBrowserMockLoadedFile()],
                 }
                 : {task_id: '', files: []},
+        ReloadTranslationFlowFiles: async (...args) =>
+            isTranslationFlowRoute()
+                ? {
+                    task_id: resolveTaskIDFromArgs(args),
+                    files: [createBrowserMockLoadedFile()],
+                    diffs: [],
+                }
+                : {task_id: '', files: [], diffs: []},
         ResumeTask: async () => undefined,
+        RunTranslationFlowSummary: async (...args) => ({
+            task_id: resolveTaskIDFromArgs(args),
+            status: isTranslationFlowRoute() ? 'completed' : 'empty',
+            dialogue_groups: 0,
+            quests: 0,
+            request_count: 0,
+            saved_count: 0,
+            failed_count: 0,
+        }),
         RunTranslationFlowTerminology: async (...args) => ({
             task_id: resolveTaskIDFromArgs(args),
             status: isTranslationFlowRoute() ? 'completed' : 'pending',
@@ -203,11 +254,13 @@ const CONTROLLER_FALLBACKS: Record<string, ControllerMap> = {
     },
     ModelCatalogController: {
         ListModels: async () => [],
+        ProviderHealth: async () => ({provider: '', state: 'closed', available: true}),
         SetContext: async () => undefined,
     },
     FileDialogController: {
         SelectFiles: async () => [],
         SelectJSONFile: async () => '',
+        SelectTranslationInputDirectory: async () => '',
         SelectTranslationInputFiles: async () => [],
         SetContext: async () => undefined,
     },
@@ -237,6 +290,11 @@ const CONTROLLER_FALLBACKS: Record<string, ControllerMap> = {
         DictUpdateEntry: async () => undefined,
         SetContext: async () => undefined,
     },
+    TranslationMemoryController: {
+        SetContext: async () => undefined,
+        TMLookup: async () => [],
+        TMStartImport: async () => '',
+    },
 };
 
 const UNKNOWN_CONTROLLER_METHOD = async (): Promise<unknown> => undefined;
@@ -79,6 +79,7 @@ const ModelSettings: React.FC<Props> = ({ title = 'モデル設定', value, onCh
         catalogError,
         catalogLoading,
         handleProviderChange,
+        providerWarning,
         selectableModelOptions,
         selectedModelCapability,
         selectedModelValue,
@@ -173,6 +174,9 @@ const ModelSettings: React.FC<Props> = ({ title = 'モデル設定', value, onCh
                             <span className="text-xs text-base-content/50 mt-1 block">
                                 {catalogLoading ? 'モデル一覧を取得中...' : catalogError || ''}
                             </span>
+                            {providerWarning && (
+                                <span className="text-xs text-warning mt-1 block">{providerWarning}</span>
+                            )}
                         </div>
 
                         <div className="flex flex-col gap-1">
@@ -227,7 +231,7 @@ const ModelSettings: React.FC<Props> = ({ title = 'モデル設定', value, onCh
                             <input
                                 type="text"
                                 className="input input-bordered input-sm w-full font-mono"
-                                placeholder="http://localhost:1234"
+                                placeholder={value.provider === 'openai_compatible' ? 'http://localhost:8000/v1' : 'http://localhost:1234'}
                                 value={draftEndpoint}
                                 onChange={(e) => {
                                     setDraftEndpoint(e.target.value);
@@ -0,0 +1,127 @@
+import React from 'react';
+import type {
+    TranslationMemoryActions,
+    TranslationMemoryState,
+} from '../../hooks/features/translationMemory/types';
+
+interface TranslationMemoryCardProps {
+    state: TranslationMemoryState;
+    actions: TranslationMemoryActions;
+}
+
+/**
+ * TranslationMemoryCard: xTranslator XML を翻訳メモリへ取り込み、原文で確定訳を検索するカード。
+ * 辞書と異なり、台詞や説明文など文レベルのレコードだけが取り込まれる。
+ */
+const TranslationMemoryCard: React.FC<TranslationMemoryCardProps> = ({ state, actions }) => {
+    const handleLookupSubmit = (e: React.FormEvent) => {
+        e.preventDefault();
+        void actions.handleLookup();
+    };
+
+    return (
+        <div className="card bg-base-100 shadow-sm border border-base-200">
+            <div className="card-body py-3 px-4">
+                <h2 className="card-title text-base">翻訳メモリ (xTranslator形式)</h2>
+                <div className="flex flex-col gap-3">
+                    <div className="flex items-center gap-3 flex-wrap">
+                        <span className="text-sm text-base-content/70">台詞・説明文などの確定訳を翻訳メモリに取り込みます。</span>
+                        <button
+                            className="btn btn-outline btn-secondary btn-sm w-fit"
+                            onClick={() => void actions.handleSelectFilesClick()}
+                            disabled={state.isImporting}
+                        >
+                            ファイルを選択
+                        </button>
+                        <button
+                            className="btn btn-secondary btn-sm"
+                            disabled={state.selectedFiles.length === 0 || state.isImporting}
+                            onClick={() => void actions.handleImport()}
+                        >
+                            {state.isImporting ? 'インポート実行中...' : '翻訳メモリに取り込む'}
+                        </button>
+                        {!state.isImporting && state.lastResultMessage && (
+                            <span className="text-xs text-base-content/70">{state.lastResultMessage}</span>
+                        )}
+                    </div>
+
+                    {state.selectedFiles.length > 0 && (
+                        <div className="flex flex-col gap-1">
+                            <span className="text-xs font-bold text-base-content/70">選択ファイル ({state.selectedFiles.length}件):</span>
+                            <div className="flex flex-wrap gap-2 max-h-24 overflow-y-auto p-2 bg-base-200/50 rounded-lg border border-base-300">
+                                {state.selectedFiles.map((filePath) => {
+                                    const fileName = filePath.split(/[\\/]/).pop() || filePath;
+                                    return (
+                                        <div key={filePath} className="badge badge-secondary badge-outline gap-1 py-3 px-2">
+                                            <span className="truncate max-w-[200px] font-mono text-xs" title={filePath}>{fileName}</span>
+                                            <button
+                                                className="btn btn-ghost btn-xs btn-circle ml-1 opacity-70 hover:opacity-100"
+                                                disabled={state.isImporting}
+                                                onClick={() => actions.removeSelectedFile(filePath)}
+                                                title="リストから外す"
+                                            >✕</button>
+                                        </div>
+                                    );
+                                })}
+                            </div>
+                        </div>
+                    )}
+
+                    {Object.keys(state.importMessages).length > 0 && (
+                        <div className="flex flex-col gap-2">
+                            <span className="text-xs font-bold block border-b border-base-200 pb-1">インポート進捗</span>
+                            {Object.entries(state.importMessages).map(([corrId, msg]) => (
+                                <div key={corrId} className="flex flex-col gap-1">
+                                    <span className="truncate max-w-full text-xs text-secondary" title={msg}>{msg}</span>
+                                    <progress className="progress progress-secondary w-full"></progress>
+                                </div>
+                            ))}
+                        </div>
+                    )}
+
+                    <form onSubmit={handleLookupSubmit} className="flex items-center gap-2">
+                        <input
+                            type="text"
+                            className="input input-bordered input-sm flex-1"
+                            placeholder="原文 (英語) を入力して翻訳メモリを検索"
+                            value={state.lookupQuery}
+                            onChange={(e) => actions.setLookupQuery(e.target.value)}
+                        />
+                        <button type="submit" className="btn btn-outline btn-sm" disabled={state.isLookingUp}>
+                            {state.isLookingUp ? '検索中...' : '検索'}
+                        </button>
+                    </form>
+
+                    {state.matches.length > 0 && (
+                        <div className="overflow-x-auto max-h-48 overflow-y-auto">
+                            <table className="table table-xs">
+                                <thead>
+                                    <tr>
+                                        <th>一致率</th>
+                                        <th>原文 (英語)</th>
+                                        <th>訳文 (日本語)</th>
+                                        <th>Record Type</th>
+                                        <th>取り込み元</th>
+                                    </tr>
+                                </thead>
+                                <tbody>
+                                    {state.matches.map((match) => (
+                                        <tr key={match.id}>
+                                            <td className="font-mono">{Math.round(match.score * 100)}%</td>
+                                            <td>{match.sourceText}</td>
+                                            <td>{match.destText}</td>
+                                            <td className="font-mono">{match.recordType}</td>
+                                            <td className="font-mono" title={match.originRef}>{match.origin}</td>
+                                        </tr>
+                                    ))}
+                                </tbody>
+                            </table>
+                        </div>
+                    )}
+                </div>
+            </div>
+        </div>
+    );
+};
+
+export default TranslationMemoryCard;
@@ -8,9 +8,12 @@ interface LoadPanelProps {
     loadedFiles: LoadedTranslationFile[];
     isLoading: boolean;
     errorMessage: string;
+    diffMessages: string[];
     onSelectFiles: () => Promise<void>;
+    onSelectFolder: () => Promise<void>;
     onRemoveFile: (pathToRemove: string) => void;
     onLoadSelectedFiles: () => Promise<void>;
+    onLoadUpdatedFiles: () => Promise<void>;
     onReloadFiles: () => Promise<void>;
     onPreviewPageChange: (fileId: number, page: number) => Promise<void>;
     onNext: () => void;
@@ -52,9 +55,12 @@ export function LoadPanel({
     loadedFiles,
     isLoading,
     errorMessage,
+    diffMessages,
     onSelectFiles,
+    onSelectFolder,
     onRemoveFile,
     onLoadSelectedFiles,
+    onLoadUpdatedFiles,
     onReloadFiles,
     onPreviewPageChange,
     onNext,
@@ -64,7 +70,7 @@ export function LoadPanel({
     return (
         <div className={`tab-content-panel flex-col gap-4 ${isActive ? 'flex' : 'hidden'}`}>
             <div className="alert alert-info shadow-sm shrink-0">
-                <span>抽出済み JSON を複数選択し、artifact に保存した翻訳対象をファイル単位で確認します。</span>
+                <span>抽出済み JSON、プラグイン (ESP/ESM/ESL)、MCM 翻訳ファイル (TXT)、または BSA を含む MOD フォルダを選択し、artifact に保存した翻訳対象をファイル単位で確認します。</span>
             </div>
 
             <div className="card bg-base-100 border border-base-200 shadow-sm shrink-0">
@@ -74,6 +80,9 @@ export function LoadPanel({
                         <button type="button" className="btn btn-outline btn-primary btn-sm" onClick={() => void onSelectFiles()} disabled={isLoading}>
                             ファイルを選択
                         </button>
+                        <button type="button" className="btn btn-outline btn-primary btn-sm" onClick={() => void onSelectFolder()} disabled={isLoading}>
+                            MOD フォルダを選択
+                        </button>
                         <button
                             type="button"
                             className="btn btn-primary btn-sm"
@@ -82,6 +91,15 @@ export function LoadPanel({
                         >
                             {isLoading ? 'ロード中...' : 'ロード実行'}
                         </button>
+                        <button
+                            type="button"
+                            className="btn btn-outline btn-secondary btn-sm"
+                            onClick={() => void onLoadUpdatedFiles()}
+                            disabled={isLoading}
+                            title="同名のロード済みファイルを置き換え、変更のない行の訳文を引き継ぎます"
+                        >
+                            更新版を差分ロード
+                        </button>
                         <button type="button" className="btn btn-ghost btn-sm" onClick={() => void onReloadFiles()} disabled={isLoading}>
                             再読込
                         </button>
@@ -113,6 +131,14 @@ export function LoadPanel({
                         </div>
                     </div>
 
+                    {diffMessages.length > 0 && (
+                        <ul className="text-sm text-base-content/70 list-disc pl-5">
+                            {diffMessages.map((message) => (
+                                <li key={message}>{message}</li>
+                            ))}
+                        </ul>
+                    )}
+
                     {errorMessage !== '' && <p className="text-error text-sm">{errorMessage}</p>}
                 </div>
             </div>
@@ -643,6 +643,15 @@ export async function installWailsMocks(page: Page): Promise<void> {
           files: loadedFiles.map((file) => buildLoadedTranslationFile(file, 1, 50)),
         };
       },
+      ReloadTranslationFlowFiles: async (taskID: string, filePaths: string[]) => {
+        const loadedFiles = buildMainTranslationScenarioFiles(filePaths);
+        translationFilesByTask.set(taskID, loadedFiles);
+        return {
+          task_id: taskID,
+          files: loadedFiles.map((file) => buildLoadedTranslationFile(file, 1, 50)),
+          diffs: [],
+        };
+      },
       RunTranslationFlowTerminology: async (taskID: string, request: Record<string, unknown>) => {
         const model = String(request.model ?? '').trim();
         if (model.length === 0) {
@@ -782,6 +791,11 @@ export async function installWailsMocks(page: Page): Promise<void> {
         }
         return mockFixture.masterPersona.modelCatalogByProvider[provider] ?? [];
       },
+      ProviderHealth: async (request: Record<string, unknown>) => ({
+        provider: String(request.provider ?? ''),
+        state: 'closed',
+        available: true,
+      }),
       SetContext: async () => undefined,
     };
 
@@ -824,9 +838,16 @@ export async function installWailsMocks(page: Page): Promise<void> {
       SetContext: async () => undefined,
     };
 
+    const translationMemoryController = {
+      TMLookup: async () => [],
+      TMStartImport: async () => 'tm-import-e2e',
+      SetContext: async () => undefined,
+    };
+
     const fileDialogController = {
       SelectFiles: async () => [],
       SelectJSONFile: async () => mockFixture.masterPersona.selectedJsonPath,
+      SelectTranslationInputDirectory: async () => '',
       SelectTranslationInputFiles: async () => [...mockFixture.translationFlow.selectedFiles],
       SetContext: async () => undefined,
     };
@@ -869,6 +890,10 @@ export async function installWailsMocks(page: Page): Promise<void> {
       ...dictionaryController,
       ...(win.go.controller.DictionaryController as Record<string, unknown> | undefined),
     };
+    win.go.controller.TranslationMemoryController = {
+      ...translationMemoryController,
+      ...(win.go.controller.TranslationMemoryController as Record<string, unknown> | undefined),
+    };
     win.go.controller.FileDialogController = {
       ...fileDialogController,
       ...(win.go.controller.FileDialogController as Record<string, unknown> | undefined),
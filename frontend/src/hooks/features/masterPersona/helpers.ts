@@ -27,7 +27,7 @@ export const formatUpdatedAt = (raw: string): string => {
 };
 
 export const normalizeProvider = (value: string | undefined): MasterPersonaLLMConfig['provider'] => {
-    if (value === 'lmstudio' || value === 'gemini' || value === 'xai') {
+    if (value === 'lmstudio' || value === 'gemini' || value === 'xai' || value === 'openai_compatible') {
         return value;
     }
     return DEFAULT_MASTER_PERSONA_LLM_CONFIG.provider;
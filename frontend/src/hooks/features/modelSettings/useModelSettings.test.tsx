@@ -7,6 +7,7 @@ import {modelcatalog} from '../../../wailsjs/go/models';
 
 vi.mock('../../../wailsjs/go/controller/ModelCatalogController', () => ({
     ListModels: vi.fn(),
+    ProviderHealth: vi.fn(),
 }));
 
 const createConfig = (overrides: Partial<MasterPersonaLLMConfig> = {}): MasterPersonaLLMConfig => ({
@@ -100,4 +101,31 @@ describe('useModelSettings', () => {
             }));
         });
     });
+
+    it('回路が開いている provider はタスク開始前に警告を表示する', async () => {
+        vi.mocked(ModelCatalogBindings.ListModels).mockResolvedValue([
+            createCatalogModel(),
+        ]);
+        vi.mocked(ModelCatalogBindings.ProviderHealth).mockResolvedValue({
+            provider: 'gemini',
+            state: 'open',
+            available: false,
+            message: 'Gemini は現在利用できません（連続 5 件失敗）',
+        });
+
+        const {result} = renderHook(() => useModelSettings({
+            value: createConfig(),
+            onChange: vi.fn(),
+            enabled: true,
+            namespace: 'translation_flow.translation',
+        }));
+
+        await waitFor(() => {
+            expect(result.current.providerWarning).toBe('Gemini は現在利用できません（連続 5 件失敗）');
+        });
+        expect(ModelCatalogBindings.ProviderHealth).toHaveBeenCalledWith({
+            namespace: 'translation_flow.translation',
+            provider: 'gemini',
+        });
+    });
 });
@@ -1,6 +1,6 @@
 import {useEffect, useMemo, useState} from 'react';
 import {z} from 'zod';
-import {ListModels} from '../../../wailsjs/go/controller/ModelCatalogController';
+import {ListModels, ProviderHealth} from '../../../wailsjs/go/controller/ModelCatalogController';
 import {
     DEFAULT_MASTER_PERSONA_LLM_CONFIG,
     type MasterPersonaExecutionProfile,
@@ -10,7 +10,9 @@ import {
     type MasterPersonaProvider,
 } from '../../../types/masterPersona';
 
-export const MASTER_PERSONA_PROVIDERS = ['lmstudio', 'gemini', 'xai'] as const;
+export const MASTER_PERSONA_PROVIDERS = ['lmstudio', 'gemini', 'xai', 'openai_compatible'] as const;
+
+const OPENAI_COMPATIBLE_DEFAULT_ENDPOINT = 'http://localhost:8000/v1';
 
 const FALLBACK_MODEL_OPTIONS: Record<MasterPersonaProvider, MasterPersonaModelOption[]> = {
     lmstudio: [{ id: '(model-unavailable)', label: '(モデルを取得できませんでした)', capability: { supportsBatch: false } }],
@@ -25,12 +27,14 @@ const FALLBACK_MODEL_OPTIONS: Record<MasterPersonaProvider, MasterPersonaModelOp
         { id: 'grok-3-mini', label: 'grok-3-mini', capability: { supportsBatch: true } },
         { id: 'grok-2', label: 'grok-2', capability: { supportsBatch: true } },
     ],
+    openai_compatible: [{ id: '(model-unavailable)', label: '(モデルを取得できませんでした)', capability: { supportsBatch: false } }],
 };
 
 export const PROVIDER_LABELS: Record<MasterPersonaProvider, string> = {
     lmstudio: 'Local LLM (LM Studio)',
     gemini: 'Google Gemini',
     xai: 'xAI (Grok)',
+    openai_compatible: 'OpenAI 互換 (vLLM / llama.cpp / Ollama / OpenRouter)',
 };
 
 const providerSchema = z.enum(MASTER_PERSONA_PROVIDERS);
@@ -50,6 +54,11 @@ const modelOptionSchema = z.object({
 
 const modelOptionListSchema = z.array(modelOptionSchema);
 
+const providerStatusSchema = z.object({
+    available: z.boolean().catch(true),
+    message: z.string().optional().catch(''),
+});
+
 const DEFAULT_MODEL_CAPABILITY: MasterPersonaModelCapability = { supportsBatch: false };
 const DEFAULT_CLOUD_MODEL_CAPABILITY: MasterPersonaModelCapability = { supportsBatch: true };
 const MODEL_UNAVAILABLE_ID = '(model-unavailable)';
@@ -83,12 +92,29 @@ interface UseModelSettingsArgs {
 }
 
 const normalizeProvider = (provider: MasterPersonaLLMConfig['provider']): MasterPersonaProvider => {
-    if (provider === 'lmstudio' || provider === 'gemini' || provider === 'xai') {
+    if (provider === 'lmstudio' || provider === 'gemini' || provider === 'xai' || provider === 'openai_compatible') {
         return provider;
     }
     return DEFAULT_MASTER_PERSONA_LLM_CONFIG.provider;
 };
 
+const resolveProviderEndpoint = (
+    nextProvider: MasterPersonaProvider,
+    currentProvider: MasterPersonaProvider,
+    currentEndpoint: string,
+): string => {
+    if (nextProvider === 'lmstudio') {
+        return currentEndpoint || 'http://localhost:1234';
+    }
+    if (nextProvider === 'openai_compatible') {
+        // LM Studio の既定値はベース URL 形式が異なるため引き継がない。
+        if (currentProvider === 'lmstudio' || currentEndpoint.trim() === '') {
+            return OPENAI_COMPATIBLE_DEFAULT_ENDPOINT;
+        }
+    }
+    return currentEndpoint;
+};
+
 const normalizeExecutionProfiles = (capability: MasterPersonaModelCapability): MasterPersonaExecutionProfile[] => {
     if (capability.supportsBatch) {
         return ['sync', 'batch'];
@@ -119,7 +145,7 @@ const resolveModelCapability = (
     if (byID) {
         return byID.capability;
     }
-    if (provider === 'lmstudio') {
+    if (provider === 'lmstudio' || provider === 'openai_compatible') {
         return DEFAULT_MODEL_CAPABILITY;
     }
     // Cloud provider capability may be unknown when catalog fetch fails.
@@ -135,6 +161,7 @@ export function useModelSettings({ value, onChange, enabled, namespace }: UseMod
     const [catalogError, setCatalogError] = useState<string>('');
     const [lastFetchedProvider, setLastFetchedProvider] = useState<MasterPersonaProvider | null>(null);
     const [lastFetchedApiKey, setLastFetchedApiKey] = useState<string>('');
+    const [providerWarning, setProviderWarning] = useState<string>('');
 
     const provider = normalizeProvider(value.provider);
     const endpoint = value.endpoint;
@@ -170,7 +197,7 @@ export function useModelSettings({ value, onChange, enabled, namespace }: UseMod
         return [{
             id: '(model-unavailable)',
             label: '(モデルを取得できませんでした)',
-            capability: provider === 'lmstudio' ? DEFAULT_MODEL_CAPABILITY : DEFAULT_CLOUD_MODEL_CAPABILITY,
+            capability: provider === 'lmstudio' || provider === 'openai_compatible' ? DEFAULT_MODEL_CAPABILITY : DEFAULT_CLOUD_MODEL_CAPABILITY,
         }];
     }, [currentModel, dynamicOptionsByProvider, modelOptions, provider]);
 
@@ -351,6 +378,32 @@ export function useModelSettings({ value, onChange, enabled, namespace }: UseMod
         lastFetchedApiKey,
     ]);
 
+    // 回路が開いている provider はタスク開始前に警告する。
+    useEffect(() => {
+        if (!enabled) {
+            return;
+        }
+
+        let alive = true;
+        void (async () => {
+            try {
+                const statusRaw: unknown = await ProviderHealth({ namespace, provider });
+                const status = providerStatusSchema.parse(statusRaw);
+                if (alive) {
+                    setProviderWarning(status.available ? '' : (status.message ?? ''));
+                }
+            } catch {
+                if (alive) {
+                    setProviderWarning('');
+                }
+            }
+        })();
+
+        return () => {
+            alive = false;
+        };
+    }, [enabled, namespace, provider]);
+
     const handleProviderChange = (rawProvider: string) => {
         const parsed = providerSchema.safeParse(rawProvider);
         if (!parsed.success) {
@@ -365,7 +418,7 @@ export function useModelSettings({ value, onChange, enabled, namespace }: UseMod
             ...value,
             provider: nextProvider,
             model: nextModel,
-            endpoint: nextProvider === 'lmstudio' ? value.endpoint || 'http://localhost:1234' : value.endpoint,
+            endpoint: resolveProviderEndpoint(nextProvider, provider, value.endpoint),
             bulkStrategy: nextCapability.supportsBatch ? value.bulkStrategy : 'sync',
         });
     };
@@ -375,6 +428,7 @@ export function useModelSettings({ value, onChange, enabled, namespace }: UseMod
         catalogError,
         catalogLoading,
         handleProviderChange,
+        providerWarning,
         selectableModelOptions,
         selectedModelCapability,
         selectedModelValue,
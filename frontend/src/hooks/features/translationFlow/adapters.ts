@@ -24,6 +24,7 @@ import type {
     WailsTerminologyTargetPreviewPage,
     WailsTerminologyTargetPreviewRow,
     WailsTranslationLoadedFile,
+    WailsTranslationFileDiff,
     WailsTranslationLoadResult,
     WailsTranslationPreviewPage,
     WailsTranslationPreviewRow,
@@ -362,6 +363,25 @@ export const mapLoadResult = (payload: unknown): {
     };
 };
 
+/**
+ * 差分ロード結果をファイルごとの表示用メッセージへ変換する。
+ */
+export const mapLoadDiffMessages = (payload: unknown): string[] => {
+    const resultPayload = (asRecord(payload) ?? {}) as WailsTranslationLoadResult;
+    const rawDiffs = Array.isArray(resultPayload.diffs) ? resultPayload.diffs : [];
+    return rawDiffs
+        .map((entry) => asRecord(entry))
+        .filter((entry): entry is Record<string, unknown> => entry !== null)
+        .map((entry) => {
+            const diff = entry as WailsTranslationFileDiff;
+            const name = pickString(diff.file_name) || pickString(diff.file_path);
+            if (diff.has_previous !== true) {
+                return `${name}: 前回ロードがないため新規ロードしました (${pickNumber(diff.added)} 件)`;
+            }
+            return `${name}: 追加 ${pickNumber(diff.added)} / 変更 ${pickNumber(diff.changed)} / 削除 ${pickNumber(diff.removed)} / 変更なし ${pickNumber(diff.unchanged)} (訳文引き継ぎ ${pickNumber(diff.carried_forward)} 件)`;
+        });
+};
+
 export const mapTerminologyPhaseResult = (payload: unknown): TerminologyPhaseSummary => {
     const resultPayload = (asRecord(payload) ?? {}) as WailsTerminologyPhaseResult;
     return {
@@ -328,6 +328,7 @@ interface TranslationFlowState {
     loadedFiles: LoadedTranslationFile[];
     isLoading: boolean;
     errorMessage: string;
+    loadDiffMessages: string[];
     terminologySummary: TerminologyPhaseSummary;
     terminologyStatusLabel: string;
     terminologyErrorMessage: string;
@@ -368,8 +369,10 @@ interface TranslationFlowState {
 interface TranslationFlowActions {
     handleTabChange: (index: number) => void;
     handleSelectFiles: () => Promise<void>;
+    handleSelectFolder: () => Promise<void>;
     handleRemoveFile: (pathToRemove: string) => void;
     handleLoadSelectedFiles: () => Promise<void>;
+    handleLoadUpdatedFiles: () => Promise<void>;
     handleReloadFiles: () => Promise<void>;
     handlePreviewPageChange: (fileId: number, page: number) => Promise<void>;
     handleAdvanceFromLoad: () => void;
@@ -412,6 +415,21 @@ export interface WailsTranslationLoadResult {
     task_id?: string;
     taskId?: string;
     files?: unknown[];
+    diffs?: unknown[];
+}
+
+/**
+ * 差分ロード時に 1 ファイル分の差分件数を表す Wails DTO。
+ */
+export interface WailsTranslationFileDiff {
+    file_name?: string;
+    file_path?: string;
+    has_previous?: boolean;
+    added?: number;
+    changed?: number;
+    removed?: number;
+    unchanged?: number;
+    carried_forward?: number;
 }
 
 /**
@@ -50,6 +50,7 @@ vi.mock('../../../wailsjs/go/controller/ConfigController', () => ({
 }));
 
 vi.mock('../../../wailsjs/go/controller/FileDialogController', () => ({
+    SelectTranslationInputDirectory: vi.fn(),
     SelectTranslationInputFiles: vi.fn(),
 }));
 
@@ -60,6 +61,7 @@ vi.mock('../../../wailsjs/go/controller/TaskController', () => ({
     ListTranslationFlowTerminologyTargets: vi.fn(),
     ListTranslationFlowPreviewRows: vi.fn(),
     LoadTranslationFlowFiles: vi.fn(),
+    ReloadTranslationFlowFiles: vi.fn(),
     ResumeTask: vi.fn(),
     RunTranslationFlowTerminology: vi.fn(),
 }));
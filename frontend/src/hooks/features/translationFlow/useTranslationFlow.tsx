@@ -1,7 +1,7 @@
 import {useCallback, useEffect, useMemo, useRef, useState} from 'react';
 import {useLocation} from 'react-router-dom';
 import {ConfigGetAll, ConfigSet} from '../../../wailsjs/go/controller/ConfigController';
-import {SelectTranslationInputFiles} from '../../../wailsjs/go/controller/FileDialogController';
+import {SelectTranslationInputDirectory, SelectTranslationInputFiles} from '../../../wailsjs/go/controller/FileDialogController';
 import {useWailsEvent} from '../../useWailsEvent';
 import {
     GetAllTasks,
@@ -11,6 +11,7 @@ import {
     ListTranslationFlowPreviewRows,
     ListTranslationFlowTerminologyTargets,
     LoadTranslationFlowFiles,
+    ReloadTranslationFlowFiles,
     RunTranslationFlowTerminology,
 } from '../../../wailsjs/go/controller/TaskController';
 import type {FrontendTask, PhaseCompletedEvent} from '../../../types/task';
@@ -22,6 +23,7 @@ import {
     type MasterPersonaPromptConfig,
 } from '../../../types/masterPersona';
 import {
+    mapLoadDiffMessages,
     mapLoadResult,
     mapMainTranslationPreviewRow,
     mapPersonaPhaseResult,
@@ -88,8 +90,8 @@ None
 Requirements:
 1. Translate the text idiomatically for Skyrim (e.g. Katakana for names, appropriate Kanji for titles).
 2. Be consistent with the Reference Terms provided.
-3. You MUST output the final translation in the following exact format and nothing else:
-TL: |translated_text|`,
+3. You MUST output a single JSON object with the final translation and nothing else:
+{"translation": "translated_text"}`,
 };
 
 const EMPTY_TERMINOLOGY_SUMMARY: TerminologyPhaseSummary = {
@@ -353,7 +355,7 @@ const normalizeTerminologyLLMConfig = (loaded: Record<string, string>): MasterPe
     const bulkStrategy = String(loaded.bulk_strategy ?? '').trim().toLowerCase() === 'batch' ? 'batch' : 'sync';
 
     return {
-        provider: provider === 'gemini' || provider === 'xai' || provider === 'lmstudio' ? provider : DEFAULT_MASTER_PERSONA_LLM_CONFIG.provider,
+        provider: provider === 'gemini' || provider === 'xai' || provider === 'lmstudio' || provider === 'openai_compatible' ? provider : DEFAULT_MASTER_PERSONA_LLM_CONFIG.provider,
         model: loaded.model ?? DEFAULT_MASTER_PERSONA_LLM_CONFIG.model,
         endpoint: loaded.endpoint || DEFAULT_MASTER_PERSONA_LLM_CONFIG.endpoint,
         apiKey: loaded.api_key ?? DEFAULT_MASTER_PERSONA_LLM_CONFIG.apiKey,
@@ -441,7 +443,7 @@ const normalizePersonaSelectionKey = (sourcePluginOrRowID: string, speakerId: st
 };
 
 const normalizeTerminologyProvider = (value: string | undefined): MasterPersonaLLMConfig['provider'] => {
-    if (value === 'gemini' || value === 'xai' || value === 'lmstudio') {
+    if (value === 'gemini' || value === 'xai' || value === 'lmstudio' || value === 'openai_compatible') {
         return value;
     }
     return DEFAULT_MASTER_PERSONA_LLM_CONFIG.provider;
@@ -630,6 +632,7 @@ export function useTranslationFlow(): UseTranslationFlowWithPersonaResult {
     const [loadedFiles, setLoadedFiles] = useState<UseTranslationFlowResult['state']['loadedFiles']>([]);
     const [isLoading, setIsLoading] = useState(false);
     const [errorMessage, setErrorMessage] = useState('');
+    const [loadDiffMessages, setLoadDiffMessages] = useState<string[]>([]);
     const [terminologySummary, setTerminologySummary] = useState<TerminologyPhaseSummary>(EMPTY_TERMINOLOGY_SUMMARY);
     const [terminologyErrorMessage, setTerminologyErrorMessage] = useState('');
     const [terminologyTargetPage, setTerminologyTargetPage] = useState<TerminologyTargetPreviewPage>(
@@ -1688,6 +1691,26 @@ export function useTranslationFlow(): UseTranslationFlowWithPersonaResult {
         }
     }, [loadedFiles, selectedFiles]);
 
+    const handleSelectFolder = useCallback(async () => {
+        setErrorMessage('');
+        try {
+            const folder = await SelectTranslationInputDirectory();
+            if (!folder) {
+                return;
+            }
+
+            const loadedFileNames = new Set(loadedFiles.map((file) => normalizeFileName(file.fileName || file.filePath)));
+            const {files: merged, duplicateBlocked} = mergeUniqueFilesByName(selectedFiles, [folder], loadedFileNames);
+
+            setSelectedFiles(merged);
+            if (duplicateBlocked) {
+                setErrorMessage(DUPLICATE_FILE_MESSAGE);
+            }
+        } catch (error) {
+            setErrorMessage(toErrorMessage(error, 'フォルダ選択に失敗しました'));
+        }
+    }, [loadedFiles, selectedFiles]);
+
     const handleRemoveFile = useCallback((pathToRemove: string) => {
         setSelectedFiles((prev) => prev.filter((path) => path !== pathToRemove));
     }, []);
@@ -1717,6 +1740,32 @@ export function useTranslationFlow(): UseTranslationFlowWithPersonaResult {
         }
     }, [handleRefreshTerminologyPhase, selectedFiles, taskId]);
 
+    const handleLoadUpdatedFiles = useCallback(async () => {
+        setErrorMessage('');
+        try {
+            const files = await SelectTranslationInputFiles();
+            if (!Array.isArray(files) || files.length === 0) {
+                return;
+            }
+
+            setIsLoading(true);
+            const payload = await ReloadTranslationFlowFiles(taskId, files);
+            const mapped = mapLoadResult(payload);
+            const resolvedTaskId = mapped.taskId !== '' ? mapped.taskId : taskId;
+            if (resolvedTaskId !== '' && resolvedTaskId !== taskId) {
+                setTaskID(resolvedTaskId);
+            }
+            setLoadedFiles(mapped.files);
+            setLoadDiffMessages(mapLoadDiffMessages(payload));
+            setTerminologySummary({...EMPTY_TERMINOLOGY_SUMMARY, taskId: resolvedTaskId});
+            await handleRefreshTerminologyPhase(resolvedTaskId);
+        } catch (error) {
+            setErrorMessage(toErrorMessage(error, '更新版の差分ロードに失敗しました'));
+        } finally {
+            setIsLoading(false);
+        }
+    }, [handleRefreshTerminologyPhase, taskId]);
+
     const handlePreviewPageChange = useCallback(async (fileId: number, page: number) => {
         if (fileId <= 0) {
             return;
@@ -2147,6 +2196,7 @@ export function useTranslationFlow(): UseTranslationFlowWithPersonaResult {
             loadedFiles,
             isLoading,
             errorMessage,
+            loadDiffMessages,
             terminologySummary,
             terminologyStatusLabel: terminologyStatusLabel(
                 terminologySummary,
@@ -2200,8 +2250,10 @@ export function useTranslationFlow(): UseTranslationFlowWithPersonaResult {
         actions: {
             handleTabChange,
             handleSelectFiles,
+            handleSelectFolder,
             handleRemoveFile,
             handleLoadSelectedFiles,
+            handleLoadUpdatedFiles,
             handleReloadFiles,
             handlePreviewPageChange,
             handleAdvanceFromLoad,
@@ -0,0 +1,34 @@
+import type { TranslationMemoryMatch } from './types';
+
+const asRecord = (value: unknown): Record<string, unknown> | null => {
+    if (value && typeof value === 'object') {
+        return value as Record<string, unknown>;
+    }
+    return null;
+};
+
+const pickString = (value: unknown, fallback = ''): string =>
+    typeof value === 'string' ? value : fallback;
+
+const pickNumber = (value: unknown, fallback = 0): number =>
+    typeof value === 'number' && Number.isFinite(value) ? value : fallback;
+
+export const mapLookupResponse = (payload: unknown): TranslationMemoryMatch[] => {
+    if (!Array.isArray(payload)) {
+        return [];
+    }
+
+    return payload.map((item) => {
+        const record = asRecord(item) ?? {};
+        return {
+            id: pickNumber(record.id),
+            sourceText: pickString(record.source_text),
+            destText: pickString(record.dest_text),
+            recordType: pickString(record.record_type),
+            sourcePlugin: pickString(record.source_plugin),
+            origin: pickString(record.origin),
+            originRef: pickString(record.origin_ref),
+            score: pickNumber(record.score),
+        };
+    });
+};
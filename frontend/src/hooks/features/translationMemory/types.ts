@@ -0,0 +1,56 @@
+/**
+ * 翻訳メモリ検索結果 1 件分のデータ。
+ */
+export interface TranslationMemoryMatch {
+    id: number;
+    sourceText: string;
+    destText: string;
+    recordType: string;
+    sourcePlugin: string;
+    origin: string;
+    originRef: string;
+    score: number;
+}
+
+/**
+ * 翻訳メモリインポート進捗イベントの payload。
+ */
+export interface TranslationMemoryProgressEvent {
+    CorrelationID: string;
+    Status: 'STARTED' | 'COMPLETED' | 'FAILED' | 'IN_PROGRESS';
+    Message: string;
+    Total: number;
+    Completed: number;
+}
+
+/**
+ * 翻訳メモリ画面が保持する state 群。
+ */
+export interface TranslationMemoryState {
+    selectedFiles: string[];
+    isImporting: boolean;
+    importMessages: Record<string, string>;
+    lastResultMessage: string | null;
+    lookupQuery: string;
+    matches: TranslationMemoryMatch[];
+    isLookingUp: boolean;
+}
+
+/**
+ * 翻訳メモリ画面から UI に公開する操作群。
+ */
+export interface TranslationMemoryActions {
+    handleSelectFilesClick: () => Promise<void>;
+    removeSelectedFile: (pathToRemove: string) => void;
+    handleImport: () => Promise<void>;
+    setLookupQuery: (query: string) => void;
+    handleLookup: () => Promise<void>;
+}
+
+/**
+ * 翻訳メモリ hook の戻り値全体。
+ */
+export interface UseTranslationMemoryResult {
+    state: TranslationMemoryState;
+    actions: TranslationMemoryActions;
+}
@@ -0,0 +1,121 @@
+import { renderHook, waitFor, act } from '@testing-library/react';
+import { describe, expect, it, vi, beforeEach } from 'vitest';
+import { useTranslationMemory } from './useTranslationMemory';
+import * as TranslationMemoryBindings from '../../../wailsjs/go/controller/TranslationMemoryController';
+import * as FileDialogBindings from '../../../wailsjs/go/controller/FileDialogController';
+
+const eventHandlers = new Map<string, (payload: unknown) => void>();
+
+type MockFnLike = {
+    mockResolvedValue: (value: unknown) => void;
+};
+
+const asMock = (fn: unknown): MockFnLike => fn as MockFnLike;
+
+vi.mock('../../../wailsjs/runtime/runtime', () => ({
+    EventsOn: vi.fn((eventName: string, callback: (payload: unknown) => void) => {
+        eventHandlers.set(eventName, callback);
+        return () => {
+            eventHandlers.delete(eventName);
+        };
+    }),
+}));
+
+vi.mock('../../../wailsjs/go/controller/TranslationMemoryController', () => ({
+    TMLookup: vi.fn(),
+    TMStartImport: vi.fn(),
+}));
+
+vi.mock('../../../wailsjs/go/controller/FileDialogController', () => ({
+    SelectFiles: vi.fn(),
+}));
+
+describe('useTranslationMemory', () => {
+    beforeEach(() => {
+        vi.clearAllMocks();
+        eventHandlers.clear();
+
+        asMock(FileDialogBindings.SelectFiles).mockResolvedValue(['C:/tmp/Skyrim_english_japanese.xml']);
+        asMock(TranslationMemoryBindings.TMStartImport).mockResolvedValue('tm-import-1');
+        asMock(TranslationMemoryBindings.TMLookup).mockResolvedValue([
+            {
+                id: 3,
+                source_text: 'I used to be an adventurer like you.',
+                dest_text: '昔はお前のような冒険者だったのだが。',
+                record_type: 'INFO:NAM1',
+                source_plugin: 'Skyrim.esm',
+                origin: 'xml',
+                origin_ref: 'Skyrim_english_japanese.xml',
+                score: 1,
+            },
+        ]);
+    });
+
+    it('選択したファイルごとにインポートを開始する', async () => {
+        const { result } = renderHook(() => useTranslationMemory());
+
+        await act(async () => {
+            await result.current.actions.handleSelectFilesClick();
+        });
+        expect(result.current.state.selectedFiles).toEqual(['C:/tmp/Skyrim_english_japanese.xml']);
+
+        await act(async () => {
+            await result.current.actions.handleImport();
+        });
+
+        expect(TranslationMemoryBindings.TMStartImport).toHaveBeenCalledWith('C:/tmp/Skyrim_english_japanese.xml');
+        expect(result.current.state.selectedFiles).toHaveLength(0);
+    });
+
+    it('import_progress イベントで進捗状態を更新する', async () => {
+        const { result } = renderHook(() => useTranslationMemory());
+
+        await waitFor(() => {
+            expect(eventHandlers.has('translation_memory:import_progress')).toBe(true);
+        });
+
+        const handler = eventHandlers.get('translation_memory:import_progress');
+        act(() => {
+            handler?.({
+                CorrelationID: 'tm-import-1',
+                Status: 'IN_PROGRESS',
+                Message: 'インポート中: 1000 件処理済み',
+                Total: 0,
+                Completed: 1000,
+            });
+        });
+
+        expect(result.current.state.isImporting).toBe(true);
+        expect(result.current.state.importMessages['tm-import-1']).toBe('インポート中: 1000 件処理済み');
+
+        act(() => {
+            handler?.({
+                CorrelationID: 'tm-import-1',
+                Status: 'COMPLETED',
+                Message: 'インポート完了: 1200 件',
+                Total: 0,
+                Completed: 1200,
+            });
+        });
+
+        expect(result.current.state.isImporting).toBe(false);
+        expect(result.current.state.importMessages['tm-import-1']).toBeUndefined();
+        expect(result.current.state.lastResultMessage).toBe('インポート完了: 1200 件');
+    });
+
+    it('原文で翻訳メモリを検索して結果を state に反映する', async () => {
+        const { result } = renderHook(() => useTranslationMemory());
+
+        act(() => {
+            result.current.actions.setLookupQuery('I used to be an adventurer like you.');
+        });
+        await act(async () => {
+            await result.current.actions.handleLookup();
+        });
+
+        expect(TranslationMemoryBindings.TMLookup).toHaveBeenCalledWith('I used to be an adventurer like you.');
+        expect(result.current.state.matches).toHaveLength(1);
+        expect(result.current.state.matches[0].destText).toBe('昔はお前のような冒険者だったのだが。');
+        expect(result.current.state.matches[0].score).toBe(1);
+    });
+});
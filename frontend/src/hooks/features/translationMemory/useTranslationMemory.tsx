@@ -0,0 +1,115 @@
+import { useState } from 'react';
+import { SelectFiles } from '../../../wailsjs/go/controller/FileDialogController';
+import { TMLookup, TMStartImport } from '../../../wailsjs/go/controller/TranslationMemoryController';
+import { useWailsEvent } from '../../useWailsEvent';
+import { mapLookupResponse } from './adapters';
+import type {
+    TranslationMemoryMatch,
+    TranslationMemoryProgressEvent,
+    UseTranslationMemoryResult,
+} from './types';
+
+/**
+ * 翻訳メモリの XML インポートと検索の state、action をまとめて返す。
+ */
+export function useTranslationMemory(): UseTranslationMemoryResult {
+    const [selectedFiles, setSelectedFiles] = useState<string[]>([]);
+    const [isStarting, setIsStarting] = useState(false);
+    const [importMessages, setImportMessages] = useState<Record<string, string>>({});
+    const [lastResultMessage, setLastResultMessage] = useState<string | null>(null);
+    const [lookupQuery, setLookupQuery] = useState('');
+    const [matches, setMatches] = useState<TranslationMemoryMatch[]>([]);
+    const [isLookingUp, setIsLookingUp] = useState(false);
+
+    useWailsEvent<TranslationMemoryProgressEvent>('translation_memory:import_progress', (payload) => {
+        const corrId = payload.CorrelationID;
+        if (payload.Status === 'COMPLETED' || payload.Status === 'FAILED') {
+            setImportMessages((prev) => {
+                const next = { ...prev };
+                delete next[corrId];
+                return next;
+            });
+            setLastResultMessage(payload.Message);
+            return;
+        }
+
+        setImportMessages((prev) => ({ ...prev, [corrId]: payload.Message }));
+    });
+
+    const handleSelectFilesClick = async () => {
+        try {
+            const files = await SelectFiles();
+            if (!files || files.length === 0) {
+                return;
+            }
+            setSelectedFiles((prev) => {
+                const currentPaths = new Set(prev);
+                const uniqueNewFiles = files.filter((filePath) => !currentPaths.has(filePath));
+                return [...prev, ...uniqueNewFiles];
+            });
+        } catch (error) {
+            console.error('Failed to select files:', error);
+        }
+    };
+
+    const removeSelectedFile = (pathToRemove: string) => {
+        setSelectedFiles((prev) => prev.filter((path) => path !== pathToRemove));
+    };
+
+    const handleImport = async () => {
+        if (selectedFiles.length === 0) {
+            return;
+        }
+        setIsStarting(true);
+        setLastResultMessage(null);
+        for (const filePath of selectedFiles) {
+            try {
+                const correlationId = await TMStartImport(filePath);
+                console.warn('Started translation memory import with ID:', correlationId);
+            } catch (error) {
+                console.error('Translation memory import error:', error);
+            }
+        }
+        setSelectedFiles([]);
+        setIsStarting(false);
+    };
+
+    const handleLookup = async () => {
+        const query = lookupQuery.trim();
+        if (query.length === 0) {
+            setMatches([]);
+            return;
+        }
+        setIsLookingUp(true);
+        try {
+            const response = await TMLookup(query);
+            setMatches(mapLookupResponse(response));
+        } catch (error) {
+            console.error('Translation memory lookup failed:', error);
+            setMatches([]);
+        } finally {
+            setIsLookingUp(false);
+        }
+    };
+
+    const isImporting = isStarting || Object.keys(importMessages).length > 0;
+
+    return {
+        state: {
+            selectedFiles,
+            isImporting,
+            importMessages,
+            lastResultMessage,
+            lookupQuery,
+            matches,
+            isLookingUp,
+        },
+        actions: {
+            handleSelectFilesClick,
+            removeSelectedFile,
+            handleImport,
+            setLookupQuery,
+            handleLookup,
+        },
+    };
+}
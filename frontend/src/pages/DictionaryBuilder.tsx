@@ -3,8 +3,10 @@ import DataTable from '../components/DataTable';
 import CrossSearchModal from '../components/dictionary/CrossSearchModal';
 import DetailPane from '../components/dictionary/DetailPane';
 import GridEditor from '../components/dictionary/GridEditor';
+import TranslationMemoryCard from '../components/dictionary/TranslationMemoryCard';
 import type { GridColumnDef } from '../components/dictionary/GridEditor';
 import { useDictionaryBuilder } from '../hooks/features/dictionaryBuilder/useDictionaryBuilder';
+import { useTranslationMemory } from '../hooks/features/translationMemory/useTranslationMemory';
 import { STATUS_BADGE } from '../hooks/features/dictionaryBuilder/types';
 import type { DictEntry } from '../hooks/features/dictionaryBuilder/types';
 
@@ -26,10 +28,11 @@ const CROSS_ENTRY_COLUMNS: GridColumnDef<DictEntry>[] = [
 ];
 
 /**
- * 辞書構築の一覧、インポート、編集画面を描画する。
+ * 辞書構築の一覧、インポート、編集画面と翻訳メモリの取り込みを描画する。
  */
 export default function DictionaryBuilder() {
     const { state, actions, ui, constants } = useDictionaryBuilder();
+    const translationMemory = useTranslationMemory();
 
     if (state.view === 'entries' && state.selectedRow) {
         return (
@@ -176,6 +179,10 @@ export default function DictionaryBuilder() {
                     </div>
                 </div>
 
+                <div className="shrink-0">
+                    <TranslationMemoryCard state={translationMemory.state} actions={translationMemory.actions} />
+                </div>
+
                 <div className="flex-1 min-h-0 flex flex-col relative">
                     <DataTable
                         columns={ui.sourceColumns}
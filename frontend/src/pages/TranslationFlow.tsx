@@ -76,9 +76,12 @@ export default function TranslationFlow() {
                         loadedFiles={state.loadedFiles}
                         isLoading={state.isLoading}
                         errorMessage={state.errorMessage}
+                        diffMessages={state.loadDiffMessages}
                         onSelectFiles={actions.handleSelectFiles}
+                        onSelectFolder={actions.handleSelectFolder}
                         onRemoveFile={actions.handleRemoveFile}
                         onLoadSelectedFiles={actions.handleLoadSelectedFiles}
+                        onLoadUpdatedFiles={actions.handleLoadUpdatedFiles}
                         onReloadFiles={actions.handleReloadFiles}
                         onPreviewPageChange={actions.handlePreviewPageChange}
                         onNext={actions.handleAdvanceFromLoad}
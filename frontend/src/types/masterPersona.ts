@@ -1,4 +1,4 @@
-export type MasterPersonaProvider = 'lmstudio' | 'gemini' | 'xai';
+export type MasterPersonaProvider = 'lmstudio' | 'gemini' | 'xai' | 'openai_compatible';
 
 export type MasterPersonaExecutionProfile = 'sync' | 'batch';
 
@@ -51,7 +51,7 @@ The user message will contain:
 
 Use the User Request as the variable instruction, then analyze the NPC Profile and Dialogue History.
 Generate a concise persona summary.
-Your response MUST be formatted strictly as: TL: |...|
+Your response MUST be a single JSON object of the form {"persona": "..."}.
 
 Keep the total response under 150 words and do not add extra conversational filler.`,
 };
@@ -17,6 +17,10 @@ declare module '*wailsjs/go/controller/ModelCatalogController' {
     endpoint: string;
     apiKey: string;
   }): Promise<Array<{ id: string; display_name?: string }>>;
+  export function ProviderHealth(input: {
+    namespace: string;
+    provider: string;
+  }): Promise<{ provider: string; endpoint?: string; model?: string; state: string; available: boolean; message?: string }>;
 }
 
 declare module '*wailsjs/go/controller/FileDialogController' {
@@ -34,6 +38,11 @@ declare module '*wailsjs/go/controller/DictionaryController' {
   export function DictDeleteSource(id: number): Promise<void>;
 }
 
+declare module '*wailsjs/go/controller/TranslationMemoryController' {
+  export function TMLookup(sourceText: string): Promise<unknown[]>;
+  export function TMStartImport(filePath: string): Promise<string>;
+}
+
 declare module '*wailsjs/go/controller/PersonaController' {
   export function ListNPCs(): Promise<unknown[]>;
   export function ListDialoguesByPersonaID(personaID: number): Promise<unknown[]>;
@@ -44,11 +53,19 @@ declare module '*wailsjs/go/controller/TaskController' {
   export function CancelTask(taskID: string): Promise<void>;
   export function GetAllTasks(): Promise<unknown[]>;
   export function GetActiveTasks(): Promise<unknown[]>;
+  export function GetTaskUsage(taskID: string): Promise<unknown>;
+  export function GetResponseCacheStats(): Promise<unknown[]>;
   export function ListLoadedTranslationFlowFiles(taskID: string): Promise<unknown>;
   export function ListTranslationFlowPreviewRows(fileID: number, page: number, pageSize: number): Promise<unknown>;
   export function LoadTranslationFlowFiles(taskID: string, filePaths: string[]): Promise<unknown>;
+  export function ReloadTranslationFlowFiles(taskID: string, filePaths: string[]): Promise<unknown>;
   export function GetTranslationFlowTerminology(taskID: string): Promise<unknown>;
   export function RunTranslationFlowTerminology(taskID: string, input: unknown): Promise<unknown>;
+  export function EstimateTranslationFlowTerminology(taskID: string, request: unknown, prompt: unknown): Promise<unknown>;
+  export function RunTranslationFlowSummary(taskID: string, request: unknown): Promise<unknown>;
+  export function EstimateTranslationFlowSummary(taskID: string, request: unknown): Promise<unknown>;
+  export function EstimateTranslationFlowPersona(taskID: string, request: unknown, prompt: unknown): Promise<unknown>;
+  export function EstimateTranslationFlowMainTranslation(taskID: string, request: unknown, prompt: unknown): Promise<unknown>;
 }
 
 declare module '*wailsjs/go/controller/PersonaTaskController' {
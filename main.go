@@ -9,6 +9,9 @@ import (
 	master_persona_artifact "github.com/ishibata91/ai-translation-engine-2/pkg/artifact/master_persona_artifact"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/artifact/translationinput"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/controller"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter/interfacetranslation"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter/stringtable"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter/xtranslator"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
@@ -17,13 +20,18 @@ import (
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/configstore"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/datastore"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmcache"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmexec"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/modelcatalog"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/queue"
 	dictionary2 "github.com/ishibata91/ai-translation-engine-2/pkg/slice/dictionary"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/persona"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/summary"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/workflow"
 	task2 "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
 	"github.com/wailsapp/wails/v2"
@@ -68,6 +76,12 @@ func main() {
 	}
 	defer terminologyDBCleanup()
 
+	translationDB, translationDBCleanup, err := datastore.NewSQLiteDB(context.Background(), "translation.db")
+	if err != nil {
+		log.Fatalf("failed to initialize translation database: %v", err)
+	}
+	defer translationDBCleanup()
+
 	// 2. Run Migrations
 	if err := configstore.Migrate(context.Background(), db); err != nil {
 		log.Fatalf("failed to run database migrations: %v", err)
@@ -133,7 +147,30 @@ func main() {
 		_ = llmQueue.Close()
 	}()
 
+	llmCacheDB, llmCacheDBCleanup, err := datastore.NewSQLiteDB(context.Background(), "llm_cache.db")
+	if err != nil {
+		log.Fatalf("failed to initialize llm response cache database: %v", err)
+	}
+	defer llmCacheDBCleanup()
+	responseCache, err := llmcache.NewCache(context.Background(), llmCacheDB, gatewayConfigStore, logger)
+	if err != nil {
+		log.Fatalf("failed to initialize llm response cache: %v", err)
+	}
+
+	llmUsageDB, llmUsageDBCleanup, err := datastore.NewSQLiteDB(context.Background(), "llm_usage.db")
+	if err != nil {
+		log.Fatalf("failed to initialize llm usage database: %v", err)
+	}
+	defer llmUsageDBCleanup()
+	usageLedger, err := llmusage.NewLedger(context.Background(), llmUsageDB)
+	if err != nil {
+		log.Fatalf("failed to initialize llm usage ledger: %v", err)
+	}
+	usageService := llmusage.NewService(usageLedger, gatewayConfigStore, logger)
+
 	queueWorker := queue.NewWorker(llmQueue, llmManager, gatewayConfigStore, gatewayConfigStore, personaProgressNotifier, logger)
+	queueWorker.SetResponseCache(responseCache)
+	queueWorker.SetUsageLedger(usageLedger)
 	if err := queueWorker.Recover(context.Background()); err != nil {
 		log.Printf("failed to recover llm queue worker state: %v", err)
 	}
@@ -147,12 +184,66 @@ func main() {
 	}
 	termSearchStemmer := terminology.NewSnowballStemmer("english")
 	termSearcher := terminology.NewSQLiteTermDictionarySearcher(dictArtifactRepo, logger, termSearchStemmer)
+	termSearcher.SetEmbedder(llmexec.NewEmbedder(llmManager, gatewayConfigStore, gatewayConfigStore))
+	dictionaryEmbeddingIndexJob := workflow.NewDictionaryEmbeddingIndexJob(termSearcher, logger)
+	dictService.SetEntriesChangedHook(dictionaryEmbeddingIndexJob.Trigger)
 	termStore := terminology.NewSQLiteModTermStore(terminologyDB, logger)
 	if err := termStore.InitSchema(context.Background()); err != nil {
 		log.Fatalf("failed to initialize terminology store schema: %v", err)
 	}
 	termTranslator := terminology.NewTermTranslator(translationInputRepo, termBuilder, termSearcher, termStore, termPromptBuilder, logger)
 
+	summaryDB, summaryDBCleanup, err := datastore.NewSQLiteDB(context.Background(), "summary.db")
+	if err != nil {
+		log.Fatalf("failed to initialize summary database: %v", err)
+	}
+	defer summaryDBCleanup()
+	summaryStore := summary.NewSummaryStore(summaryDB)
+	if err := summaryStore.Init(context.Background()); err != nil {
+		log.Fatalf("failed to initialize summary store schema: %v", err)
+	}
+	summaryGenerator := summary.NewSummaryGenerator(summaryStore, summary.SummaryConfig{})
+
+	translationMemoryDB, translationMemoryDBCleanup, err := datastore.NewSQLiteDB(context.Background(), "translation_memory.db")
+	if err != nil {
+		log.Fatalf("failed to initialize translation memory database: %v", err)
+	}
+	defer translationMemoryDBCleanup()
+	translationMemoryConfig := translationmemory.DefaultConfig()
+	translationMemoryStore := translationmemory.NewTranslationMemoryStore(translationMemoryDB, translationMemoryConfig)
+	if err := translationMemoryStore.InitSchema(context.Background()); err != nil {
+		log.Fatalf("failed to initialize translation memory store schema: %v", err)
+	}
+	translationMemoryNotifier := progress.NewWailsNotifier(logger)
+	translationMemoryNotifier.SetEventName("translation_memory:import_progress")
+	translationMemoryService := translationmemory.NewTranslationMemoryService(
+		translationMemoryConfig,
+		translationMemoryStore,
+		translationmemory.NewImporter(translationMemoryConfig, translationMemoryStore, translationMemoryNotifier, logger),
+		logger,
+	)
+
+	mainTranslationStore := translator.NewSQLiteTaskResultStore(translationDB, logger)
+	if err := mainTranslationStore.InitSchema(context.Background()); err != nil {
+		log.Fatalf("failed to initialize main translation store schema: %v", err)
+	}
+	mainTranslator := translator.NewMainTranslator(
+		translationInputRepo,
+		mainTranslationStore,
+		translator.NewContextEngine(
+			translator.NewDefaultToneResolver(),
+			workflow.NewPersonaLookup(translationFlowSlice),
+			workflow.NewTermLookup(termStore),
+			workflow.NewSummaryLookup(summaryGenerator),
+			workflow.NewTranslationMemoryLookup(translationMemoryService),
+		),
+		translator.NewDefaultPromptBuilder(),
+		translator.NewTagProcessor(),
+		translator.NewBookChunker(),
+		logger,
+	)
+	mainTranslator.SetResponseRejecter(responseCache)
+
 	personaStore := persona.NewPersonaStore(personaArtifactRepo)
 	if err := personaStore.InitSchema(context.Background()); err != nil {
 		log.Fatalf("failed to initialize persona artifact store: %v", err)
@@ -168,23 +259,36 @@ func main() {
 	personaController := controller.NewPersonaController(personaService)
 
 	// 7. Setup Bridge
+	syncExecutor := llmexec.NewSyncExecutor(llmManager)
+	syncExecutor.SetConfigReader(gatewayConfigStore)
+	syncExecutor.SetResponseCache(responseCache)
+	syncExecutor.SetUsageLedger(usageLedger)
 	masterPersonaWorkflow := workflow.NewMasterPersonaService(taskManager, logger, parserLoader, personaGenerator, personaProgressNotifier, llmQueue, queueWorker)
 	translationFlowWorkflow := workflow.NewTranslationFlowService(
 		parserLoader,
 		translationFlowSlice,
 		termTranslator,
 		masterPersonaWorkflow,
-		llmexec.NewSyncExecutor(llmManager),
+		mainTranslator,
+		workflow.NewXMLExportService(xtranslator.NewExporter(), stringtable.NewExporter(), interfacetranslation.NewExporter()),
+		syncExecutor,
 		translationFlowProgressNotifier,
 	)
+	translationFlowWorkflow.SetCostEstimator(usageService)
+	translationFlowWorkflow.SetSummary(summaryGenerator)
+	translationFlowWorkflow.SetTranslationMemory(translationMemoryService)
+	translationFlowWorkflow.SetTaskMetadataStore(taskManager.Store())
 	taskManager.RegisterRunner(task2.TypeTranslationProject, translationFlowWorkflow)
 	taskManager.RegisterRunner(task2.TypePersonaExtraction, masterPersonaWorkflow)
 	taskManager.RegisterCompletionHook(task2.TypeTranslationProject, masterPersonaWorkflow.CleanupCompletedTask)
 	taskManager.RegisterCompletionHook(task2.TypePersonaExtraction, masterPersonaWorkflow.CleanupCompletedTask)
 	taskController := controller.NewTaskController(taskManager)
 	taskController.SetTranslationFlowWorkflow(translationFlowWorkflow)
+	taskController.SetUsageReporter(usageService)
+	taskController.SetResponseCacheReporter(responseCache)
 	personaTaskController := controller.NewPersonaTaskController(taskManager, masterPersonaWorkflow)
 	dictionaryController := controller.NewDictionaryController(dictService)
+	translationMemoryController := controller.NewTranslationMemoryController(translationMemoryService)
 	fileDialogController := controller.NewFileDialogController()
 
 	// 8. Setup Telemetry Controller（フロントエンド発ログのバックエンド橋渡し）
@@ -214,6 +318,7 @@ func main() {
 			taskController.SetContext(ctx)
 			personaTaskController.SetContext(ctx)
 			dictionaryController.SetContext(ctx)
+			translationMemoryController.SetContext(ctx)
 			fileDialogController.SetContext(ctx)
 			modelCatalogController.SetContext(ctx)
 			personaController.SetContext(ctx)
@@ -225,6 +330,8 @@ func main() {
 			wailsNotifier.SetContext(ctx)
 			personaProgressNotifier.SetContext(ctx)
 			translationFlowProgressNotifier.SetContext(ctx)
+			// 前回終了時に埋め込みが未作成だった辞書エントリをバックグラウンドで索引する
+			dictionaryEmbeddingIndexJob.Trigger(ctx)
 		},
 		OnShutdown: app.shutdown,
 		Bind: []interface{}{
@@ -232,6 +339,7 @@ func main() {
 			personaTaskController,
 			configController,
 			dictionaryController,
+			translationMemoryController,
 			fileDialogController,
 			modelCatalogController,
 			personaController,
@@ -30,6 +30,19 @@ type Entry struct {
 	DestText   string
 }
 
+// EntryEmbedding is one embedding vector stored for a dictionary entry under a given model.
+type EntryEmbedding struct {
+	EntryID int64
+	Model   string
+	Vector  []float32
+}
+
+// ScoredEntry is a dictionary entry ranked by cosine similarity to a query vector.
+type ScoredEntry struct {
+	Entry
+	Score float64
+}
+
 // EntryPage is one paged response for dictionary entries.
 type EntryPage struct {
 	Entries    []Entry
@@ -52,4 +65,7 @@ type Repository interface {
 	SaveEntries(ctx context.Context, entries []Entry) error
 	UpdateEntry(ctx context.Context, entry Entry) error
 	DeleteEntry(ctx context.Context, id int64) error
+	ListEntriesWithoutEmbedding(ctx context.Context, model string, limit int) ([]Entry, error)
+	SaveEmbeddings(ctx context.Context, embeddings []EntryEmbedding) error
+	SearchByEmbedding(ctx context.Context, model string, query []float32, limit int, minScore float64) ([]ScoredEntry, error)
 }
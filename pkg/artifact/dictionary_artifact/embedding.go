@@ -0,0 +1,185 @@
+package dictionaryartifact
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ListEntriesWithoutEmbedding returns entries that have no vector stored for model yet.
+func (r *sqliteRepository) ListEntriesWithoutEmbedding(ctx context.Context, model string, limit int) ([]Entry, error) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil, fmt.Errorf("embedding model is required")
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.source_id, e.edid, e.record_type, e.source_text, e.dest_text
+		FROM artifact_dictionary_entries e
+		WHERE NOT EXISTS (
+			SELECT 1 FROM artifact_dictionary_embeddings v
+			WHERE v.entry_id = e.id AND v.model = ?
+		)
+		ORDER BY e.id
+		LIMIT ?
+	`, model, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list dictionary entries without embedding model=%s: %w", model, err)
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.ID, &entry.SourceID, &entry.EDID, &entry.RecordType, &entry.SourceText, &entry.DestText); err != nil {
+			return nil, fmt.Errorf("scan dictionary entry without embedding model=%s: %w", model, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dictionary entries without embedding model=%s: %w", model, err)
+	}
+	return entries, nil
+}
+
+// SaveEmbeddings upserts vectors; they are stored unit-normalized so search reduces to a dot product.
+func (r *sqliteRepository) SaveEmbeddings(ctx context.Context, embeddings []EntryEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin dictionary embedding transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO artifact_dictionary_embeddings (entry_id, model, dimension, vector, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(entry_id, model) DO UPDATE SET
+			dimension = excluded.dimension,
+			vector = excluded.vector,
+			updated_at = excluded.updated_at
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare dictionary embedding upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now().UTC()
+	for _, embedding := range embeddings {
+		model := strings.TrimSpace(embedding.Model)
+		if model == "" {
+			return fmt.Errorf("embedding model is required entry_id=%d", embedding.EntryID)
+		}
+		normalized, ok := normalizeVector(embedding.Vector)
+		if !ok {
+			return fmt.Errorf("embedding vector is empty or zero entry_id=%d", embedding.EntryID)
+		}
+		if _, err := stmt.ExecContext(ctx, embedding.EntryID, model, len(normalized), encodeVector(normalized), now); err != nil {
+			return fmt.Errorf("upsert dictionary embedding entry_id=%d: %w", embedding.EntryID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit dictionary embedding transaction: %w", err)
+	}
+	return nil
+}
+
+// SearchByEmbedding scans stored vectors of model and returns the top entries by cosine similarity.
+// The index is a flat scan, which stays fast enough for dictionary sizes in the tens of thousands.
+func (r *sqliteRepository) SearchByEmbedding(ctx context.Context, model string, query []float32, limit int, minScore float64) ([]ScoredEntry, error) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil, fmt.Errorf("embedding model is required")
+	}
+	normalizedQuery, ok := normalizeVector(query)
+	if !ok || limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT e.id, e.source_id, e.edid, e.record_type, e.source_text, e.dest_text, v.vector
+		FROM artifact_dictionary_embeddings v
+		JOIN artifact_dictionary_entries e ON e.id = v.entry_id
+		WHERE v.model = ? AND v.dimension = ?
+	`, model, len(normalizedQuery))
+	if err != nil {
+		return nil, fmt.Errorf("search dictionary embeddings model=%s: %w", model, err)
+	}
+	defer rows.Close()
+
+	top := make([]ScoredEntry, 0, limit+1)
+	for rows.Next() {
+		var entry Entry
+		var blob []byte
+		if err := rows.Scan(&entry.ID, &entry.SourceID, &entry.EDID, &entry.RecordType, &entry.SourceText, &entry.DestText, &blob); err != nil {
+			return nil, fmt.Errorf("scan dictionary embedding row model=%s: %w", model, err)
+		}
+		score, ok := dotEncoded(normalizedQuery, blob)
+		if !ok || score < minScore {
+			continue
+		}
+		if len(top) == limit && score <= top[len(top)-1].Score {
+			continue
+		}
+		top = insertScored(top, ScoredEntry{Entry: entry, Score: score}, limit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dictionary embedding rows model=%s: %w", model, err)
+	}
+	return top, nil
+}
+
+// insertScored keeps top sorted by descending score and bounded to limit.
+func insertScored(top []ScoredEntry, candidate ScoredEntry, limit int) []ScoredEntry {
+	idx := sort.Search(len(top), func(i int) bool { return top[i].Score < candidate.Score })
+	top = append(top, ScoredEntry{})
+	copy(top[idx+1:], top[idx:])
+	top[idx] = candidate
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
+
+func normalizeVector(vector []float32) ([]float32, bool) {
+	var sum float64
+	for _, v := range vector {
+		sum += float64(v) * float64(v)
+	}
+	if len(vector) == 0 || sum == 0 {
+		return nil, false
+	}
+	norm := math.Sqrt(sum)
+	normalized := make([]float32, len(vector))
+	for i, v := range vector {
+		normalized[i] = float32(float64(v) / norm)
+	}
+	return normalized, true
+}
+
+func encodeVector(vector []float32) []byte {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func dotEncoded(query []float32, blob []byte) (float64, bool) {
+	if len(blob) != 4*len(query) {
+		return 0, false
+	}
+	var dot float64
+	for i, q := range query {
+		dot += float64(q) * float64(math.Float32frombits(binary.LittleEndian.Uint32(blob[i*4:])))
+	}
+	return dot, true
+}
@@ -44,6 +44,16 @@ func Migrate(ctx context.Context, db *sql.DB) error {
 			dest_text TEXT NOT NULL
 		);
 		CREATE INDEX IF NOT EXISTS idx_artifact_dictionary_entries_source_id ON artifact_dictionary_entries(source_id);
+
+		CREATE TABLE IF NOT EXISTS artifact_dictionary_embeddings (
+			entry_id INTEGER NOT NULL REFERENCES artifact_dictionary_entries(id) ON DELETE CASCADE,
+			model TEXT NOT NULL,
+			dimension INTEGER NOT NULL,
+			vector BLOB NOT NULL,
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (entry_id, model)
+		);
+		CREATE INDEX IF NOT EXISTS idx_artifact_dictionary_embeddings_model ON artifact_dictionary_embeddings(model);
 	`); err != nil {
 		return fmt.Errorf("create dictionary artifact tables: %w", err)
 	}
@@ -370,6 +370,10 @@ func (r *sqliteRepository) UpdateEntry(ctx context.Context, entry Entry) error {
 	`, entry.SourceText, entry.DestText, entry.ID); err != nil {
 		return fmt.Errorf("update dictionary entry id=%d: %w", entry.ID, err)
 	}
+	// The source text may have changed, so the stored vectors no longer describe it.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM artifact_dictionary_embeddings WHERE entry_id = ?`, entry.ID); err != nil {
+		return fmt.Errorf("invalidate dictionary entry embeddings id=%d: %w", entry.ID, err)
+	}
 	return nil
 }
 
@@ -90,12 +90,94 @@ type PersonaDialogue struct {
 	Order            int
 }
 
+// MainTranslationInput groups body-text targets and speaker attributes for one main-translation run.
+type MainTranslationInput struct {
+	TaskID  string
+	NPCs    map[string]MainTranslationNPC
+	Entries []MainTranslationEntry
+}
+
+// MainTranslationNPC represents one NPC row projected as speaker context for main translation.
+type MainTranslationNPC struct {
+	SpeakerID string
+	EditorID  string
+	Name      string
+	Race      string
+	Sex       string
+	VoiceType string
+}
+
+// MainTranslationEntry represents one body-text row projected for the main translation phase.
+type MainTranslationEntry struct {
+	RowID            string
+	Section          string
+	ID               string
+	EditorID         string
+	RecordType       string
+	SourceText       string
+	SourceFile       string
+	SourcePlugin     string
+	SpeakerID        string
+	QuestID          string
+	ParentID         string
+	ParentEditorID   string
+	TypeHint         string
+	IsServicesBranch bool
+	Order            int
+	PreviousID       string
+	TopicText        string
+	PlayerText       string
+}
+
+// RowDiffStatus classifies one translatable row when a source file is reloaded.
+type RowDiffStatus string
+
+const (
+	// RowDiffAdded marks a row that has no counterpart in the previous load.
+	RowDiffAdded RowDiffStatus = "added"
+	// RowDiffChanged marks a row whose record matches the previous load but whose source text differs.
+	RowDiffChanged RowDiffStatus = "changed"
+	// RowDiffRemoved marks a previous row that no longer exists in the reloaded file.
+	RowDiffRemoved RowDiffStatus = "removed"
+	// RowDiffUnchanged marks a row whose record and source text both match the previous load.
+	RowDiffUnchanged RowDiffStatus = "unchanged"
+)
+
+// RowDiff pairs one reloaded row with its counterpart in the previous load.
+// RowID is empty for removed rows and PreviousRowID is empty for added rows.
+type RowDiff struct {
+	Status        RowDiffStatus
+	RowID         string
+	PreviousRowID string
+	Section       string
+	ID            string
+	EditorID      string
+	RecordType    string
+}
+
+// FileDiff summarizes how a reloaded source file differs from the previously loaded file of the same name.
+type FileDiff struct {
+	PreviousFileID int64
+	HasPrevious    bool
+	Added          int
+	Changed        int
+	Removed        int
+	Unchanged      int
+	Rows           []RowDiff
+}
+
+// ParsedOutputStream feeds parser output chunks to sink until the source is exhausted.
+type ParsedOutputStream func(sink skyrim.ParserOutputSink) error
+
 // Repository defines artifact persistence operations for translation-flow input data.
 type Repository interface {
 	EnsureTask(ctx context.Context, taskID string) error
 	SaveParsedOutput(ctx context.Context, taskID string, sourceFilePath string, output *skyrim.ParserOutput) (InputFile, error)
+	SaveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream ParsedOutputStream) (InputFile, error)
+	ReloadParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream ParsedOutputStream) (InputFile, FileDiff, error)
 	ListFiles(ctx context.Context, taskID string) ([]InputFile, error)
 	ListPreviewRows(ctx context.Context, fileID int64, page int, pageSize int) (PreviewPage, error)
 	LoadTerminologyInput(ctx context.Context, taskID string) (TerminologyInput, error)
 	LoadPersonaInput(ctx context.Context, taskID string) (PersonaInput, error)
+	LoadMainTranslationInput(ctx context.Context, taskID string) (MainTranslationInput, error)
 }
@@ -241,6 +241,19 @@ func Migrate(ctx context.Context, db *sql.DB) error {
 		);
 		CREATE INDEX IF NOT EXISTS idx_translation_input_npcs_file_id ON translation_input_npcs(file_id);
 
+		CREATE TABLE IF NOT EXISTS translation_input_interface_translations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			file_id INTEGER NOT NULL,
+			source_record_id TEXT NOT NULL,
+			record_type TEXT NOT NULL,
+			source_json_path TEXT,
+			mod_name TEXT NOT NULL,
+			text TEXT,
+			source TEXT,
+			FOREIGN KEY (file_id) REFERENCES translation_input_files(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_translation_input_interface_translations_file_id ON translation_input_interface_translations(file_id);
+
 		CREATE TABLE IF NOT EXISTS translation_input_terminology_entries (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			file_id INTEGER NOT NULL,
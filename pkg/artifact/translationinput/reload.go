@@ -0,0 +1,153 @@
+package translationinput
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// diffRow is the identity and source text of one translatable row used to diff reloads.
+type diffRow struct {
+	RowID      string
+	Section    string
+	ID         string
+	EditorID   string
+	RecordType string
+	SourceText string
+	Order      int
+}
+
+// key identifies a row by section, FormID, EDID and REC. Order is left out so inserting or removing
+// a row does not shift the identity of every row after it.
+func (d diffRow) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s", d.Section, d.ID, d.EditorID, d.RecordType)
+}
+
+// takeCandidate removes and returns the previous row to pair with row among same-key candidates.
+// A candidate at the same order wins; otherwise duplicates pair up in order.
+func takeCandidate(candidates []diffRow, row diffRow) (diffRow, []diffRow) {
+	index := 0
+	for i, candidate := range candidates {
+		if candidate.Order == row.Order {
+			index = i
+			break
+		}
+	}
+	taken := candidates[index]
+	rest := append(append(make([]diffRow, 0, len(candidates)-1), candidates[:index]...), candidates[index+1:]...)
+	return taken, rest
+}
+
+func (r *sqliteRepository) findFilesByName(ctx context.Context, tx *sql.Tx, taskID string, fileName string) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id
+		FROM translation_input_files
+		WHERE task_id = ? AND LOWER(source_file_name) = LOWER(?)
+		ORDER BY id ASC
+	`, taskID, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("find translation files by name task_id=%s file=%s: %w", taskID, fileName, err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, 1)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan translation file id task_id=%s file=%s: %w", taskID, fileName, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate translation files by name task_id=%s file=%s: %w", taskID, fileName, err)
+	}
+	return ids, nil
+}
+
+// loadDiffRows projects the translatable rows of one file through the main translation union.
+func (r *sqliteRepository) loadDiffRows(ctx context.Context, tx *sql.Tx, taskID string, fileID int64) ([]diffRow, error) {
+	args := append(mainTranslationUnionArgs(taskID), fileID)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT row_id, section, COALESCE(source_record_id, ''), COALESCE(editor_id, ''), COALESCE(record_type, ''),
+			COALESCE(source_text, ''), COALESCE(sort_order, 0)
+		FROM (`+mainTranslationUnionSQL+`)
+		WHERE file_id = ?
+		ORDER BY section_order ASC, row_pk ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query diff rows file_id=%d: %w", fileID, err)
+	}
+	defer rows.Close()
+
+	result := make([]diffRow, 0)
+	for rows.Next() {
+		var row diffRow
+		if err := rows.Scan(&row.RowID, &row.Section, &row.ID, &row.EditorID, &row.RecordType, &row.SourceText, &row.Order); err != nil {
+			return nil, fmt.Errorf("scan diff row file_id=%d: %w", fileID, err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate diff rows file_id=%d: %w", fileID, err)
+	}
+	return result, nil
+}
+
+// diffFileRows matches rows by section, FormID, EDID and REC; order only pairs up duplicates of the same key.
+func diffFileRows(diff *FileDiff, previous []diffRow, current []diffRow) {
+	pending := make(map[string][]diffRow, len(previous))
+	for _, row := range previous {
+		pending[row.key()] = append(pending[row.key()], row)
+	}
+	for key, candidates := range pending {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Order < candidates[j].Order })
+		pending[key] = candidates
+	}
+	matched := make(map[string]bool, len(previous))
+
+	diff.Rows = make([]RowDiff, 0, len(current)+len(previous))
+	for _, row := range current {
+		entry := RowDiff{
+			RowID:      row.RowID,
+			Section:    row.Section,
+			ID:         row.ID,
+			EditorID:   row.EditorID,
+			RecordType: row.RecordType,
+		}
+		key := row.key()
+		if len(pending[key]) == 0 {
+			entry.Status = RowDiffAdded
+			diff.Added++
+			diff.Rows = append(diff.Rows, entry)
+			continue
+		}
+		var previousRow diffRow
+		previousRow, pending[key] = takeCandidate(pending[key], row)
+		matched[previousRow.RowID] = true
+		entry.PreviousRowID = previousRow.RowID
+		if previousRow.SourceText == row.SourceText {
+			entry.Status = RowDiffUnchanged
+			diff.Unchanged++
+		} else {
+			entry.Status = RowDiffChanged
+			diff.Changed++
+		}
+		diff.Rows = append(diff.Rows, entry)
+	}
+
+	for _, row := range previous {
+		if matched[row.RowID] {
+			continue
+		}
+		diff.Rows = append(diff.Rows, RowDiff{
+			Status:        RowDiffRemoved,
+			PreviousRowID: row.RowID,
+			Section:       row.Section,
+			ID:            row.ID,
+			EditorID:      row.EditorID,
+			RecordType:    row.RecordType,
+		})
+		diff.Removed++
+	}
+}
@@ -51,19 +51,39 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 	if output == nil {
 		return InputFile{}, fmt.Errorf("parser output is required")
 	}
+	return r.SaveParsedOutputStream(ctx, taskID, sourceFilePath, func(sink skyrim.ParserOutputSink) error {
+		return sink(output)
+	})
+}
+
+// SaveParsedOutputStream replaces one source file payload, inserting each chunk the stream emits in a single transaction.
+func (r *sqliteRepository) SaveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream ParsedOutputStream) (InputFile, error) {
+	file, _, err := r.saveParsedOutputStream(ctx, taskID, sourceFilePath, stream, false)
+	return file, err
+}
+
+// ReloadParsedOutputStream replaces the previously loaded file of the same name and reports a row-level diff against it.
+func (r *sqliteRepository) ReloadParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream ParsedOutputStream) (InputFile, FileDiff, error) {
+	return r.saveParsedOutputStream(ctx, taskID, sourceFilePath, stream, true)
+}
+
+func (r *sqliteRepository) saveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream ParsedOutputStream, withDiff bool) (InputFile, FileDiff, error) {
+	if stream == nil {
+		return InputFile{}, FileDiff{}, fmt.Errorf("parser output stream is required")
+	}
 
 	trimmedTaskID := strings.TrimSpace(taskID)
 	if trimmedTaskID == "" {
-		return InputFile{}, fmt.Errorf("task_id is required")
+		return InputFile{}, FileDiff{}, fmt.Errorf("task_id is required")
 	}
 
 	normalizedPath := strings.TrimSpace(filepath.Clean(sourceFilePath))
 	if normalizedPath == "" {
-		return InputFile{}, fmt.Errorf("source_file_path is required")
+		return InputFile{}, FileDiff{}, fmt.Errorf("source_file_path is required")
 	}
 
 	if err := r.EnsureTask(ctx, trimmedTaskID); err != nil {
-		return InputFile{}, fmt.Errorf("ensure task before save parsed output task_id=%s: %w", trimmedTaskID, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("ensure task before save parsed output task_id=%s: %w", trimmedTaskID, err)
 	}
 
 	fileName := filepath.Base(normalizedPath)
@@ -72,7 +92,7 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return InputFile{}, fmt.Errorf("begin transaction task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("begin transaction task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
 	}
 	defer func() {
 		_ = tx.Rollback()
@@ -80,11 +100,38 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 
 	existingFileID, hasExisting, err := r.findExistingFile(ctx, tx, trimmedTaskID, fileHash)
 	if err != nil {
-		return InputFile{}, fmt.Errorf("find existing file before save task_id=%s file_hash=%s: %w", trimmedTaskID, fileHash, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("find existing file before save task_id=%s file_hash=%s: %w", trimmedTaskID, fileHash, err)
 	}
+	replacedFileIDs := make([]int64, 0, 1)
 	if hasExisting {
-		if _, err := tx.ExecContext(ctx, `DELETE FROM translation_input_files WHERE id = ?`, existingFileID); err != nil {
-			return InputFile{}, fmt.Errorf("delete existing translation file id=%d: %w", existingFileID, err)
+		replacedFileIDs = append(replacedFileIDs, existingFileID)
+	}
+
+	var previousRows []diffRow
+	fileDiff := FileDiff{}
+	if withDiff {
+		sameNameIDs, err := r.findFilesByName(ctx, tx, trimmedTaskID, fileName)
+		if err != nil {
+			return InputFile{}, FileDiff{}, fmt.Errorf("find previous file before reload task_id=%s file=%s: %w", trimmedTaskID, fileName, err)
+		}
+		for _, id := range sameNameIDs {
+			if !hasExisting || id != existingFileID {
+				replacedFileIDs = append(replacedFileIDs, id)
+			}
+		}
+		if len(sameNameIDs) > 0 {
+			// The most recently loaded file of the same name is the baseline for the diff.
+			fileDiff.PreviousFileID = sameNameIDs[len(sameNameIDs)-1]
+			fileDiff.HasPrevious = true
+			previousRows, err = r.loadDiffRows(ctx, tx, trimmedTaskID, fileDiff.PreviousFileID)
+			if err != nil {
+				return InputFile{}, FileDiff{}, fmt.Errorf("snapshot previous rows file_id=%d: %w", fileDiff.PreviousFileID, err)
+			}
+		}
+	}
+	for _, id := range replacedFileIDs {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM translation_input_files WHERE id = ?`, id); err != nil {
+			return InputFile{}, FileDiff{}, fmt.Errorf("delete existing translation file id=%d: %w", id, err)
 		}
 	}
 
@@ -96,51 +143,36 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 		VALUES (?, ?, ?, ?, 'loaded', 0, ?, ?, ?)
 	`, trimmedTaskID, normalizedPath, fileName, fileHash, now, now, now)
 	if err != nil {
-		return InputFile{}, fmt.Errorf("insert translation file task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("insert translation file task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
 	}
 
 	fileID, err := insertResult.LastInsertId()
 	if err != nil {
-		return InputFile{}, fmt.Errorf("resolve inserted translation file id task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("resolve inserted translation file id task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
 	}
 
-	if err := r.insertDialogue(ctx, tx, fileID, output.DialogueGroups); err != nil {
-		return InputFile{}, fmt.Errorf("insert dialogue file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertQuests(ctx, tx, fileID, output.Quests); err != nil {
-		return InputFile{}, fmt.Errorf("insert quests file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertItems(ctx, tx, fileID, output.Items); err != nil {
-		return InputFile{}, fmt.Errorf("insert items file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertMagic(ctx, tx, fileID, output.Magic); err != nil {
-		return InputFile{}, fmt.Errorf("insert magic file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertLocations(ctx, tx, fileID, output.Locations); err != nil {
-		return InputFile{}, fmt.Errorf("insert locations file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertCells(ctx, tx, fileID, output.Cells); err != nil {
-		return InputFile{}, fmt.Errorf("insert cells file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertSystemRecords(ctx, tx, fileID, output.System); err != nil {
-		return InputFile{}, fmt.Errorf("insert system records file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertMessages(ctx, tx, fileID, output.Messages); err != nil {
-		return InputFile{}, fmt.Errorf("insert messages file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertLoadScreens(ctx, tx, fileID, output.LoadScreens); err != nil {
-		return InputFile{}, fmt.Errorf("insert load screens file_id=%d: %w", fileID, err)
-	}
-	if err := r.insertNPCs(ctx, tx, fileID, output.NPCs); err != nil {
-		return InputFile{}, fmt.Errorf("insert npcs file_id=%d: %w", fileID, err)
+	chunkCount := 0
+	if err := stream(func(chunk *skyrim.ParserOutput) error {
+		if chunk == nil {
+			return nil
+		}
+		chunkCount++
+		return r.insertParsedChunk(ctx, tx, fileID, fileName, chunk)
+	}); err != nil {
+		return InputFile{}, FileDiff{}, fmt.Errorf("save parsed output stream file_id=%d chunks=%d: %w", fileID, chunkCount, err)
 	}
-	if err := r.insertTerminologyEntries(ctx, tx, fileID, fileName, output); err != nil {
-		return InputFile{}, fmt.Errorf("insert terminology entries file_id=%d: %w", fileID, err)
+
+	if withDiff {
+		currentRows, err := r.loadDiffRows(ctx, tx, trimmedTaskID, fileID)
+		if err != nil {
+			return InputFile{}, FileDiff{}, fmt.Errorf("load reloaded rows file_id=%d: %w", fileID, err)
+		}
+		diffFileRows(&fileDiff, previousRows, currentRows)
 	}
 
 	previewCount, err := r.countPreviewRows(ctx, tx, fileID)
 	if err != nil {
-		return InputFile{}, fmt.Errorf("count preview rows after save file_id=%d: %w", fileID, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("count preview rows after save file_id=%d: %w", fileID, err)
 	}
 
 	if _, err := tx.ExecContext(ctx, `
@@ -148,7 +180,7 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 		SET preview_row_count = ?, updated_at = ?
 		WHERE id = ?
 	`, previewCount, now, fileID); err != nil {
-		return InputFile{}, fmt.Errorf("update preview_row_count file_id=%d: %w", fileID, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("update preview_row_count file_id=%d: %w", fileID, err)
 	}
 
 	if _, err := tx.ExecContext(ctx, `
@@ -156,11 +188,11 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 		SET status = 'loaded', updated_at = ?
 		WHERE task_id = ?
 	`, now, trimmedTaskID); err != nil {
-		return InputFile{}, fmt.Errorf("update translation input task status task_id=%s: %w", trimmedTaskID, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("update translation input task status task_id=%s: %w", trimmedTaskID, err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return InputFile{}, fmt.Errorf("commit translation file save task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
+		return InputFile{}, FileDiff{}, fmt.Errorf("commit translation file save task_id=%s file=%s: %w", trimmedTaskID, normalizedPath, err)
 	}
 
 	return InputFile{
@@ -171,7 +203,7 @@ func (r *sqliteRepository) SaveParsedOutput(ctx context.Context, taskID string,
 		SourceFileHash:  fileHash,
 		ParseStatus:     "loaded",
 		PreviewRowCount: previewCount,
-	}, nil
+	}, fileDiff, nil
 }
 
 // ListFiles returns parsed file rows for one task.
@@ -457,6 +489,157 @@ func (r *sqliteRepository) LoadPersonaInput(ctx context.Context, taskID string)
 	return input, nil
 }
 
+// LoadMainTranslationInput projects body-text targets not covered by terminology from saved translation input rows.
+func (r *sqliteRepository) LoadMainTranslationInput(ctx context.Context, taskID string) (MainTranslationInput, error) {
+	trimmedTaskID := strings.TrimSpace(taskID)
+	if trimmedTaskID == "" {
+		return MainTranslationInput{}, fmt.Errorf("task_id is required")
+	}
+
+	input := MainTranslationInput{
+		TaskID:  trimmedTaskID,
+		NPCs:    make(map[string]MainTranslationNPC),
+		Entries: make([]MainTranslationEntry, 0),
+	}
+
+	npcRows, err := r.db.QueryContext(ctx, `
+		SELECT
+			COALESCE(n.source_record_id, ''),
+			COALESCE(n.editor_id, ''),
+			COALESCE(n.name, ''),
+			COALESCE(n.race, ''),
+			COALESCE(n.sex, ''),
+			COALESCE(n.voice, '')
+		FROM translation_input_npcs n
+		JOIN translation_input_files f ON f.id = n.file_id
+		WHERE f.task_id = ?
+		ORDER BY f.id, n.id
+	`, trimmedTaskID)
+	if err != nil {
+		return MainTranslationInput{}, fmt.Errorf("load main translation npcs task_id=%s: %w", trimmedTaskID, err)
+	}
+	defer npcRows.Close()
+
+	for npcRows.Next() {
+		var npc MainTranslationNPC
+		if err := npcRows.Scan(&npc.SpeakerID, &npc.EditorID, &npc.Name, &npc.Race, &npc.Sex, &npc.VoiceType); err != nil {
+			return MainTranslationInput{}, fmt.Errorf("scan main translation npc task_id=%s: %w", trimmedTaskID, err)
+		}
+		npc.SpeakerID = strings.TrimSpace(npc.SpeakerID)
+		if npc.SpeakerID == "" {
+			continue
+		}
+		input.NPCs[npc.SpeakerID] = npc
+	}
+	if err := npcRows.Err(); err != nil {
+		return MainTranslationInput{}, fmt.Errorf("iterate main translation npcs task_id=%s: %w", trimmedTaskID, err)
+	}
+
+	dialogueContexts, err := r.loadMainTranslationDialogueContexts(ctx, trimmedTaskID)
+	if err != nil {
+		return MainTranslationInput{}, err
+	}
+
+	entryRows, err := r.db.QueryContext(ctx, mainTranslationUnionSQL+` ORDER BY file_id ASC, section_order ASC, row_pk ASC`, mainTranslationUnionArgs(trimmedTaskID)...)
+	if err != nil {
+		return MainTranslationInput{}, fmt.Errorf("load main translation entries task_id=%s: %w", trimmedTaskID, err)
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var entry MainTranslationEntry
+		var fileID int64
+		var sectionOrder int
+		var rowPK int64
+		var isServicesBranch int
+		var sourceFileName string
+		var sourceJSONPath string
+		var source string
+		if err := entryRows.Scan(
+			&fileID,
+			&sectionOrder,
+			&rowPK,
+			&entry.RowID,
+			&entry.Section,
+			&entry.ID,
+			&entry.EditorID,
+			&entry.RecordType,
+			&entry.SourceText,
+			&sourceFileName,
+			&sourceJSONPath,
+			&source,
+			&entry.SpeakerID,
+			&entry.QuestID,
+			&entry.ParentID,
+			&entry.ParentEditorID,
+			&entry.TypeHint,
+			&isServicesBranch,
+			&entry.Order,
+		); err != nil {
+			return MainTranslationInput{}, fmt.Errorf("scan main translation entry task_id=%s: %w", trimmedTaskID, err)
+		}
+
+		entry.RecordType = mainTranslationRecordType(entry.Section, entry.RecordType)
+		if isTerminologyCoveredEntry(entry) {
+			continue
+		}
+		entry.SourceFile = sourceFileName
+		entry.SourcePlugin = resolvePersonaSourcePlugin(sourceFileName, sourceJSONPath, source)
+		entry.IsServicesBranch = isServicesBranch != 0
+		if dialogueCtx, ok := dialogueContexts[rowPK]; ok && entry.Section == "dialogue_response" {
+			entry.PreviousID = dialogueCtx.PreviousID
+			entry.TopicText = dialogueCtx.TopicText
+			entry.PlayerText = dialogueCtx.PlayerText
+		}
+		input.Entries = append(input.Entries, entry)
+	}
+	if err := entryRows.Err(); err != nil {
+		return MainTranslationInput{}, fmt.Errorf("iterate main translation entries task_id=%s: %w", trimmedTaskID, err)
+	}
+
+	return input, nil
+}
+
+// mainTranslationDialogueContext carries the conversation links of one dialogue response row.
+type mainTranslationDialogueContext struct {
+	PreviousID string
+	TopicText  string
+	PlayerText string
+}
+
+// loadMainTranslationDialogueContexts loads conversation links keyed by dialogue response primary key.
+func (r *sqliteRepository) loadMainTranslationDialogueContexts(ctx context.Context, taskID string) (map[int64]mainTranslationDialogueContext, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			r.id,
+			COALESCE(r.previous_id, ''),
+			COALESCE(r.topic_text, ''),
+			COALESCE(g.player_text, '')
+		FROM translation_input_dialogue_responses r
+		JOIN translation_input_dialogue_groups g ON g.id = r.dialogue_group_id
+		JOIN translation_input_files f ON f.id = g.file_id
+		WHERE f.task_id = ?
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load main translation dialogue contexts task_id=%s: %w", taskID, err)
+	}
+	defer rows.Close()
+
+	contexts := make(map[int64]mainTranslationDialogueContext)
+	for rows.Next() {
+		var rowPK int64
+		var dialogueCtx mainTranslationDialogueContext
+		if err := rows.Scan(&rowPK, &dialogueCtx.PreviousID, &dialogueCtx.TopicText, &dialogueCtx.PlayerText); err != nil {
+			return nil, fmt.Errorf("scan main translation dialogue context task_id=%s: %w", taskID, err)
+		}
+		contexts[rowPK] = dialogueCtx
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate main translation dialogue contexts task_id=%s: %w", taskID, err)
+	}
+	return contexts, nil
+}
+
 func (r *sqliteRepository) insertTerminologyEntries(ctx context.Context, tx *sql.Tx, fileID int64, sourceFileName string, output *skyrim.ParserOutput) error {
 	entries := terminologyEntriesFromOutput(output, sourceFileName)
 	now := time.Now().UTC()
@@ -483,6 +666,47 @@ func (r *sqliteRepository) insertTerminologyEntries(ctx context.Context, tx *sql
 	return nil
 }
 
+// insertParsedChunk writes every section of one parser output chunk under the file row.
+func (r *sqliteRepository) insertParsedChunk(ctx context.Context, tx *sql.Tx, fileID int64, fileName string, chunk *skyrim.ParserOutput) error {
+	if err := r.insertDialogue(ctx, tx, fileID, chunk.DialogueGroups); err != nil {
+		return fmt.Errorf("insert dialogue file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertQuests(ctx, tx, fileID, chunk.Quests); err != nil {
+		return fmt.Errorf("insert quests file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertItems(ctx, tx, fileID, chunk.Items); err != nil {
+		return fmt.Errorf("insert items file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertMagic(ctx, tx, fileID, chunk.Magic); err != nil {
+		return fmt.Errorf("insert magic file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertLocations(ctx, tx, fileID, chunk.Locations); err != nil {
+		return fmt.Errorf("insert locations file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertCells(ctx, tx, fileID, chunk.Cells); err != nil {
+		return fmt.Errorf("insert cells file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertSystemRecords(ctx, tx, fileID, chunk.System); err != nil {
+		return fmt.Errorf("insert system records file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertMessages(ctx, tx, fileID, chunk.Messages); err != nil {
+		return fmt.Errorf("insert messages file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertLoadScreens(ctx, tx, fileID, chunk.LoadScreens); err != nil {
+		return fmt.Errorf("insert load screens file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertNPCs(ctx, tx, fileID, chunk.NPCs); err != nil {
+		return fmt.Errorf("insert npcs file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertInterfaceTranslations(ctx, tx, fileID, chunk.InterfaceTranslations); err != nil {
+		return fmt.Errorf("insert interface translations file_id=%d: %w", fileID, err)
+	}
+	if err := r.insertTerminologyEntries(ctx, tx, fileID, fileName, chunk); err != nil {
+		return fmt.Errorf("insert terminology entries file_id=%d: %w", fileID, err)
+	}
+	return nil
+}
+
 func (r *sqliteRepository) findExistingFile(ctx context.Context, tx *sql.Tx, taskID string, sourceHash string) (int64, bool, error) {
 	var fileID int64
 	err := tx.QueryRowContext(ctx, `
@@ -829,6 +1053,28 @@ func (r *sqliteRepository) insertNPCs(ctx context.Context, tx *sql.Tx, fileID in
 	return nil
 }
 
+func (r *sqliteRepository) insertInterfaceTranslations(ctx context.Context, tx *sql.Tx, fileID int64, translations []skyrim.InterfaceTranslation) error {
+	for _, translation := range translations {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO translation_input_interface_translations (
+				file_id, source_record_id, record_type, source_json_path, mod_name, text, source
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`,
+			fileID,
+			translation.ID,
+			translation.Type,
+			nullableStringValue(translation.SourceJSON),
+			translation.ModName,
+			translation.Text,
+			nullableString(translation.Source),
+		); err != nil {
+			return fmt.Errorf("insert interface translation file_id=%d key=%s: %w", fileID, translation.ID, err)
+		}
+	}
+	return nil
+}
+
 func (r *sqliteRepository) countPreviewRows(ctx context.Context, querier sqlQuerier, fileID int64) (int, error) {
 	query := `SELECT COUNT(1) FROM (` + previewUnionSQL + `)`
 	var count int
@@ -881,8 +1127,8 @@ func boolToInt(value bool) int {
 }
 
 func previewUnionArgs(fileID int64) []any {
-	args := make([]any, 0, 16)
-	for i := 0; i < 16; i++ {
+	args := make([]any, 0, 17)
+	for i := 0; i < 17; i++ {
 		args = append(args, fileID)
 	}
 	return args
@@ -991,6 +1237,55 @@ func trimStringPtr(value *string) string {
 	return strings.TrimSpace(*value)
 }
 
+// mainTranslationFieldBySection maps preview sections to the xEdit field signature they translate.
+var mainTranslationFieldBySection = map[string]string{
+	"dialogue_response":  "NAM1",
+	"quest_stage":        "CNAM",
+	"quest_objective":    "NNAM",
+	"item_name":          "FULL",
+	"item_description":   "DESC",
+	"item_text":          "DESC",
+	"magic_name":         "FULL",
+	"magic_description":  "DESC",
+	"location_name":      "FULL",
+	"cell_name":          "FULL",
+	"system_name":        "FULL",
+	"system_description": "DESC",
+	"message_text":       "DESC",
+	"message_title":      "FULL",
+	"load_screen_text":   "DESC",
+	"interface_text":     "TEXT",
+}
+
+// mainTranslationRecordType resolves "SIG FIELD" for one section using the owning record signature.
+func mainTranslationRecordType(section string, recordType string) string {
+	signature := ""
+	if fields := strings.Fields(strings.TrimSpace(recordType)); len(fields) > 0 {
+		signature = strings.ToUpper(strings.SplitN(fields[0], ":", 2)[0])
+	}
+	field, ok := mainTranslationFieldBySection[section]
+	if !ok || signature == "" {
+		return strings.TrimSpace(recordType)
+	}
+	return signature + " " + field
+}
+
+// isTerminologyCoveredEntry reports whether the terminology phase already owns the row.
+func isTerminologyCoveredEntry(entry MainTranslationEntry) bool {
+	if mainTranslationFieldBySection[entry.Section] != "FULL" {
+		return false
+	}
+	return foundation.IsDictionaryImportREC(normalizeTerminologyRecordType(entry.RecordType))
+}
+
+func mainTranslationUnionArgs(taskID string) []any {
+	args := make([]any, 0, 16)
+	for i := 0; i < 16; i++ {
+		args = append(args, taskID)
+	}
+	return args
+}
+
 var sourcePluginPattern = regexp.MustCompile(`(?i)[^\\/:*?"<>|]+\.(esm|esl|esp)`)
 
 func resolvePersonaSourcePlugin(candidates ...string) string {
@@ -1192,5 +1487,266 @@ FROM (
 		n.name AS source_text
 	FROM translation_input_npcs n
 	WHERE n.file_id = ? AND TRIM(COALESCE(n.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		printf('interface_text:%d', it.id) AS row_id,
+		'interface_text' AS section,
+		COALESCE(it.record_type, '') AS record_type,
+		it.source_record_id AS editor_id,
+		it.text AS source_text
+	FROM translation_input_interface_translations it
+	WHERE it.file_id = ? AND TRIM(COALESCE(it.text, '')) <> ''
 ) preview_rows
 `
+
+const mainTranslationUnionSQL = `
+SELECT
+	file_id, section_order, row_pk, row_id, section, source_record_id, editor_id, record_type, source_text,
+	source_file_name, source_json_path, source, speaker_id, quest_id, parent_id, parent_editor_id,
+	type_hint, is_services_branch, sort_order
+FROM (
+	SELECT
+		f.id AS file_id, 1 AS section_order, r.id AS row_pk,
+		printf('dialogue_response:%d', r.id) AS row_id,
+		'dialogue_response' AS section,
+		r.source_record_id AS source_record_id,
+		COALESCE(NULLIF(r.editor_id, ''), NULLIF(g.editor_id, ''), '') AS editor_id,
+		COALESCE(r.record_type, '') AS record_type,
+		r.text AS source_text,
+		f.source_file_name AS source_file_name,
+		COALESCE(NULLIF(r.source_json_path, ''), g.source_json_path, '') AS source_json_path,
+		COALESCE(NULLIF(r.source, ''), g.source, '') AS source,
+		COALESCE(r.speaker_id, '') AS speaker_id,
+		COALESCE(g.quest_id, '') AS quest_id,
+		g.source_record_id AS parent_id,
+		COALESCE(g.editor_id, '') AS parent_editor_id,
+		'' AS type_hint,
+		g.is_services_branch AS is_services_branch,
+		r.response_order AS sort_order
+	FROM translation_input_dialogue_responses r
+	JOIN translation_input_dialogue_groups g ON g.id = r.dialogue_group_id
+	JOIN translation_input_files f ON f.id = g.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(r.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 2, qs.id,
+		printf('quest_stage:%d', qs.id),
+		'quest_stage',
+		q.source_record_id,
+		COALESCE(NULLIF(q.editor_id, ''), qs.parent_editor_id, ''),
+		COALESCE(q.record_type, ''),
+		qs.text,
+		f.source_file_name,
+		COALESCE(q.source_json_path, ''),
+		COALESCE(NULLIF(qs.source, ''), q.source, ''),
+		'',
+		q.source_record_id,
+		COALESCE(NULLIF(qs.parent_id, ''), q.source_record_id),
+		COALESCE(NULLIF(qs.parent_editor_id, ''), q.editor_id, ''),
+		'',
+		0,
+		qs.stage_index
+	FROM translation_input_quest_stages qs
+	JOIN translation_input_quests q ON q.id = qs.quest_id
+	JOIN translation_input_files f ON f.id = q.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(qs.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 3, qo.id,
+		printf('quest_objective:%d', qo.id),
+		'quest_objective',
+		q.source_record_id,
+		COALESCE(NULLIF(q.editor_id, ''), qo.parent_editor_id, ''),
+		COALESCE(q.record_type, ''),
+		qo.text,
+		f.source_file_name,
+		COALESCE(q.source_json_path, ''),
+		COALESCE(NULLIF(qo.source, ''), q.source, ''),
+		'',
+		q.source_record_id,
+		COALESCE(NULLIF(qo.parent_id, ''), q.source_record_id),
+		COALESCE(NULLIF(qo.parent_editor_id, ''), q.editor_id, ''),
+		'',
+		0,
+		0
+	FROM translation_input_quest_objectives qo
+	JOIN translation_input_quests q ON q.id = qo.quest_id
+	JOIN translation_input_files f ON f.id = q.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(qo.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 4, i.id,
+		printf('item_name:%d', i.id),
+		'item_name',
+		i.source_record_id, COALESCE(i.editor_id, ''), COALESCE(i.record_type, ''), i.name,
+		f.source_file_name, COALESCE(i.source_json_path, ''), COALESCE(i.source, ''),
+		'', '', '', '', COALESCE(i.type_hint, ''), 0, 0
+	FROM translation_input_items i
+	JOIN translation_input_files f ON f.id = i.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(i.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 5, i.id,
+		printf('item_description:%d', i.id),
+		'item_description',
+		i.source_record_id, COALESCE(i.editor_id, ''), COALESCE(i.record_type, ''), i.description,
+		f.source_file_name, COALESCE(i.source_json_path, ''), COALESCE(i.source, ''),
+		'', '', '', '', COALESCE(i.type_hint, ''), 0, 0
+	FROM translation_input_items i
+	JOIN translation_input_files f ON f.id = i.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(i.description, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 6, i.id,
+		printf('item_text:%d', i.id),
+		'item_text',
+		i.source_record_id, COALESCE(i.editor_id, ''), COALESCE(i.record_type, ''), i.text,
+		f.source_file_name, COALESCE(i.source_json_path, ''), COALESCE(i.source, ''),
+		'', '', '', '', COALESCE(i.type_hint, ''), 0, 0
+	FROM translation_input_items i
+	JOIN translation_input_files f ON f.id = i.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(i.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 7, m.id,
+		printf('magic_name:%d', m.id),
+		'magic_name',
+		m.source_record_id, COALESCE(m.editor_id, ''), COALESCE(m.record_type, ''), m.name,
+		f.source_file_name, COALESCE(m.source_json_path, ''), COALESCE(m.source, ''),
+		'', '', '', '', '', 0, 0
+	FROM translation_input_magic m
+	JOIN translation_input_files f ON f.id = m.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(m.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 8, m.id,
+		printf('magic_description:%d', m.id),
+		'magic_description',
+		m.source_record_id, COALESCE(m.editor_id, ''), COALESCE(m.record_type, ''), m.description,
+		f.source_file_name, COALESCE(m.source_json_path, ''), COALESCE(m.source, ''),
+		'', '', '', '', '', 0, 0
+	FROM translation_input_magic m
+	JOIN translation_input_files f ON f.id = m.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(m.description, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 9, l.id,
+		printf('location_name:%d', l.id),
+		'location_name',
+		l.source_record_id, COALESCE(l.editor_id, ''), COALESCE(l.record_type, ''), l.name,
+		f.source_file_name, COALESCE(l.source_json_path, ''), COALESCE(l.source, ''),
+		'', '', COALESCE(l.parent_id, ''), '', '', 0, 0
+	FROM translation_input_locations l
+	JOIN translation_input_files f ON f.id = l.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(l.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 10, c.id,
+		printf('cell_name:%d', c.id),
+		'cell_name',
+		c.source_record_id, COALESCE(c.editor_id, ''), COALESCE(c.record_type, ''), c.name,
+		f.source_file_name, COALESCE(c.source_json_path, ''), COALESCE(c.source, ''),
+		'', '', COALESCE(c.parent_id, ''), '', '', 0, 0
+	FROM translation_input_cells c
+	JOIN translation_input_files f ON f.id = c.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(c.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 11, s.id,
+		printf('system_name:%d', s.id),
+		'system_name',
+		s.source_record_id, COALESCE(s.editor_id, ''), COALESCE(s.record_type, ''), s.name,
+		f.source_file_name, COALESCE(s.source_json_path, ''), COALESCE(s.source, ''),
+		'', '', '', '', '', 0, 0
+	FROM translation_input_system_records s
+	JOIN translation_input_files f ON f.id = s.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(s.name, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 12, s.id,
+		printf('system_description:%d', s.id),
+		'system_description',
+		s.source_record_id, COALESCE(s.editor_id, ''), COALESCE(s.record_type, ''), s.description,
+		f.source_file_name, COALESCE(s.source_json_path, ''), COALESCE(s.source, ''),
+		'', '', '', '', '', 0, 0
+	FROM translation_input_system_records s
+	JOIN translation_input_files f ON f.id = s.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(s.description, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 13, m.id,
+		printf('message_text:%d', m.id),
+		'message_text',
+		m.source_record_id, COALESCE(m.editor_id, ''), COALESCE(m.record_type, ''), m.text,
+		f.source_file_name, COALESCE(m.source_json_path, ''), COALESCE(m.source, ''),
+		'', COALESCE(m.quest_id, ''), '', '', '', 0, 0
+	FROM translation_input_messages m
+	JOIN translation_input_files f ON f.id = m.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(m.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 14, m.id,
+		printf('message_title:%d', m.id),
+		'message_title',
+		m.source_record_id, COALESCE(m.editor_id, ''), COALESCE(m.record_type, ''), m.title,
+		f.source_file_name, COALESCE(m.source_json_path, ''), COALESCE(m.source, ''),
+		'', COALESCE(m.quest_id, ''), '', '', '', 0, 0
+	FROM translation_input_messages m
+	JOIN translation_input_files f ON f.id = m.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(m.title, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 15, ls.id,
+		printf('load_screen_text:%d', ls.id),
+		'load_screen_text',
+		ls.source_record_id, COALESCE(ls.editor_id, ''), COALESCE(ls.record_type, ''), ls.text,
+		f.source_file_name, COALESCE(ls.source_json_path, ''), COALESCE(ls.source, ''),
+		'', '', '', '', '', 0, 0
+	FROM translation_input_load_screens ls
+	JOIN translation_input_files f ON f.id = ls.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(ls.text, '')) <> ''
+
+	UNION ALL
+
+	SELECT
+		f.id, 16, it.id,
+		printf('interface_text:%d', it.id),
+		'interface_text',
+		it.source_record_id, it.source_record_id, COALESCE(it.record_type, ''), it.text,
+		f.source_file_name, COALESCE(it.source_json_path, ''), COALESCE(it.source, ''),
+		'', '', it.mod_name, '', 'MCM', 0, 0
+	FROM translation_input_interface_translations it
+	JOIN translation_input_files f ON f.id = it.file_id
+	WHERE f.task_id = ? AND TRIM(COALESCE(it.text, '')) <> ''
+) main_translation_rows
+`
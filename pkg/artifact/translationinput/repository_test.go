@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
@@ -21,7 +23,7 @@ func TestRepository_SaveAndPreview_TableDriven(t *testing.T) {
 		{
 			name:                       "all sections are saved and projected into preview",
 			buildOutput:                buildAllSectionOutput,
-			expectedPreviewRows:        19,
+			expectedPreviewRows:        20,
 			expectedTerminologyEntries: 9,
 			verify: func(t *testing.T, db *sql.DB, repo Repository, file InputFile) {
 				t.Helper()
@@ -30,6 +32,7 @@ func TestRepository_SaveAndPreview_TableDriven(t *testing.T) {
 				assertQueryCount(t, db, `SELECT COUNT(1) FROM translation_input_quests WHERE file_id = ?`, file.ID, 1)
 				assertQueryCount(t, db, `SELECT COUNT(1) FROM translation_input_quest_stages qs JOIN translation_input_quests q ON q.id = qs.quest_id WHERE q.file_id = ?`, file.ID, 1)
 				assertQueryCount(t, db, `SELECT COUNT(1) FROM translation_input_quest_objectives qo JOIN translation_input_quests q ON q.id = qo.quest_id WHERE q.file_id = ?`, file.ID, 1)
+				assertQueryCount(t, db, `SELECT COUNT(1) FROM translation_input_interface_translations WHERE file_id = ?`, file.ID, 1)
 
 				preview, err := repo.ListPreviewRows(context.Background(), file.ID, 1, 50)
 				if err != nil {
@@ -52,6 +55,7 @@ func TestRepository_SaveAndPreview_TableDriven(t *testing.T) {
 					"message_title":      false,
 					"load_screen_text":   false,
 					"npc_name":           false,
+					"interface_text":     false,
 				}
 				for _, row := range preview.Rows {
 					if _, ok := requiredSections[row.Section]; ok {
@@ -204,6 +208,150 @@ func TestRepository_SaveAndPreview_TableDriven(t *testing.T) {
 	}
 }
 
+func TestRepository_SaveParsedOutputStream_MatchesSingleSave(t *testing.T) {
+	db, cleanup := setupRepositoryTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	full := buildAllSectionOutput()
+	first := &skyrim.ParserOutput{
+		DialogueGroups: full.DialogueGroups,
+		Quests:         full.Quests,
+		Items:          full.Items,
+		Magic:          full.Magic,
+	}
+	second := &skyrim.ParserOutput{
+		Locations:             full.Locations,
+		Cells:                 full.Cells,
+		System:                full.System,
+		Messages:              full.Messages,
+		LoadScreens:           full.LoadScreens,
+		NPCs:                  full.NPCs,
+		InterfaceTranslations: full.InterfaceTranslations,
+	}
+
+	savedFile, err := repo.SaveParsedOutputStream(context.Background(), "task-stream", `C:\mods\input.json`, func(sink skyrim.ParserOutputSink) error {
+		for _, chunk := range []*skyrim.ParserOutput{first, second} {
+			if err := sink(chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SaveParsedOutputStream failed: %v", err)
+	}
+	if savedFile.PreviewRowCount != 20 {
+		t.Fatalf("unexpected preview row count: got=%d want=20", savedFile.PreviewRowCount)
+	}
+	terminologyInput, err := repo.LoadTerminologyInput(context.Background(), "task-stream")
+	if err != nil {
+		t.Fatalf("LoadTerminologyInput failed: %v", err)
+	}
+	if len(terminologyInput.Entries) != 9 {
+		t.Fatalf("unexpected terminology entry count: got=%d want=9", len(terminologyInput.Entries))
+	}
+}
+
+func TestRepository_SaveParsedOutputStream_RollsBackOnStreamError(t *testing.T) {
+	db, cleanup := setupRepositoryTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	streamErr := fmt.Errorf("decode failed")
+	_, err := repo.SaveParsedOutputStream(context.Background(), "task-stream-error", `C:\mods\broken.json`, func(sink skyrim.ParserOutputSink) error {
+		if err := sink(buildAllSectionOutput()); err != nil {
+			return err
+		}
+		return streamErr
+	})
+	if err == nil || !strings.Contains(err.Error(), "decode failed") {
+		t.Fatalf("expected stream error, got %v", err)
+	}
+
+	files, err := repo.ListFiles(context.Background(), "task-stream-error")
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected partially streamed file to be rolled back, got %d files", len(files))
+	}
+}
+
+func TestRepository_ReloadParsedOutputStream_DiffsAgainstPreviousLoad(t *testing.T) {
+	db, cleanup := setupRepositoryTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	message := func(id string, text string) skyrim.Message {
+		editorID := "EDID_" + id
+		return skyrim.Message{
+			BaseExtractedRecord: skyrim.BaseExtractedRecord{ID: id, EditorID: &editorID, Type: "MESG"},
+			Text:                text,
+		}
+	}
+	single := func(output *skyrim.ParserOutput) ParsedOutputStream {
+		return func(sink skyrim.ParserOutputSink) error {
+			return sink(output)
+		}
+	}
+
+	previousFile, previousDiff, err := repo.ReloadParsedOutputStream(context.Background(), "task-reload", "/mods/v1/MyMod.esp", single(&skyrim.ParserOutput{
+		Messages: []skyrim.Message{
+			message("00000001", "Keep me"),
+			message("00000002", "Old text"),
+			message("00000003", "Drop me"),
+		},
+	}))
+	if err != nil {
+		t.Fatalf("initial ReloadParsedOutputStream failed: %v", err)
+	}
+	if previousDiff.HasPrevious || previousDiff.Added != 3 {
+		t.Fatalf("unexpected initial diff: %+v", previousDiff)
+	}
+
+	_, diff, err := repo.ReloadParsedOutputStream(context.Background(), "task-reload", "/mods/v2/mymod.ESP", single(&skyrim.ParserOutput{
+		Messages: []skyrim.Message{
+			message("00000001", "Keep me"),
+			message("00000002", "New text"),
+			message("00000004", "Brand new"),
+		},
+	}))
+	if err != nil {
+		t.Fatalf("ReloadParsedOutputStream failed: %v", err)
+	}
+	if !diff.HasPrevious || diff.PreviousFileID != previousFile.ID {
+		t.Fatalf("expected diff against previous file id=%d, got %+v", previousFile.ID, diff)
+	}
+	if diff.Added != 1 || diff.Changed != 1 || diff.Removed != 1 || diff.Unchanged != 1 {
+		t.Fatalf("unexpected diff counts: %+v", diff)
+	}
+	statusByID := make(map[string]RowDiff, len(diff.Rows))
+	for _, row := range diff.Rows {
+		statusByID[row.ID] = row
+	}
+	if row := statusByID["00000001"]; row.Status != RowDiffUnchanged || row.PreviousRowID == "" || row.RowID == row.PreviousRowID {
+		t.Fatalf("unexpected unchanged row: %+v", row)
+	}
+	if row := statusByID["00000002"]; row.Status != RowDiffChanged {
+		t.Fatalf("unexpected changed row: %+v", row)
+	}
+	if row := statusByID["00000003"]; row.Status != RowDiffRemoved || row.RowID != "" {
+		t.Fatalf("unexpected removed row: %+v", row)
+	}
+	if row := statusByID["00000004"]; row.Status != RowDiffAdded || row.PreviousRowID != "" {
+		t.Fatalf("unexpected added row: %+v", row)
+	}
+
+	files, err := repo.ListFiles(context.Background(), "task-reload")
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(files) != 1 || files[0].SourceFileName != "mymod.ESP" {
+		t.Fatalf("expected previous load to be replaced, got %+v", files)
+	}
+}
+
 func TestRepository_LoadTerminologyInput_NormalizesLegacyRecordTypes(t *testing.T) {
 	db, cleanup := setupRepositoryTestDB(t)
 	defer cleanup()
@@ -379,6 +527,64 @@ func TestRepository_LoadPersonaInput_PreservesHintWhenPluginCannotBeResolved(t *
 	}
 }
 
+func TestRepository_LoadMainTranslationInput_ProjectsBodyTextAndSkipsTerminologyNames(t *testing.T) {
+	db, cleanup := setupRepositoryTestDB(t)
+	defer cleanup()
+
+	repo := NewRepository(db)
+	if _, err := repo.SaveParsedOutput(context.Background(), "task-main", "Skyrim.esm", buildAllSectionOutput()); err != nil {
+		t.Fatalf("SaveParsedOutput failed: %v", err)
+	}
+
+	input, err := repo.LoadMainTranslationInput(context.Background(), "task-main")
+	if err != nil {
+		t.Fatalf("LoadMainTranslationInput failed: %v", err)
+	}
+	if len(input.Entries) != 15 {
+		t.Fatalf("unexpected main translation entry count: got=%d want=15", len(input.Entries))
+	}
+	if _, ok := input.NPCs["npc-1"]; !ok {
+		t.Fatalf("expected npc keyed by source_record_id")
+	}
+
+	recordTypes := make(map[string]int)
+	for _, entry := range input.Entries {
+		recordTypes[entry.RecordType]++
+		if entry.SourcePlugin != "Skyrim.esm" {
+			t.Fatalf("unexpected source plugin: got=%q want=%q", entry.SourcePlugin, "Skyrim.esm")
+		}
+	}
+	for _, excluded := range []string{"BOOK FULL", "WEAP FULL", "LCTN FULL", "CELL FULL"} {
+		if recordTypes[excluded] != 0 {
+			t.Fatalf("expected terminology-covered record type %s to be excluded", excluded)
+		}
+	}
+	for _, included := range []string{"INFO NAM1", "QUST CNAM", "QUST NNAM", "SPEL FULL", "SPEL DESC", "GMST FULL", "MESG FULL", "LSCR DESC", "MCM TEXT"} {
+		if recordTypes[included] != 1 {
+			t.Fatalf("unexpected count for record type %s: got=%d want=1", included, recordTypes[included])
+		}
+	}
+
+	dialogue := input.Entries[0]
+	if dialogue.Section != "dialogue_response" {
+		t.Fatalf("unexpected first section: got=%q want=%q", dialogue.Section, "dialogue_response")
+	}
+	if dialogue.SpeakerID != "npc_1" || dialogue.QuestID != "QuestID01" || dialogue.ParentID != "dg-1" {
+		t.Fatalf("unexpected dialogue context: speaker=%q quest=%q parent=%q", dialogue.SpeakerID, dialogue.QuestID, dialogue.ParentID)
+	}
+	if dialogue.PreviousID != "prev" || dialogue.TopicText != "topic" || dialogue.PlayerText != "Player" {
+		t.Fatalf("unexpected dialogue conversation links: previous=%q topic=%q player=%q", dialogue.PreviousID, dialogue.TopicText, dialogue.PlayerText)
+	}
+	if !strings.HasPrefix(dialogue.RowID, "dialogue_response:") {
+		t.Fatalf("unexpected dialogue row id: got=%q", dialogue.RowID)
+	}
+
+	mcm := input.Entries[len(input.Entries)-1]
+	if mcm.Section != "interface_text" || mcm.ID != "$MYMOD_ENABLE" || mcm.ParentID != "MyMod" || mcm.TypeHint != "MCM" {
+		t.Fatalf("unexpected interface translation entry: %+v", mcm)
+	}
+}
+
 func setupRepositoryTestDB(t *testing.T) (*sql.DB, func()) {
 	t.Helper()
 
@@ -583,6 +789,11 @@ func buildAllSectionOutput() *skyrim.ParserOutput {
 				Source:              &npcSource,
 			},
 		},
+		InterfaceTranslations: []skyrim.InterfaceTranslation{{
+			BaseExtractedRecord: skyrim.BaseExtractedRecord{ID: "$MYMOD_ENABLE", Type: skyrim.InterfaceTranslationRecordType, SourceJSON: sourcePath},
+			Text:                "Enable feature",
+			ModName:             "MyMod",
+		}},
 	}
 }
 
@@ -633,3 +844,51 @@ func buildDialogueOnlyOutput(count int) *skyrim.ParserOutput {
 		},
 	}
 }
+
+func TestDiffFileRows_MatchesByIdentityAndPairsDuplicatesByOrder(t *testing.T) {
+	row := func(rowID string, id string, order int, text string) diffRow {
+		return diffRow{RowID: rowID, Section: "dialogue_response", ID: id, EditorID: "EDID_" + id, RecordType: "INFO NAM1", SourceText: text, Order: order}
+	}
+	tests := []struct {
+		name     string
+		previous []diffRow
+		current  []diffRow
+		want     map[string]RowDiffStatus
+	}{
+		{
+			name:     "前に行が挿入されても後続の行は未変更になる",
+			previous: []diffRow{row("p1", "00000001", 1, "Hello"), row("p2", "00000002", 2, "Bye")},
+			current:  []diffRow{row("c0", "00000009", 1, "New"), row("c1", "00000001", 2, "Hello"), row("c2", "00000002", 3, "Bye")},
+			want:     map[string]RowDiffStatus{"c0": RowDiffAdded, "c1": RowDiffUnchanged, "c2": RowDiffUnchanged},
+		},
+		{
+			name:     "同じキーの重複は順序で対応付ける",
+			previous: []diffRow{row("p2", "00000001", 2, "Second"), row("p1", "00000001", 1, "First")},
+			current:  []diffRow{row("c1", "00000001", 1, "First"), row("c2", "00000001", 2, "Second changed")},
+			want:     map[string]RowDiffStatus{"c1": RowDiffUnchanged, "c2": RowDiffChanged},
+		},
+		{
+			name:     "重複が減った場合は残りを削除として扱う",
+			previous: []diffRow{row("p1", "00000001", 1, "First"), row("p2", "00000001", 2, "Second")},
+			current:  []diffRow{row("c1", "00000001", 1, "First")},
+			want:     map[string]RowDiffStatus{"c1": RowDiffUnchanged, "removed:p2": RowDiffRemoved},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var diff FileDiff
+			diffFileRows(&diff, tc.previous, tc.current)
+			got := make(map[string]RowDiffStatus, len(diff.Rows))
+			for _, entry := range diff.Rows {
+				if entry.Status == RowDiffRemoved {
+					got["removed:"+entry.PreviousRowID] = entry.Status
+					continue
+				}
+				got[entry.RowID] = entry.Status
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected diff statuses: got=%v want=%v", got, tc.want)
+			}
+		})
+	}
+}
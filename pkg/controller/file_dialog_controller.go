@@ -9,12 +9,14 @@ import (
 
 type openFileDialogFunc func(ctx context.Context, options runtime.OpenDialogOptions) (string, error)
 type openMultipleFilesDialogFunc func(ctx context.Context, options runtime.OpenDialogOptions) ([]string, error)
+type openDirectoryDialogFunc func(ctx context.Context, options runtime.OpenDialogOptions) (string, error)
 
 // FileDialogController exposes Wails-facing file selection dialogs.
 type FileDialogController struct {
 	ctx                     context.Context
 	openFileDialog          openFileDialogFunc
 	openMultipleFilesDialog openMultipleFilesDialogFunc
+	openDirectoryDialog     openDirectoryDialogFunc
 }
 
 // NewFileDialogController constructs the file dialog controller adapter.
@@ -23,6 +25,7 @@ func NewFileDialogController() *FileDialogController {
 		ctx:                     context.Background(),
 		openFileDialog:          runtime.OpenFileDialog,
 		openMultipleFilesDialog: runtime.OpenMultipleFilesDialog,
+		openDirectoryDialog:     runtime.OpenDirectoryDialog,
 	}
 }
 
@@ -50,12 +53,14 @@ func (c *FileDialogController) SelectFiles() ([]string, error) {
 	return files, nil
 }
 
-// SelectTranslationInputFiles opens a multi-file dialog for translation input JSON files.
+// SelectTranslationInputFiles opens a multi-file dialog for translation input JSON, plugin or MCM translation files.
 func (c *FileDialogController) SelectTranslationInputFiles() ([]string, error) {
 	files, err := c.openMultipleFilesDialog(c.context(), runtime.OpenDialogOptions{
-		Title: "翻訳対象JSONファイルを選択",
+		Title: "翻訳対象ファイルを選択",
 		Filters: []runtime.FileFilter{
 			{DisplayName: "JSON Files (*.json)", Pattern: "*.json"},
+			{DisplayName: "Skyrim Plugins (*.esp;*.esm;*.esl)", Pattern: "*.esp;*.esm;*.esl"},
+			{DisplayName: "MCM Translations (*.txt)", Pattern: "*.txt"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
 		},
 	})
@@ -65,6 +70,17 @@ func (c *FileDialogController) SelectTranslationInputFiles() ([]string, error) {
 	return files, nil
 }
 
+// SelectTranslationInputDirectory opens a directory dialog for a mod folder holding plugins and BSA archives.
+func (c *FileDialogController) SelectTranslationInputDirectory() (string, error) {
+	path, err := c.openDirectoryDialog(c.context(), runtime.OpenDialogOptions{
+		Title: "翻訳対象の MOD フォルダを選択",
+	})
+	if err != nil {
+		return "", fmt.Errorf("open translation input directory dialog: %w", err)
+	}
+	return path, nil
+}
+
 // SelectJSONFile opens a single-file dialog for JSON input.
 func (c *FileDialogController) SelectJSONFile() (string, error) {
 	path, err := c.openFileDialog(c.context(), runtime.OpenDialogOptions{
@@ -45,9 +45,11 @@ func TestFileDialogController_API_TableDriven(t *testing.T) {
 			name: "SelectTranslationInputFiles uses expected filter and returns files",
 			run: func(t *testing.T, controller *FileDialogController) {
 				controller.openMultipleFilesDialog = func(_ context.Context, options runtime.OpenDialogOptions) ([]string, error) {
-					assert.Equal(t, "翻訳対象JSONファイルを選択", options.Title)
-					require.Len(t, options.Filters, 2)
+					assert.Equal(t, "翻訳対象ファイルを選択", options.Title)
+					require.Len(t, options.Filters, 4)
 					assert.Equal(t, "*.json", options.Filters[0].Pattern)
+					assert.Equal(t, "*.esp;*.esm;*.esl", options.Filters[1].Pattern)
+					assert.Equal(t, "*.txt", options.Filters[2].Pattern)
 					return []string{"input-a.json", "input-b.json"}, nil
 				}
 				files, err := controller.SelectTranslationInputFiles()
@@ -67,6 +69,30 @@ func TestFileDialogController_API_TableDriven(t *testing.T) {
 				assert.Contains(t, err.Error(), "translation dialog failed")
 			},
 		},
+		{
+			name: "SelectTranslationInputDirectory returns selected folder",
+			run: func(t *testing.T, controller *FileDialogController) {
+				controller.openDirectoryDialog = func(_ context.Context, options runtime.OpenDialogOptions) (string, error) {
+					assert.Equal(t, "翻訳対象の MOD フォルダを選択", options.Title)
+					return "mods/MyMod", nil
+				}
+				path, err := controller.SelectTranslationInputDirectory()
+				require.NoError(t, err)
+				assert.Equal(t, "mods/MyMod", path)
+			},
+		},
+		{
+			name: "SelectTranslationInputDirectory wraps runtime error",
+			run: func(t *testing.T, controller *FileDialogController) {
+				controller.openDirectoryDialog = func(_ context.Context, _ runtime.OpenDialogOptions) (string, error) {
+					return "", errors.New("directory dialog failed")
+				}
+				_, err := controller.SelectTranslationInputDirectory()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "open translation input directory dialog")
+				assert.Contains(t, err.Error(), "directory dialog failed")
+			},
+		},
 		{
 			name: "SelectJSONFile uses expected filter and returns file",
 			run: func(t *testing.T, controller *FileDialogController) {
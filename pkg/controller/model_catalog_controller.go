@@ -33,3 +33,8 @@ func (c *ModelCatalogController) SetContext(ctx context.Context) {
 func (c *ModelCatalogController) ListModels(input modelcatalog2.ListModelsInput) ([]modelcatalog2.ModelOption, error) {
 	return c.service.ListModels(c.ctx, input)
 }
+
+// ProviderHealth returns whether the provider currently accepts requests.
+func (c *ModelCatalogController) ProviderHealth(input modelcatalog2.ProviderHealthInput) (modelcatalog2.ProviderStatus, error) {
+	return c.service.ProviderHealth(c.ctx, input)
+}
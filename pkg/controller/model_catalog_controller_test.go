@@ -38,6 +38,18 @@ func TestModelCatalogController_API_TableDriven(t *testing.T) {
 				assert.ErrorIs(t, err, serviceErr)
 			},
 		},
+		{
+			name: "ProviderHealth returns service status",
+			run: func(t *testing.T, controller *ModelCatalogController, env *modelcatalogcontrollertest.Env) {
+				healthInput := modelcatalog.ProviderHealthInput{Namespace: "master_persona.llm", Provider: "gemini"}
+				env.Service.Status = modelcatalog.ProviderStatus{Provider: "gemini", State: "open", Message: "Gemini は現在利用できません（連続 5 件失敗）"}
+				got, err := controller.ProviderHealth(healthInput)
+				require.NoError(t, err)
+				assert.Equal(t, env.Service.Status, got)
+				assert.Equal(t, healthInput, env.Service.LastHealthInput)
+				assert.Equal(t, env.TestEnv.Ctx, env.Service.LastCtx)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
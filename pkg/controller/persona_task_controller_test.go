@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
 	runtimequeue "github.com/ishibata91/ai-translation-engine-2/pkg/runtime/queue"
 	personataskcontrollertest "github.com/ishibata91/ai-translation-engine-2/pkg/tests/api_tests/personataskcontroller"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/workflow"
@@ -172,6 +173,10 @@ func (a *masterPersonaWorkflowAdapter) RunPersonaPhase(context.Context, workflow
 	return nil
 }
 
+func (a *masterPersonaWorkflowAdapter) PreviewPersonaRequests(context.Context, workflow.PersonaExecutionInput) ([]llmio.Request, error) {
+	return nil, nil
+}
+
 func (a *masterPersonaWorkflowAdapter) ListPersonaRuntime(context.Context, string) ([]workflow.PersonaRuntimeEntry, error) {
 	return nil, nil
 }
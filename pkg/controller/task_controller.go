@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmcache"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/workflow"
 	task2 "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
 )
@@ -23,10 +25,29 @@ type translationFlowWorkflow interface {
 	ListPreviewRows(ctx context.Context, fileID int64, page int, pageSize int) (workflow.TranslationPreviewPage, error)
 	ListTerminologyTargets(ctx context.Context, taskID string, page int, pageSize int) (workflow.TerminologyTargetPreviewPage, error)
 	RunTerminologyPhase(ctx context.Context, input workflow.RunTerminologyPhaseInput) (workflow.TerminologyPhaseResult, error)
+	EstimateTerminologyPhase(ctx context.Context, input workflow.RunTerminologyPhaseInput) (workflow.PhaseCostEstimate, error)
 	GetTerminologyPhase(ctx context.Context, taskID string) (workflow.TerminologyPhaseResult, error)
 	ListTranslationFlowPersonaTargets(ctx context.Context, taskID string, page int, pageSize int) (workflow.PersonaTargetPreviewPage, error)
 	RunTranslationFlowPersonaPhase(ctx context.Context, input workflow.RunTranslationFlowPersonaPhaseInput) (workflow.PersonaPhaseResult, error)
+	EstimateTranslationFlowPersonaPhase(ctx context.Context, input workflow.RunTranslationFlowPersonaPhaseInput) (workflow.PhaseCostEstimate, error)
 	GetTranslationFlowPersonaPhase(ctx context.Context, taskID string) (workflow.PersonaPhaseResult, error)
+	RunSummaryPhase(ctx context.Context, input workflow.RunSummaryPhaseInput) (workflow.SummaryPhaseResult, error)
+	EstimateSummaryPhase(ctx context.Context, input workflow.RunSummaryPhaseInput) (workflow.PhaseCostEstimate, error)
+	RunMainTranslationPhase(ctx context.Context, input workflow.RunMainTranslationPhaseInput) (workflow.MainTranslationPhaseResult, error)
+	EstimateMainTranslationPhase(ctx context.Context, input workflow.RunMainTranslationPhaseInput) (workflow.PhaseCostEstimate, error)
+	GetMainTranslationPhase(ctx context.Context, taskID string) (workflow.MainTranslationPhaseResult, error)
+	RunExportPhase(ctx context.Context, input workflow.RunExportPhaseInput) (workflow.ExportPhaseResult, error)
+}
+
+type taskUsageReporter interface {
+	TaskUsage(ctx context.Context, taskID string) (llmusage.TaskUsage, error)
+}
+
+// ResponseCacheStats is the hit/miss summary of one LLM response cache namespace, such as "translation_flow.translation".
+type ResponseCacheStats = llmcache.Stats
+
+type responseCacheReporter interface {
+	Stats(ctx context.Context) ([]ResponseCacheStats, error)
 }
 
 // TaskController exposes generic Wails-facing task operations.
@@ -34,6 +55,8 @@ type TaskController struct {
 	ctx             context.Context
 	manager         taskManager
 	translationFlow translationFlowWorkflow
+	usage           taskUsageReporter
+	responseCache   responseCacheReporter
 }
 
 // NewTaskController constructs the task controller adapter.
@@ -58,6 +81,32 @@ func (c *TaskController) SetTranslationFlowWorkflow(translationFlow translationF
 	c.translationFlow = translationFlow
 }
 
+// SetUsageReporter injects token/cost accounting.
+func (c *TaskController) SetUsageReporter(usage taskUsageReporter) {
+	c.usage = usage
+}
+
+// GetTaskUsage returns token usage and cost per phase/provider/model for one task.
+func (c *TaskController) GetTaskUsage(taskID string) (llmusage.TaskUsage, error) {
+	if c.usage == nil {
+		return llmusage.TaskUsage{}, fmt.Errorf("task usage reporter is not configured")
+	}
+	return c.usage.TaskUsage(c.ctx, taskID)
+}
+
+// SetResponseCacheReporter injects LLM response cache hit/miss accounting.
+func (c *TaskController) SetResponseCacheReporter(responseCache responseCacheReporter) {
+	c.responseCache = responseCache
+}
+
+// GetResponseCacheStats returns LLM response cache hits, misses and hit rate per namespace.
+func (c *TaskController) GetResponseCacheStats() ([]ResponseCacheStats, error) {
+	if c.responseCache == nil {
+		return nil, fmt.Errorf("response cache reporter is not configured")
+	}
+	return c.responseCache.Stats(c.ctx)
+}
+
 // GetActiveTasks returns in-memory active tasks for dashboard polling.
 func (c *TaskController) GetActiveTasks() []task2.Task {
 	return c.manager.GetActiveTasks()
@@ -85,6 +134,15 @@ func (c *TaskController) CancelTask(taskID string) {
 
 // LoadTranslationFlowFiles parses and saves selected files under one translation project task.
 func (c *TaskController) LoadTranslationFlowFiles(taskID string, filePaths []string) (workflow.TranslationLoadResult, error) {
+	return c.loadTranslationFlowFiles(taskID, filePaths, false)
+}
+
+// ReloadTranslationFlowFiles loads updated mod files, replacing same-name files and keeping translations of unchanged rows.
+func (c *TaskController) ReloadTranslationFlowFiles(taskID string, filePaths []string) (workflow.TranslationLoadResult, error) {
+	return c.loadTranslationFlowFiles(taskID, filePaths, true)
+}
+
+func (c *TaskController) loadTranslationFlowFiles(taskID string, filePaths []string, incremental bool) (workflow.TranslationLoadResult, error) {
 	if c.translationFlow == nil {
 		return workflow.TranslationLoadResult{}, fmt.Errorf("translation flow workflow is not configured")
 	}
@@ -93,8 +151,9 @@ func (c *TaskController) LoadTranslationFlowFiles(taskID string, filePaths []str
 		return workflow.TranslationLoadResult{}, fmt.Errorf("ensure translation project task task_id=%s: %w", taskID, err)
 	}
 	result, err := c.translationFlow.LoadFiles(c.ctx, workflow.LoadTranslationFlowInput{
-		TaskID:    resolvedTaskID,
-		FilePaths: filePaths,
+		TaskID:      resolvedTaskID,
+		FilePaths:   filePaths,
+		Incremental: incremental,
 	})
 	if err != nil {
 		return workflow.TranslationLoadResult{}, fmt.Errorf("load translation flow files task_id=%s: %w", resolvedTaskID, err)
@@ -166,6 +225,22 @@ func (c *TaskController) RunTranslationFlowTerminology(taskID string, request wo
 	return result, nil
 }
 
+// EstimateTranslationFlowTerminology builds the terminology requests without running them and returns token, time and price estimates.
+func (c *TaskController) EstimateTranslationFlowTerminology(taskID string, request workflow.TranslationRequestConfig, prompt workflow.TranslationPromptConfig) (workflow.PhaseCostEstimate, error) {
+	if c.translationFlow == nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	result, err := c.translationFlow.EstimateTerminologyPhase(c.ctx, workflow.RunTerminologyPhaseInput{
+		TaskID:  taskID,
+		Request: request,
+		Prompt:  prompt,
+	})
+	if err != nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("estimate translation flow terminology task_id=%s: %w", taskID, err)
+	}
+	return result, nil
+}
+
 // GetTranslationFlowTerminology returns the current terminology phase summary for one task.
 func (c *TaskController) GetTranslationFlowTerminology(taskID string) (workflow.TerminologyPhaseResult, error) {
 	if c.translationFlow == nil {
@@ -218,6 +293,22 @@ func (c *TaskController) RunTranslationFlowPersona(taskID string, request workfl
 	return result, nil
 }
 
+// EstimateTranslationFlowPersona builds the persona requests without running them and returns token, time and price estimates.
+func (c *TaskController) EstimateTranslationFlowPersona(taskID string, request workflow.TranslationRequestConfig, prompt workflow.TranslationPromptConfig) (workflow.PhaseCostEstimate, error) {
+	if c.translationFlow == nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	result, err := c.translationFlow.EstimateTranslationFlowPersonaPhase(c.ctx, workflow.RunTranslationFlowPersonaPhaseInput{
+		TaskID:  taskID,
+		Request: request,
+		Prompt:  prompt,
+	})
+	if err != nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("estimate translation flow persona task_id=%s: %w", taskID, err)
+	}
+	return result, nil
+}
+
 // GetTranslationFlowPersona returns the current persona phase summary for one task.
 func (c *TaskController) GetTranslationFlowPersona(taskID string) (workflow.PersonaPhaseResult, error) {
 	if c.translationFlow == nil {
@@ -233,3 +324,111 @@ func (c *TaskController) GetTranslationFlowPersona(taskID string) (workflow.Pers
 	}
 	return result, nil
 }
+
+// RunTranslationFlowSummary generates dialogue and quest summaries used as main translation context.
+func (c *TaskController) RunTranslationFlowSummary(taskID string, request workflow.TranslationRequestConfig) (workflow.SummaryPhaseResult, error) {
+	if c.translationFlow == nil {
+		return workflow.SummaryPhaseResult{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	resolvedTaskID, err := c.manager.EnsureTranslationProjectTask(c.ctx, taskID)
+	if err != nil {
+		return workflow.SummaryPhaseResult{}, fmt.Errorf("ensure translation project task task_id=%s: %w", taskID, err)
+	}
+	result, err := c.translationFlow.RunSummaryPhase(c.ctx, workflow.RunSummaryPhaseInput{
+		TaskID:  resolvedTaskID,
+		Request: request,
+	})
+	if err != nil {
+		return workflow.SummaryPhaseResult{}, fmt.Errorf("run translation flow summary task_id=%s: %w", resolvedTaskID, err)
+	}
+	return result, nil
+}
+
+// EstimateTranslationFlowSummary builds the uncached summary requests without running them and returns token, time and price estimates.
+func (c *TaskController) EstimateTranslationFlowSummary(taskID string, request workflow.TranslationRequestConfig) (workflow.PhaseCostEstimate, error) {
+	if c.translationFlow == nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	result, err := c.translationFlow.EstimateSummaryPhase(c.ctx, workflow.RunSummaryPhaseInput{
+		TaskID:  taskID,
+		Request: request,
+	})
+	if err != nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("estimate translation flow summary task_id=%s: %w", taskID, err)
+	}
+	return result, nil
+}
+
+// RunTranslationFlowMainTranslation executes the main translation phase for one task.
+func (c *TaskController) RunTranslationFlowMainTranslation(taskID string, request workflow.TranslationRequestConfig, prompt workflow.TranslationPromptConfig) (workflow.MainTranslationPhaseResult, error) {
+	if c.translationFlow == nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	resolvedTaskID, err := c.manager.EnsureTranslationProjectTask(c.ctx, taskID)
+	if err != nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("ensure translation project task task_id=%s: %w", taskID, err)
+	}
+	result, err := c.translationFlow.RunMainTranslationPhase(c.ctx, workflow.RunMainTranslationPhaseInput{
+		TaskID:  resolvedTaskID,
+		Request: request,
+		Prompt:  prompt,
+	})
+	if err != nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("run translation flow main translation task_id=%s: %w", resolvedTaskID, err)
+	}
+	return result, nil
+}
+
+// EstimateTranslationFlowMainTranslation builds the main translation requests without running them and returns token, time and price estimates.
+func (c *TaskController) EstimateTranslationFlowMainTranslation(taskID string, request workflow.TranslationRequestConfig, prompt workflow.TranslationPromptConfig) (workflow.PhaseCostEstimate, error) {
+	if c.translationFlow == nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	result, err := c.translationFlow.EstimateMainTranslationPhase(c.ctx, workflow.RunMainTranslationPhaseInput{
+		TaskID:  taskID,
+		Request: request,
+		Prompt:  prompt,
+	})
+	if err != nil {
+		return workflow.PhaseCostEstimate{}, fmt.Errorf("estimate translation flow main translation task_id=%s: %w", taskID, err)
+	}
+	return result, nil
+}
+
+// GetTranslationFlowMainTranslation returns the current main translation phase summary for one task.
+func (c *TaskController) GetTranslationFlowMainTranslation(taskID string) (workflow.MainTranslationPhaseResult, error) {
+	if c.translationFlow == nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	resolvedTaskID, err := c.manager.EnsureTranslationProjectTask(c.ctx, taskID)
+	if err != nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("ensure translation project task task_id=%s: %w", taskID, err)
+	}
+	result, err := c.translationFlow.GetMainTranslationPhase(c.ctx, resolvedTaskID)
+	if err != nil {
+		return workflow.MainTranslationPhaseResult{}, fmt.Errorf("get translation flow main translation task_id=%s: %w", resolvedTaskID, err)
+	}
+	return result, nil
+}
+
+// RunTranslationFlowExport writes xTranslator XML files, and optionally translated string tables, for one task.
+func (c *TaskController) RunTranslationFlowExport(taskID string, outputDir string, sourceLanguage string, destLanguage string, includeStringTables bool) (workflow.ExportPhaseResult, error) {
+	if c.translationFlow == nil {
+		return workflow.ExportPhaseResult{}, fmt.Errorf("translation flow workflow is not configured")
+	}
+	resolvedTaskID, err := c.manager.EnsureTranslationProjectTask(c.ctx, taskID)
+	if err != nil {
+		return workflow.ExportPhaseResult{}, fmt.Errorf("ensure translation project task task_id=%s: %w", taskID, err)
+	}
+	result, err := c.translationFlow.RunExportPhase(c.ctx, workflow.RunExportPhaseInput{
+		TaskID:              resolvedTaskID,
+		OutputDir:           outputDir,
+		SourceLanguage:      sourceLanguage,
+		DestLanguage:        destLanguage,
+		IncludeStringTables: includeStringTables,
+	})
+	if err != nil {
+		return workflow.ExportPhaseResult{}, fmt.Errorf("run translation flow export task_id=%s: %w", resolvedTaskID, err)
+	}
+	return result, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 	taskcontrollertest "github.com/ishibata91/ai-translation-engine-2/pkg/tests/api_tests/taskcontroller"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/workflow"
 	task "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
@@ -134,6 +135,19 @@ func TestTaskController_TranslationFlowAPI_TableDriven(t *testing.T) {
 		GeneratedCount: 2,
 		FailedCount:    0,
 	}
+	exportResult := workflow.ExportPhaseResult{
+		TaskID:        "task-1",
+		Status:        "completed",
+		ExportedCount: 2,
+		Files:         []workflow.ExportedFile{{PluginName: "Skyrim.esm", OutputFilePath: "out/Skyrim_english_japanese.xml", TermCount: 1, MainCount: 1}},
+	}
+	mainTranslationResult := workflow.MainTranslationPhaseResult{
+		TaskID:      "task-1",
+		Status:      "completed_partial",
+		TargetCount: 4,
+		SavedCount:  3,
+		FailedCount: 1,
+	}
 
 	testCases := []struct {
 		name string
@@ -150,6 +164,20 @@ func TestTaskController_TranslationFlowAPI_TableDriven(t *testing.T) {
 				assert.Equal(t, "task-1", env.Manager.EnsureTaskInput)
 				assert.Equal(t, "task-resolved", wf.lastLoadInput.TaskID)
 				assert.Equal(t, []string{"a.json", "b.json"}, wf.lastLoadInput.FilePaths)
+				assert.False(t, wf.lastLoadInput.Incremental)
+			},
+		},
+		{
+			name: "ReloadTranslationFlowFiles requests incremental load",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				env.Manager.EnsureTaskResolvedID = "task-resolved"
+				wf.loadResult = loadResult
+				got, err := controller.ReloadTranslationFlowFiles("task-1", []string{"MyMod.esp"})
+				require.NoError(t, err)
+				assert.Equal(t, loadResult, got)
+				assert.Equal(t, "task-resolved", wf.lastLoadInput.TaskID)
+				assert.Equal(t, []string{"MyMod.esp"}, wf.lastLoadInput.FilePaths)
+				assert.True(t, wf.lastLoadInput.Incremental)
 			},
 		},
 		{
@@ -321,6 +349,131 @@ func TestTaskController_TranslationFlowAPI_TableDriven(t *testing.T) {
 				assert.ErrorIs(t, err, workflowErr)
 			},
 		},
+		{
+			name: "RunTranslationFlowMainTranslation resolves task id and returns result",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				env.Manager.EnsureTaskResolvedID = "task-resolved"
+				wf.mainTranslationResult = mainTranslationResult
+				request := workflow.TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.5-flash"}
+				prompt := workflow.TranslationPromptConfig{UserPrompt: "main", SystemPrompt: "system"}
+				got, err := controller.RunTranslationFlowMainTranslation("task-1", request, prompt)
+				require.NoError(t, err)
+				assert.Equal(t, mainTranslationResult, got)
+				assert.Equal(t, "task-1", env.Manager.EnsureTaskInput)
+				assert.Equal(t, "task-resolved", wf.lastMainTranslationInput.TaskID)
+				assert.Equal(t, request, wf.lastMainTranslationInput.Request)
+				assert.Equal(t, prompt, wf.lastMainTranslationInput.Prompt)
+			},
+		},
+		{
+			name: "RunTranslationFlowMainTranslation returns workflow error",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				wf.mainTranslationErr = workflowErr
+				_, err := controller.RunTranslationFlowMainTranslation("task-6", workflow.TranslationRequestConfig{}, workflow.TranslationPromptConfig{})
+				require.Error(t, err)
+				assert.Equal(t, "task-6", env.Manager.EnsureTaskInput)
+				assert.ErrorIs(t, err, workflowErr)
+			},
+		},
+		{
+			name: "GetTranslationFlowMainTranslation resolves task id and returns result",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				env.Manager.EnsureTaskResolvedID = "task-resolved"
+				wf.mainTranslationResult = mainTranslationResult
+				got, err := controller.GetTranslationFlowMainTranslation("task-1")
+				require.NoError(t, err)
+				assert.Equal(t, mainTranslationResult, got)
+				assert.Equal(t, "task-1", env.Manager.EnsureTaskInput)
+				assert.Equal(t, "task-resolved", wf.lastGetMainTranslationTaskID)
+			},
+		},
+		{
+			name: "GetTranslationFlowMainTranslation returns workflow error",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				wf.mainTranslationErr = workflowErr
+				_, err := controller.GetTranslationFlowMainTranslation("task-7")
+				require.Error(t, err)
+				assert.Equal(t, "task-7", env.Manager.EnsureTaskInput)
+				assert.ErrorIs(t, err, workflowErr)
+			},
+		},
+		{
+			name: "RunTranslationFlowExport resolves task id and returns result",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				env.Manager.EnsureTaskResolvedID = "task-resolved"
+				wf.exportResult = exportResult
+				got, err := controller.RunTranslationFlowExport("task-1", "out", "english", "japanese", true)
+				require.NoError(t, err)
+				assert.Equal(t, exportResult, got)
+				assert.Equal(t, "task-1", env.Manager.EnsureTaskInput)
+				assert.Equal(t, workflow.RunExportPhaseInput{
+					TaskID:              "task-resolved",
+					OutputDir:           "out",
+					SourceLanguage:      "english",
+					DestLanguage:        "japanese",
+					IncludeStringTables: true,
+				}, wf.lastExportInput)
+			},
+		},
+		{
+			name: "RunTranslationFlowSummary resolves task and forwards request",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				env.Manager.EnsureTaskResolvedID = "task-resolved"
+				request := workflow.TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.0-flash"}
+				wf.summaryResult = workflow.SummaryPhaseResult{TaskID: "task-resolved", Status: "completed", RequestCount: 2, SavedCount: 2}
+
+				got, err := controller.RunTranslationFlowSummary("task-1", request)
+				require.NoError(t, err)
+				assert.Equal(t, wf.summaryResult, got)
+				assert.Equal(t, "task-1", env.Manager.EnsureTaskInput)
+				assert.Equal(t, workflow.RunSummaryPhaseInput{TaskID: "task-resolved", Request: request}, wf.lastSummaryInput)
+			},
+		},
+		{
+			name: "EstimateTranslationFlow phases pass inputs without ensuring a task",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				request := workflow.TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.0-flash"}
+				prompt := workflow.TranslationPromptConfig{UserPrompt: "u"}
+				wf.estimateResult = workflow.PhaseCostEstimate{Requests: 3, InputTokens: 120}
+
+				got, err := controller.EstimateTranslationFlowTerminology("task-1", request, prompt)
+				require.NoError(t, err)
+				assert.Equal(t, wf.estimateResult, got)
+				assert.Equal(t, "terminology:task-1", wf.lastEstimate)
+
+				_, err = controller.EstimateTranslationFlowPersona("task-1", request, prompt)
+				require.NoError(t, err)
+				assert.Equal(t, "persona:task-1", wf.lastEstimate)
+
+				_, err = controller.EstimateTranslationFlowSummary("task-1", request)
+				require.NoError(t, err)
+				assert.Equal(t, "summary:task-1", wf.lastEstimate)
+
+				_, err = controller.EstimateTranslationFlowMainTranslation("task-1", request, prompt)
+				require.NoError(t, err)
+				assert.Equal(t, "main_translation:task-1", wf.lastEstimate)
+				assert.Empty(t, env.Manager.EnsureTaskInput)
+			},
+		},
+		{
+			name: "EstimateTranslationFlowMainTranslation returns workflow error",
+			run: func(t *testing.T, controller *TaskController, _ *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				wf.estimateErr = workflowErr
+				_, err := controller.EstimateTranslationFlowMainTranslation("task-9", workflow.TranslationRequestConfig{}, workflow.TranslationPromptConfig{})
+				require.Error(t, err)
+				assert.ErrorIs(t, err, workflowErr)
+			},
+		},
+		{
+			name: "RunTranslationFlowExport returns workflow error",
+			run: func(t *testing.T, controller *TaskController, env *taskcontrollertest.Env, wf *fakeTranslationFlowWorkflow) {
+				wf.exportErr = workflowErr
+				_, err := controller.RunTranslationFlowExport("task-8", "out", "", "", false)
+				require.Error(t, err)
+				assert.Equal(t, "task-8", env.Manager.EnsureTaskInput)
+				assert.ErrorIs(t, err, workflowErr)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -370,6 +523,77 @@ func TestTaskController_TranslationFlowAPI_NilWorkflowGuard(t *testing.T) {
 	_, err = controller.GetTranslationFlowPersona("task-1")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not configured")
+
+	_, err = controller.RunTranslationFlowMainTranslation("task-1", workflow.TranslationRequestConfig{}, workflow.TranslationPromptConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+
+	_, err = controller.GetTranslationFlowMainTranslation("task-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+
+	_, err = controller.RunTranslationFlowExport("task-1", "out", "", "", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+}
+
+func TestTaskController_GetTaskUsage(t *testing.T) {
+	env := taskcontrollertest.Build(t, "task usage")
+	controller := NewTaskController(env.Manager)
+	controller.SetContext(env.TestEnv.Ctx)
+
+	_, err := controller.GetTaskUsage("task-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+
+	usage := &fakeTaskUsageReporter{result: llmusage.TaskUsage{TaskID: "task-1", TotalTokens: 42}}
+	controller.SetUsageReporter(usage)
+	got, err := controller.GetTaskUsage("task-1")
+	require.NoError(t, err)
+	assert.Equal(t, 42, got.TotalTokens)
+	assert.Equal(t, "task-1", usage.lastTaskID)
+	assert.Equal(t, env.TestEnv.Ctx, usage.lastCtx)
+}
+
+func TestTaskController_GetResponseCacheStats(t *testing.T) {
+	env := taskcontrollertest.Build(t, "response cache stats")
+	controller := NewTaskController(env.Manager)
+	controller.SetContext(env.TestEnv.Ctx)
+
+	_, err := controller.GetResponseCacheStats()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+
+	reporter := &fakeResponseCacheReporter{result: []ResponseCacheStats{
+		{Namespace: "translation_flow.translation", Hits: 3, Misses: 1, HitRate: 0.75},
+	}}
+	controller.SetResponseCacheReporter(reporter)
+	got, err := controller.GetResponseCacheStats()
+	require.NoError(t, err)
+	assert.Equal(t, reporter.result, got)
+	assert.Equal(t, env.TestEnv.Ctx, reporter.lastCtx)
+}
+
+type fakeResponseCacheReporter struct {
+	lastCtx context.Context
+	result  []ResponseCacheStats
+}
+
+func (f *fakeResponseCacheReporter) Stats(ctx context.Context) ([]ResponseCacheStats, error) {
+	f.lastCtx = ctx
+	return f.result, nil
+}
+
+type fakeTaskUsageReporter struct {
+	lastCtx    context.Context
+	lastTaskID string
+	result     llmusage.TaskUsage
+}
+
+func (f *fakeTaskUsageReporter) TaskUsage(ctx context.Context, taskID string) (llmusage.TaskUsage, error) {
+	f.lastCtx = ctx
+	f.lastTaskID = taskID
+	return f.result, nil
 }
 
 type fakeTranslationFlowWorkflow struct {
@@ -388,6 +612,11 @@ type fakeTranslationFlowWorkflow struct {
 	lastPersonaPreviewPageSize     int
 	lastPersonaInput               workflow.RunTranslationFlowPersonaPhaseInput
 	lastGetPersonaTaskID           string
+	lastSummaryInput               workflow.RunSummaryPhaseInput
+	lastMainTranslationInput       workflow.RunMainTranslationPhaseInput
+	lastGetMainTranslationTaskID   string
+	lastExportInput                workflow.RunExportPhaseInput
+	lastEstimate                   string
 
 	loadResult               workflow.TranslationLoadResult
 	loadErr                  error
@@ -403,6 +632,14 @@ type fakeTranslationFlowWorkflow struct {
 	personaPreviewErr        error
 	personaResult            workflow.PersonaPhaseResult
 	personaErr               error
+	summaryResult            workflow.SummaryPhaseResult
+	summaryErr               error
+	mainTranslationResult    workflow.MainTranslationPhaseResult
+	mainTranslationErr       error
+	exportResult             workflow.ExportPhaseResult
+	exportErr                error
+	estimateResult           workflow.PhaseCostEstimate
+	estimateErr              error
 }
 
 func (f *fakeTranslationFlowWorkflow) LoadFiles(ctx context.Context, input workflow.LoadTranslationFlowInput) (workflow.TranslationLoadResult, error) {
@@ -464,3 +701,51 @@ func (f *fakeTranslationFlowWorkflow) GetTranslationFlowPersonaPhase(ctx context
 	f.lastGetPersonaTaskID = taskID
 	return f.personaResult, f.personaErr
 }
+
+func (f *fakeTranslationFlowWorkflow) RunSummaryPhase(ctx context.Context, input workflow.RunSummaryPhaseInput) (workflow.SummaryPhaseResult, error) {
+	f.lastCtx = ctx
+	f.lastSummaryInput = input
+	return f.summaryResult, f.summaryErr
+}
+
+func (f *fakeTranslationFlowWorkflow) EstimateSummaryPhase(ctx context.Context, input workflow.RunSummaryPhaseInput) (workflow.PhaseCostEstimate, error) {
+	f.lastCtx = ctx
+	f.lastEstimate = "summary:" + input.TaskID
+	return f.estimateResult, f.estimateErr
+}
+
+func (f *fakeTranslationFlowWorkflow) RunMainTranslationPhase(ctx context.Context, input workflow.RunMainTranslationPhaseInput) (workflow.MainTranslationPhaseResult, error) {
+	f.lastCtx = ctx
+	f.lastMainTranslationInput = input
+	return f.mainTranslationResult, f.mainTranslationErr
+}
+
+func (f *fakeTranslationFlowWorkflow) EstimateTerminologyPhase(ctx context.Context, input workflow.RunTerminologyPhaseInput) (workflow.PhaseCostEstimate, error) {
+	f.lastCtx = ctx
+	f.lastEstimate = "terminology:" + input.TaskID
+	return f.estimateResult, f.estimateErr
+}
+
+func (f *fakeTranslationFlowWorkflow) EstimateTranslationFlowPersonaPhase(ctx context.Context, input workflow.RunTranslationFlowPersonaPhaseInput) (workflow.PhaseCostEstimate, error) {
+	f.lastCtx = ctx
+	f.lastEstimate = "persona:" + input.TaskID
+	return f.estimateResult, f.estimateErr
+}
+
+func (f *fakeTranslationFlowWorkflow) EstimateMainTranslationPhase(ctx context.Context, input workflow.RunMainTranslationPhaseInput) (workflow.PhaseCostEstimate, error) {
+	f.lastCtx = ctx
+	f.lastEstimate = "main_translation:" + input.TaskID
+	return f.estimateResult, f.estimateErr
+}
+
+func (f *fakeTranslationFlowWorkflow) GetMainTranslationPhase(ctx context.Context, taskID string) (workflow.MainTranslationPhaseResult, error) {
+	f.lastCtx = ctx
+	f.lastGetMainTranslationTaskID = taskID
+	return f.mainTranslationResult, f.mainTranslationErr
+}
+
+func (f *fakeTranslationFlowWorkflow) RunExportPhase(ctx context.Context, input workflow.RunExportPhaseInput) (workflow.ExportPhaseResult, error) {
+	f.lastCtx = ctx
+	f.lastExportInput = input
+	return f.exportResult, f.exportErr
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+	translationmemory "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+)
+
+type translationMemoryService interface {
+	Lookup(ctx context.Context, sourceText string) ([]translationmemory.Match, error)
+	StartImport(ctx context.Context, filePath string) (string, error)
+}
+
+// TranslationMemoryController exposes Wails-facing translation memory operations.
+type TranslationMemoryController struct {
+	ctx     context.Context
+	service translationMemoryService
+}
+
+// NewTranslationMemoryController constructs the translation memory controller adapter.
+func NewTranslationMemoryController(service translationMemoryService) *TranslationMemoryController {
+	return &TranslationMemoryController{
+		ctx:     context.Background(),
+		service: service,
+	}
+}
+
+// SetContext injects the Wails application context for downstream propagation.
+func (c *TranslationMemoryController) SetContext(ctx context.Context) {
+	if ctx == nil {
+		c.ctx = context.Background()
+		return
+	}
+	c.ctx = ctx
+}
+
+// TMLookup returns the exact or fuzzy translation memory matches for one source text.
+func (c *TranslationMemoryController) TMLookup(sourceText string) ([]translationmemory.Match, error) {
+	return c.service.Lookup(c.context(), sourceText)
+}
+
+// TMStartImport starts importing one xTranslator XML into the translation memory.
+// It returns the correlation ID of the import progress events.
+func (c *TranslationMemoryController) TMStartImport(filePath string) (string, error) {
+	return c.service.StartImport(c.context(), filePath)
+}
+
+func (c *TranslationMemoryController) context() context.Context {
+	return telemetry.WithTraceID(c.ctx)
+}
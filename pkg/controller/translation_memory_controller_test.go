@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	translationmemory "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+	apitestenv "github.com/ishibata91/ai-translation-engine-2/pkg/tests/api_tests/testenv"
+	translationmemorycontrollertest "github.com/ishibata91/ai-translation-engine-2/pkg/tests/api_tests/translationmemorycontroller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationMemoryController_API_TableDriven(t *testing.T) {
+	errDummy := errors.New("dummy")
+	expectedTraceID := "translation-memory-controller-trace"
+
+	testCases := []struct {
+		name string
+		run  func(t *testing.T, controller *TranslationMemoryController, fake *translationmemorycontrollertest.FakeService)
+	}{
+		{
+			name: "TMLookup returns service matches",
+			run: func(t *testing.T, controller *TranslationMemoryController, fake *translationmemorycontrollertest.FakeService) {
+				expected := []translationmemory.Match{{Entry: translationmemory.Entry{SourceText: "Lead the way.", DestText: "案内してくれ。"}, Score: 1}}
+				fake.Matches = expected
+				got, err := controller.TMLookup("Lead the way.")
+				require.NoError(t, err)
+				assert.Equal(t, expected, got)
+				assert.Equal(t, "Lead the way.", fake.LastLookupText)
+				assert.Equal(t, expectedTraceID, apitestenv.TraceIDValue(fake.LastCtx))
+			},
+		},
+		{
+			name: "TMLookup returns service error",
+			run: func(t *testing.T, controller *TranslationMemoryController, fake *translationmemorycontrollertest.FakeService) {
+				fake.LookupErr = errDummy
+				_, err := controller.TMLookup("Lead the way.")
+				require.Error(t, err)
+				assert.ErrorIs(t, err, errDummy)
+			},
+		},
+		{
+			name: "TMStartImport returns correlation id and delegates path",
+			run: func(t *testing.T, controller *TranslationMemoryController, fake *translationmemorycontrollertest.FakeService) {
+				fake.ImportID = "tm-import-test.xml"
+				id, err := controller.TMStartImport("C:/tmp/test.xml")
+				require.NoError(t, err)
+				assert.Equal(t, "tm-import-test.xml", id)
+				assert.Equal(t, "C:/tmp/test.xml", fake.LastImportPath)
+			},
+		},
+		{
+			name: "TMStartImport returns error",
+			run: func(t *testing.T, controller *TranslationMemoryController, fake *translationmemorycontrollertest.FakeService) {
+				fake.StartImportErr = errDummy
+				_, err := controller.TMStartImport("bad.xml")
+				require.Error(t, err)
+				assert.ErrorIs(t, err, errDummy)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := translationmemorycontrollertest.Build(t, tc.name)
+			controller := NewTranslationMemoryController(env.Service)
+			controller.SetContext(apitestenv.NewTraceContext(expectedTraceID))
+			tc.run(t, controller, env.Service)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package bsa
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+const (
+	// VersionSkyrim is the archive version used by Skyrim LE (zlib compression).
+	VersionSkyrim uint32 = 104
+	// VersionSkyrimSE is the archive version used by Skyrim SE/AE (LZ4 frame compression).
+	VersionSkyrimSE uint32 = 105
+
+	headerSize = 36
+
+	archiveFlagDirectoryNames uint32 = 0x001
+	archiveFlagFileNames      uint32 = 0x002
+	archiveFlagCompressed     uint32 = 0x004
+	archiveFlagEmbedNames     uint32 = 0x100
+
+	fileSizeCompressionToggle uint32 = 0x40000000
+	fileSizeMask              uint32 = 0x3FFFFFFF
+)
+
+// Archive is an opened BSA whose file table has been read; file contents are read on demand.
+type Archive struct {
+	path    string
+	file    *os.File
+	version uint32
+	flags   uint32
+	entries map[string]entry
+	names   []string
+}
+
+type entry struct {
+	offset     uint32
+	size       uint32
+	compressed bool
+}
+
+// Open reads the header and file table of a v104/v105 BSA archive.
+func Open(path string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bsa path=%s: %w", path, err)
+	}
+	archive := &Archive{path: path, file: f, entries: make(map[string]entry)}
+	if err := archive.readIndex(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("read bsa index path=%s: %w", path, err)
+	}
+	return archive, nil
+}
+
+// Close releases the underlying file handle.
+func (a *Archive) Close() error {
+	return a.file.Close()
+}
+
+// Version returns the archive format version.
+func (a *Archive) Version() uint32 {
+	return a.version
+}
+
+// Files returns every file path in the archive, lower-cased with forward slashes, in sorted order.
+func (a *Archive) Files() []string {
+	return append([]string(nil), a.names...)
+}
+
+// Has reports whether the archive contains the given path.
+func (a *Archive) Has(name string) bool {
+	_, ok := a.entries[NormalizePath(name)]
+	return ok
+}
+
+// ReadFile returns the decompressed contents of one archived file.
+func (a *Archive) ReadFile(name string) ([]byte, error) {
+	normalized := NormalizePath(name)
+	e, ok := a.entries[normalized]
+	if !ok {
+		return nil, fmt.Errorf("bsa file not found name=%s: %w", normalized, os.ErrNotExist)
+	}
+
+	data := make([]byte, e.size)
+	if _, err := a.file.ReadAt(data, int64(e.offset)); err != nil {
+		return nil, fmt.Errorf("read bsa file name=%s: %w", normalized, err)
+	}
+	if a.flags&archiveFlagEmbedNames != 0 {
+		if len(data) == 0 || int(data[0])+1 > len(data) {
+			return nil, fmt.Errorf("invalid embedded name name=%s", normalized)
+		}
+		data = data[int(data[0])+1:]
+	}
+	if !e.compressed {
+		return data, nil
+	}
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("compressed bsa file is too short name=%s", normalized)
+	}
+	originalSize := binary.LittleEndian.Uint32(data[0:4])
+	var reader io.Reader
+	if a.version == VersionSkyrimSE {
+		reader = lz4.NewReader(bytes.NewReader(data[4:]))
+	} else {
+		zr, err := zlib.NewReader(bytes.NewReader(data[4:]))
+		if err != nil {
+			return nil, fmt.Errorf("open zlib stream name=%s: %w", normalized, err)
+		}
+		defer zr.Close()
+		reader = zr
+	}
+	decompressed := make([]byte, originalSize)
+	if _, err := io.ReadFull(reader, decompressed); err != nil {
+		return nil, fmt.Errorf("decompress bsa file name=%s: %w", normalized, err)
+	}
+	return decompressed, nil
+}
+
+// NormalizePath converts a path to the lower-case, forward-slash form used as archive keys.
+func NormalizePath(name string) string {
+	return strings.Trim(strings.ToLower(strings.ReplaceAll(name, "\\", "/")), "/")
+}
+
+func (a *Archive) readIndex() error {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(a.file, header); err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+	if string(header[0:4]) != "BSA\x00" {
+		return fmt.Errorf("unsupported archive signature %q", string(header[0:4]))
+	}
+	a.version = binary.LittleEndian.Uint32(header[4:8])
+	if a.version != VersionSkyrim && a.version != VersionSkyrimSE {
+		return fmt.Errorf("unsupported bsa version=%d", a.version)
+	}
+	folderOffset := binary.LittleEndian.Uint32(header[8:12])
+	a.flags = binary.LittleEndian.Uint32(header[12:16])
+	folderCount := binary.LittleEndian.Uint32(header[16:20])
+	fileCount := binary.LittleEndian.Uint32(header[20:24])
+	totalFileNameLength := binary.LittleEndian.Uint32(header[28:32])
+	if a.flags&archiveFlagDirectoryNames == 0 || a.flags&archiveFlagFileNames == 0 {
+		return fmt.Errorf("archives without directory and file names are not supported")
+	}
+
+	folderRecordSize := 16
+	if a.version == VersionSkyrimSE {
+		folderRecordSize = 24
+	}
+	folderRecords := make([]byte, int(folderCount)*folderRecordSize)
+	if _, err := a.file.ReadAt(folderRecords, int64(folderOffset)); err != nil {
+		return fmt.Errorf("read folder records: %w", err)
+	}
+	fileCounts := make([]uint32, folderCount)
+	for i := range fileCounts {
+		record := folderRecords[i*folderRecordSize:]
+		fileCounts[i] = binary.LittleEndian.Uint32(record[8:12])
+	}
+
+	// File record blocks follow the folder records: a bstring folder name, then 16-byte file records.
+	reader := io.NewSectionReader(a.file, int64(folderOffset)+int64(len(folderRecords)), 1<<62)
+	type pendingFile struct {
+		folder string
+		entry  entry
+	}
+	pending := make([]pendingFile, 0, fileCount)
+	fileRecord := make([]byte, 16)
+	lengthByte := make([]byte, 1)
+	for _, count := range fileCounts {
+		if _, err := io.ReadFull(reader, lengthByte); err != nil {
+			return fmt.Errorf("read folder name length: %w", err)
+		}
+		folderName := make([]byte, lengthByte[0])
+		if _, err := io.ReadFull(reader, folderName); err != nil {
+			return fmt.Errorf("read folder name: %w", err)
+		}
+		folder := NormalizePath(string(bytes.TrimRight(folderName, "\x00")))
+		for j := uint32(0); j < count; j++ {
+			if _, err := io.ReadFull(reader, fileRecord); err != nil {
+				return fmt.Errorf("read file record folder=%s: %w", folder, err)
+			}
+			size := binary.LittleEndian.Uint32(fileRecord[8:12])
+			compressed := a.flags&archiveFlagCompressed != 0
+			if size&fileSizeCompressionToggle != 0 {
+				compressed = !compressed
+			}
+			pending = append(pending, pendingFile{
+				folder: folder,
+				entry: entry{
+					offset:     binary.LittleEndian.Uint32(fileRecord[12:16]),
+					size:       size & fileSizeMask,
+					compressed: compressed,
+				},
+			})
+		}
+	}
+
+	fileNames := make([]byte, totalFileNameLength)
+	if _, err := io.ReadFull(reader, fileNames); err != nil {
+		return fmt.Errorf("read file names: %w", err)
+	}
+	names := bytes.Split(bytes.TrimRight(fileNames, "\x00"), []byte{0})
+	if len(names) != len(pending) {
+		return fmt.Errorf("file name count mismatch: names=%d records=%d", len(names), len(pending))
+	}
+	for i, file := range pending {
+		fullPath := NormalizePath(file.folder + "/" + string(names[i]))
+		a.entries[fullPath] = file.entry
+		a.names = append(a.names, fullPath)
+	}
+	sort.Strings(a.names)
+	return nil
+}
@@ -0,0 +1,197 @@
+package bsa
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+type testFile struct {
+	folder string
+	name   string
+	data   []byte
+}
+
+// buildTestArchive encodes a minimal BSA with directory and file names; every file is compressed when compress is set.
+func buildTestArchive(t *testing.T, version uint32, compress bool, files []testFile) []byte {
+	t.Helper()
+	folders := make([]string, 0)
+	byFolder := make(map[string][]testFile)
+	for _, file := range files {
+		if _, ok := byFolder[file.folder]; !ok {
+			folders = append(folders, file.folder)
+		}
+		byFolder[file.folder] = append(byFolder[file.folder], file)
+	}
+	sort.Strings(folders)
+
+	folderRecordSize := 16
+	if version == VersionSkyrimSE {
+		folderRecordSize = 24
+	}
+	flags := archiveFlagDirectoryNames | archiveFlagFileNames
+	if compress {
+		flags |= archiveFlagCompressed
+	}
+
+	payloads := make([][]byte, 0, len(files))
+	totalFolderNameLength := 0
+	fileNames := bytes.Buffer{}
+	for _, folder := range folders {
+		totalFolderNameLength += len(folder) + 1
+		for _, file := range byFolder[folder] {
+			fileNames.WriteString(file.name)
+			fileNames.WriteByte(0)
+			payloads = append(payloads, encodePayload(t, version, compress, file.data))
+		}
+	}
+
+	fileRecordsSize := 0
+	for _, folder := range folders {
+		fileRecordsSize += 1 + len(folder) + 1 + 16*len(byFolder[folder])
+	}
+	dataOffset := headerSize + folderRecordSize*len(folders) + fileRecordsSize + fileNames.Len()
+
+	var out bytes.Buffer
+	out.WriteString("BSA\x00")
+	for _, v := range []uint32{version, headerSize, flags, uint32(len(folders)), uint32(len(files)), uint32(totalFolderNameLength), uint32(fileNames.Len()), 0} {
+		_ = binary.Write(&out, binary.LittleEndian, v)
+	}
+
+	for _, folder := range folders {
+		_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+		_ = binary.Write(&out, binary.LittleEndian, uint32(len(byFolder[folder])))
+		if version == VersionSkyrimSE {
+			_ = binary.Write(&out, binary.LittleEndian, uint32(0))
+			_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+		} else {
+			_ = binary.Write(&out, binary.LittleEndian, uint32(0))
+		}
+	}
+
+	offset := dataOffset
+	index := 0
+	for _, folder := range folders {
+		out.WriteByte(byte(len(folder) + 1))
+		out.WriteString(folder)
+		out.WriteByte(0)
+		for range byFolder[folder] {
+			_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+			_ = binary.Write(&out, binary.LittleEndian, uint32(len(payloads[index])))
+			_ = binary.Write(&out, binary.LittleEndian, uint32(offset))
+			offset += len(payloads[index])
+			index++
+		}
+	}
+	out.Write(fileNames.Bytes())
+	for _, payload := range payloads {
+		out.Write(payload)
+	}
+	return out.Bytes()
+}
+
+func encodePayload(t *testing.T, version uint32, compress bool, data []byte) []byte {
+	t.Helper()
+	if !compress {
+		return data
+	}
+	var compressed bytes.Buffer
+	_ = binary.Write(&compressed, binary.LittleEndian, uint32(len(data)))
+	if version == VersionSkyrimSE {
+		w := lz4.NewWriter(&compressed)
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("lz4 write failed: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("lz4 close failed: %v", err)
+		}
+		return compressed.Bytes()
+	}
+	w := zlib.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("zlib write failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zlib close failed: %v", err)
+	}
+	return compressed.Bytes()
+}
+
+func writeTestArchive(t *testing.T, raw []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Test.bsa")
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	return path
+}
+
+func TestArchive_ReadFile(t *testing.T) {
+	files := []testFile{
+		{folder: `strings`, name: "MyMod_english.strings", data: []byte("table-bytes")},
+		{folder: `interface\translations`, name: "MyMod_english.txt", data: []byte(strings.Repeat("$KEY\ttext\r\n", 8))},
+	}
+	testCases := []struct {
+		name     string
+		version  uint32
+		compress bool
+	}{
+		{name: "v104 uncompressed", version: VersionSkyrim},
+		{name: "v104 zlib", version: VersionSkyrim, compress: true},
+		{name: "v105 uncompressed", version: VersionSkyrimSE},
+		{name: "v105 lz4", version: VersionSkyrimSE, compress: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			archive, err := Open(writeTestArchive(t, buildTestArchive(t, tc.version, tc.compress, files)))
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			defer archive.Close()
+
+			if archive.Version() != tc.version {
+				t.Fatalf("unexpected version: got=%d want=%d", archive.Version(), tc.version)
+			}
+			wantNames := []string{"interface/translations/mymod_english.txt", "strings/mymod_english.strings"}
+			if got := archive.Files(); strings.Join(got, ",") != strings.Join(wantNames, ",") {
+				t.Fatalf("unexpected file list: %v", got)
+			}
+			for _, file := range files {
+				data, err := archive.ReadFile(file.folder + `\` + strings.ToUpper(file.name))
+				if err != nil {
+					t.Fatalf("ReadFile failed: %v", err)
+				}
+				if !bytes.Equal(data, file.data) {
+					t.Fatalf("unexpected content for %s: %q", file.name, data)
+				}
+			}
+		})
+	}
+}
+
+func TestArchive_OpenRejectsUnsupportedVersion(t *testing.T) {
+	raw := buildTestArchive(t, VersionSkyrim, false, []testFile{{folder: "strings", name: "a.strings", data: []byte("a")}})
+	binary.LittleEndian.PutUint32(raw[4:8], 103)
+	if _, err := Open(writeTestArchive(t, raw)); err == nil {
+		t.Fatalf("Open unexpectedly accepted a v103 archive")
+	}
+}
+
+func TestArchive_ReadFileReportsMissingEntry(t *testing.T) {
+	raw := buildTestArchive(t, VersionSkyrimSE, false, []testFile{{folder: "strings", name: "a.strings", data: []byte("a")}})
+	archive, err := Open(writeTestArchive(t, raw))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer archive.Close()
+	if _, err := archive.ReadFile("strings/missing.strings"); err == nil {
+		t.Fatalf("ReadFile unexpectedly found a missing entry")
+	}
+}
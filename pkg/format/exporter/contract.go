@@ -6,3 +6,13 @@ import "context"
 type Exporter interface {
 	GenerateXML(ctx context.Context, input ExportInput) error
 }
+
+// StringTableExporter defines the workflow-facing contract for translated plugin string table generation.
+type StringTableExporter interface {
+	GenerateStringTables(ctx context.Context, input StringTableExportInput) ([]string, error)
+}
+
+// InterfaceTranslationExporter defines the workflow-facing contract for translated MCM Interface/Translations files.
+type InterfaceTranslationExporter interface {
+	GenerateInterfaceTranslation(ctx context.Context, input InterfaceTranslationExportInput) (string, error)
+}
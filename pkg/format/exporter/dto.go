@@ -18,3 +18,21 @@ type ExportRecord struct {
 	SourceText     string
 	TranslatedText string
 }
+
+// StringTableExportInput is the slice-local DTO used by workflow to request translated STRINGS/DLSTRINGS/ILSTRINGS files.
+type StringTableExportInput struct {
+	PluginPath     string
+	SourceLanguage string
+	DestLanguage   string
+	Records        []ExportRecord
+	OutputDir      string
+}
+
+// InterfaceTranslationExportInput is the slice-local DTO used by workflow to request one translated "<Mod>_<LANGUAGE>.txt" file.
+// Records carry the MCM key in FormID; rows without translated text keep their source text.
+type InterfaceTranslationExportInput struct {
+	ModName      string
+	DestLanguage string
+	Records      []ExportRecord
+	OutputDir    string
+}
@@ -0,0 +1,93 @@
+package interfacetranslation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+var translationsDir = filepath.Join("Interface", "Translations")
+
+type exporter struct {
+	logger *slog.Logger
+}
+
+// NewExporter creates a new instance of the MCM interface translation exporter.
+func NewExporter() formatexporter.InterfaceTranslationExporter {
+	return &exporter{
+		logger: slog.Default().With("slice", "interface_translation_exporter"),
+	}
+}
+
+// GenerateInterfaceTranslation writes "<OutputDir>/Interface/Translations/<Mod>_<LANGUAGE>.txt" in UTF-16LE with a BOM.
+// Every key is written so the menu never falls back to a raw "$KEY"; untranslated keys keep their source text.
+func (e *exporter) GenerateInterfaceTranslation(ctx context.Context, input formatexporter.InterfaceTranslationExportInput) (string, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionExport)()
+
+	modName := strings.TrimSpace(input.ModName)
+	if modName == "" {
+		return "", fmt.Errorf("mod_name is required")
+	}
+	outputDir := strings.TrimSpace(input.OutputDir)
+	if outputDir == "" {
+		return "", fmt.Errorf("output_dir is required")
+	}
+	destLanguage := strings.TrimSpace(input.DestLanguage)
+	if destLanguage == "" {
+		return "", fmt.Errorf("dest_language is required")
+	}
+
+	byKey := make(map[string]string, len(input.Records))
+	translatedCount := 0
+	for _, record := range input.Records {
+		key := strings.TrimSpace(record.FormID)
+		if key == "" {
+			continue
+		}
+		if strings.TrimSpace(record.TranslatedText) != "" {
+			byKey[key] = record.TranslatedText
+			translatedCount++
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = record.SourceText
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]skyrim.InterfaceTranslationLine, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, skyrim.InterfaceTranslationLine{Key: key, Text: byKey[key]})
+	}
+
+	var buf bytes.Buffer
+	if err := skyrim.WriteInterfaceTranslations(&buf, lines); err != nil {
+		return "", fmt.Errorf("encode interface translation mod=%s: %w", modName, err)
+	}
+	targetDir := filepath.Join(outputDir, translationsDir)
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return "", fmt.Errorf("create interface translation directory path=%s: %w", targetDir, err)
+	}
+	outputPath := filepath.Join(targetDir, skyrim.InterfaceTranslationFileName(modName, destLanguage))
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0600); err != nil {
+		return "", fmt.Errorf("write interface translation path=%s: %w", outputPath, err)
+	}
+	e.logger.InfoContext(ctx, "interface translation export completed",
+		slog.String("path", outputPath),
+		slog.Int("key_count", len(lines)),
+		slog.Int("translated_count", translatedCount),
+	)
+	return outputPath, nil
+}
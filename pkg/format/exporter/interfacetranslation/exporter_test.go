@@ -0,0 +1,60 @@
+package interfacetranslation
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+func TestInterfaceTranslationExporter_GenerateInterfaceTranslation(t *testing.T) {
+	outputDir := t.TempDir()
+	path, err := NewExporter().GenerateInterfaceTranslation(context.Background(), formatexporter.InterfaceTranslationExportInput{
+		ModName:      "MyMod",
+		DestLanguage: "japanese",
+		OutputDir:    outputDir,
+		Records: []formatexporter.ExportRecord{
+			{FormID: "$MYMOD_HELP", SourceText: "Help"},
+			{FormID: "$MYMOD_ENABLE", SourceText: "Enable feature", TranslatedText: "機能を有効化"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateInterfaceTranslation failed: %v", err)
+	}
+
+	wantPath := filepath.Join(outputDir, "Interface", "Translations", "MyMod_JAPANESE.txt")
+	if path != wantPath {
+		t.Fatalf("unexpected output path: got=%q want=%q", path, wantPath)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if !bytes.HasPrefix(raw, []byte{0xFF, 0xFE}) {
+		t.Fatalf("expected UTF-16LE BOM in output")
+	}
+	lines, err := skyrim.ReadInterfaceTranslations(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadInterfaceTranslations failed: %v", err)
+	}
+	want := []skyrim.InterfaceTranslationLine{
+		{Key: "$MYMOD_ENABLE", Text: "機能を有効化"},
+		{Key: "$MYMOD_HELP", Text: "Help"},
+	}
+	if len(lines) != len(want) || lines[0] != want[0] || lines[1] != want[1] {
+		t.Fatalf("unexpected output lines: %+v", lines)
+	}
+}
+
+func TestInterfaceTranslationExporter_RequiresModName(t *testing.T) {
+	if _, err := NewExporter().GenerateInterfaceTranslation(context.Background(), formatexporter.InterfaceTranslationExportInput{
+		DestLanguage: "japanese",
+		OutputDir:    t.TempDir(),
+	}); err == nil {
+		t.Fatalf("GenerateInterfaceTranslation unexpectedly succeeded without mod_name")
+	}
+}
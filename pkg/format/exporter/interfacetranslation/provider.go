@@ -0,0 +1,10 @@
+package interfacetranslation
+
+import (
+	"github.com/google/wire"
+)
+
+// ProviderSet is the wire provider set for the MCM interface translation exporter.
+var ProviderSet = wire.NewSet(
+	NewExporter,
+)
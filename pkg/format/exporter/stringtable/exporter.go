@@ -0,0 +1,172 @@
+package stringtable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+const stringsDirName = "Strings"
+
+type exporter struct {
+	logger *slog.Logger
+}
+
+// NewExporter creates a new instance of the plugin string table exporter.
+func NewExporter() formatexporter.StringTableExporter {
+	return &exporter{
+		logger: slog.Default().With("slice", "stringtable_exporter"),
+	}
+}
+
+// GenerateStringTables rewrites the source-language string tables of a localized plugin with translated text.
+// Each record is matched to the string IDs its form references, so only those entries are replaced even when
+// other strings share the same source text; the rest keep their original text.
+func (e *exporter) GenerateStringTables(ctx context.Context, input formatexporter.StringTableExportInput) ([]string, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionExport)()
+
+	pluginPath := strings.TrimSpace(input.PluginPath)
+	if pluginPath == "" {
+		return nil, fmt.Errorf("plugin_path is required")
+	}
+	outputDir := strings.TrimSpace(input.OutputDir)
+	if outputDir == "" {
+		return nil, fmt.Errorf("output_dir is required")
+	}
+	destLanguage := strings.TrimSpace(input.DestLanguage)
+	if destLanguage == "" {
+		return nil, fmt.Errorf("dest_language is required")
+	}
+
+	sourceTables, err := skyrim.LoadPluginStringTables(pluginPath, input.SourceLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("load source string tables plugin=%s: %w", pluginPath, err)
+	}
+	if len(sourceTables) == 0 {
+		e.logger.DebugContext(ctx, "plugin has no string tables; skipping", slog.String("plugin", pluginPath))
+		return nil, nil
+	}
+
+	refs, err := skyrim.LoadPluginStringReferences(pluginPath, sourceTables)
+	if err != nil {
+		return nil, fmt.Errorf("load string references plugin=%s: %w", pluginPath, err)
+	}
+	translations := mapTranslationsByStringID(input.Records, refs, sourceTables)
+
+	targetDir := filepath.Join(outputDir, stringsDirName)
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return nil, fmt.Errorf("create string table directory path=%s: %w", targetDir, err)
+	}
+
+	written := make([]string, 0, len(sourceTables))
+	for _, kind := range skyrim.StringTableKinds {
+		source, ok := sourceTables[kind]
+		if !ok {
+			continue
+		}
+		translated := make(skyrim.StringTable, len(source))
+		replacedCount := 0
+		for id, text := range source {
+			if replacement, ok := translations[stringKey{kind: kind, id: id}]; ok {
+				translated[id] = replacement
+				replacedCount++
+				continue
+			}
+			translated[id] = text
+		}
+
+		var buf bytes.Buffer
+		if err := skyrim.WriteStringTable(&buf, kind, translated); err != nil {
+			return nil, fmt.Errorf("encode string table kind=%s plugin=%s: %w", kind, pluginPath, err)
+		}
+		outputPath := filepath.Join(targetDir, skyrim.StringTableFileName(pluginPath, destLanguage, kind))
+		if err := os.WriteFile(outputPath, buf.Bytes(), 0600); err != nil {
+			return nil, fmt.Errorf("write string table path=%s: %w", outputPath, err)
+		}
+		e.logger.InfoContext(ctx, "string table export completed",
+			slog.String("path", outputPath),
+			slog.Int("string_count", len(translated)),
+			slog.Int("translated_count", replacedCount),
+		)
+		written = append(written, outputPath)
+	}
+	return written, nil
+}
+
+// stringKey identifies one entry across the STRINGS/DLSTRINGS/ILSTRINGS tables of a plugin.
+type stringKey struct {
+	kind skyrim.StringTableKind
+	id   uint32
+}
+
+// mapTranslationsByStringID resolves each translated record to the string entries of its form whose source text matches.
+// Entries of the field named by the record type ("WEAP:FULL", "INFO NAM1") win; other fields of the form are
+// used only when none of those match, since terminology rows normalize every field to FULL.
+func mapTranslationsByStringID(records []formatexporter.ExportRecord, refs []skyrim.StringReference, tables skyrim.StringTables) map[stringKey]string {
+	refsByForm := make(map[uint32][]skyrim.StringReference, len(refs))
+	for _, ref := range refs {
+		refsByForm[ref.FormID] = append(refsByForm[ref.FormID], ref)
+	}
+	translations := make(map[stringKey]string, len(records))
+	for _, record := range records {
+		if record.SourceText == "" || strings.TrimSpace(record.TranslatedText) == "" {
+			continue
+		}
+		formID, ok := parseExportFormID(record.FormID)
+		if !ok {
+			continue
+		}
+		field := exportRecordField(record.RecordType)
+		var sameField, otherFields []stringKey
+		for _, ref := range refsByForm[formID] {
+			if text, _ := tables[ref.Kind].LookupString(ref.ID); text != record.SourceText {
+				continue
+			}
+			key := stringKey{kind: ref.Kind, id: ref.ID}
+			if ref.Subrecord == field {
+				sameField = append(sameField, key)
+				continue
+			}
+			otherFields = append(otherFields, key)
+		}
+		if len(sameField) == 0 {
+			sameField = otherFields
+		}
+		for _, key := range sameField {
+			translations[key] = record.TranslatedText
+		}
+	}
+	return translations
+}
+
+// exportRecordField returns the subrecord signature of a "SIG FIELD" or "SIG:FIELD" record type.
+func exportRecordField(recordType string) string {
+	fields := strings.FieldsFunc(recordType, func(r rune) bool { return r == ' ' || r == ':' })
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.ToUpper(fields[len(fields)-1])
+}
+
+// parseExportFormID accepts the "0x0001A2B3|Plugin.esp" and bare "0001A2B3" form ID notations used by export records.
+func parseExportFormID(value string) (uint32, bool) {
+	formID, _, _ := strings.Cut(strings.TrimSpace(value), "|")
+	formID = strings.TrimPrefix(strings.TrimPrefix(formID, "0x"), "0X")
+	if formID == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(formID, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(id), true
+}
@@ -0,0 +1,181 @@
+package stringtable
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+func writeSourceTable(t *testing.T, dir string, kind skyrim.StringTableKind, table skyrim.StringTable) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := skyrim.WriteStringTable(&buf, kind, table); err != nil {
+		t.Fatalf("WriteStringTable failed: %v", err)
+	}
+	path := filepath.Join(dir, "Strings", skyrim.StringTableFileName("MyMod.esp", "english", kind))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create strings dir: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("failed to write source table: %v", err)
+	}
+}
+
+// writeLocalizedPlugin writes a minimal localized plugin whose records reference string IDs through 4-byte subrecords.
+func writeLocalizedPlugin(t *testing.T, dir string, records map[uint32][]pluginField) {
+	t.Helper()
+	var body bytes.Buffer
+	body.Write(encodeRecord("TES4", 0, 0x00000080, []pluginField{{typ: "HEDR", data: make([]byte, 12)}}))
+	formIDs := make([]uint32, 0, len(records))
+	for formID := range records {
+		formIDs = append(formIDs, formID)
+	}
+	slices.Sort(formIDs)
+	for _, formID := range formIDs {
+		fields := records[formID]
+		body.Write(encodeRecord(fields[0].signature, formID, 0, fields))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "MyMod.esp"), body.Bytes(), 0600); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+}
+
+// pluginField is one subrecord of a test record; signature names the owning record type.
+type pluginField struct {
+	signature string
+	typ       string
+	data      []byte
+}
+
+func stringID(id uint32) []byte {
+	out := make([]byte, 4)
+	binary.LittleEndian.PutUint32(out, id)
+	return out
+}
+
+func encodeRecord(signature string, formID uint32, flags uint32, fields []pluginField) []byte {
+	var data bytes.Buffer
+	for _, field := range fields {
+		data.WriteString(field.typ)
+		_ = binary.Write(&data, binary.LittleEndian, uint16(len(field.data)))
+		data.Write(field.data)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(signature)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(data.Len()))
+	_ = binary.Write(&buf, binary.LittleEndian, flags)
+	_ = binary.Write(&buf, binary.LittleEndian, formID)
+	buf.Write(make([]byte, 8))
+	buf.Write(data.Bytes())
+	return buf.Bytes()
+}
+
+func readOutputTable(t *testing.T, path string, kind skyrim.StringTableKind) skyrim.StringTable {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output table: %v", err)
+	}
+	table, err := skyrim.ReadStringTable(bytes.NewReader(raw), kind)
+	if err != nil {
+		t.Fatalf("ReadStringTable failed: %v", err)
+	}
+	return table
+}
+
+func TestStringTableExporter_GenerateStringTables(t *testing.T) {
+	pluginDir := t.TempDir()
+	writeSourceTable(t, pluginDir, skyrim.StringTableStrings, skyrim.StringTable{1: "Iron Sword", 2: "Untouched"})
+	writeSourceTable(t, pluginDir, skyrim.StringTableILStrings, skyrim.StringTable{10: "I am sworn to carry your burdens."})
+	writeLocalizedPlugin(t, pluginDir, map[uint32][]pluginField{
+		0x01000800: {{signature: "WEAP", typ: "FULL", data: stringID(1)}},
+		0x01000801: {{signature: "MISC", typ: "FULL", data: stringID(2)}},
+		0x01000802: {{signature: "INFO", typ: "NAM1", data: stringID(10)}},
+	})
+
+	outputDir := t.TempDir()
+	paths, err := NewExporter().GenerateStringTables(context.Background(), formatexporter.StringTableExportInput{
+		PluginPath:     filepath.Join(pluginDir, "MyMod.esp"),
+		SourceLanguage: "english",
+		DestLanguage:   "japanese",
+		OutputDir:      outputDir,
+		Records: []formatexporter.ExportRecord{
+			{FormID: "0x01000800|MyMod.esp", RecordType: "WEAP:FULL", SourceText: "Iron Sword", TranslatedText: "鉄の剣"},
+			{FormID: "01000802", RecordType: "INFO NAM1", SourceText: "I am sworn to carry your burdens.", TranslatedText: "あなたの重荷を背負います。"},
+			{FormID: "0x01000801|MyMod.esp", RecordType: "MISC:FULL", SourceText: "Untouched", TranslatedText: "  "},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateStringTables failed: %v", err)
+	}
+
+	wantStrings := filepath.Join(outputDir, "Strings", "MyMod_japanese.STRINGS")
+	wantILStrings := filepath.Join(outputDir, "Strings", "MyMod_japanese.ILSTRINGS")
+	if len(paths) != 2 || paths[0] != wantStrings || paths[1] != wantILStrings {
+		t.Fatalf("unexpected output paths: %v", paths)
+	}
+
+	stringsTable := readOutputTable(t, wantStrings, skyrim.StringTableStrings)
+	if stringsTable[1] != "鉄の剣" || stringsTable[2] != "Untouched" {
+		t.Fatalf("unexpected STRINGS output: %+v", stringsTable)
+	}
+	ilStringsTable := readOutputTable(t, wantILStrings, skyrim.StringTableILStrings)
+	if ilStringsTable[10] != "あなたの重荷を背負います。" {
+		t.Fatalf("unexpected ILSTRINGS output: %+v", ilStringsTable)
+	}
+}
+
+func TestStringTableExporter_KeysTranslationsByStringID(t *testing.T) {
+	pluginDir := t.TempDir()
+	writeSourceTable(t, pluginDir, skyrim.StringTableStrings, skyrim.StringTable{1: "Bow", 2: "Bow"})
+	writeSourceTable(t, pluginDir, skyrim.StringTableDLStrings, skyrim.StringTable{3: "Bow"})
+	writeLocalizedPlugin(t, pluginDir, map[uint32][]pluginField{
+		0x01000800: {{signature: "WEAP", typ: "FULL", data: stringID(1)}, {signature: "WEAP", typ: "DESC", data: stringID(3)}},
+		0x01000801: {{signature: "KEYM", typ: "FULL", data: stringID(2)}},
+	})
+
+	outputDir := t.TempDir()
+	_, err := NewExporter().GenerateStringTables(context.Background(), formatexporter.StringTableExportInput{
+		PluginPath:   filepath.Join(pluginDir, "MyMod.esp"),
+		DestLanguage: "japanese",
+		OutputDir:    outputDir,
+		Records: []formatexporter.ExportRecord{
+			{FormID: "0x01000800|MyMod.esp", RecordType: "WEAP:FULL", SourceText: "Bow", TranslatedText: "弓"},
+			{FormID: "0x01000801|MyMod.esp", RecordType: "KEYM:FULL", SourceText: "Bow", TranslatedText: "船首の鍵"},
+			{FormID: "0x01000FFF|MyMod.esp", RecordType: "MISC:FULL", SourceText: "Bow", TranslatedText: "無関係"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateStringTables failed: %v", err)
+	}
+
+	stringsTable := readOutputTable(t, filepath.Join(outputDir, "Strings", "MyMod_japanese.STRINGS"), skyrim.StringTableStrings)
+	if stringsTable[1] != "弓" || stringsTable[2] != "船首の鍵" {
+		t.Fatalf("expected each string ID to keep its own translation, got %+v", stringsTable)
+	}
+	dlStringsTable := readOutputTable(t, filepath.Join(outputDir, "Strings", "MyMod_japanese.DLSTRINGS"), skyrim.StringTableDLStrings)
+	if dlStringsTable[3] != "Bow" {
+		t.Fatalf("expected the DESC entry of the form to keep its text when only FULL was translated, got %+v", dlStringsTable)
+	}
+}
+
+func TestStringTableExporter_SkipsPluginWithoutTables(t *testing.T) {
+	paths, err := NewExporter().GenerateStringTables(context.Background(), formatexporter.StringTableExportInput{
+		PluginPath:   filepath.Join(t.TempDir(), "Inline.esp"),
+		DestLanguage: "japanese",
+		OutputDir:    t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("GenerateStringTables failed: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Fatalf("expected no output for a plugin without tables, got %v", paths)
+	}
+}
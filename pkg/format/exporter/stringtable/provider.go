@@ -0,0 +1,10 @@
+package stringtable
+
+import (
+	"github.com/google/wire"
+)
+
+// ProviderSet is the wire provider set for the string table exporter.
+var ProviderSet = wire.NewSet(
+	NewExporter,
+)
@@ -9,4 +9,10 @@ type Parser interface {
 	// LoadExtractedJSON loads extracted data from a JSON file.
 	// It supports automatic encoding detection and parallel processing.
 	LoadExtractedJSON(ctx context.Context, path string) (*ParserOutput, error)
+	// LoadPlugin reads an ESP/ESM/ESL plugin directly without an xEdit export.
+	LoadPlugin(ctx context.Context, path string) (*ParserOutput, error)
+	// LoadInterfaceTranslation reads an MCM Interface/Translations text file.
+	LoadInterfaceTranslation(ctx context.Context, path string) (*ParserOutput, error)
+	// StreamExtractedJSON decodes a JSON extraction incrementally and emits normalized records to sink in chunks.
+	StreamExtractedJSON(ctx context.Context, path string, sink ParserOutputSink) error
 }
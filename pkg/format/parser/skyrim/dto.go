@@ -3,17 +3,18 @@ package skyrim
 // ParserOutput is the root container for all extracted records.
 // It replaces the domain/models.ExtractedData to isolate the loader slice.
 type ParserOutput struct {
-	DialogueGroups []DialogueGroup `json:"dialogue_groups"`
-	Quests         []Quest         `json:"quests"`
-	Items          []Item          `json:"items"`
-	Magic          []Magic         `json:"magic"`
-	Locations      []Location      `json:"locations"`
-	Cells          []Location      `json:"cells"`
-	System         []SystemRecord  `json:"system"`
-	Messages       []Message       `json:"messages"`
-	LoadScreens    []LoadScreen    `json:"load_screens"`
-	NPCs           map[string]NPC  `json:"npcs"`
-	SourceJSON     string          `json:"-"` // Metadata, not in JSON
+	DialogueGroups        []DialogueGroup        `json:"dialogue_groups"`
+	Quests                []Quest                `json:"quests"`
+	Items                 []Item                 `json:"items"`
+	Magic                 []Magic                `json:"magic"`
+	Locations             []Location             `json:"locations"`
+	Cells                 []Location             `json:"cells"`
+	System                []SystemRecord         `json:"system"`
+	Messages              []Message              `json:"messages"`
+	LoadScreens           []LoadScreen           `json:"load_screens"`
+	NPCs                  map[string]NPC         `json:"npcs"`
+	InterfaceTranslations []InterfaceTranslation `json:"interface_translations"`
+	SourceJSON            string                 `json:"-"` // Metadata, not in JSON
 }
 
 // BaseExtractedRecord is embedded in all domain models.
@@ -146,3 +147,11 @@ type LoadScreen struct {
 	Text   string  `json:"text"`
 	Source *string `json:"source,omitempty"`
 }
+
+// InterfaceTranslation represents one "$KEY<TAB>text" entry of an MCM translation file.
+type InterfaceTranslation struct {
+	BaseExtractedRecord
+	Text    string  `json:"text"`
+	ModName string  `json:"mod_name"`
+	Source  *string `json:"source,omitempty"`
+}
@@ -0,0 +1,118 @@
+package skyrim
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// InterfaceTranslationsDir is the game-relative directory SkyUI reads MCM translation files from.
+	InterfaceTranslationsDir = "interface/translations"
+	// InterfaceTranslationRecordType is the record type assigned to MCM translation keys.
+	InterfaceTranslationRecordType = "MCM TEXT"
+
+	interfaceTranslationExtension = ".txt"
+)
+
+// InterfaceTranslationLine is one "$KEY<TAB>text" entry of an MCM translation file.
+type InterfaceTranslationLine struct {
+	Key  string
+	Text string
+}
+
+// IsInterfaceTranslationFile reports whether the path looks like an MCM translation text file.
+func IsInterfaceTranslationFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), interfaceTranslationExtension)
+}
+
+// InterfaceTranslationFileName builds "<Mod>_<LANGUAGE>.txt", the file name SkyUI resolves for a language.
+func InterfaceTranslationFileName(modName string, language string) string {
+	return fmt.Sprintf("%s_%s%s", modName, strings.ToUpper(language), interfaceTranslationExtension)
+}
+
+// InterfaceTranslationModName strips the language suffix from an MCM translation file name.
+func InterfaceTranslationModName(path string) string {
+	base := filepath.Base(path)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	if idx := strings.LastIndex(stem, "_"); idx > 0 {
+		return stem[:idx]
+	}
+	return stem
+}
+
+// ReadInterfaceTranslations decodes an MCM translation file.
+// Files are UTF-16LE with a BOM in the game; UTF-8 and BOM-less UTF-16LE are accepted as well.
+// Lines without a "$" key or a tab separator are ignored.
+func ReadInterfaceTranslations(r io.Reader) ([]InterfaceTranslationLine, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read interface translation: %w", err)
+	}
+	decoded, err := decodeInterfaceTranslation(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]InterfaceTranslationLine, 0)
+	scanner := bufio.NewScanner(strings.NewReader(decoded))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		key, text, ok := strings.Cut(line, "\t")
+		key = strings.TrimSpace(key)
+		if !ok || !strings.HasPrefix(key, "$") {
+			continue
+		}
+		lines = append(lines, InterfaceTranslationLine{Key: key, Text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan interface translation: %w", err)
+	}
+	return lines, nil
+}
+
+// WriteInterfaceTranslations encodes entries as UTF-16LE with a BOM and CRLF line endings.
+func WriteInterfaceTranslations(w io.Writer, lines []InterfaceTranslationLine) error {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(line.Key)
+		sb.WriteByte('\t')
+		sb.WriteString(strings.NewReplacer("\r", "", "\n", " ").Replace(line.Text))
+		sb.WriteString("\r\n")
+	}
+	encoder := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder()
+	encoded, _, err := transform.String(encoder, sb.String())
+	if err != nil {
+		return fmt.Errorf("encode interface translation: %w", err)
+	}
+	if _, err := io.WriteString(w, encoded); err != nil {
+		return fmt.Errorf("write interface translation: %w", err)
+	}
+	return nil
+}
+
+func decodeInterfaceTranslation(raw []byte) (string, error) {
+	var decoder transform.Transformer
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xFF, 0xFE}):
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder()
+	case bytes.HasPrefix(raw, []byte{0xFE, 0xFF}):
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder()
+	case len(raw) >= 2 && raw[1] == 0:
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder()
+	default:
+		return strings.TrimPrefix(string(raw), "\uFEFF"), nil
+	}
+	decoded, _, err := transform.Bytes(decoder, raw)
+	if err != nil {
+		return "", fmt.Errorf("decode interface translation: %w", err)
+	}
+	return string(decoded), nil
+}
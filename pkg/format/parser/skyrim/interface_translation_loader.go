@@ -0,0 +1,104 @@
+package skyrim
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+// LoadInterfaceTranslation reads one MCM translation text file into the InterfaceTranslations section.
+func (l *loader) LoadInterfaceTranslation(ctx context.Context, filePath string) (*ParserOutput, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionParser)()
+	slog.DebugContext(ctx, "starting interface translation load", slog.String("path", filePath))
+
+	f, err := openFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open interface translation path=%s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	lines, err := ReadInterfaceTranslations(f)
+	if err != nil {
+		return nil, fmt.Errorf("read interface translation path=%s: %w", filePath, err)
+	}
+
+	data := &ParserOutput{
+		NPCs:                  make(map[string]NPC),
+		InterfaceTranslations: interfaceTranslationRecords(lines, InterfaceTranslationModName(filePath), filePath, nil),
+		SourceJSON:            filePath,
+	}
+	slog.InfoContext(ctx, "interface translation load completed",
+		slog.String("path", filePath),
+		slog.Int("key_count", len(data.InterfaceTranslations)),
+	)
+	return data, nil
+}
+
+// loadPluginInterfaceTranslations collects every English MCM translation file under interface/translations,
+// from loose files next to the plugin and the plugin's BSA archives. A mod may ship files for several
+// MCM names (e.g. "SkyUI_SE_english.txt" next to "SkyUI_SE.esp" plus patches), so the directory is enumerated.
+func loadPluginInterfaceTranslations(pluginPath string) ([]InterfaceTranslation, error) {
+	pluginName := filepath.Base(pluginPath)
+	pluginBase := strings.TrimSuffix(pluginName, filepath.Ext(pluginName))
+	resources, err := ListPluginResources(pluginPath, InterfaceTranslationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("list interface translation resources plugin=%s: %w", pluginName, err)
+	}
+
+	suffix := strings.ToLower("_" + DefaultStringsLanguage + interfaceTranslationExtension)
+	var records []InterfaceTranslation
+	for _, relPath := range resources {
+		if !strings.HasSuffix(relPath, suffix) {
+			continue
+		}
+		raw, ok, err := ReadPluginResource(pluginPath, relPath)
+		if err != nil {
+			return nil, fmt.Errorf("read interface translation resource path=%s: %w", relPath, err)
+		}
+		if !ok {
+			continue
+		}
+		lines, err := ReadInterfaceTranslations(bytes.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("decode interface translation resource path=%s: %w", relPath, err)
+		}
+		modName := interfaceTranslationResourceModName(pluginPath, pluginBase, relPath)
+		records = append(records, interfaceTranslationRecords(lines, modName, relPath, &pluginName)...)
+	}
+	return records, nil
+}
+
+// interfaceTranslationResourceModName restores the MCM name casing that resource listing lower-cases:
+// the plugin's own name when it matches, otherwise the loose file's name, otherwise the archived name.
+func interfaceTranslationResourceModName(pluginPath string, pluginBase string, relPath string) string {
+	modName := InterfaceTranslationModName(relPath)
+	if strings.EqualFold(modName, pluginBase) {
+		return pluginBase
+	}
+	if loosePath, ok := findLooseResource(filepath.Dir(pluginPath), relPath); ok {
+		return InterfaceTranslationModName(loosePath)
+	}
+	return modName
+}
+
+func interfaceTranslationRecords(lines []InterfaceTranslationLine, modName string, sourcePath string, source *string) []InterfaceTranslation {
+	records := make([]InterfaceTranslation, 0, len(lines))
+	for _, line := range lines {
+		records = append(records, InterfaceTranslation{
+			BaseExtractedRecord: BaseExtractedRecord{
+				ID:         line.Key,
+				Type:       InterfaceTranslationRecordType,
+				SourceJSON: sourcePath,
+			},
+			Text:    line.Text,
+			ModName: modName,
+			Source:  source,
+		})
+	}
+	return records
+}
@@ -8,19 +8,19 @@ import (
 	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
 )
 
-// jsonLoader implements contract.Parser interface.
-type jsonLoader struct{}
+// loader implements contract.Parser interface.
+type loader struct{}
 
-// newJSONLoader creates a new instance of jsonLoader.
-func newJSONLoader() Parser {
-	return &jsonLoader{}
+// newLoader creates a new instance of loader.
+func newLoader() Parser {
+	return &loader{}
 }
 
 // LoadExtractedJSON loads extracted data from a JSON file.
 // It follows the Two-Phase Load strategy:
 // 1. Decode file into map[string]json.RawMessage (Serial)
 // 2. Unmarshal and normalize each section in parallel (Parallel)
-func (l *jsonLoader) LoadExtractedJSON(ctx context.Context, path string) (*ParserOutput, error) {
+func (l *loader) LoadExtractedJSON(ctx context.Context, path string) (*ParserOutput, error) {
 	defer telemetry2.StartSpan(ctx, telemetry2.ActionParser)()
 	slog.DebugContext(ctx, "starting JSON load", slog.String("path", path))
 
@@ -70,6 +70,7 @@ func (p *ParallelProcessor) launchUnmarshalWorkers(ctx context.Context, data *Pa
 	launch(func() error { return p.unmarshalSystem(data) })
 	launch(func() error { return p.unmarshalMessages(data) })
 	launch(func() error { return p.unmarshalLoadScreens(data) })
+	launch(func() error { return p.unmarshalInterfaceTranslations(data) })
 
 	// Close errChan when all workers are done
 	go func() {
@@ -215,6 +216,17 @@ func (p *ParallelProcessor) unmarshalLoadScreens(data *ParserOutput) error {
 	return nil
 }
 
+func (p *ParallelProcessor) unmarshalInterfaceTranslations(data *ParserOutput) error {
+	if raw, ok := p.rawMap["interface_translations"]; ok {
+		var translations []InterfaceTranslation
+		if err := json.Unmarshal(raw, &translations); err != nil {
+			return fmt.Errorf("failed to unmarshal interface_translations: %w", err)
+		}
+		data.InterfaceTranslations = translations
+	}
+	return nil
+}
+
 // --- Normalization ---
 
 func normalizeData(data *ParserOutput) {
@@ -242,31 +254,35 @@ func normalizeData(data *ParserOutput) {
 // normalizeQuestMetadata ensures parent ID and EditorID are propagated to stages and objectives.
 func normalizeQuestMetadata(data *ParserOutput) {
 	for i := range data.Quests {
-		q := &data.Quests[i]
-		parentID := q.ID
-		parentEditorID := ""
-		if q.EditorID != nil {
-			parentEditorID = *q.EditorID
-		}
+		normalizeQuest(&data.Quests[i])
+	}
+}
 
-		for j := range q.Stages {
-			s := &q.Stages[j]
-			if s.ParentID == "" {
-				s.ParentID = parentID
-			}
-			if s.ParentEditorID == "" {
-				s.ParentEditorID = parentEditorID
-			}
+// normalizeQuest propagates the quest's ID and EditorID to stages and objectives that lack them.
+func normalizeQuest(q *Quest) {
+	parentID := q.ID
+	parentEditorID := ""
+	if q.EditorID != nil {
+		parentEditorID = *q.EditorID
+	}
+
+	for j := range q.Stages {
+		s := &q.Stages[j]
+		if s.ParentID == "" {
+			s.ParentID = parentID
+		}
+		if s.ParentEditorID == "" {
+			s.ParentEditorID = parentEditorID
 		}
+	}
 
-		for j := range q.Objectives {
-			o := &q.Objectives[j]
-			if o.ParentID == "" {
-				o.ParentID = parentID
-			}
-			if o.ParentEditorID == "" {
-				o.ParentEditorID = parentEditorID
-			}
+	for j := range q.Objectives {
+		o := &q.Objectives[j]
+		if o.ParentID == "" {
+			o.ParentID = parentID
+		}
+		if o.ParentEditorID == "" {
+			o.ParentEditorID = parentEditorID
 		}
 	}
 }
@@ -274,7 +290,12 @@ func normalizeQuestMetadata(data *ParserOutput) {
 // normalizeNPCNames trims whitespace from NPC names.
 func normalizeNPCNames(data *ParserOutput) {
 	for k, npc := range data.NPCs {
-		npc.Name = strings.TrimSpace(npc.Name)
+		normalizeNPC(&npc)
 		data.NPCs[k] = npc
 	}
 }
+
+// normalizeNPC trims whitespace from one NPC name.
+func normalizeNPC(npc *NPC) {
+	npc.Name = strings.TrimSpace(npc.Name)
+}
@@ -0,0 +1,550 @@
+package skyrim
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+const (
+	dialogueCategoryService       = 6
+	dialogueCategoryMiscellaneous = 7
+
+	conditionFunctionGetIsID = 72
+	conditionRecordSize      = 32
+)
+
+// weaponAnimationTypes maps WEAP DNAM animation types to the names xEdit exports.
+var weaponAnimationTypes = map[byte]string{
+	0: "HandToHandMelee",
+	1: "OneHandSword",
+	2: "OneHandDagger",
+	3: "OneHandAxe",
+	4: "OneHandMace",
+	5: "TwoHandSword",
+	6: "TwoHandAxe",
+	7: "Bow",
+	8: "Staff",
+	9: "Crossbow",
+}
+
+// armorTypes maps ARMO BOD2/BODT armor types to the names xEdit exports.
+var armorTypes = map[uint32]string{
+	0: "Light Armor",
+	1: "Heavy Armor",
+	2: "Clothing",
+}
+
+var pluginItemSignatures = map[string]struct{}{
+	"WEAP": {}, "ARMO": {}, "AMMO": {}, "ALCH": {}, "INGR": {}, "KEYM": {},
+	"MISC": {}, "LIGH": {}, "CONT": {}, "SLGM": {}, "BOOK": {},
+}
+
+var pluginMagicSignatures = map[string]struct{}{
+	"SPEL": {}, "MGEF": {}, "ENCH": {}, "SCRL": {}, "SHOU": {},
+}
+
+// pluginExtractor maps decoded plugin records into ParserOutput the same way extractData.pas does.
+type pluginExtractor struct {
+	header    pluginHeader
+	source    string
+	strings   StringLookup
+	editorIDs map[uint32]string
+	npcs      map[uint32]pluginRecord
+}
+
+// pluginInfoUnit keeps the responses produced by one INFO record together for PNAM ordering.
+type pluginInfoUnit struct {
+	id         string
+	previousID string
+	responses  []DialogueResponse
+}
+
+func newPluginExtractor(header pluginHeader, source string, lookup StringLookup, records []pluginRecord) *pluginExtractor {
+	e := &pluginExtractor{
+		header:    header,
+		source:    source,
+		strings:   lookup,
+		editorIDs: make(map[uint32]string, len(records)),
+		npcs:      make(map[uint32]pluginRecord),
+	}
+	for _, record := range records {
+		if data, ok := record.first("EDID"); ok {
+			e.editorIDs[record.FormID] = decodeZString(data)
+		}
+		if record.Signature == "NPC_" {
+			e.npcs[record.FormID] = record
+		}
+	}
+	return e
+}
+
+// Extract converts the records into a ParserOutput.
+func (e *pluginExtractor) Extract(records []pluginRecord) *ParserOutput {
+	data := &ParserOutput{NPCs: make(map[string]NPC)}
+	dialogueIndex := make(map[uint32]int)
+	infosByTopic := make(map[uint32][]pluginRecord)
+
+	for _, record := range records {
+		switch record.Signature {
+		case "DIAL":
+			dialogueIndex[record.FormID] = len(data.DialogueGroups)
+			data.DialogueGroups = append(data.DialogueGroups, e.extractDialogue(record))
+		case "INFO":
+			infosByTopic[record.TopicID] = append(infosByTopic[record.TopicID], record)
+		case "NPC_":
+			if npc, ok := e.extractNPC(record); ok {
+				data.NPCs[npc.ID] = npc
+			}
+		case "QUST":
+			if quest, ok := e.extractQuest(record); ok {
+				data.Quests = append(data.Quests, quest)
+			}
+		case "MESG":
+			if message, ok := e.extractMessage(record); ok {
+				data.Messages = append(data.Messages, message)
+			}
+		case "LSCR":
+			if text := e.lstring(record, "DESC"); text != "" {
+				data.LoadScreens = append(data.LoadScreens, LoadScreen{
+					BaseExtractedRecord: e.base(record, "LSCR DESC"),
+					Text:                text,
+					Source:              stringPtr(e.source),
+				})
+			}
+		case "PERK":
+			if name := e.lstring(record, "FULL"); name != "" {
+				data.System = append(data.System, SystemRecord{
+					BaseExtractedRecord: e.base(record, "PERK FULL"),
+					Name:                stringPtr(name),
+					Description:         optionalStringPtr(e.lstring(record, "DESC")),
+					Source:              stringPtr(e.source),
+				})
+			}
+		case "LCTN", "WRLD", "CELL":
+			if location, ok := e.extractLocation(record); ok {
+				data.Locations = append(data.Locations, location)
+			}
+		default:
+			if _, ok := pluginItemSignatures[record.Signature]; ok {
+				data.Items = append(data.Items, e.extractItems(record)...)
+				continue
+			}
+			if _, ok := pluginMagicSignatures[record.Signature]; ok {
+				if magic, ok := e.extractMagic(record); ok {
+					data.Magic = append(data.Magic, magic)
+				}
+			}
+		}
+	}
+
+	for topicID, infos := range infosByTopic {
+		idx, ok := dialogueIndex[topicID]
+		if !ok {
+			// INFOs without a DIAL header in this plugin are dropped, as in the xEdit export.
+			continue
+		}
+		group := &data.DialogueGroups[idx]
+		units := make([]pluginInfoUnit, 0, len(infos))
+		for _, info := range infos {
+			units = append(units, e.extractInfo(info, group.PlayerText))
+		}
+		for _, unit := range orderInfoUnits(units) {
+			group.Responses = append(group.Responses, unit.responses...)
+		}
+	}
+	for i := range data.DialogueGroups {
+		if data.DialogueGroups[i].Responses == nil {
+			data.DialogueGroups[i].Responses = make([]DialogueResponse, 0)
+		}
+	}
+	return data
+}
+
+func (e *pluginExtractor) extractDialogue(record pluginRecord) DialogueGroup {
+	group := DialogueGroup{
+		BaseExtractedRecord: e.base(record, "DIAL FULL"),
+		PlayerText:          optionalStringPtr(e.lstring(record, "FULL")),
+		QuestID:             optionalStringPtr(e.formIDRef(record, "QNAM")),
+		Source:              stringPtr(e.source),
+	}
+	if data, ok := record.first("DATA"); ok && len(data) >= 2 {
+		category := data[1]
+		group.IsServicesBranch = category == dialogueCategoryService || category == dialogueCategoryMiscellaneous
+	}
+	if data, ok := record.first("SNAM"); ok && len(data) == 4 {
+		group.ServicesType = optionalStringPtr(decodeZString(data))
+	}
+	return group
+}
+
+func (e *pluginExtractor) extractInfo(record pluginRecord, topicText *string) pluginInfoUnit {
+	infoID := formIDString(record.FormID)
+	prompt := e.lstring(record, "RNAM")
+	speakerID := e.formIDRef(record, "ANAM")
+	if speakerID == "" {
+		speakerID = e.conditionSpeaker(record)
+	}
+	var voiceType *string
+	if speakerID != "" {
+		if speaker, ok := e.npcs[parseFormID(speakerID)]; ok {
+			voiceType = optionalStringPtr(e.recordRef(speaker, "VTCK"))
+		}
+	}
+
+	unit := pluginInfoUnit{id: infoID, previousID: e.formIDRef(record, "PNAM")}
+	type responseDraft struct {
+		text  string
+		index *int
+	}
+	drafts := make([]responseDraft, 0, 1)
+	for _, sub := range record.Subrecords {
+		switch sub.Type {
+		case "TRDT":
+			draft := responseDraft{}
+			if len(sub.Data) > 12 {
+				number := int(sub.Data[12])
+				draft.index = &number
+			}
+			drafts = append(drafts, draft)
+		case "NAM1":
+			if len(drafts) == 0 {
+				drafts = append(drafts, responseDraft{})
+			}
+			drafts[len(drafts)-1].text = e.decodeLString(sub.Data)
+		}
+	}
+
+	for order, draft := range drafts {
+		if draft.text == "" && speakerID == "" {
+			continue
+		}
+		unit.responses = append(unit.responses, DialogueResponse{
+			BaseExtractedRecord: e.base(record, "INFO NAM1"),
+			Text:                draft.text,
+			Prompt:              optionalStringPtr(prompt),
+			TopicText:           topicText,
+			MenuDisplayText:     optionalStringPtr(prompt),
+			SpeakerID:           optionalStringPtr(speakerID),
+			VoiceType:           voiceType,
+			Order:               order,
+			PreviousID:          optionalStringPtr(unit.previousID),
+			Source:              stringPtr(e.source),
+			Index:               draft.index,
+		})
+	}
+	return unit
+}
+
+// conditionSpeaker returns the NPC referenced by the first GetIsID condition.
+func (e *pluginExtractor) conditionSpeaker(record pluginRecord) string {
+	for _, sub := range record.Subrecords {
+		if sub.Type != "CTDA" || len(sub.Data) < conditionRecordSize {
+			continue
+		}
+		if binary.LittleEndian.Uint16(sub.Data[8:10]) != conditionFunctionGetIsID {
+			continue
+		}
+		if id := binary.LittleEndian.Uint32(sub.Data[12:16]); id != 0 {
+			return formIDString(id)
+		}
+	}
+	return ""
+}
+
+func (e *pluginExtractor) extractNPC(record pluginRecord) (NPC, bool) {
+	name := e.lstring(record, "FULL")
+	if name == "" {
+		return NPC{}, false
+	}
+	sex := "Male"
+	if data, ok := record.first("ACBS"); ok && len(data) >= 4 && binary.LittleEndian.Uint32(data[0:4])&1 != 0 {
+		sex = "Female"
+	}
+	return NPC{
+		BaseExtractedRecord: e.base(record, "NPC_ FULL"),
+		Name:                name,
+		Race:                e.recordRef(record, "RNAM"),
+		Voice:               e.recordRef(record, "VTCK"),
+		Sex:                 sex,
+		ClassName:           optionalStringPtr(e.recordRef(record, "CNAM")),
+		Source:              stringPtr(e.source),
+	}, true
+}
+
+func (e *pluginExtractor) extractQuest(record pluginRecord) (Quest, bool) {
+	name := e.lstring(record, "FULL")
+	if name == "" {
+		return Quest{}, false
+	}
+	questID := formIDString(record.FormID)
+	editorID := e.editorIDs[record.FormID]
+	quest := Quest{
+		BaseExtractedRecord: e.base(record, "QUST FULL"),
+		Name:                stringPtr(name),
+		Stages:              make([]QuestStage, 0),
+		Objectives:          make([]QuestObjective, 0),
+		Source:              stringPtr(e.source),
+	}
+
+	stageIndex := 0
+	logIndex := -1
+	inStage := false
+	objectiveIndex := ""
+	for _, sub := range record.Subrecords {
+		switch sub.Type {
+		case "INDX":
+			if len(sub.Data) >= 2 {
+				stageIndex = int(binary.LittleEndian.Uint16(sub.Data[0:2]))
+			}
+			inStage = true
+			logIndex = -1
+		case "QSDT":
+			logIndex++
+		case "CNAM":
+			if !inStage {
+				continue
+			}
+			if logIndex < 0 {
+				logIndex = 0
+			}
+			if text := e.decodeLString(sub.Data); text != "" {
+				quest.Stages = append(quest.Stages, QuestStage{
+					StageIndex:     stageIndex,
+					LogIndex:       logIndex,
+					Type:           "QUST CNAM",
+					Text:           text,
+					ParentID:       questID,
+					ParentEditorID: editorID,
+				})
+			}
+		case "QOBJ":
+			inStage = false
+			if len(sub.Data) >= 2 {
+				objectiveIndex = strconv.Itoa(int(int16(binary.LittleEndian.Uint16(sub.Data[0:2]))))
+			}
+		case "NNAM":
+			if text := e.decodeLString(sub.Data); text != "" {
+				quest.Objectives = append(quest.Objectives, QuestObjective{
+					Index:          objectiveIndex,
+					Type:           "QUST NNAM",
+					Text:           text,
+					ParentID:       questID,
+					ParentEditorID: editorID,
+				})
+			}
+		case "ANAM", "ALST", "ALLS":
+			// Aliases follow stages and objectives; nothing after them is extracted.
+			inStage = false
+		}
+	}
+	return quest, true
+}
+
+func (e *pluginExtractor) extractItems(record pluginRecord) []Item {
+	items := make([]Item, 0, 2)
+	name := e.lstring(record, "FULL")
+	description := e.lstring(record, "DESC")
+	text := ""
+	typeHint := ""
+	switch record.Signature {
+	case "WEAP":
+		if data, ok := record.first("DNAM"); ok && len(data) > 0 {
+			typeHint = weaponAnimationTypes[data[0]]
+		}
+	case "ARMO":
+		typeHint = armorTypeHint(record)
+	case "BOOK":
+		text = description
+		description = ""
+	}
+
+	if name != "" {
+		items = append(items, Item{
+			BaseExtractedRecord: e.base(record, record.Signature+" FULL"),
+			Name:                stringPtr(name),
+			Description:         optionalStringPtr(description),
+			TypeHint:            optionalStringPtr(typeHint),
+			Source:              stringPtr(e.source),
+		})
+	}
+	if record.Signature == "BOOK" && text != "" {
+		items = append(items, Item{
+			BaseExtractedRecord: e.base(record, "BOOK DESC"),
+			Text:                stringPtr(text),
+			Source:              stringPtr(e.source),
+		})
+	}
+	return items
+}
+
+func armorTypeHint(record pluginRecord) string {
+	if data, ok := record.first("BOD2"); ok && len(data) >= 8 {
+		return armorTypes[binary.LittleEndian.Uint32(data[4:8])]
+	}
+	if data, ok := record.first("BODT"); ok && len(data) >= 12 {
+		return armorTypes[binary.LittleEndian.Uint32(data[8:12])]
+	}
+	return ""
+}
+
+func (e *pluginExtractor) extractMagic(record pluginRecord) (Magic, bool) {
+	name := e.lstring(record, "FULL")
+	if name == "" {
+		return Magic{}, false
+	}
+	description := e.lstring(record, "DESC")
+	if description == "" && record.Signature == "MGEF" {
+		description = e.lstring(record, "DNAM")
+	}
+	return Magic{
+		BaseExtractedRecord: e.base(record, record.Signature+" FULL"),
+		Name:                stringPtr(name),
+		Description:         optionalStringPtr(description),
+		Source:              stringPtr(e.source),
+	}, true
+}
+
+func (e *pluginExtractor) extractLocation(record pluginRecord) (Location, bool) {
+	name := e.lstring(record, "FULL")
+	if name == "" {
+		return Location{}, false
+	}
+	parentField := map[string]string{"LCTN": "PNAM", "WRLD": "WNAM", "CELL": "XLCN"}[record.Signature]
+	return Location{
+		BaseExtractedRecord: e.base(record, record.Signature+" FULL"),
+		Name:                stringPtr(name),
+		ParentID:            optionalStringPtr(e.recordRef(record, parentField)),
+		Source:              stringPtr(e.source),
+	}, true
+}
+
+func (e *pluginExtractor) extractMessage(record pluginRecord) (Message, bool) {
+	text := e.lstring(record, "DESC")
+	if text == "" {
+		return Message{}, false
+	}
+	return Message{
+		BaseExtractedRecord: e.base(record, "MESG DESC"),
+		Text:                text,
+		Title:               optionalStringPtr(e.lstring(record, "FULL")),
+		QuestID:             optionalStringPtr(e.formIDRef(record, "QNAM")),
+		Source:              stringPtr(e.source),
+	}, true
+}
+
+func (e *pluginExtractor) base(record pluginRecord, recordType string) BaseExtractedRecord {
+	return BaseExtractedRecord{
+		ID:       formIDString(record.FormID),
+		EditorID: optionalStringPtr(e.editorIDs[record.FormID]),
+		Type:     recordType,
+	}
+}
+
+// lstring returns the text of a localizable subrecord.
+func (e *pluginExtractor) lstring(record pluginRecord, subrecordType string) string {
+	data, ok := record.first(subrecordType)
+	if !ok {
+		return ""
+	}
+	return e.decodeLString(data)
+}
+
+// decodeLString resolves string table IDs for localized plugins and decodes inline strings otherwise.
+func (e *pluginExtractor) decodeLString(data []byte) string {
+	if !e.header.IsLocalized() {
+		return decodeZString(data)
+	}
+	if len(data) != 4 || e.strings == nil {
+		return ""
+	}
+	text, ok := e.strings.LookupString(binary.LittleEndian.Uint32(data))
+	if !ok {
+		return ""
+	}
+	return text
+}
+
+// formIDRef returns a referenced form ID as 8-digit hex, or empty for a null reference.
+func (e *pluginExtractor) formIDRef(record pluginRecord, subrecordType string) string {
+	data, ok := record.first(subrecordType)
+	if !ok || len(data) < 4 {
+		return ""
+	}
+	id := binary.LittleEndian.Uint32(data[0:4])
+	if id == 0 {
+		return ""
+	}
+	return formIDString(id)
+}
+
+// recordRef returns the editor ID of a referenced record defined in this plugin, or its form ID otherwise.
+func (e *pluginExtractor) recordRef(record pluginRecord, subrecordType string) string {
+	ref := e.formIDRef(record, subrecordType)
+	if ref == "" {
+		return ""
+	}
+	if editorID := e.editorIDs[parseFormID(ref)]; editorID != "" {
+		return editorID
+	}
+	return ref
+}
+
+// orderInfoUnits follows the PNAM chain breadth-first from the root INFOs, appends unreachable INFOs
+// in file order, and reverses the result to match the response order of the xEdit export.
+func orderInfoUnits(units []pluginInfoUnit) []pluginInfoUnit {
+	if len(units) <= 1 {
+		return units
+	}
+	children := make(map[string][]int, len(units))
+	for i, unit := range units {
+		children[unit.previousID] = append(children[unit.previousID], i)
+	}
+
+	visited := make([]bool, len(units))
+	ordered := make([]pluginInfoUnit, 0, len(units))
+	queue := append([]int(nil), children[""]...)
+	for len(queue) > 0 {
+		idx := queue[0]
+		queue = queue[1:]
+		if visited[idx] {
+			continue
+		}
+		visited[idx] = true
+		ordered = append(ordered, units[idx])
+		queue = append(queue, children[units[idx].id]...)
+	}
+	for i, unit := range units {
+		if !visited[i] {
+			ordered = append(ordered, unit)
+		}
+	}
+
+	for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	}
+	return ordered
+}
+
+func formIDString(id uint32) string {
+	return fmt.Sprintf("%08X", id)
+}
+
+func parseFormID(value string) uint32 {
+	id, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(id)
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func optionalStringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
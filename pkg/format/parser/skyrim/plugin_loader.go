@@ -0,0 +1,56 @@
+package skyrim
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+// LoadPlugin reads a TES5 plugin file and maps its records into ParserOutput.
+func (l *loader) LoadPlugin(ctx context.Context, path string) (*ParserOutput, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionParser)()
+	slog.DebugContext(ctx, "starting plugin load", slog.String("path", path))
+
+	f, err := openFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open plugin path=%s: %w", path, err)
+	}
+	defer f.Close()
+
+	header, records, err := readPluginRecords(f)
+	if err != nil {
+		slog.ErrorContext(ctx, "plugin read failed", telemetry2.ErrorAttrs(err)...)
+		return nil, fmt.Errorf("read plugin path=%s: %w", path, err)
+	}
+
+	var lookup StringLookup
+	if header.IsLocalized() {
+		tables, err := LoadPluginStringTables(path, DefaultStringsLanguage)
+		if err != nil {
+			return nil, fmt.Errorf("load string tables path=%s: %w", path, err)
+		}
+		if len(tables) == 0 {
+			slog.WarnContext(ctx, "localized plugin has no string tables; localized text is skipped", slog.String("path", path))
+		}
+		lookup = tables
+	}
+
+	data := newPluginExtractor(header, filepath.Base(path), lookup, records).Extract(records)
+	normalizeData(data)
+	data.InterfaceTranslations, err = loadPluginInterfaceTranslations(path)
+	if err != nil {
+		return nil, fmt.Errorf("load interface translations path=%s: %w", path, err)
+	}
+	data.SourceJSON = path
+	slog.InfoContext(ctx, "plugin load completed",
+		slog.String("path", path),
+		slog.Int("record_count", len(records)),
+		slog.Int("dialogue_group_count", len(data.DialogueGroups)),
+		slog.Int("npc_count", len(data.NPCs)),
+		slog.Int("interface_translation_count", len(data.InterfaceTranslations)),
+	)
+	return data, nil
+}
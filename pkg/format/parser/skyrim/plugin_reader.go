@@ -0,0 +1,266 @@
+package skyrim
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	pluginHeaderSize = 24
+
+	pluginFlagLocalized  uint32 = 0x00000080
+	recordFlagCompressed uint32 = 0x00040000
+
+	groupTypeTopicChildren int32 = 7
+)
+
+// pluginRecordSignatures lists the record types the plugin reader decodes; every other record is skipped.
+var pluginRecordSignatures = map[string]struct{}{
+	"DIAL": {}, "INFO": {}, "NPC_": {}, "QUST": {}, "MESG": {}, "LSCR": {}, "PERK": {},
+	"WEAP": {}, "ARMO": {}, "AMMO": {}, "ALCH": {}, "INGR": {}, "KEYM": {},
+	"MISC": {}, "LIGH": {}, "CONT": {}, "SLGM": {}, "BOOK": {},
+	"SPEL": {}, "MGEF": {}, "ENCH": {}, "SCRL": {}, "SHOU": {},
+	"LCTN": {}, "WRLD": {}, "CELL": {},
+	"RACE": {}, "VTYP": {}, "CLAS": {},
+}
+
+// StringLookup resolves localized string IDs to text.
+type StringLookup interface {
+	LookupString(id uint32) (string, bool)
+}
+
+// pluginHeader holds the TES4 header values needed while decoding records.
+type pluginHeader struct {
+	Flags   uint32
+	Masters []string
+}
+
+// IsLocalized reports whether lstring fields are stored as string table IDs.
+func (h pluginHeader) IsLocalized() bool {
+	return h.Flags&pluginFlagLocalized != 0
+}
+
+// pluginSubrecord is a single typed field inside a record.
+type pluginSubrecord struct {
+	Type string
+	Data []byte
+}
+
+// pluginRecord is a decoded record with its subrecords in file order.
+type pluginRecord struct {
+	Signature  string
+	FormID     uint32
+	Flags      uint32
+	Subrecords []pluginSubrecord
+	// TopicID is the owning DIAL form ID for INFO records.
+	TopicID uint32
+}
+
+// first returns the first subrecord of the given type.
+func (r pluginRecord) first(subrecordType string) ([]byte, bool) {
+	for _, sub := range r.Subrecords {
+		if sub.Type == subrecordType {
+			return sub.Data, true
+		}
+	}
+	return nil, false
+}
+
+// pluginGroup tracks an open GRUP while walking the file.
+type pluginGroup struct {
+	End       int64
+	Label     uint32
+	GroupType int32
+}
+
+// IsPluginFile reports whether the path points to an ESP/ESM/ESL plugin.
+func IsPluginFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(strings.TrimSpace(path))) {
+	case ".esp", ".esm", ".esl":
+		return true
+	default:
+		return false
+	}
+}
+
+// readPluginRecords walks a TES5 plugin stream and returns its header and the records of interest.
+func readPluginRecords(r io.Reader) (pluginHeader, []pluginRecord, error) {
+	reader := bufio.NewReaderSize(r, 1<<20)
+	var offset int64
+
+	headerBytes := make([]byte, pluginHeaderSize)
+	if _, err := io.ReadFull(reader, headerBytes); err != nil {
+		return pluginHeader{}, nil, fmt.Errorf("read plugin header: %w", err)
+	}
+	offset += pluginHeaderSize
+	if string(headerBytes[0:4]) != "TES4" {
+		return pluginHeader{}, nil, fmt.Errorf("unsupported plugin signature %q", string(headerBytes[0:4]))
+	}
+	tes4, err := readRecordBody(reader, headerBytes)
+	if err != nil {
+		return pluginHeader{}, nil, fmt.Errorf("read TES4 record: %w", err)
+	}
+	offset += int64(binary.LittleEndian.Uint32(headerBytes[4:8]))
+
+	header := pluginHeader{Flags: tes4.Flags}
+	for _, sub := range tes4.Subrecords {
+		if sub.Type == "MAST" {
+			header.Masters = append(header.Masters, decodeZString(sub.Data))
+		}
+	}
+
+	records := make([]pluginRecord, 0)
+	groups := make([]pluginGroup, 0, 8)
+	for {
+		for len(groups) > 0 && groups[len(groups)-1].End <= offset {
+			groups = groups[:len(groups)-1]
+		}
+
+		if _, err := io.ReadFull(reader, headerBytes); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return pluginHeader{}, nil, fmt.Errorf("read record header offset=%d: %w", offset, err)
+		}
+		offset += pluginHeaderSize
+		signature := string(headerBytes[0:4])
+		size := binary.LittleEndian.Uint32(headerBytes[4:8])
+
+		if signature == "GRUP" {
+			if size < pluginHeaderSize {
+				return pluginHeader{}, nil, fmt.Errorf("invalid group size=%d offset=%d", size, offset-pluginHeaderSize)
+			}
+			groups = append(groups, pluginGroup{
+				End:       offset - pluginHeaderSize + int64(size),
+				Label:     binary.LittleEndian.Uint32(headerBytes[8:12]),
+				GroupType: int32(binary.LittleEndian.Uint32(headerBytes[12:16])),
+			})
+			continue
+		}
+
+		if _, ok := pluginRecordSignatures[signature]; !ok {
+			if _, err := reader.Discard(int(size)); err != nil {
+				return pluginHeader{}, nil, fmt.Errorf("skip record signature=%s offset=%d: %w", signature, offset, err)
+			}
+			offset += int64(size)
+			continue
+		}
+
+		record, err := readRecordBody(reader, headerBytes)
+		if err != nil {
+			return pluginHeader{}, nil, fmt.Errorf("read record signature=%s offset=%d: %w", signature, offset, err)
+		}
+		offset += int64(size)
+		if signature == "INFO" && len(groups) > 0 && groups[len(groups)-1].GroupType == groupTypeTopicChildren {
+			record.TopicID = groups[len(groups)-1].Label
+		}
+		records = append(records, record)
+	}
+	return header, records, nil
+}
+
+// readRecordBody reads the record data following a 24-byte header and splits it into subrecords.
+func readRecordBody(reader io.Reader, headerBytes []byte) (pluginRecord, error) {
+	record := pluginRecord{
+		Signature: string(headerBytes[0:4]),
+		Flags:     binary.LittleEndian.Uint32(headerBytes[8:12]),
+		FormID:    binary.LittleEndian.Uint32(headerBytes[12:16]),
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(headerBytes[4:8]))
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return pluginRecord{}, fmt.Errorf("read record data: %w", err)
+	}
+
+	if record.Flags&recordFlagCompressed != 0 {
+		decompressed, err := decompressRecordData(data)
+		if err != nil {
+			return pluginRecord{}, fmt.Errorf("decompress form_id=%08X: %w", record.FormID, err)
+		}
+		data = decompressed
+	}
+
+	subrecords, err := splitSubrecords(data)
+	if err != nil {
+		return pluginRecord{}, fmt.Errorf("split subrecords form_id=%08X: %w", record.FormID, err)
+	}
+	record.Subrecords = subrecords
+	return record, nil
+}
+
+// decompressRecordData inflates a compressed record body prefixed with its decompressed size.
+func decompressRecordData(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("compressed record is too short")
+	}
+	expectedSize := binary.LittleEndian.Uint32(data[0:4])
+	zr, err := zlib.NewReader(bytes.NewReader(data[4:]))
+	if err != nil {
+		return nil, fmt.Errorf("open zlib stream: %w", err)
+	}
+	defer zr.Close()
+
+	decompressed := make([]byte, expectedSize)
+	if _, err := io.ReadFull(zr, decompressed); err != nil {
+		return nil, fmt.Errorf("inflate record: %w", err)
+	}
+	return decompressed, nil
+}
+
+// splitSubrecords parses record data into subrecords, honoring XXXX size overrides.
+func splitSubrecords(data []byte) ([]pluginSubrecord, error) {
+	subrecords := make([]pluginSubrecord, 0)
+	var overrideSize uint32
+	hasOverride := false
+	for pos := 0; pos < len(data); {
+		if pos+6 > len(data) {
+			return nil, fmt.Errorf("truncated subrecord header at %d", pos)
+		}
+		subType := string(data[pos : pos+4])
+		size := uint32(binary.LittleEndian.Uint16(data[pos+4 : pos+6]))
+		pos += 6
+		if hasOverride {
+			size = overrideSize
+			hasOverride = false
+		}
+		if pos+int(size) > len(data) {
+			return nil, fmt.Errorf("truncated subrecord type=%s size=%d at %d", subType, size, pos)
+		}
+		payload := data[pos : pos+int(size)]
+		pos += int(size)
+
+		if subType == "XXXX" {
+			if len(payload) != 4 {
+				return nil, fmt.Errorf("invalid XXXX payload size=%d", len(payload))
+			}
+			overrideSize = binary.LittleEndian.Uint32(payload)
+			hasOverride = true
+			continue
+		}
+		subrecords = append(subrecords, pluginSubrecord{Type: subType, Data: payload})
+	}
+	return subrecords, nil
+}
+
+// decodeZString converts a NUL-terminated plugin string to UTF-8, falling back to Windows-1252.
+func decodeZString(data []byte) string {
+	if idx := bytes.IndexByte(data, 0); idx >= 0 {
+		data = data[:idx]
+	}
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
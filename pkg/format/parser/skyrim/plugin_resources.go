@@ -0,0 +1,168 @@
+package skyrim
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/archive/bsa"
+)
+
+const archiveExtension = ".bsa"
+
+// ReadPluginResource reads a game-relative resource (e.g. "strings/Mod_english.STRINGS") for a plugin.
+// Loose files next to the plugin take precedence over files packed in the plugin's BSA archives.
+// The boolean result is false when the resource exists in neither place.
+func ReadPluginResource(pluginPath string, relPath string) ([]byte, bool, error) {
+	if loosePath, ok := findLooseResource(filepath.Dir(pluginPath), relPath); ok {
+		data, err := os.ReadFile(loosePath)
+		if err != nil {
+			return nil, false, fmt.Errorf("read loose resource path=%s: %w", loosePath, err)
+		}
+		return data, true, nil
+	}
+
+	for _, archivePath := range PluginArchivePaths(pluginPath) {
+		data, ok, err := readArchivedResource(archivePath, relPath)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return data, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// ListPluginResources lists resources under a game-relative directory (e.g. "interface/translations")
+// from both loose files and the plugin's BSA archives. Paths are lower-cased, slash-separated and unique.
+func ListPluginResources(pluginPath string, dir string) ([]string, error) {
+	prefix := bsa.NormalizePath(dir)
+	seen := make(map[string]struct{})
+
+	if looseDir, ok := findLooseResource(filepath.Dir(pluginPath), prefix); ok {
+		entries, err := os.ReadDir(looseDir)
+		if err != nil {
+			return nil, fmt.Errorf("list loose resources path=%s: %w", looseDir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			seen[bsa.NormalizePath(prefix+"/"+entry.Name())] = struct{}{}
+		}
+	}
+
+	for _, archivePath := range PluginArchivePaths(pluginPath) {
+		archive, err := bsa.Open(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range archive.Files() {
+			if strings.HasPrefix(name, prefix+"/") && !strings.Contains(name[len(prefix)+1:], "/") {
+				seen[name] = struct{}{}
+			}
+		}
+		_ = archive.Close()
+	}
+
+	resources := make([]string, 0, len(seen))
+	for name := range seen {
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+	return resources, nil
+}
+
+// PluginArchivePaths returns the BSA archives that belong to a plugin, following the game's naming rule:
+// "<Plugin>.bsa" and "<Plugin> - <Suffix>.bsa" in the plugin's directory.
+func PluginArchivePaths(pluginPath string) []string {
+	dir := filepath.Dir(pluginPath)
+	base := strings.ToLower(strings.TrimSuffix(filepath.Base(pluginPath), filepath.Ext(pluginPath)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	paths := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(name), archiveExtension) {
+			continue
+		}
+		stem := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+		if stem == base || strings.HasPrefix(stem, base+" - ") {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// ExpandPluginInputPaths replaces mod folders in the input list with the plugin files they contain.
+// Regular files are kept as-is so extracted JSON and plugins can be mixed with folders.
+func ExpandPluginInputPaths(paths []string) ([]string, error) {
+	expanded := make([]string, 0, len(paths))
+	for _, path := range paths {
+		trimmed := strings.TrimSpace(path)
+		if trimmed == "" {
+			continue
+		}
+		info, err := os.Stat(trimmed)
+		if err != nil || !info.IsDir() {
+			expanded = append(expanded, trimmed)
+			continue
+		}
+		entries, err := os.ReadDir(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("list mod folder path=%s: %w", trimmed, err)
+		}
+		found := 0
+		for _, entry := range entries {
+			if entry.IsDir() || !IsPluginFile(entry.Name()) {
+				continue
+			}
+			expanded = append(expanded, filepath.Join(trimmed, entry.Name()))
+			found++
+		}
+		if found == 0 {
+			return nil, fmt.Errorf("mod folder has no plugin files path=%s", trimmed)
+		}
+	}
+	return expanded, nil
+}
+
+func readArchivedResource(archivePath string, relPath string) ([]byte, bool, error) {
+	archive, err := bsa.Open(archivePath)
+	if err != nil {
+		return nil, false, err
+	}
+	defer archive.Close()
+	if !archive.Has(relPath) {
+		return nil, false, nil
+	}
+	data, err := archive.ReadFile(relPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("read archived resource archive=%s: %w", archivePath, err)
+	}
+	return data, true, nil
+}
+
+// findLooseResource resolves a slash-separated relative path segment by segment, ignoring case.
+func findLooseResource(root string, relPath string) (string, bool) {
+	current := root
+	for _, segment := range strings.Split(bsa.NormalizePath(relPath), "/") {
+		next, ok := findEntryFold(current, segment)
+		if !ok {
+			return "", false
+		}
+		current = next
+	}
+	return current, true
+}
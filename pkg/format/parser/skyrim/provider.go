@@ -6,7 +6,7 @@ import (
 
 // ProvideParser returns an implementation of Parser.
 func ProvideParser() Parser {
-	return newJSONLoader()
+	return newLoader()
 }
 
 // ParserSet provides the loader components for dependency injection.
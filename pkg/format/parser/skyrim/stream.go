@@ -0,0 +1,256 @@
+package skyrim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+// streamChunkSize bounds how many records are buffered before a chunk is handed to the sink.
+const streamChunkSize = 500
+
+// ParserOutputSink receives parser output in chunks; each chunk only holds records not emitted before.
+type ParserOutputSink func(chunk *ParserOutput) error
+
+// StreamExtractedJSON decodes an xEdit extraction token by token and emits normalized records in bounded chunks.
+// Unlike LoadExtractedJSON it never holds the whole document, so memory stays flat for multi-gigabyte files.
+func (l *loader) StreamExtractedJSON(ctx context.Context, path string, sink ParserOutputSink) error {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionParser)()
+	slog.DebugContext(ctx, "starting JSON stream", slog.String("path", path))
+
+	f, err := openFile(path)
+	if err != nil {
+		return fmt.Errorf("open parser input path=%s: %w", path, err)
+	}
+	defer f.Close()
+
+	reader, err := createUTF8Reader(f)
+	if err != nil {
+		return fmt.Errorf("create utf8 reader path=%s: %w", path, err)
+	}
+
+	stream := newSectionStream(ctx, json.NewDecoder(reader), sink)
+	if err := stream.run(); err != nil {
+		slog.ErrorContext(ctx, "JSON stream failed", telemetry2.ErrorAttrs(err)...)
+		return fmt.Errorf("stream parser input path=%s: %w", path, err)
+	}
+	slog.InfoContext(ctx, "JSON stream completed",
+		slog.String("path", path),
+		slog.Int("record_count", stream.total),
+		slog.Int("chunk_count", stream.chunks),
+	)
+	return nil
+}
+
+type sectionStream struct {
+	ctx     context.Context
+	decoder *json.Decoder
+	sink    ParserOutputSink
+	pending *ParserOutput
+	size    int
+	total   int
+	chunks  int
+}
+
+func newSectionStream(ctx context.Context, decoder *json.Decoder, sink ParserOutputSink) *sectionStream {
+	return &sectionStream{
+		ctx:     ctx,
+		decoder: decoder,
+		sink:    sink,
+		pending: newParserOutputChunk(),
+	}
+}
+
+func newParserOutputChunk() *ParserOutput {
+	return &ParserOutput{NPCs: make(map[string]NPC)}
+}
+
+func (s *sectionStream) run() error {
+	if err := expectDelim(s.decoder, '{'); err != nil {
+		return err
+	}
+	for s.decoder.More() {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return fmt.Errorf("read section name: %w", err)
+		}
+		section, _ := token.(string)
+		if err := s.streamSection(section); err != nil {
+			return fmt.Errorf("stream section=%s: %w", section, err)
+		}
+	}
+	if err := expectDelim(s.decoder, '}'); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *sectionStream) streamSection(section string) error {
+	switch section {
+	case "dialogue_groups":
+		return streamArray(s, func(chunk *ParserOutput, group DialogueGroup) {
+			chunk.DialogueGroups = append(chunk.DialogueGroups, group)
+		})
+	case "quests":
+		return streamArray(s, func(chunk *ParserOutput, quest Quest) {
+			normalizeQuest(&quest)
+			chunk.Quests = append(chunk.Quests, quest)
+		})
+	case "items":
+		return streamArray(s, func(chunk *ParserOutput, item Item) {
+			chunk.Items = append(chunk.Items, item)
+		})
+	case "magic":
+		return streamArray(s, func(chunk *ParserOutput, magic Magic) {
+			chunk.Magic = append(chunk.Magic, magic)
+		})
+	case "locations":
+		return streamArray(s, func(chunk *ParserOutput, location Location) {
+			chunk.Locations = append(chunk.Locations, location)
+		})
+	case "cells":
+		return streamArray(s, func(chunk *ParserOutput, cell Location) {
+			chunk.Cells = append(chunk.Cells, cell)
+		})
+	case "system":
+		return streamArray(s, func(chunk *ParserOutput, record SystemRecord) {
+			chunk.System = append(chunk.System, record)
+		})
+	case "messages":
+		return streamArray(s, func(chunk *ParserOutput, message Message) {
+			chunk.Messages = append(chunk.Messages, message)
+		})
+	case "load_screens":
+		return streamArray(s, func(chunk *ParserOutput, loadScreen LoadScreen) {
+			chunk.LoadScreens = append(chunk.LoadScreens, loadScreen)
+		})
+	case "interface_translations":
+		return streamArray(s, func(chunk *ParserOutput, translation InterfaceTranslation) {
+			chunk.InterfaceTranslations = append(chunk.InterfaceTranslations, translation)
+		})
+	case "npcs":
+		return s.streamNPCs()
+	default:
+		return skipValue(s.decoder)
+	}
+}
+
+// streamArray decodes one array section element by element; a null section is treated as empty.
+func streamArray[T any](s *sectionStream, add func(chunk *ParserOutput, record T)) error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("read array start: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", token)
+	}
+	for s.decoder.More() {
+		var record T
+		if err := s.decoder.Decode(&record); err != nil {
+			return fmt.Errorf("decode record index=%d: %w", s.total, err)
+		}
+		add(s.pending, record)
+		if err := s.recordAdded(); err != nil {
+			return err
+		}
+	}
+	return expectDelim(s.decoder, ']')
+}
+
+func (s *sectionStream) streamNPCs() error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("read npc map start: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected object, got %v", token)
+	}
+	for s.decoder.More() {
+		keyToken, err := s.decoder.Token()
+		if err != nil {
+			return fmt.Errorf("read npc key: %w", err)
+		}
+		key, _ := keyToken.(string)
+		var npc NPC
+		if err := s.decoder.Decode(&npc); err != nil {
+			return fmt.Errorf("decode npc key=%s: %w", key, err)
+		}
+		normalizeNPC(&npc)
+		s.pending.NPCs[key] = npc
+		if err := s.recordAdded(); err != nil {
+			return err
+		}
+	}
+	return expectDelim(s.decoder, '}')
+}
+
+func (s *sectionStream) recordAdded() error {
+	s.size++
+	s.total++
+	if s.size < streamChunkSize {
+		return nil
+	}
+	return s.flush()
+}
+
+func (s *sectionStream) flush() error {
+	if s.size == 0 {
+		return nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.sink(s.pending); err != nil {
+		return fmt.Errorf("emit parser chunk index=%d: %w", s.chunks, err)
+	}
+	s.chunks++
+	s.size = 0
+	s.pending = newParserOutputChunk()
+	return nil
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("unexpected end of JSON, expected %q", want)
+		}
+		return fmt.Errorf("read JSON delimiter: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, token)
+	}
+	return nil
+}
+
+// skipValue consumes one JSON value token by token without buffering it.
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("skip JSON value: %w", err)
+		}
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package skyrim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultStringsLanguage is the language suffix used for plugin string tables when none is given.
+const DefaultStringsLanguage = "english"
+
+// StringTableKind identifies one of the three localized string table formats.
+type StringTableKind string
+
+const (
+	// StringTableStrings holds short texts such as FULL names, stored as zero-terminated strings.
+	StringTableStrings StringTableKind = "STRINGS"
+	// StringTableDLStrings holds long descriptions such as DESC and book text, stored length-prefixed.
+	StringTableDLStrings StringTableKind = "DLSTRINGS"
+	// StringTableILStrings holds dialogue response texts, stored length-prefixed.
+	StringTableILStrings StringTableKind = "ILSTRINGS"
+)
+
+// StringTableKinds lists every string table kind in the order they are loaded.
+var StringTableKinds = []StringTableKind{StringTableStrings, StringTableDLStrings, StringTableILStrings}
+
+// LengthPrefixed reports whether entries in this table carry a uint32 length before the text.
+func (k StringTableKind) LengthPrefixed() bool {
+	return k == StringTableDLStrings || k == StringTableILStrings
+}
+
+// StringTable maps localized string IDs to text.
+type StringTable map[uint32]string
+
+// LookupString returns the text for a string ID.
+func (t StringTable) LookupString(id uint32) (string, bool) {
+	text, ok := t[id]
+	return text, ok
+}
+
+// StringTables combines the tables of one plugin so any lstring ID can be resolved.
+type StringTables map[StringTableKind]StringTable
+
+// LookupString searches every loaded table for the string ID.
+func (t StringTables) LookupString(id uint32) (string, bool) {
+	for _, kind := range StringTableKinds {
+		if text, ok := t[kind].LookupString(id); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// StringTableFileName returns the "<plugin>_<language>.<KIND>" file name used under the Strings directory.
+func StringTableFileName(pluginName string, language string, kind StringTableKind) string {
+	base := strings.TrimSuffix(filepath.Base(pluginName), filepath.Ext(pluginName))
+	return fmt.Sprintf("%s_%s.%s", base, strings.ToLower(language), kind)
+}
+
+// ReadStringTable decodes a STRINGS, DLSTRINGS or ILSTRINGS file.
+func ReadStringTable(r io.Reader, kind StringTableKind) (StringTable, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read string table kind=%s: %w", kind, err)
+	}
+	if len(raw) < 8 {
+		return nil, fmt.Errorf("string table kind=%s is too short", kind)
+	}
+	count := binary.LittleEndian.Uint32(raw[0:4])
+	dataSize := binary.LittleEndian.Uint32(raw[4:8])
+	directoryEnd := 8 + uint64(count)*8
+	if directoryEnd+uint64(dataSize) > uint64(len(raw)) {
+		return nil, fmt.Errorf("string table kind=%s is truncated: count=%d data_size=%d", kind, count, dataSize)
+	}
+	data := raw[directoryEnd : directoryEnd+uint64(dataSize)]
+
+	table := make(StringTable, count)
+	for i := uint32(0); i < count; i++ {
+		entry := raw[8+i*8 : 16+i*8]
+		id := binary.LittleEndian.Uint32(entry[0:4])
+		offset := binary.LittleEndian.Uint32(entry[4:8])
+		if offset >= uint32(len(data)) {
+			return nil, fmt.Errorf("string table kind=%s id=%d offset=%d is out of range", kind, id, offset)
+		}
+		text := data[offset:]
+		if kind.LengthPrefixed() {
+			if len(text) < 4 {
+				return nil, fmt.Errorf("string table kind=%s id=%d has no length prefix", kind, id)
+			}
+			length := binary.LittleEndian.Uint32(text[0:4])
+			if uint64(length)+4 > uint64(len(text)) {
+				return nil, fmt.Errorf("string table kind=%s id=%d length=%d is out of range", kind, id, length)
+			}
+			text = text[4 : 4+length]
+		} else if end := bytes.IndexByte(text, 0); end >= 0 {
+			text = text[:end]
+		}
+		table[id] = decodeZString(text)
+	}
+	return table, nil
+}
+
+// WriteStringTable encodes a table in the given format with UTF-8 text, ordered by string ID.
+func WriteStringTable(w io.Writer, kind StringTableKind, table StringTable) error {
+	ids := make([]uint32, 0, len(table))
+	for id := range table {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var directory bytes.Buffer
+	var data bytes.Buffer
+	for _, id := range ids {
+		_ = binary.Write(&directory, binary.LittleEndian, id)
+		_ = binary.Write(&directory, binary.LittleEndian, uint32(data.Len()))
+		text := []byte(table[id])
+		if kind.LengthPrefixed() {
+			_ = binary.Write(&data, binary.LittleEndian, uint32(len(text)+1))
+		}
+		data.Write(text)
+		data.WriteByte(0)
+	}
+
+	header := make([]byte, 8)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(len(ids)))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(data.Len()))
+	for _, chunk := range [][]byte{header, directory.Bytes(), data.Bytes()} {
+		if _, err := w.Write(chunk); err != nil {
+			return fmt.Errorf("write string table kind=%s: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// LoadPluginStringTables reads the string tables for a plugin from its Strings directory or, failing that, its BSA archives.
+// Missing tables are skipped; the returned map only holds the kinds that were found.
+func LoadPluginStringTables(pluginPath string, language string) (StringTables, error) {
+	if strings.TrimSpace(language) == "" {
+		language = DefaultStringsLanguage
+	}
+	tables := make(StringTables, len(StringTableKinds))
+	for _, kind := range StringTableKinds {
+		relPath := "strings/" + StringTableFileName(pluginPath, language, kind)
+		raw, ok, err := ReadPluginResource(pluginPath, relPath)
+		if err != nil {
+			return nil, fmt.Errorf("read string table resource path=%s: %w", relPath, err)
+		}
+		if !ok {
+			continue
+		}
+		table, err := ReadStringTable(bytes.NewReader(raw), kind)
+		if err != nil {
+			return nil, fmt.Errorf("decode string table path=%s: %w", relPath, err)
+		}
+		tables[kind] = table
+	}
+	return tables, nil
+}
+
+// localizedSubrecordTypes lists the subrecords that hold a string table ID in localized plugins.
+var localizedSubrecordTypes = map[string]struct{}{
+	"FULL": {}, "SHRT": {}, "DESC": {}, "DNAM": {}, "NAM1": {}, "RNAM": {}, "CNAM": {}, "NNAM": {},
+}
+
+// StringReference ties one localized subrecord of a plugin record to the string table entry it points at.
+type StringReference struct {
+	FormID    uint32
+	Subrecord string
+	Kind      StringTableKind
+	ID        uint32
+}
+
+// LoadPluginStringReferences lists the string table entries referenced by the records of a localized plugin.
+// Only IDs present in tables are returned, so subrecords that merely share a localized field name are ignored.
+// Plugins that store their text inline have no references.
+func LoadPluginStringReferences(pluginPath string, tables StringTables) ([]StringReference, error) {
+	f, err := openFile(pluginPath)
+	if err != nil {
+		return nil, fmt.Errorf("open plugin path=%s: %w", pluginPath, err)
+	}
+	defer f.Close()
+
+	header, records, err := readPluginRecords(f)
+	if err != nil {
+		return nil, fmt.Errorf("read plugin path=%s: %w", pluginPath, err)
+	}
+	if !header.IsLocalized() {
+		return nil, nil
+	}
+	refs := make([]StringReference, 0, len(records))
+	for _, record := range records {
+		for _, sub := range record.Subrecords {
+			if _, ok := localizedSubrecordTypes[sub.Type]; !ok || len(sub.Data) != 4 {
+				continue
+			}
+			id := binary.LittleEndian.Uint32(sub.Data)
+			if id == 0 {
+				continue
+			}
+			for _, kind := range StringTableKinds {
+				if _, ok := tables[kind].LookupString(id); ok {
+					refs = append(refs, StringReference{FormID: record.FormID, Subrecord: sub.Type, Kind: kind, ID: id})
+					break
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// findEntryFold resolves a directory entry by case-insensitive name, since mod archives mix casing.
+func findEntryFold(dir string, name string) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			return filepath.Join(dir, entry.Name()), true
+		}
+	}
+	return "", false
+}
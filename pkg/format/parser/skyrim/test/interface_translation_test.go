@@ -0,0 +1,169 @@
+package test_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+func TestInterfaceTranslation_WriteAndReadRoundTrip(t *testing.T) {
+	lines := []skyrim.InterfaceTranslationLine{
+		{Key: "$MYMOD_ENABLE", Text: "機能を有効化"},
+		{Key: "$MYMOD_HELP", Text: "Shows {0} entries"},
+	}
+	var buf bytes.Buffer
+	if err := skyrim.WriteInterfaceTranslations(&buf, lines); err != nil {
+		t.Fatalf("WriteInterfaceTranslations failed: %v", err)
+	}
+	raw := buf.Bytes()
+	if !bytes.HasPrefix(raw, []byte{0xFF, 0xFE}) {
+		t.Fatalf("expected UTF-16LE BOM, got % x", raw[:2])
+	}
+	if !bytes.HasPrefix(raw[2:], []byte{'$', 0, 'M', 0}) {
+		t.Fatalf("expected UTF-16LE payload, got % x", raw[2:6])
+	}
+
+	got, err := skyrim.ReadInterfaceTranslations(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadInterfaceTranslations failed: %v", err)
+	}
+	if len(got) != len(lines) {
+		t.Fatalf("unexpected line count: got=%d want=%d", len(got), len(lines))
+	}
+	for i := range lines {
+		if got[i] != lines[i] {
+			t.Fatalf("unexpected line %d: got=%+v want=%+v", i, got[i], lines[i])
+		}
+	}
+}
+
+func TestInterfaceTranslation_ReadSkipsNonKeyLinesAndAcceptsUTF8(t *testing.T) {
+	content := "; comment\r\n$KEY_A\tAlpha\r\n\r\nnot a key\tvalue\r\n$KEY_B\tBeta with\ttab\n"
+	got, err := skyrim.ReadInterfaceTranslations(bytes.NewReader([]byte(content)))
+	if err != nil {
+		t.Fatalf("ReadInterfaceTranslations failed: %v", err)
+	}
+	want := []skyrim.InterfaceTranslationLine{
+		{Key: "$KEY_A", Text: "Alpha"},
+		{Key: "$KEY_B", Text: "Beta with\ttab"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("unexpected lines: %+v", got)
+	}
+}
+
+func TestInterfaceTranslation_FileNames(t *testing.T) {
+	if got := skyrim.InterfaceTranslationFileName("MyMod", "japanese"); got != "MyMod_JAPANESE.txt" {
+		t.Fatalf("unexpected file name: %q", got)
+	}
+	if got := skyrim.InterfaceTranslationModName(filepath.Join("Interface", "Translations", "My_Mod_ENGLISH.txt")); got != "My_Mod" {
+		t.Fatalf("unexpected mod name: %q", got)
+	}
+}
+
+func TestLoader_LoadInterfaceTranslation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := skyrim.WriteInterfaceTranslations(&buf, []skyrim.InterfaceTranslationLine{{Key: "$MYMOD_ENABLE", Text: "Enable feature"}}); err != nil {
+		t.Fatalf("WriteInterfaceTranslations failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "MyMod_ENGLISH.txt")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("failed to write translation file: %v", err)
+	}
+
+	data, err := skyrim.ProvideParser().LoadInterfaceTranslation(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LoadInterfaceTranslation failed: %v", err)
+	}
+	if len(data.InterfaceTranslations) != 1 {
+		t.Fatalf("unexpected interface translations: %+v", data.InterfaceTranslations)
+	}
+	got := data.InterfaceTranslations[0]
+	if got.ID != "$MYMOD_ENABLE" || got.Text != "Enable feature" || got.ModName != "MyMod" || got.Type != skyrim.InterfaceTranslationRecordType {
+		t.Fatalf("unexpected interface translation: %+v", got)
+	}
+}
+
+func TestLoader_LoadPlugin_ReadsInterfaceTranslationsFromArchive(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "Packed.esp")
+	writeLocalizedBookPlugin(t, pluginPath)
+
+	var translation bytes.Buffer
+	if err := skyrim.WriteInterfaceTranslations(&translation, []skyrim.InterfaceTranslationLine{{Key: "$PACKED_TITLE", Text: "Packed Menu"}}); err != nil {
+		t.Fatalf("WriteInterfaceTranslations failed: %v", err)
+	}
+	archive := encodeUncompressedArchive(`interface\translations`,
+		[]string{"packed_english.txt"},
+		[][]byte{translation.Bytes()},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "Packed.bsa"), archive, 0600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+
+	data, err := skyrim.ProvideParser().LoadPlugin(context.Background(), pluginPath)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+	if len(data.InterfaceTranslations) != 1 {
+		t.Fatalf("unexpected interface translations: %+v", data.InterfaceTranslations)
+	}
+	got := data.InterfaceTranslations[0]
+	if got.ID != "$PACKED_TITLE" || got.ModName != "Packed" || got.Source == nil || *got.Source != "Packed.esp" {
+		t.Fatalf("unexpected interface translation: %+v", got)
+	}
+}
+
+func TestLoader_LoadPlugin_EnumeratesInterfaceTranslationFiles(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "Packed.esp")
+	writeLocalizedBookPlugin(t, pluginPath)
+
+	writeTranslation := func(lines []skyrim.InterfaceTranslationLine) []byte {
+		var buf bytes.Buffer
+		if err := skyrim.WriteInterfaceTranslations(&buf, lines); err != nil {
+			t.Fatalf("WriteInterfaceTranslations failed: %v", err)
+		}
+		return buf.Bytes()
+	}
+	archive := encodeUncompressedArchive(`interface\translations`,
+		[]string{"packedmcm_english.txt", "packedmcm_japanese.txt"},
+		[][]byte{
+			writeTranslation([]skyrim.InterfaceTranslationLine{{Key: "$PACKED_MCM", Text: "Packed MCM"}}),
+			writeTranslation([]skyrim.InterfaceTranslationLine{{Key: "$PACKED_MCM", Text: "パック"}}),
+		},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "Packed.bsa"), archive, 0600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	looseDir := filepath.Join(dir, "Interface", "Translations")
+	if err := os.MkdirAll(looseDir, 0755); err != nil {
+		t.Fatalf("failed to create loose dir: %v", err)
+	}
+	loose := writeTranslation([]skyrim.InterfaceTranslationLine{{Key: "$PATCH_TITLE", Text: "Patch Menu"}})
+	if err := os.WriteFile(filepath.Join(looseDir, "PackedPatch_ENGLISH.txt"), loose, 0600); err != nil {
+		t.Fatalf("failed to write loose file: %v", err)
+	}
+
+	data, err := skyrim.ProvideParser().LoadPlugin(context.Background(), pluginPath)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+	if len(data.InterfaceTranslations) != 2 {
+		t.Fatalf("expected both english translation files, got %+v", data.InterfaceTranslations)
+	}
+	byKey := make(map[string]skyrim.InterfaceTranslation)
+	for _, record := range data.InterfaceTranslations {
+		byKey[record.ID] = record
+	}
+	if got := byKey["$PACKED_MCM"]; got.ModName != "packedmcm" || got.Text != "Packed MCM" {
+		t.Fatalf("unexpected archived translation: %+v", got)
+	}
+	if got := byKey["$PATCH_TITLE"]; got.ModName != "PackedPatch" || got.Source == nil || *got.Source != "Packed.esp" {
+		t.Fatalf("unexpected loose translation: %+v", got)
+	}
+}
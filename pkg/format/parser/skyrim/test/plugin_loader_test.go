@@ -0,0 +1,233 @@
+package test_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+type testSubrecord struct {
+	typ  string
+	data []byte
+}
+
+func zstr(value string) []byte {
+	return append([]byte(value), 0)
+}
+
+func u32(value uint32) []byte {
+	out := make([]byte, 4)
+	binary.LittleEndian.PutUint32(out, value)
+	return out
+}
+
+func encodeSubrecords(subs []testSubrecord) []byte {
+	var buf bytes.Buffer
+	for _, sub := range subs {
+		buf.WriteString(sub.typ)
+		_ = binary.Write(&buf, binary.LittleEndian, uint16(len(sub.data)))
+		buf.Write(sub.data)
+	}
+	return buf.Bytes()
+}
+
+func encodeRecord(t *testing.T, signature string, formID uint32, flags uint32, subs []testSubrecord) []byte {
+	t.Helper()
+	data := encodeSubrecords(subs)
+	if flags&0x00040000 != 0 {
+		var compressed bytes.Buffer
+		compressed.Write(u32(uint32(len(data))))
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(data); err != nil {
+			t.Fatalf("compress record: %v", err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatalf("close zlib writer: %v", err)
+		}
+		data = compressed.Bytes()
+	}
+	var buf bytes.Buffer
+	buf.WriteString(signature)
+	buf.Write(u32(uint32(len(data))))
+	buf.Write(u32(flags))
+	buf.Write(u32(formID))
+	buf.Write(make([]byte, 8))
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func encodeGroup(label uint32, groupType int32, contents ...[]byte) []byte {
+	body := bytes.Join(contents, nil)
+	var buf bytes.Buffer
+	buf.WriteString("GRUP")
+	buf.Write(u32(uint32(24 + len(body))))
+	buf.Write(u32(label))
+	buf.Write(u32(uint32(groupType)))
+	buf.Write(make([]byte, 8))
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func labelOf(signature string) uint32 {
+	return binary.LittleEndian.Uint32([]byte(signature))
+}
+
+func trdt(responseNumber byte) []byte {
+	data := make([]byte, 24)
+	data[12] = responseNumber
+	return data
+}
+
+func buildTestPlugin(t *testing.T) []byte {
+	t.Helper()
+	tes4 := encodeRecord(t, "TES4", 0, 0, []testSubrecord{
+		{"HEDR", make([]byte, 12)},
+		{"MAST", zstr("Skyrim.esm")},
+	})
+
+	vtyp := encodeGroup(labelOf("VTYP"), 0,
+		encodeRecord(t, "VTYP", 0x01000010, 0, []testSubrecord{{"EDID", zstr("FemaleNord")}}),
+	)
+	npc := encodeGroup(labelOf("NPC_"), 0,
+		encodeRecord(t, "NPC_", 0x01000020, 0, []testSubrecord{
+			{"EDID", zstr("MyLydia")},
+			{"ACBS", append(u32(1), make([]byte, 20)...)},
+			{"RNAM", u32(0x00013746)},
+			{"VTCK", u32(0x01000010)},
+			{"FULL", zstr("Lydia")},
+		}),
+	)
+	book := encodeGroup(labelOf("BOOK"), 0,
+		encodeRecord(t, "BOOK", 0x01000030, 0x00040000, []testSubrecord{
+			{"EDID", zstr("MyBook")},
+			{"FULL", zstr("Old Tome")},
+			{"DESC", zstr("Once upon a time.")},
+		}),
+	)
+	quest := encodeGroup(labelOf("QUST"), 0,
+		encodeRecord(t, "QUST", 0x01000040, 0, []testSubrecord{
+			{"EDID", zstr("MyQuest")},
+			{"FULL", zstr("The Quest")},
+			{"INDX", []byte{10, 0, 0, 0}},
+			{"QSDT", []byte{0}},
+			{"CNAM", zstr("I met Lydia.")},
+			{"QOBJ", []byte{20, 0}},
+			{"NNAM", zstr("Talk to Lydia")},
+		}),
+	)
+	dial := encodeGroup(labelOf("DIAL"), 0,
+		encodeRecord(t, "DIAL", 0x01000050, 0, []testSubrecord{
+			{"EDID", zstr("MyTopic")},
+			{"FULL", zstr("What's new?")},
+			{"QNAM", u32(0x01000040)},
+			{"DATA", []byte{0, 0, 0, 0}},
+		}),
+		encodeGroup(0x01000050, 7,
+			encodeRecord(t, "INFO", 0x01000061, 0, []testSubrecord{
+				{"PNAM", u32(0x01000060)},
+				{"TRDT", trdt(1)},
+				{"NAM1", zstr("Second line.")},
+				{"ANAM", u32(0x01000020)},
+			}),
+			encodeRecord(t, "INFO", 0x01000060, 0, []testSubrecord{
+				{"EDID", zstr("MyInfo")},
+				{"TRDT", trdt(1)},
+				{"NAM1", zstr("First line.")},
+				{"TRDT", trdt(2)},
+				{"NAM1", zstr("First line, continued.")},
+				{"RNAM", zstr("Tell me more.")},
+				{"ANAM", u32(0x01000020)},
+			}),
+		),
+	)
+
+	return bytes.Join([][]byte{tes4, vtyp, npc, book, quest, dial}, nil)
+}
+
+func TestLoader_LoadPlugin_ExtractsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "MyMod.esp")
+	if err := os.WriteFile(path, buildTestPlugin(t), 0600); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+
+	data, err := skyrim.ProvideParser().LoadPlugin(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+
+	npc, ok := data.NPCs["01000020"]
+	if !ok {
+		t.Fatalf("expected NPC 01000020, got %+v", data.NPCs)
+	}
+	if npc.Name != "Lydia" || !npc.IsFemale() || npc.Voice != "FemaleNord" || npc.Race != "00013746" || npc.Type != "NPC_ FULL" {
+		t.Fatalf("unexpected NPC: %+v", npc)
+	}
+	if npc.Source == nil || *npc.Source != "MyMod.esp" {
+		t.Fatalf("unexpected NPC source: %v", npc.Source)
+	}
+
+	if len(data.Items) != 2 || data.Items[0].Type != "BOOK FULL" || data.Items[1].Type != "BOOK DESC" {
+		t.Fatalf("unexpected items: %+v", data.Items)
+	}
+	if data.Items[1].Text == nil || *data.Items[1].Text != "Once upon a time." {
+		t.Fatalf("unexpected compressed book text: %+v", data.Items[1])
+	}
+
+	if len(data.Quests) != 1 {
+		t.Fatalf("expected 1 quest, got %d", len(data.Quests))
+	}
+	quest := data.Quests[0]
+	if len(quest.Stages) != 1 || quest.Stages[0].StageIndex != 10 || quest.Stages[0].Text != "I met Lydia." || quest.Stages[0].ParentEditorID != "MyQuest" {
+		t.Fatalf("unexpected quest stages: %+v", quest.Stages)
+	}
+	if len(quest.Objectives) != 1 || quest.Objectives[0].Index != "20" || quest.Objectives[0].Text != "Talk to Lydia" {
+		t.Fatalf("unexpected quest objectives: %+v", quest.Objectives)
+	}
+
+	if len(data.DialogueGroups) != 1 {
+		t.Fatalf("expected 1 dialogue group, got %d", len(data.DialogueGroups))
+	}
+	group := data.DialogueGroups[0]
+	if group.PlayerText == nil || *group.PlayerText != "What's new?" || group.QuestID == nil || *group.QuestID != "01000040" {
+		t.Fatalf("unexpected dialogue group: %+v", group)
+	}
+	gotTexts := make([]string, 0, len(group.Responses))
+	for _, response := range group.Responses {
+		gotTexts = append(gotTexts, response.Text)
+		if response.SpeakerID == nil || *response.SpeakerID != "01000020" {
+			t.Fatalf("unexpected speaker: %+v", response)
+		}
+	}
+	wantTexts := []string{"Second line.", "First line.", "First line, continued."}
+	if len(gotTexts) != len(wantTexts) {
+		t.Fatalf("unexpected responses: %v", gotTexts)
+	}
+	for i := range wantTexts {
+		if gotTexts[i] != wantTexts[i] {
+			t.Fatalf("unexpected response order: got=%v want=%v", gotTexts, wantTexts)
+		}
+	}
+	if group.Responses[2].Order != 1 || group.Responses[2].Index == nil || *group.Responses[2].Index != 2 {
+		t.Fatalf("unexpected response metadata: %+v", group.Responses[2])
+	}
+	if group.Responses[1].Prompt == nil || *group.Responses[1].Prompt != "Tell me more." {
+		t.Fatalf("unexpected response prompt: %+v", group.Responses[1])
+	}
+}
+
+func TestLoader_LoadPlugin_RejectsNonPluginFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.esp")
+	if err := os.WriteFile(path, []byte("{\"quests\": []}"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, err := skyrim.ProvideParser().LoadPlugin(context.Background(), path); err == nil {
+		t.Fatalf("LoadPlugin unexpectedly succeeded for a non-plugin file")
+	}
+}
@@ -0,0 +1,159 @@
+package test_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+// encodeUncompressedArchive builds a v105 BSA holding the given files in a single folder.
+func encodeUncompressedArchive(folder string, names []string, payloads [][]byte) []byte {
+	const headerSize, folderRecordSize = 36, 24
+	var fileNames bytes.Buffer
+	for _, name := range names {
+		fileNames.WriteString(name)
+		fileNames.WriteByte(0)
+	}
+	offset := headerSize + folderRecordSize + 1 + len(folder) + 1 + 16*len(names) + fileNames.Len()
+
+	var out bytes.Buffer
+	out.WriteString("BSA\x00")
+	for _, v := range []uint32{105, headerSize, 0x3, 1, uint32(len(names)), uint32(len(folder) + 1), uint32(fileNames.Len()), 0} {
+		_ = binary.Write(&out, binary.LittleEndian, v)
+	}
+	_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+	_ = binary.Write(&out, binary.LittleEndian, uint32(len(names)))
+	_ = binary.Write(&out, binary.LittleEndian, uint32(0))
+	_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+	out.WriteByte(byte(len(folder) + 1))
+	out.WriteString(folder)
+	out.WriteByte(0)
+	for _, payload := range payloads {
+		_ = binary.Write(&out, binary.LittleEndian, uint64(0))
+		_ = binary.Write(&out, binary.LittleEndian, uint32(len(payload)))
+		_ = binary.Write(&out, binary.LittleEndian, uint32(offset))
+		offset += len(payload)
+	}
+	out.Write(fileNames.Bytes())
+	for _, payload := range payloads {
+		out.Write(payload)
+	}
+	return out.Bytes()
+}
+
+func writeLocalizedBookPlugin(t *testing.T, path string) {
+	t.Helper()
+	tes4 := encodeRecord(t, "TES4", 0, 0x80, []testSubrecord{{"HEDR", make([]byte, 12)}})
+	plugin := bytes.Join([][]byte{
+		tes4,
+		encodeGroup(labelOf("BOOK"), 0,
+			encodeRecord(t, "BOOK", 0x00000800, 0, []testSubrecord{
+				{"EDID", zstr("ArchivedBook")},
+				{"FULL", u32(1)},
+			}),
+		),
+	}, nil)
+	if err := os.WriteFile(path, plugin, 0600); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+}
+
+func TestLoader_LoadPlugin_ResolvesStringsFromArchive(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "Packed.esp")
+	writeLocalizedBookPlugin(t, pluginPath)
+
+	var table bytes.Buffer
+	if err := skyrim.WriteStringTable(&table, skyrim.StringTableStrings, skyrim.StringTable{1: "Archived Tome"}); err != nil {
+		t.Fatalf("WriteStringTable failed: %v", err)
+	}
+	archive := encodeUncompressedArchive("strings",
+		[]string{"packed_english.strings", "unrelated.txt"},
+		[][]byte{table.Bytes(), []byte("ignored")},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "Packed - Textures.bsa"), archive, 0600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Other.bsa"), []byte("not an archive for this plugin"), 0600); err != nil {
+		t.Fatalf("failed to write unrelated archive: %v", err)
+	}
+
+	data, err := skyrim.ProvideParser().LoadPlugin(context.Background(), pluginPath)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+	if len(data.Items) != 1 || data.Items[0].Name == nil || *data.Items[0].Name != "Archived Tome" {
+		t.Fatalf("unexpected archived string resolution: %+v", data.Items)
+	}
+}
+
+func TestListPluginResources_MergesLooseFilesAndArchives(t *testing.T) {
+	dir := t.TempDir()
+	pluginPath := filepath.Join(dir, "Packed.esp")
+	archive := encodeUncompressedArchive(`interface\translations`,
+		[]string{"packed_english.txt"},
+		[][]byte{[]byte("archived")},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "Packed.bsa"), archive, 0600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	looseDir := filepath.Join(dir, "Interface", "Translations")
+	if err := os.MkdirAll(looseDir, 0755); err != nil {
+		t.Fatalf("failed to create loose dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(looseDir, "Packed_ENGLISH.txt"), []byte("loose"), 0600); err != nil {
+		t.Fatalf("failed to write loose file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(looseDir, "Packed_japanese.txt"), []byte("loose"), 0600); err != nil {
+		t.Fatalf("failed to write loose file: %v", err)
+	}
+
+	resources, err := skyrim.ListPluginResources(pluginPath, "Interface/Translations")
+	if err != nil {
+		t.Fatalf("ListPluginResources failed: %v", err)
+	}
+	want := []string{"interface/translations/packed_english.txt", "interface/translations/packed_japanese.txt"}
+	if len(resources) != len(want) || resources[0] != want[0] || resources[1] != want[1] {
+		t.Fatalf("unexpected resources: %v", resources)
+	}
+
+	data, ok, err := skyrim.ReadPluginResource(pluginPath, want[0])
+	if err != nil || !ok {
+		t.Fatalf("ReadPluginResource failed: ok=%v err=%v", ok, err)
+	}
+	if string(data) != "loose" {
+		t.Fatalf("expected loose file to take precedence, got %q", data)
+	}
+}
+
+func TestExpandPluginInputPaths_ExpandsModFolders(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"B.esp", "A.esm", "Readme.txt", "A.bsa"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte{}, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	paths, err := skyrim.ExpandPluginInputPaths([]string{dir, "extracted.json", " "})
+	if err != nil {
+		t.Fatalf("ExpandPluginInputPaths failed: %v", err)
+	}
+	want := []string{filepath.Join(dir, "A.esm"), filepath.Join(dir, "B.esp"), "extracted.json"}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected expanded paths: %v", paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("unexpected expanded paths: %v", paths)
+		}
+	}
+
+	if _, err := skyrim.ExpandPluginInputPaths([]string{t.TempDir()}); err == nil {
+		t.Fatalf("ExpandPluginInputPaths unexpectedly accepted a folder without plugins")
+	}
+}
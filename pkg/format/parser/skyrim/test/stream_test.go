@@ -0,0 +1,117 @@
+package test_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+func writeStreamFixture(t *testing.T, itemCount int) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString(`{"unknown_section": {"nested": [1, {"deep": [true, null]}], "s": "x"},`)
+	b.WriteString(`"cells": null,`)
+	b.WriteString(`"quests": [{"id": "00012345", "editor_id": "MQ101", "type": "QUST", "stages": [{"stage_index": 10, "log_index": 0, "type": "QUST", "text": "Go"}], "objectives": []}],`)
+	b.WriteString(`"items": [`)
+	for i := 0; i < itemCount; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id": "%08X", "type": "BOOK", "name": "Book %d"}`, i, i)
+	}
+	b.WriteString(`],`)
+	b.WriteString(`"npcs": {"000ABCDE": {"id": "000ABCDE", "type": "NPC_", "name": "  Lydia  ", "sex": "Female"}}}`)
+
+	path := filepath.Join(t.TempDir(), "stream.json")
+	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
+		t.Fatalf("failed to write stream fixture: %v", err)
+	}
+	return path
+}
+
+func TestLoader_StreamExtractedJSON_MatchesLoadExtractedJSON(t *testing.T) {
+	path := writeStreamFixture(t, 1201)
+	parser := skyrim.ProvideParser()
+
+	var chunks []*skyrim.ParserOutput
+	if err := parser.StreamExtractedJSON(context.Background(), path, func(chunk *skyrim.ParserOutput) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}); err != nil {
+		t.Fatalf("StreamExtractedJSON failed: %v", err)
+	}
+	if len(chunks) < 3 {
+		t.Fatalf("expected records to be emitted in several chunks, got %d", len(chunks))
+	}
+
+	streamed := &skyrim.ParserOutput{NPCs: make(map[string]skyrim.NPC)}
+	for _, chunk := range chunks {
+		if size := len(chunk.Items) + len(chunk.Quests) + len(chunk.NPCs); size > 500 {
+			t.Fatalf("chunk exceeds bound: %d records", size)
+		}
+		streamed.Items = append(streamed.Items, chunk.Items...)
+		streamed.Quests = append(streamed.Quests, chunk.Quests...)
+		for key, npc := range chunk.NPCs {
+			streamed.NPCs[key] = npc
+		}
+	}
+
+	loaded, err := parser.LoadExtractedJSON(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LoadExtractedJSON failed: %v", err)
+	}
+	if len(streamed.Items) != len(loaded.Items) || len(streamed.Items) != 1201 {
+		t.Fatalf("unexpected item count: streamed=%d loaded=%d", len(streamed.Items), len(loaded.Items))
+	}
+	for i := range loaded.Items {
+		if streamed.Items[i].ID != loaded.Items[i].ID {
+			t.Fatalf("item order differs at %d: streamed=%s loaded=%s", i, streamed.Items[i].ID, loaded.Items[i].ID)
+		}
+	}
+	if len(streamed.Quests) != 1 || len(streamed.Quests[0].Stages) != 1 {
+		t.Fatalf("unexpected streamed quests: %+v", streamed.Quests)
+	}
+	stage := streamed.Quests[0].Stages[0]
+	if stage != loaded.Quests[0].Stages[0] || stage.ParentID != "00012345" || stage.ParentEditorID != "MQ101" {
+		t.Fatalf("quest stage was not normalized like LoadExtractedJSON: streamed=%+v loaded=%+v", stage, loaded.Quests[0].Stages[0])
+	}
+	if npc := streamed.NPCs["000ABCDE"]; npc.Name != "Lydia" || npc.Name != loaded.NPCs["000ABCDE"].Name {
+		t.Fatalf("npc name was not normalized like LoadExtractedJSON: %q", npc.Name)
+	}
+}
+
+func TestLoader_StreamExtractedJSON_StopsOnSinkError(t *testing.T) {
+	path := writeStreamFixture(t, 1201)
+	sinkErr := errors.New("sink failed")
+
+	calls := 0
+	err := skyrim.ProvideParser().StreamExtractedJSON(context.Background(), path, func(chunk *skyrim.ParserOutput) error {
+		calls++
+		return sinkErr
+	})
+	if !errors.Is(err, sinkErr) {
+		t.Fatalf("expected sink error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected stream to stop after first failing chunk, got %d calls", calls)
+	}
+}
+
+func TestLoader_StreamExtractedJSON_RejectsMalformedSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(path, []byte(`{"items": {"id": "00000001"}}`), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	err := skyrim.ProvideParser().StreamExtractedJSON(context.Background(), path, func(chunk *skyrim.ParserOutput) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "section=items") {
+		t.Fatalf("expected malformed section error, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package test_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+)
+
+func TestStringTable_WriteAndReadRoundTrip(t *testing.T) {
+	table := skyrim.StringTable{
+		1:  "Iron Sword",
+		7:  "鉄の剣",
+		42: "",
+	}
+	for _, kind := range skyrim.StringTableKinds {
+		t.Run(string(kind), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := skyrim.WriteStringTable(&buf, kind, table); err != nil {
+				t.Fatalf("WriteStringTable failed: %v", err)
+			}
+			got, err := skyrim.ReadStringTable(bytes.NewReader(buf.Bytes()), kind)
+			if err != nil {
+				t.Fatalf("ReadStringTable failed: %v", err)
+			}
+			if len(got) != len(table) {
+				t.Fatalf("unexpected entry count: got=%d want=%d", len(got), len(table))
+			}
+			for id, want := range table {
+				if text, ok := got.LookupString(id); !ok || text != want {
+					t.Fatalf("unexpected text for id=%d: got=%q want=%q", id, text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStringTable_ReadRejectsTruncatedTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := skyrim.WriteStringTable(&buf, skyrim.StringTableDLStrings, skyrim.StringTable{1: "A long description."}); err != nil {
+		t.Fatalf("WriteStringTable failed: %v", err)
+	}
+	truncated := buf.Bytes()[:buf.Len()-4]
+	if _, err := skyrim.ReadStringTable(bytes.NewReader(truncated), skyrim.StringTableDLStrings); err == nil {
+		t.Fatalf("ReadStringTable unexpectedly succeeded for a truncated table")
+	}
+}
+
+func TestLoader_LoadPlugin_ResolvesLocalizedStrings(t *testing.T) {
+	dir := t.TempDir()
+	tes4 := encodeRecord(t, "TES4", 0, 0x80, []testSubrecord{{"HEDR", make([]byte, 12)}})
+	plugin := bytes.Join([][]byte{
+		tes4,
+		encodeGroup(labelOf("BOOK"), 0,
+			encodeRecord(t, "BOOK", 0x00000800, 0, []testSubrecord{
+				{"EDID", zstr("LocalizedBook")},
+				{"FULL", u32(1)},
+				{"DESC", u32(2)},
+			}),
+		),
+	}, nil)
+	if err := os.WriteFile(filepath.Join(dir, "Localized.esm"), plugin, 0600); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+
+	stringsDir := filepath.Join(dir, "strings")
+	if err := os.MkdirAll(stringsDir, 0755); err != nil {
+		t.Fatalf("failed to create strings dir: %v", err)
+	}
+	writeTable := func(kind skyrim.StringTableKind, table skyrim.StringTable) {
+		var buf bytes.Buffer
+		if err := skyrim.WriteStringTable(&buf, kind, table); err != nil {
+			t.Fatalf("WriteStringTable failed: %v", err)
+		}
+		path := filepath.Join(stringsDir, skyrim.StringTableFileName("Localized.esm", "english", kind))
+		if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+			t.Fatalf("failed to write string table: %v", err)
+		}
+	}
+	writeTable(skyrim.StringTableStrings, skyrim.StringTable{1: "Localized Tome"})
+	writeTable(skyrim.StringTableDLStrings, skyrim.StringTable{2: "Text from the DLSTRINGS table."})
+
+	data, err := skyrim.ProvideParser().LoadPlugin(context.Background(), filepath.Join(dir, "Localized.esm"))
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+	if len(data.Items) != 2 {
+		t.Fatalf("expected name and text items, got %+v", data.Items)
+	}
+	if data.Items[0].Name == nil || *data.Items[0].Name != "Localized Tome" {
+		t.Fatalf("unexpected localized name: %+v", data.Items[0])
+	}
+	if data.Items[1].Text == nil || *data.Items[1].Text != "Text from the DLSTRINGS table." {
+		t.Fatalf("unexpected localized text: %+v", data.Items[1])
+	}
+}
@@ -0,0 +1,60 @@
+package xtranslator
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// String is one <String> element of an xTranslator XML file.
+type String struct {
+	// Addon is the first <Addon> name seen before this element, or empty when the file has none.
+	Addon  string `xml:"-"`
+	EDID   string `xml:"EDID"`
+	REC    string `xml:"REC"`
+	Source string `xml:"Source"`
+	Dest   string `xml:"Dest"`
+}
+
+// ReadStrings streams an xTranslator XML document and calls fn for every <String> element in document order.
+// Elements that cannot be decoded are logged and skipped; an error returned by fn stops the read and is returned as is.
+func ReadStrings(ctx context.Context, r io.Reader, logger *slog.Logger, fn func(String) error) error {
+	decoder := xml.NewDecoder(r)
+
+	var addonName string
+	for {
+		t, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("error reading xml token: %w", err)
+		}
+
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+		case "Addon":
+			var addon string
+			if err := decoder.DecodeElement(&addon, &se); err == nil && addonName == "" {
+				addonName = addon
+			}
+		case "String":
+			var entry String
+			if err := decoder.DecodeElement(&entry, &se); err != nil {
+				logger.WarnContext(ctx, "failed to decode String element, skipping", "error", err)
+				continue
+			}
+			entry.Addon = addonName
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package xtranslator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadStrings(t *testing.T) {
+	xmlData := `<?xml version="1.0" encoding="UTF-8"?>
+<SSTXMLRessources>
+	<Params>
+		<Addon>Skyrim</Addon>
+		<Addon>Ignored</Addon>
+	</Params>
+	<Content>
+		<String List="0" sID="000001">
+			<EDID>IronSword</EDID>
+			<REC>WEAP:FULL</REC>
+			<Source>Iron Sword</Source>
+			<Dest>鉄の剣</Dest>
+		</String>
+		<String List="0" sID="000002">
+			<EDID>GreetingInfo</EDID>
+			<REC>INFO:NAM1</REC>
+			<Source>Hello.</Source>
+			<Dest>こんにちは。</Dest>
+		</String>
+	</Content>
+</SSTXMLRessources>`
+
+	var got []String
+	err := ReadStrings(context.Background(), strings.NewReader(xmlData), slog.Default(), func(s String) error {
+		got = append(got, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadStrings failed: %v", err)
+	}
+
+	want := []String{
+		{Addon: "Skyrim", EDID: "IronSword", REC: "WEAP:FULL", Source: "Iron Sword", Dest: "鉄の剣"},
+		{Addon: "Skyrim", EDID: "GreetingInfo", REC: "INFO:NAM1", Source: "Hello.", Dest: "こんにちは。"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected strings: got=%+v want=%+v", got, want)
+	}
+}
+
+func TestReadStrings_StopsOnCallbackError(t *testing.T) {
+	xmlData := `<SSTXMLRessources><Content>
+		<String><Source>A</Source><Dest>あ</Dest></String>
+		<String><Source>B</Source><Dest>い</Dest></String>
+	</Content></SSTXMLRessources>`
+
+	stop := errors.New("stop")
+	calls := 0
+	err := ReadStrings(context.Background(), strings.NewReader(xmlData), slog.Default(), func(String) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected read to stop after first string, got %d calls", calls)
+	}
+}
+
+func TestReadStrings_ReturnsMalformedXMLError(t *testing.T) {
+	err := ReadStrings(context.Background(), strings.NewReader(`<SSTXMLRessources><Content>`), slog.Default(), func(String) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected error for truncated xml")
+	}
+}
@@ -10,4 +10,9 @@ type ExecutionConfig struct {
 	ContextLength   int
 	SyncConcurrency int
 	BulkStrategy    string
+	// ConfigNamespace scopes runtime options such as the response cache opt-out; empty uses defaults.
+	ConfigNamespace string
+	// TaskID and Phase attribute token usage for cost accounting; empty TaskID skips recording.
+	TaskID string
+	Phase  string
 }
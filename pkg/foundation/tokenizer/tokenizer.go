@@ -0,0 +1,144 @@
+// Package tokenizer estimates LLM token counts offline, per model family.
+package tokenizer
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Family identifies a tokenizer vocabulary whose script costs differ noticeably.
+type Family string
+
+const (
+	// FamilyO200K covers gpt-4o / gpt-4.1 / o-series and xAI Grok.
+	FamilyO200K Family = "o200k"
+	// FamilyCL100K covers gpt-4 / gpt-3.5 era models, which spend more tokens on Japanese.
+	FamilyCL100K Family = "cl100k"
+	// FamilyGemini covers Gemini and Gemma SentencePiece vocabularies.
+	FamilyGemini Family = "gemini"
+	// FamilyLlama is the fallback for local models (Llama, Qwen, Mistral, ...).
+	FamilyLlama Family = "llama"
+)
+
+// Tokenizer approximates a family's BPE by counting latin word runs and CJK characters separately.
+// A plain chars/4 rule undercounts Japanese by 3-4x because kana/kanji cost about a token each.
+type Tokenizer struct {
+	family             Family
+	latinCharsPerToken float64
+	cjkTokensPerChar   float64
+	otherTokensPerChar float64
+}
+
+var families = map[Family]Tokenizer{
+	FamilyO200K:  {family: FamilyO200K, latinCharsPerToken: 4.2, cjkTokensPerChar: 0.8, otherTokensPerChar: 0.4},
+	FamilyCL100K: {family: FamilyCL100K, latinCharsPerToken: 4.0, cjkTokensPerChar: 1.2, otherTokensPerChar: 0.5},
+	FamilyGemini: {family: FamilyGemini, latinCharsPerToken: 4.0, cjkTokensPerChar: 0.7, otherTokensPerChar: 0.4},
+	FamilyLlama:  {family: FamilyLlama, latinCharsPerToken: 3.8, cjkTokensPerChar: 1.1, otherTokensPerChar: 0.5},
+}
+
+// japaneseCharsPerLatinChar is the typical length ratio of a Japanese translation to English source.
+const japaneseCharsPerLatinChar = 0.6
+
+// ForFamily returns the tokenizer of family, falling back to FamilyLlama.
+func ForFamily(family Family) Tokenizer {
+	if t, ok := families[family]; ok {
+		return t
+	}
+	return families[FamilyLlama]
+}
+
+// ForModel picks the tokenizer family from provider and model name.
+func ForModel(provider, model string) Tokenizer {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	model = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(model), "models/"))
+	switch provider {
+	case "gemini":
+		return ForFamily(FamilyGemini)
+	case "xai":
+		return ForFamily(FamilyO200K)
+	case "openai":
+		if strings.HasPrefix(model, "gpt-4-") || model == "gpt-4" || strings.HasPrefix(model, "gpt-3.5") {
+			return ForFamily(FamilyCL100K)
+		}
+		return ForFamily(FamilyO200K)
+	}
+	switch {
+	case strings.Contains(model, "gemini"), strings.Contains(model, "gemma"):
+		return ForFamily(FamilyGemini)
+	case strings.Contains(model, "gpt"), strings.Contains(model, "grok"):
+		return ForFamily(FamilyO200K)
+	default:
+		return ForFamily(FamilyLlama)
+	}
+}
+
+// Family returns the vocabulary family this tokenizer approximates.
+func (t Tokenizer) Family() Family {
+	return t.family
+}
+
+// Count estimates the token count of text.
+func (t Tokenizer) Count(text string) int {
+	s := scan(text)
+	tokens := float64(s.symbols) + float64(s.cjk)*t.cjkTokensPerChar + float64(s.other)*t.otherTokensPerChar
+	for _, run := range s.latinRuns {
+		tokens += math.Ceil(float64(run) / t.latinCharsPerToken)
+	}
+	return int(math.Ceil(tokens))
+}
+
+// EstimateJapaneseTranslation estimates output tokens of a Japanese translation of source.
+func (t Tokenizer) EstimateJapaneseTranslation(source string) int {
+	s := scan(source)
+	latin := 0
+	for _, run := range s.latinRuns {
+		latin += run
+	}
+	japaneseChars := float64(latin)*japaneseCharsPerLatinChar + float64(s.cjk+s.other)
+	return int(math.Ceil(japaneseChars*t.cjkTokensPerChar)) + s.symbols
+}
+
+type scanResult struct {
+	latinRuns []int
+	cjk       int
+	other     int
+	symbols   int
+}
+
+func scan(text string) scanResult {
+	var s scanResult
+	run := 0
+	flush := func() {
+		if run > 0 {
+			s.latinRuns = append(s.latinRuns, run)
+			run = 0
+		}
+	}
+	for _, r := range text {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			run++
+		case unicode.IsSpace(r):
+			flush()
+		case isCJK(r):
+			flush()
+			s.cjk++
+		case r < unicode.MaxASCII:
+			flush()
+			s.symbols++
+		default:
+			flush()
+			s.other++
+		}
+	}
+	flush()
+	return s
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || // CJK symbols and punctuation
+		(r >= 0x30A0 && r <= 0x30FF) || // katakana incl. prolonged sound mark
+		(r >= 0xFF00 && r <= 0xFFEF) // halfwidth and fullwidth forms
+}
@@ -0,0 +1,56 @@
+package tokenizer
+
+import "testing"
+
+func TestForModel_PicksFamily(t *testing.T) {
+	cases := []struct {
+		provider string
+		model    string
+		want     Family
+	}{
+		{provider: "gemini", model: "models/gemini-2.0-flash", want: FamilyGemini},
+		{provider: "openai", model: "gpt-4o-mini", want: FamilyO200K},
+		{provider: "openai", model: "gpt-4-turbo", want: FamilyCL100K},
+		{provider: "xai", model: "grok-3", want: FamilyO200K},
+		{provider: "lmstudio", model: "google/gemma-3-12b", want: FamilyGemini},
+		{provider: "lmstudio", model: "qwen2.5-7b-instruct", want: FamilyLlama},
+	}
+	for _, tc := range cases {
+		if got := ForModel(tc.provider, tc.model).Family(); got != tc.want {
+			t.Errorf("ForModel(%q, %q) = %s, want %s", tc.provider, tc.model, got, tc.want)
+		}
+	}
+}
+
+func TestCount_JapaneseCostsMoreThanCharsOverFour(t *testing.T) {
+	tok := ForFamily(FamilyO200K)
+	english := "The Jarl of Whiterun wants to see you."
+	if got := tok.Count(english); got < 9 || got > 14 {
+		t.Fatalf("english count = %d, want roughly one token per word", got)
+	}
+
+	japanese := "ホワイトランの首長があなたに会いたがっている。"
+	runes := len([]rune(japanese))
+	got := tok.Count(japanese)
+	if got <= runes/4*2 || got > runes {
+		t.Fatalf("japanese count = %d for %d chars", got, runes)
+	}
+	if ForFamily(FamilyCL100K).Count(japanese) <= got {
+		t.Fatalf("cl100k must spend more tokens on japanese than o200k")
+	}
+}
+
+func TestEstimateJapaneseTranslation(t *testing.T) {
+	tok := ForFamily(FamilyGemini)
+	if got := tok.EstimateJapaneseTranslation(""); got != 0 {
+		t.Fatalf("empty source = %d, want 0", got)
+	}
+	// 9 latin chars -> ~5.4 Japanese chars -> ceil(5.4 * 0.7) tokens.
+	if got := tok.EstimateJapaneseTranslation("Iron Sword"); got != 4 {
+		t.Fatalf("EstimateJapaneseTranslation(Iron Sword) = %d, want 4", got)
+	}
+	// ASCII symbols are kept one token each; latin runs are priced as translated text.
+	if got := tok.EstimateJapaneseTranslation("<Alias=Player>."); got != 9 {
+		t.Fatalf("EstimateJapaneseTranslation(tag) = %d, want 9", got)
+	}
+}
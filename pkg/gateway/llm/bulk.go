@@ -185,19 +185,29 @@ func runWorkerPoolWithProgress(
 }
 
 // executeOne sends a single request to the LLM client and returns a Response.
+// Requests with a ResponseSchema go through GenerateStructured so the output is schema-validated.
 // On error, it returns a Response with Success=false and Error set, without propagating the error.
+// Token usage the client reported alongside the error is kept, since failed attempts are still billed.
 func executeOne(ctx context.Context, client LLMClient, index int, req Request) Response {
 	slog.DebugContext(ctx, "ENTER executeOne", slog.Int("index", index))
 
-	resp, err := client.Complete(ctx, req)
+	var resp Response
+	var err error
+	if len(req.ResponseSchema) > 0 {
+		resp, err = client.GenerateStructured(ctx, req)
+	} else {
+		resp, err = client.Complete(ctx, req)
+	}
 	if err != nil {
 		slog.WarnContext(ctx, "EXIT executeOne: request failed",
 			slog.Int("index", index),
 			slog.String("error", err.Error()),
 		)
 		return Response{
-			Success: false,
-			Error:   err.Error(),
+			Success:   false,
+			Error:     err.Error(),
+			Transient: IsTransientError(err),
+			Usage:     resp.Usage,
 		}
 	}
 
@@ -0,0 +1,415 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// サーキットブレーカーの設定キー（LLMConfig.Parameters）。未設定は既定値。
+const (
+	CircuitFailureThresholdParam = "circuit_failure_threshold"
+	CircuitProbeIntervalParam    = "circuit_probe_interval_seconds"
+)
+
+// CircuitBreakerParams は設定ストアから LLMConfig.Parameters へ引き継ぐサーキットブレーカーキーの一覧。
+var CircuitBreakerParams = []string{CircuitFailureThresholdParam, CircuitProbeIntervalParam}
+
+const (
+	// DefaultCircuitFailureThreshold は回路を開くまでの連続一時エラー数。
+	DefaultCircuitFailureThreshold = 5
+	// DefaultCircuitProbeInterval は回路が開いている間にバックグラウンドで HealthCheck を行う間隔。
+	DefaultCircuitProbeInterval = 30 * time.Second
+)
+
+// CircuitState はプロバイダーの回路状態。
+type CircuitState string
+
+const (
+	// CircuitClosed は通常どおりリクエストを送る状態。
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen は連続失敗によりリクエストを即座に拒否している状態。
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen は HealthCheck が通り、1 件の試行リクエストで復旧を確認している状態。
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerSettings は回路を開く閾値と再確認間隔を表す。
+type CircuitBreakerSettings struct {
+	FailureThreshold int
+	ProbeInterval    time.Duration
+}
+
+// CircuitBreakerSettingsFromConfig は LLMConfig.Parameters から設定を読み取り、未設定は既定値で補う。
+func CircuitBreakerSettingsFromConfig(config LLMConfig) CircuitBreakerSettings {
+	settings := CircuitBreakerSettings{
+		FailureThreshold: parameterInt(config.Parameters, CircuitFailureThresholdParam),
+		ProbeInterval:    time.Duration(parameterInt(config.Parameters, CircuitProbeIntervalParam)) * time.Second,
+	}
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+	if settings.ProbeInterval <= 0 {
+		settings.ProbeInterval = DefaultCircuitProbeInterval
+	}
+	return settings
+}
+
+// circuitProbeTimeout は 1 回の HealthCheck を打ち切るまでの時間。
+const circuitProbeTimeout = 10 * time.Second
+
+// ProviderHealth は接続先（プロバイダー・エンドポイント・モデル）単位の回路状態のスナップショット。
+type ProviderHealth struct {
+	Provider            string       `json:"provider"`
+	Endpoint            string       `json:"endpoint,omitempty"`
+	Model               string       `json:"model,omitempty"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	LastError           string       `json:"last_error,omitempty"`
+	OpenedAt            time.Time    `json:"opened_at,omitempty"`
+	NextProbeAt         time.Time    `json:"next_probe_at,omitempty"`
+}
+
+// Available は新しいリクエストを受け付けられるかを返す。
+func (h ProviderHealth) Available() bool {
+	return h.State != CircuitOpen
+}
+
+type healthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// probeTimer は予約した HealthCheck を取り消すためのハンドル。
+type probeTimer interface {
+	Stop() bool
+}
+
+func realAfterFunc(d time.Duration, f func()) probeTimer {
+	return time.AfterFunc(d, f)
+}
+
+// CircuitBreaker は連続した一時エラーで接続先への送信を止め、再確認間隔ごとに HealthCheck で復旧を確認する。
+// 同じプロバイダー・エンドポイント・モデルを使うクライアント間で共有される。
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	key       circuitKey
+	settings  CircuitBreakerSettings
+	now       func() time.Time
+	afterFunc func(time.Duration, func()) probeTimer
+
+	state       CircuitState
+	failures    int
+	lastErr     string
+	openedAt    time.Time
+	nextProbeAt time.Time
+	probing     bool
+	probeTimer  probeTimer
+	trialActive bool
+	prober      healthChecker
+}
+
+// NewCircuitBreaker は閉じた状態の回路を作成する。
+func NewCircuitBreaker(provider string, settings CircuitBreakerSettings) *CircuitBreaker {
+	return newCircuitBreaker(circuitKey{provider: provider}, settings, time.Now, realAfterFunc)
+}
+
+func newCircuitBreaker(key circuitKey, settings CircuitBreakerSettings, now func() time.Time, afterFunc func(time.Duration, func()) probeTimer) *CircuitBreaker {
+	return &CircuitBreaker{
+		key:       key,
+		settings:  settings,
+		now:       now,
+		afterFunc: afterFunc,
+		state:     CircuitClosed,
+	}
+}
+
+// SetSettings は閾値と再確認間隔を差し替える。現在の状態は維持する。
+func (b *CircuitBreaker) SetSettings(settings CircuitBreakerSettings) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.settings = settings
+}
+
+// setProber は HealthCheck に使うクライアントを差し替える。回路は接続先ごとなので、
+// 差し替え後も同じ接続先を最新の認証情報で確認する。
+func (b *CircuitBreaker) setProber(prober healthChecker) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.prober = prober
+}
+
+// Allow はリクエストを送ってよいかを返す。
+// 半開状態では復旧確認のための 1 件だけを通し、その結果が Record されるまで他は拒否する。
+func (b *CircuitBreaker) Allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitClosed:
+		return nil
+	case CircuitHalfOpen:
+		if !b.trialActive {
+			b.trialActive = true
+			return nil
+		}
+		return fmt.Errorf("%w: provider=%s endpoint=%s model=%s recovery trial in flight", ErrProviderUnavailable, b.key.provider, b.key.endpoint, b.key.model)
+	}
+	return fmt.Errorf("%w: provider=%s endpoint=%s model=%s last_error=%s", ErrProviderUnavailable, b.key.provider, b.key.endpoint, b.key.model, b.lastErr)
+}
+
+// Record はリクエスト結果を反映する。一時エラーのみを失敗として数え、キャンセルは無視する。
+// 半開状態の試行がキャンセルされた場合は、次のリクエストを新たな試行として通す。
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trialActive = false
+	if err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrProviderUnavailable)) {
+		return
+	}
+	if !IsTransientError(err) {
+		// 応答が返ってきた（4xx を含む）なら接続先自体は到達可能。
+		b.state = CircuitClosed
+		b.failures = 0
+		b.stopProbe()
+		return
+	}
+	b.failures++
+	b.lastErr = err.Error()
+	if b.state == CircuitHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.open()
+	}
+}
+
+// Health は現在の状態を返す。
+func (b *CircuitBreaker) Health(ctx context.Context) ProviderHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.snapshot()
+}
+
+func (b *CircuitBreaker) snapshot() ProviderHealth {
+	health := ProviderHealth{
+		Provider:            b.key.provider,
+		Endpoint:            b.key.endpoint,
+		Model:               b.key.model,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastErr,
+	}
+	if b.state == CircuitOpen {
+		health.OpenedAt = b.openedAt
+		health.NextProbeAt = b.nextProbeAt
+	}
+	return health
+}
+
+// open は回路を開き、再確認間隔後の HealthCheck を予約する。呼び出し側で mu を保持すること。
+func (b *CircuitBreaker) open() {
+	now := b.now()
+	if b.state != CircuitOpen {
+		b.openedAt = now
+	}
+	b.state = CircuitOpen
+	b.scheduleProbe(now)
+}
+
+func (b *CircuitBreaker) scheduleProbe(now time.Time) {
+	b.stopProbe()
+	b.nextProbeAt = now.Add(b.settings.ProbeInterval)
+	b.probeTimer = b.afterFunc(b.settings.ProbeInterval, b.probe)
+}
+
+func (b *CircuitBreaker) stopProbe() {
+	if b.probeTimer != nil {
+		b.probeTimer.Stop()
+		b.probeTimer = nil
+	}
+}
+
+// probe は予約時刻に HealthCheck を行い、成功すれば半開状態へ移し、失敗すれば次の確認を予約する。
+func (b *CircuitBreaker) probe() {
+	b.mu.Lock()
+	b.probeTimer = nil
+	if b.state != CircuitOpen || b.probing {
+		b.mu.Unlock()
+		return
+	}
+	prober := b.prober
+	if prober == nil {
+		b.scheduleProbe(b.now())
+		b.mu.Unlock()
+		return
+	}
+	b.probing = true
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), circuitProbeTimeout)
+	err := prober.HealthCheck(ctx)
+	cancel()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if b.state != CircuitOpen {
+		return
+	}
+	if err != nil {
+		b.lastErr = err.Error()
+		b.scheduleProbe(b.now())
+		return
+	}
+	b.state = CircuitHalfOpen
+	b.trialActive = false
+}
+
+// circuitKey は回路を共有する接続先。同じ種類のプロバイダーでもエンドポイントやモデルが違えば別の回路を持つ。
+type circuitKey struct {
+	provider string
+	endpoint string
+	model    string
+}
+
+func circuitKeyFromConfig(config LLMConfig) circuitKey {
+	return circuitKey{
+		provider: NormalizeProvider(config.Provider),
+		endpoint: strings.TrimRight(strings.TrimSpace(config.Endpoint), "/"),
+		model:    strings.TrimSpace(config.Model),
+	}
+}
+
+// matches は filter の空でない項目がすべて一致するかを返す。
+func (k circuitKey) matches(filter circuitKey) bool {
+	return k.provider == filter.provider &&
+		(filter.endpoint == "" || k.endpoint == filter.endpoint) &&
+		(filter.model == "" || k.model == filter.model)
+}
+
+// circuitBreakerRegistry は接続先ごとの回路を保持し、クライアント間で共有する。
+type circuitBreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[circuitKey]*CircuitBreaker
+}
+
+func (r *circuitBreakerRegistry) get(config LLMConfig, settings CircuitBreakerSettings) *CircuitBreaker {
+	key := circuitKeyFromConfig(config)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.breakers == nil {
+		r.breakers = make(map[circuitKey]*CircuitBreaker)
+	}
+	if breaker, ok := r.breakers[key]; ok {
+		breaker.SetSettings(settings)
+		return breaker
+	}
+	breaker := newCircuitBreaker(key, settings, time.Now, realAfterFunc)
+	r.breakers[key] = breaker
+	return breaker
+}
+
+// matching は filter に一致する回路をプロバイダー・エンドポイント・モデル順で返す。
+// filter の空の項目は任意の値に一致する。
+func (r *circuitBreakerRegistry) matching(filter circuitKey) []*CircuitBreaker {
+	r.mu.Lock()
+	matched := make([]*CircuitBreaker, 0, len(r.breakers))
+	for key, breaker := range r.breakers {
+		if filter.provider == "" || key.matches(filter) {
+			matched = append(matched, breaker)
+		}
+	}
+	r.mu.Unlock()
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i].key, matched[j].key
+		if a.provider != b.provider {
+			return a.provider < b.provider
+		}
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		return a.model < b.model
+	})
+	return matched
+}
+
+// circuitBreakerClient は回路が開いている間はリクエストを送らずに ErrProviderUnavailable を返す。
+type circuitBreakerClient struct {
+	inner   LLMClient
+	breaker *CircuitBreaker
+}
+
+// newCircuitBreakerClient は inner を包む。LM Studio のモデル管理インターフェースは維持する。
+func newCircuitBreakerClient(inner LLMClient, breaker *CircuitBreaker) LLMClient {
+	breaker.setProber(inner)
+	guarded := &circuitBreakerClient{inner: inner, breaker: breaker}
+	if lifecycle, ok := inner.(ModelLifecycleClient); ok {
+		return &circuitBreakerLifecycleClient{circuitBreakerClient: guarded, lifecycle: lifecycle}
+	}
+	return guarded
+}
+
+func (c *circuitBreakerClient) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	return c.inner.ListModels(ctx)
+}
+
+func (c *circuitBreakerClient) HealthCheck(ctx context.Context) error {
+	return c.inner.HealthCheck(ctx)
+}
+
+func (c *circuitBreakerClient) Complete(ctx context.Context, req Request) (Response, error) {
+	return c.do(ctx, req, c.inner.Complete)
+}
+
+func (c *circuitBreakerClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	return c.do(ctx, req, c.inner.GenerateStructured)
+}
+
+func (c *circuitBreakerClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
+	if err := c.breaker.Allow(ctx); err != nil {
+		return nil, err
+	}
+	stream, err := c.inner.StreamComplete(ctx, req)
+	c.breaker.Record(err)
+	return stream, err
+}
+
+func (c *circuitBreakerClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if err := c.breaker.Allow(ctx); err != nil {
+		return nil, err
+	}
+	vector, err := c.inner.GetEmbedding(ctx, text)
+	c.breaker.Record(err)
+	return vector, err
+}
+
+func (c *circuitBreakerClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if err := c.breaker.Allow(ctx); err != nil {
+		return nil, err
+	}
+	vectors, err := GetEmbeddings(ctx, c.inner, texts)
+	c.breaker.Record(err)
+	return vectors, err
+}
+
+func (c *circuitBreakerClient) do(ctx context.Context, req Request, call func(context.Context, Request) (Response, error)) (Response, error) {
+	if err := c.breaker.Allow(ctx); err != nil {
+		return Response{}, err
+	}
+	resp, err := call(ctx, req)
+	c.breaker.Record(err)
+	return resp, err
+}
+
+type circuitBreakerLifecycleClient struct {
+	*circuitBreakerClient
+	lifecycle ModelLifecycleClient
+}
+
+func (c *circuitBreakerLifecycleClient) LoadModel(ctx context.Context, model string, contextLength int) (string, error) {
+	return c.lifecycle.LoadModel(ctx, model, contextLength)
+}
+
+func (c *circuitBreakerLifecycleClient) UnloadModel(ctx context.Context, instanceID string) error {
+	return c.lifecycle.UnloadModel(ctx, instanceID)
+}
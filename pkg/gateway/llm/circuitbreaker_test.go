@@ -0,0 +1,237 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// withoutCircuitBreaker returns the client wrapped by the manager's circuit breaker,
+// which itself sits below the structured output validator.
+func withoutCircuitBreaker(t *testing.T, client LLMClient) LLMClient {
+	t.Helper()
+	switch c := client.(type) {
+	case *structuredOutputClient:
+		client = c.LLMClient
+	case *structuredOutputLifecycleClient:
+		client = c.LLMClient
+	}
+	switch c := client.(type) {
+	case *circuitBreakerClient:
+		return c.inner
+	case *circuitBreakerLifecycleClient:
+		return c.inner
+	default:
+		t.Fatalf("expected circuit breaker client, got %T", client)
+		return nil
+	}
+}
+
+type stubHealthChecker struct {
+	err   error
+	calls int
+}
+
+func (s *stubHealthChecker) HealthCheck(ctx context.Context) error {
+	s.calls++
+	return s.err
+}
+
+// manualTimer はテストから予約済みの HealthCheck を発火させる。
+type manualTimer struct {
+	fire    func()
+	delay   time.Duration
+	stopped bool
+}
+
+func (t *manualTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+type manualTimers struct {
+	scheduled []*manualTimer
+}
+
+func (m *manualTimers) afterFunc(d time.Duration, f func()) probeTimer {
+	timer := &manualTimer{fire: f, delay: d}
+	m.scheduled = append(m.scheduled, timer)
+	return timer
+}
+
+// fireLatest は最後に予約され、取り消されていない HealthCheck を実行する。
+func (m *manualTimers) fireLatest(t *testing.T) {
+	t.Helper()
+	if len(m.scheduled) == 0 {
+		t.Fatalf("no probe scheduled")
+	}
+	timer := m.scheduled[len(m.scheduled)-1]
+	if timer.stopped {
+		t.Fatalf("latest probe was cancelled")
+	}
+	timer.fire()
+}
+
+func TestCircuitBreaker_OpensProbesOnTimerAndRecovers(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	timers := &manualTimers{}
+	breaker := newCircuitBreaker(circuitKey{provider: "gemini"}, CircuitBreakerSettings{FailureThreshold: 3, ProbeInterval: time.Minute}, func() time.Time { return clock }, timers.afterFunc)
+	prober := &stubHealthChecker{err: errors.New("still down")}
+	breaker.setProber(prober)
+	ctx := context.Background()
+
+	transient := &RetryableError{StatusCode: 503, Message: "overloaded"}
+	breaker.Record(transient)
+	breaker.Record(errors.New("gemini: status 400"))
+	for i := 0; i < 3; i++ {
+		breaker.Record(transient)
+	}
+	if err := breaker.Allow(ctx); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected open circuit after 3 consecutive transient failures, got %v", err)
+	}
+	if !IsTransientError(breaker.Allow(ctx)) {
+		t.Fatalf("open circuit errors must be eligible for fallback")
+	}
+	if prober.calls != 0 || len(timers.scheduled) != 1 || timers.scheduled[0].delay != time.Minute {
+		t.Fatalf("expected one probe scheduled after the probe interval, calls=%d scheduled=%d", prober.calls, len(timers.scheduled))
+	}
+
+	clock = clock.Add(time.Minute)
+	timers.fireLatest(t)
+	health := breaker.Health(ctx)
+	if prober.calls != 1 || health.Available() || !health.NextProbeAt.Equal(clock.Add(time.Minute)) || len(timers.scheduled) != 2 {
+		t.Fatalf("expected failed probe to reschedule, calls=%d health=%+v", prober.calls, health)
+	}
+
+	clock = clock.Add(time.Minute)
+	prober.err = nil
+	timers.fireLatest(t)
+	if err := breaker.Allow(ctx); err != nil {
+		t.Fatalf("expected half-open circuit after successful probe, got %v", err)
+	}
+	breaker.Record(transient)
+	if health := breaker.Health(ctx); health.State != CircuitOpen {
+		t.Fatalf("a failure while half-open must reopen the circuit, got %+v", health)
+	}
+
+	clock = clock.Add(time.Minute)
+	timers.fireLatest(t)
+	if err := breaker.Allow(ctx); err != nil {
+		t.Fatalf("expected half-open circuit, got %v", err)
+	}
+	breaker.Record(nil)
+	if health := breaker.Health(ctx); health.State != CircuitClosed || health.ConsecutiveFailures != 0 {
+		t.Fatalf("expected closed circuit after success, got %+v", health)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAllowsOneTrialAtATime(t *testing.T) {
+	timers := &manualTimers{}
+	breaker := newCircuitBreaker(circuitKey{provider: "lmstudio"}, CircuitBreakerSettings{FailureThreshold: 1, ProbeInterval: time.Second}, time.Now, timers.afterFunc)
+	breaker.setProber(&stubHealthChecker{})
+	ctx := context.Background()
+
+	breaker.Record(&RetryableError{StatusCode: 503, Message: "down"})
+	timers.fireLatest(t)
+
+	if err := breaker.Allow(ctx); err != nil {
+		t.Fatalf("expected the first request to be let through as the trial, got %v", err)
+	}
+	if err := breaker.Allow(ctx); !errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected other requests to be rejected while the trial is in flight, got %v", err)
+	}
+
+	breaker.Record(context.Canceled)
+	if err := breaker.Allow(ctx); err != nil {
+		t.Fatalf("a cancelled trial must let the next request try again, got %v", err)
+	}
+	breaker.Record(nil)
+	for i := 0; i < 2; i++ {
+		if err := breaker.Allow(ctx); err != nil {
+			t.Fatalf("expected closed circuit to accept every request, got %v", err)
+		}
+	}
+}
+
+func TestManager_ProviderHealth_ReportsOpenCircuit(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil))).(*Manager)
+	if health := manager.ProviderHealth(context.Background(), LLMConfig{Provider: "openai_compatible"}); !health.Available() {
+		t.Fatalf("unused provider must be reported available, got %+v", health)
+	}
+	client, err := manager.GetClient(context.Background(), LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL,
+		Model:      "m1",
+		Parameters: map[string]interface{}{CircuitFailureThresholdParam: "2"},
+	})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	withoutCircuitBreaker(t, client).(*openAICompatibleClient).retryCfg = RetryConfig{MaxAttempts: 1, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+
+	for i := 0; i < 3; i++ {
+		_, _ = client.Complete(context.Background(), Request{UserPrompt: "hi"})
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected requests to stop once the circuit opened, calls=%d", got)
+	}
+	health := manager.ProviderHealthAll(context.Background())
+	if len(health) != 1 || health[0].Provider != "openai_compatible" || health[0].Endpoint != srv.URL || health[0].Model != "m1" || health[0].State != CircuitOpen || health[0].LastError == "" {
+		t.Fatalf("unexpected provider health: %+v", health)
+	}
+}
+
+func TestManager_CircuitBreakersAreKeyedByEndpoint(t *testing.T) {
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer dead.Close()
+	var liveCalls atomic.Int32
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		liveCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"choices":[{"message":{"content":"ok"}}]}`)
+	}))
+	defer live.Close()
+
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil))).(*Manager)
+	ctx := context.Background()
+	params := map[string]interface{}{CircuitFailureThresholdParam: "1"}
+	deadClient, err := manager.GetClient(ctx, LLMConfig{Provider: "openai_compatible", Endpoint: dead.URL, Model: "m1", Parameters: params})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	withoutCircuitBreaker(t, deadClient).(*openAICompatibleClient).retryCfg = RetryConfig{MaxAttempts: 1, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+	_, _ = deadClient.Complete(ctx, Request{UserPrompt: "hi"})
+
+	liveClient, err := manager.GetClient(ctx, LLMConfig{Provider: "openai_compatible", Endpoint: live.URL, Model: "m1", Parameters: params})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	if _, err := liveClient.Complete(ctx, Request{UserPrompt: "hi"}); err != nil {
+		t.Fatalf("a dead endpoint must not block another endpoint of the same provider: %v", err)
+	}
+	if liveCalls.Load() != 1 {
+		t.Fatalf("expected the live endpoint to receive the request, calls=%d", liveCalls.Load())
+	}
+
+	if health := manager.ProviderHealth(ctx, LLMConfig{Provider: "openai_compatible", Endpoint: live.URL, Model: "m1"}); !health.Available() {
+		t.Fatalf("expected the live endpoint to be available, got %+v", health)
+	}
+	if health := manager.ProviderHealth(ctx, LLMConfig{Provider: "openai_compatible"}); health.Available() || health.Endpoint != dead.URL {
+		t.Fatalf("a provider-wide check must report the open endpoint, got %+v", health)
+	}
+}
@@ -13,12 +13,24 @@ type LLMClient interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// BatchEmbeddingClient is implemented by clients that embed several texts in one request.
+// Use GetEmbeddings to fall back to one request per text for other clients.
+type BatchEmbeddingClient interface {
+	GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+}
+
 // LLMManager manages available LLM providers and creates client instances
 // based on the current configuration (provider selection, API key, endpoint, etc.).
 type LLMManager interface {
 	GetClient(ctx context.Context, config LLMConfig) (LLMClient, error)
 	GetBatchClient(ctx context.Context, config LLMConfig) (BatchClient, error)
-	ResolveBulkStrategy(ctx context.Context, strategy BulkStrategy, provider string) BulkStrategy
+	ResolveBulkStrategy(ctx context.Context, strategy BulkStrategy, config LLMConfig) BulkStrategy
+}
+
+// ProviderHealthReporter exposes circuit breaker state per provider, endpoint and model.
+type ProviderHealthReporter interface {
+	ProviderHealth(ctx context.Context, config LLMConfig) ProviderHealth
+	ProviderHealthAll(ctx context.Context) []ProviderHealth
 }
 
 // BatchClient abstracts asynchronous batch API job management and result retrieval.
@@ -28,6 +28,9 @@ type Response struct {
 	Error    string                 `json:"error,omitempty"`
 	Usage    TokenUsage             `json:"usage"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Transient marks a failure that exhausted retries on a 5xx/429, quota or network error,
+	// so another provider in the fallback chain may still succeed.
+	Transient bool `json:"transient,omitempty"`
 }
 
 // TokenUsage represents token consumption for a request.
@@ -41,7 +44,7 @@ type TokenUsage struct {
 type BulkStrategy string
 
 const (
-	// BulkStrategyBatch uses provider-native async Batch API (e.g., Gemini, xAI).
+	// BulkStrategyBatch uses provider-native async Batch API (e.g., Gemini, xAI, OpenAI).
 	BulkStrategyBatch BulkStrategy = "batch"
 	// BulkStrategySync uses ExecuteBulkSync for synchronous concurrent processing.
 	// This is the only option for local LLM providers.
@@ -63,8 +66,40 @@ const (
 	// LLMSyncConcurrencyKeySuffix is the suffix appended to the provider name
 	// to build the Config key for sync concurrency (e.g., "sync_concurrency.gemini").
 	LLMSyncConcurrencyKeySuffix = "sync_concurrency"
+	// LLMFallbackProvidersKey lists fallback targets in order as "provider:model" pairs separated by commas.
+	// Example: "xai:grok-3-mini,lmstudio:qwen2.5-7b-instruct".
+	LLMFallbackProvidersKey = "fallback_providers"
 )
 
+// Parameter keys understood by the openai_compatible provider.
+const (
+	// OpenAICompatibleAuthHeaderParam overrides the header carrying the API key (default "Authorization").
+	OpenAICompatibleAuthHeaderParam = "auth_header"
+	// OpenAICompatibleAuthSchemeParam overrides the value prefix (default "Bearer" for Authorization, none otherwise; "none" sends the raw key).
+	OpenAICompatibleAuthSchemeParam = "auth_scheme"
+	// OpenAICompatibleModelsEndpointParam overrides the model list path or URL (default "/models").
+	OpenAICompatibleModelsEndpointParam = "models_endpoint"
+	// OpenAICompatibleBatchAPIParam set to "true" declares the endpoint implements the OpenAI /files + /batches protocol.
+	// vLLM, Ollama and OpenRouter do not, so batch requests fall back to sync unless it is set.
+	OpenAICompatibleBatchAPIParam = "batch_api"
+)
+
+// ProviderParameterKeys lists the optional LLMConfig.Parameters keys a provider reads from config:
+// cassette recording, rate limits and the circuit breaker, plus the auth, model list and batch API
+// settings of openai_compatible. The worker and the sync executor both resolve exactly these keys.
+func ProviderParameterKeys(provider string) []string {
+	keys := append(append([]string{RecordCassetteParam}, RateLimitParams...), CircuitBreakerParams...)
+	if NormalizeProvider(provider) == "openai_compatible" {
+		keys = append(keys,
+			OpenAICompatibleAuthHeaderParam,
+			OpenAICompatibleAuthSchemeParam,
+			OpenAICompatibleModelsEndpointParam,
+			OpenAICompatibleBatchAPIParam,
+		)
+	}
+	return keys
+}
+
 // Batch correlation metadata keys shared across worker/provider implementations.
 const (
 	BatchMetadataQueueJobIDKey      = "queue_job_id"
@@ -92,6 +127,9 @@ type LLMConfig struct {
 	// Concurrency controls how many parallel workers are used in ExecuteBulkSync.
 	// Only relevant when BulkStrategy is BulkStrategySync.
 	Concurrency int `json:"concurrency,omitempty"`
+	// Fallbacks are tried in order for requests whose failure on this provider was transient.
+	// With BulkStrategyBatch every failed batch result is retried on them through the sync path.
+	Fallbacks []LLMConfig `json:"fallbacks,omitempty"`
 }
 
 // BatchJobID identifies a batch processing job.
@@ -124,12 +162,15 @@ func NormalizeProvider(provider string) string {
 	switch strings.ToLower(strings.TrimSpace(provider)) {
 	case "local", "local-llm", "lmstudio":
 		return "lmstudio"
+	case "openai_compatible", "openai-compatible", "openai_compat", "openai-compat":
+		return "openai_compatible"
 	default:
 		return strings.ToLower(strings.TrimSpace(provider))
 	}
 }
 
 // ProviderSupportsBatch returns whether provider has native Batch API support.
+// openai_compatible endpoints only support it when declared; see ConfigSupportsBatch.
 func ProviderSupportsBatch(provider string) bool {
 	switch NormalizeProvider(provider) {
 	case "gemini", "xai":
@@ -138,3 +179,21 @@ func ProviderSupportsBatch(provider string) bool {
 		return false
 	}
 }
+
+// ConfigSupportsBatch returns whether config can run the Batch API,
+// including openai_compatible endpoints that opt in with OpenAICompatibleBatchAPIParam.
+func ConfigSupportsBatch(config LLMConfig) bool {
+	if ProviderSupportsBatch(config.Provider) {
+		return true
+	}
+	return NormalizeProvider(config.Provider) == "openai_compatible" && openAICompatibleBatchAPIEnabled(config.Parameters)
+}
+
+func openAICompatibleBatchAPIEnabled(params map[string]interface{}) bool {
+	switch strings.ToLower(parameterString(params, OpenAICompatibleBatchAPIParam)) {
+	case "true", "1", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
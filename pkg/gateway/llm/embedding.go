@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// LLMEmbeddingModelParam selects the embedding model when it differs from the chat model.
+const LLMEmbeddingModelParam = "embedding_model"
+
+const geminiDefaultEmbeddingModel = "text-embedding-004"
+
+// embeddingModel returns the configured embedding model, falling back to fallback.
+func embeddingModel(config LLMConfig, fallback string) string {
+	if model := parameterString(config.Parameters, LLMEmbeddingModelParam); model != "" {
+		return model
+	}
+	return fallback
+}
+
+// GetEmbeddings embeds texts in input order, in one request when client implements BatchEmbeddingClient
+// and one request per text otherwise.
+func GetEmbeddings(ctx context.Context, client LLMClient, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if batch, ok := client.(BatchEmbeddingClient); ok {
+		return batch.GetEmbeddings(ctx, texts)
+	}
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector, err := client.GetEmbedding(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+// doOpenAIEmbedding calls an OpenAI-compatible /embeddings endpoint for a single input.
+func doOpenAIEmbedding(ctx context.Context, client *http.Client, url string, model string, text string, setAuth func(*http.Request)) ([]float32, error) {
+	vectors, err := doOpenAIEmbeddings(ctx, client, url, model, []string{text}, setAuth)
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+// doOpenAIEmbeddings calls an OpenAI-compatible /embeddings endpoint with an input array
+// and returns the vectors in input order.
+func doOpenAIEmbeddings(ctx context.Context, client *http.Client, url string, model string, texts []string, setAuth func(*http.Request)) ([][]float32, error) {
+	if strings.TrimSpace(model) == "" {
+		return nil, ErrModelRequired
+	}
+	var input interface{} = texts
+	if len(texts) == 1 {
+		input = texts[0]
+	}
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"model": model,
+		"input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal embedding request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create embedding request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	setAuth(httpReq)
+
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read embedding response: %w", err)
+	}
+	if IsRetryableStatusCode(httpResp.StatusCode) {
+		return nil, newRetryableError(httpResp, respBody)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding API error %d: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	var raw struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal embedding response: %w", err)
+	}
+	if len(raw.Data) == 0 {
+		return nil, fmt.Errorf("empty embedding in response")
+	}
+	if len(raw.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding response has %d vectors for %d inputs", len(raw.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, item := range raw.Data {
+		if item.Index < 0 || item.Index >= len(texts) || len(item.Embedding) == 0 || vectors[item.Index] != nil {
+			return nil, fmt.Errorf("empty or misplaced embedding in response index=%d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
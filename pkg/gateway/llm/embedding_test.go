@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAICompatibleClient_GetEmbedding_UsesEmbeddingModel(t *testing.T) {
+	t.Parallel()
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization header: %q", got)
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"embedding": []float32{0.1, 0.2, 0.3}}},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL + "/v1",
+		APIKey:     "secret",
+		Model:      "chat-model",
+		Parameters: map[string]interface{}{LLMEmbeddingModelParam: "nomic-embed-text"},
+	})
+	vector, err := client.GetEmbedding(context.Background(), "Jarl's Longhouse")
+	if err != nil {
+		t.Fatalf("GetEmbedding failed: %v", err)
+	}
+	if len(vector) != 3 || vector[2] != 0.3 {
+		t.Fatalf("unexpected vector: %v", vector)
+	}
+	if payload["model"] != "nomic-embed-text" || payload["input"] != "Jarl's Longhouse" {
+		t.Fatalf("unexpected embedding request: %v", payload)
+	}
+}
+
+func TestGetEmbeddings_SendsOneBatchRequestThroughManagerClient(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		// 入力順と異なる順序で返しても index で並べ直されること
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"index": 1, "embedding": []float32{0, 1}},
+				{"index": 0, "embedding": []float32{1, 0}},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client, err := manager.GetClient(context.Background(), LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL + "/v1",
+		Model:      "chat-model",
+		Parameters: map[string]interface{}{LLMEmbeddingModelParam: "nomic-embed-text"},
+	})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	vectors, err := GetEmbeddings(context.Background(), client, []string{"Whiterun", "Solitude"})
+	if err != nil {
+		t.Fatalf("GetEmbeddings failed: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected one batch request, got %d", requests)
+	}
+	if len(vectors) != 2 || vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Fatalf("vectors must follow input order: %v", vectors)
+	}
+	inputs, ok := payload["input"].([]any)
+	if !ok || len(inputs) != 2 || inputs[0] != "Whiterun" {
+		t.Fatalf("expected input array in request, got %v", payload["input"])
+	}
+}
+
+func TestLMStudioClient_GetEmbedding_FallsBackToChatModel(t *testing.T) {
+	t.Parallel()
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{}})
+	}))
+	defer srv.Close()
+
+	client := NewLMStudioClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider: "lmstudio",
+		Endpoint: srv.URL,
+		Model:    "text-embedding-bge-m3",
+	})
+	if _, err := client.GetEmbedding(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), "empty embedding") {
+		t.Fatalf("expected empty embedding error, got %v", err)
+	}
+	if payload["model"] != "text-embedding-bge-m3" {
+		t.Fatalf("expected chat model as embedding fallback, got %v", payload["model"])
+	}
+}
+
+func TestGeminiClient_GetEmbedding_UsesEmbedContent(t *testing.T) {
+	t.Parallel()
+	var gotPath string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.Path
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{"embedding":{"values":[0.5,-0.5]}}`)),
+			Request:    req,
+		}, nil
+	})
+	rawClient := NewGeminiClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{APIKey: "k", Model: "gemini-2.0-flash"})
+	client := rawClient.(*geminiClient)
+	client.httpClient = &http.Client{Transport: transport}
+
+	vector, err := client.GetEmbedding(context.Background(), "longhouse")
+	if err != nil {
+		t.Fatalf("GetEmbedding failed: %v", err)
+	}
+	if gotPath != "/v1beta/models/text-embedding-004:embedContent" {
+		t.Fatalf("unexpected embedding endpoint: %s", gotPath)
+	}
+	if len(vector) != 2 || vector[0] != 0.5 {
+		t.Fatalf("unexpected vector: %v", vector)
+	}
+}
@@ -5,6 +5,18 @@ import "errors"
 var (
 	// ErrStructuredOutputNotSupported is returned when provider does not implement structured output.
 	ErrStructuredOutputNotSupported = errors.New("llm: structured output not supported by provider")
+	// ErrStructuredOutputInvalid is returned when a structured response still fails schema validation after all repair attempts.
+	ErrStructuredOutputInvalid = errors.New("llm: structured output does not match response schema")
 	// ErrModelRequired is returned when model is omitted in configuration.
 	ErrModelRequired = errors.New("llm: model must be specified")
+	// ErrEndpointRequired is returned when a provider without a default endpoint is configured without one.
+	ErrEndpointRequired = errors.New("llm: endpoint must be specified")
+	// ErrDailyQuotaExceeded is returned when a configured daily request or token cap has been reached.
+	ErrDailyQuotaExceeded = errors.New("llm: daily quota exceeded")
+	// ErrCassetteRequired is returned when the replay provider has no cassette path configured.
+	ErrCassetteRequired = errors.New("llm: replay cassette path must be specified")
+	// ErrCassetteMiss is returned when the replay cassette has no recorded response for a request.
+	ErrCassetteMiss = errors.New("llm: request not found in replay cassette")
+	// ErrProviderUnavailable is returned without sending when the provider's circuit breaker is open.
+	ErrProviderUnavailable = errors.New("llm: provider unavailable (circuit open)")
 )
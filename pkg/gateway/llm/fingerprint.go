@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// RequestFingerprint hashes the prompt-affecting fields of req.
+// Metadata (task/record IDs) is excluded so the same prompt from different tasks shares one fingerprint.
+func RequestFingerprint(req Request) string {
+	payload, err := json.Marshal(struct {
+		SystemPrompt   string                 `json:"system_prompt"`
+		UserPrompt     string                 `json:"user_prompt"`
+		Temperature    float32                `json:"temperature"`
+		ResponseSchema map[string]interface{} `json:"response_schema,omitempty"`
+		StopSequences  []string               `json:"stop_sequences,omitempty"`
+	}{req.SystemPrompt, req.UserPrompt, req.Temperature, req.ResponseSchema, req.StopSequences})
+	if err != nil {
+		// ResponseSchema is decoded JSON, so marshal cannot fail in practice; fall back to the prompts alone.
+		payload = []byte(req.SystemPrompt + "\x00" + req.UserPrompt)
+	}
+	sum := sha256.Sum256(payload)
+	return fmt.Sprintf("%x", sum[:])
+}
+
+func embeddingFingerprint(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("%x", sum[:])
+}
@@ -127,20 +127,151 @@ func (c *geminiClient) GenerateStructured(ctx context.Context, req Request) (Res
 	return Response{}, ErrStructuredOutputNotSupported
 }
 
-// StreamComplete はストリーミングレスポンスを返す（現在は非ストリーミングフォールバック）。
+// StreamComplete は streamGenerateContent (SSE) で部分テキストを逐次返す。
 func (c *geminiClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
-	c.logger.DebugContext(ctx, "ENTER StreamComplete (non-streaming fallback)")
-	resp, err := c.Complete(ctx, req)
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	logFinalPrompt(ctx, c.logger, "gemini", requestModeAttr(false), req)
+
+	body, err := openSSEStream(ctx, streamingHTTPClient(c.httpClient), c.retryCfg, func() (*http.Request, error) {
+		return c.buildStreamRequest(ctx, req)
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "Gemini stream request failed", telemetry2.ErrorAttrs(err)...)
+		return nil, fmt.Errorf("gemini: stream request failed: %w", err)
+	}
+	return newSSEStreamResponse(ctx, body, decodeGeminiStreamData, req.Metadata), nil
+}
+
+// GetEmbedding は embedContent でテキストの埋め込みベクトルを返す。
+// チャットモデルとは別に Parameters["embedding_model"]（既定 text-embedding-004）を使う。
+func (c *geminiClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	model := embeddingModel(c.config, geminiDefaultEmbeddingModel)
+	var vector []float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vector, innerErr = c.doEmbedding(ctx, model, text)
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gemini: embedding model=%s: %w", model, err)
+	}
+	return vector, nil
+}
+
+func (c *geminiClient) doEmbedding(ctx context.Context, model string, text string) ([]float32, error) {
+	modelPath := normalizeGeminiModelResource(model)
+	url := fmt.Sprintf("%s/%s/%s:embedContent?key=%s", geminiBaseURL, geminiAPIVersion, modelPath, c.config.APIKey)
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"model":   modelPath,
+		"content": map[string]interface{}{"parts": []map[string]string{{"text": text}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal embedding request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create embedding request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read embedding response: %w", err)
+	}
+	if IsRetryableStatusCode(httpResp.StatusCode) {
+		return nil, newRetryableError(httpResp, body)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding API error %d: %s", httpResp.StatusCode, string(body))
+	}
+	var raw struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal embedding response: %w", err)
+	}
+	if len(raw.Embedding.Values) == 0 {
+		return nil, fmt.Errorf("empty embedding in response")
+	}
+	return raw.Embedding.Values, nil
+}
+
+// GetEmbeddings は batchEmbedContents で複数テキストを 1 回のリクエストでベクトル化する。
+func (c *geminiClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	model := embeddingModel(c.config, geminiDefaultEmbeddingModel)
+	var vectors [][]float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vectors, innerErr = c.doBatchEmbedding(ctx, model, texts)
+		return innerErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("gemini: stream completion fallback failed: %w", err)
+		return nil, fmt.Errorf("gemini: embeddings model=%s count=%d: %w", model, len(texts), err)
 	}
-	c.logger.DebugContext(ctx, "EXIT StreamComplete")
-	return &geminiStreamResponse{resp: resp}, nil
+	return vectors, nil
 }
 
-// GetEmbedding はテキストの埋め込みベクトルを返す（スタブ）。
-func (c *geminiClient) GetEmbedding(_ context.Context, _ string) ([]float32, error) {
-	return nil, fmt.Errorf("gemini: GetEmbedding not implemented")
+func (c *geminiClient) doBatchEmbedding(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	modelPath := normalizeGeminiModelResource(model)
+	url := fmt.Sprintf("%s/%s/%s:batchEmbedContents?key=%s", geminiBaseURL, geminiAPIVersion, modelPath, c.config.APIKey)
+	requests := make([]map[string]interface{}, 0, len(texts))
+	for _, text := range texts {
+		requests = append(requests, map[string]interface{}{
+			"model":   modelPath,
+			"content": map[string]interface{}{"parts": []map[string]string{{"text": text}}},
+		})
+	}
+	bodyBytes, err := json.Marshal(map[string]interface{}{"requests": requests})
+	if err != nil {
+		return nil, fmt.Errorf("marshal batch embedding request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create batch embedding request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("batch embedding request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read batch embedding response: %w", err)
+	}
+	if IsRetryableStatusCode(httpResp.StatusCode) {
+		return nil, newRetryableError(httpResp, body)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("batch embedding API error %d: %s", httpResp.StatusCode, string(body))
+	}
+	var raw struct {
+		Embeddings []struct {
+			Values []float32 `json:"values"`
+		} `json:"embeddings"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal batch embedding response: %w", err)
+	}
+	if len(raw.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("batch embedding response has %d vectors for %d inputs", len(raw.Embeddings), len(texts))
+	}
+	vectors := make([][]float32, 0, len(raw.Embeddings))
+	for i, embedding := range raw.Embeddings {
+		if len(embedding.Values) == 0 {
+			return nil, fmt.Errorf("empty embedding in response index=%d", i)
+		}
+		vectors = append(vectors, embedding.Values)
+	}
+	return vectors, nil
 }
 
 // HealthCheck は Gemini API への疎通確認を行う。
@@ -185,10 +316,7 @@ func (c *geminiClient) doComplete(ctx context.Context, req Request) (Response, e
 	}
 
 	if IsRetryableStatusCode(httpResp.StatusCode) {
-		return Response{}, &RetryableError{
-			StatusCode: httpResp.StatusCode,
-			Message:    string(body),
-		}
+		return Response{}, newRetryableError(httpResp, body)
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return Response{}, fmt.Errorf("gemini: API error %d: %s", httpResp.StatusCode, string(body))
@@ -203,11 +331,20 @@ func (c *geminiClient) doComplete(ctx context.Context, req Request) (Response, e
 
 // buildRequest は Gemini API 形式の *http.Request を構築する。
 func (c *geminiClient) buildRequest(ctx context.Context, req Request) (*http.Request, error) {
-	c.logger.DebugContext(ctx, "ENTER buildRequest", "model", c.config.Model)
+	return c.newContentRequest(ctx, req, "generateContent", "")
+}
+
+// buildStreamRequest は SSE 形式で応答させる streamGenerateContent の *http.Request を構築する。
+func (c *geminiClient) buildStreamRequest(ctx context.Context, req Request) (*http.Request, error) {
+	return c.newContentRequest(ctx, req, "streamGenerateContent", "alt=sse&")
+}
+
+func (c *geminiClient) newContentRequest(ctx context.Context, req Request, method string, query string) (*http.Request, error) {
+	c.logger.DebugContext(ctx, "ENTER buildRequest", "model", c.config.Model, "method", method)
 
 	modelPath := normalizeGeminiModelResource(c.config.Model)
-	url := fmt.Sprintf("%s/%s/%s:generateContent?key=%s",
-		geminiBaseURL, geminiAPIVersion, modelPath, c.config.APIKey)
+	url := fmt.Sprintf("%s/%s/%s:%s?%skey=%s",
+		geminiBaseURL, geminiAPIVersion, modelPath, method, query, c.config.APIKey)
 
 	type part struct {
 		Text string `json:"text"`
@@ -251,7 +388,7 @@ func (c *geminiClient) buildRequest(ctx context.Context, req Request) (*http.Req
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	c.logger.DebugContext(ctx, "EXIT buildRequest", "url_path", fmt.Sprintf("/%s:generateContent", modelPath))
+	c.logger.DebugContext(ctx, "EXIT buildRequest", "url_path", fmt.Sprintf("/%s:%s", modelPath, method))
 	return httpReq, nil
 }
 
@@ -299,19 +436,3 @@ func (c *geminiClient) parseResponse(ctx context.Context, body []byte) (Response
 	)
 	return resp, nil
 }
-
-// geminiStreamResponse — Complete のフォールバック用
-type geminiStreamResponse struct {
-	resp Response
-	done bool
-}
-
-func (s *geminiStreamResponse) Next() (Response, bool) {
-	if s.done {
-		return Response{}, false
-	}
-	s.done = true
-	return s.resp, true
-}
-
-func (s *geminiStreamResponse) Close() error { return nil }
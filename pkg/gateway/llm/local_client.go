@@ -149,16 +149,51 @@ func (c *lmStudioClient) GenerateStructured(ctx context.Context, req Request) (R
 	return resp, nil
 }
 
+// StreamComplete streams chat completion deltas over SSE.
 func (c *lmStudioClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
-	resp, err := c.Complete(ctx, req)
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	if c.config.Model == "" {
+		return nil, ErrModelRequired
+	}
+	logFinalPrompt(ctx, c.logger, "lmstudio", requestModeAttr(false), req)
+
+	body, err := openSSEStream(ctx, streamingHTTPClient(c.httpClient), c.retryCfg, func() (*http.Request, error) {
+		return c.newChatRequest(ctx, req, false, true)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("lmstudio: stream completion fallback failed: %w", err)
+		return nil, fmt.Errorf("lmstudio: stream request failed: %w", err)
 	}
-	return &localStreamResponse{resp: resp}, nil
+	return newSSEStreamResponse(ctx, body, decodeOpenAIStreamData, req.Metadata), nil
 }
 
-func (c *lmStudioClient) GetEmbedding(_ context.Context, _ string) ([]float32, error) {
-	return nil, fmt.Errorf("lmstudio: GetEmbedding not implemented")
+// GetEmbedding returns the embedding vector from /v1/embeddings.
+func (c *lmStudioClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vector []float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vector, innerErr = doOpenAIEmbedding(ctx, c.httpClient, fmt.Sprintf("%s/v1/embeddings", c.config.Endpoint), model, text, c.setAuthHeader)
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lmstudio: embedding model=%s: %w", model, err)
+	}
+	return vector, nil
+}
+
+// GetEmbeddings embeds texts in one /v1/embeddings request.
+func (c *lmStudioClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vectors [][]float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vectors, innerErr = doOpenAIEmbeddings(ctx, c.httpClient, fmt.Sprintf("%s/v1/embeddings", c.config.Endpoint), model, texts, c.setAuthHeader)
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lmstudio: embeddings model=%s count=%d: %w", model, len(texts), err)
+	}
+	return vectors, nil
 }
 
 func (c *lmStudioClient) HealthCheck(ctx context.Context) error {
@@ -251,7 +286,7 @@ func (c *lmStudioClient) UnloadModel(ctx context.Context, instanceID string) err
 	return nil
 }
 
-func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, structured bool) (Response, error) {
+func (c *lmStudioClient) newChatRequest(ctx context.Context, req Request, structured bool, stream bool) (*http.Request, error) {
 	type message struct {
 		Role    string `json:"role"`
 		Content string `json:"content"`
@@ -260,11 +295,15 @@ func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, stru
 		Type       string                 `json:"type"`
 		JSONSchema map[string]interface{} `json:"json_schema,omitempty"`
 	}
+	type streamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	}
 	type requestBody struct {
 		Model          string          `json:"model"`
 		Messages       []message       `json:"messages"`
 		Temperature    float32         `json:"temperature,omitempty"`
 		Stream         bool            `json:"stream"`
+		StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
 		ResponseFormat *responseFormat `json:"response_format,omitempty"`
 	}
 
@@ -278,7 +317,10 @@ func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, stru
 		Model:       c.config.Model,
 		Messages:    msgs,
 		Temperature: req.Temperature,
-		Stream:      false,
+		Stream:      stream,
+	}
+	if stream {
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
 	}
 	if structured {
 		body.ResponseFormat = &responseFormat{
@@ -293,16 +335,24 @@ func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, stru
 
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
-		return Response{}, fmt.Errorf("lmstudio: marshal request failed: %w", err)
+		return nil, fmt.Errorf("lmstudio: marshal request failed: %w", err)
 	}
 
 	url := fmt.Sprintf("%s/v1/chat/completions", c.config.Endpoint)
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return Response{}, fmt.Errorf("lmstudio: request creation failed: %w", err)
+		return nil, fmt.Errorf("lmstudio: request creation failed: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 	c.setAuthHeader(httpReq)
+	return httpReq, nil
+}
+
+func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, structured bool) (Response, error) {
+	httpReq, err := c.newChatRequest(ctx, req, structured, false)
+	if err != nil {
+		return Response{}, err
+	}
 
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -315,7 +365,7 @@ func (c *lmStudioClient) doChatCompletion(ctx context.Context, req Request, stru
 	}
 
 	if IsRetryableStatusCode(httpResp.StatusCode) {
-		return Response{}, &RetryableError{StatusCode: httpResp.StatusCode, Message: string(respBody)}
+		return Response{}, newRetryableError(httpResp, respBody)
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return Response{}, fmt.Errorf("lmstudio: chat completions error %d: %s", httpResp.StatusCode, string(respBody))
@@ -377,19 +427,3 @@ func parseLMStudioMessageContent(raw json.RawMessage) (string, error) {
 	}
 	return string(normalized), nil
 }
-
-// localStreamResponse is a fallback stream wrapper.
-type localStreamResponse struct {
-	resp Response
-	done bool
-}
-
-func (s *localStreamResponse) Next() (Response, bool) {
-	if s.done {
-		return Response{}, false
-	}
-	s.done = true
-	return s.resp, true
-}
-
-func (s *localStreamResponse) Close() error { return nil }
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 )
 
 // Manager は LLMManager インターフェースの実装。
 type Manager struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	limiters rateLimiterRegistry
+	breakers circuitBreakerRegistry
 }
 
 // NewLLMManager は LLMManager のインスタンスを返す。
@@ -20,7 +23,8 @@ func NewLLMManager(logger *slog.Logger) LLMManager {
 }
 
 // GetClient は LLMConfig に基づいて LLMClient を返す。
-// サポートプロバイダー: "gemini", "lmstudio"(互換: "local", "local-llm"), "xai"
+// サポートプロバイダー: "gemini", "lmstudio"(互換: "local", "local-llm"), "xai", "openai_compatible", "replay"
+// Parameters["record_cassette"] があれば実クライアントの応答をカセットへ記録する。
 func (m *Manager) GetClient(ctx context.Context, config LLMConfig) (LLMClient, error) {
 	config.Provider = NormalizeProvider(config.Provider)
 	m.logger.DebugContext(ctx, "ENTER GetClient", "provider", config.Provider, "model", config.Model)
@@ -36,16 +40,65 @@ func (m *Manager) GetClient(ctx context.Context, config LLMConfig) (LLMClient, e
 		c = NewLMStudioClient(m.logger, config)
 	case "xai":
 		c = NewXAIClient(m.logger, config)
+	case "openai_compatible":
+		if strings.TrimSpace(config.Endpoint) == "" {
+			return nil, ErrEndpointRequired
+		}
+		c = NewOpenAICompatibleClient(m.logger, config)
+	case "replay":
+		// オフライン再生ではレート制限も記録も不要。記録時と同じ再依頼を再生できるよう検証だけは掛ける
+		return newStructuredOutputClient(m.logger, NewReplayClient(m.logger, config), config), nil
 	default:
-		return nil, fmt.Errorf("llm_manager: unknown provider %q (supported: gemini, lmstudio, xai)", config.Provider)
+		return nil, fmt.Errorf("llm_manager: unknown provider %q (supported: gemini, lmstudio, xai, openai_compatible, replay)", config.Provider)
+	}
+
+	if path := parameterString(config.Parameters, RecordCassetteParam); path != "" {
+		c = newRecordingClient(m.logger, c, config, path)
+	}
+
+	// レート制限が設定されていれば、同じプロバイダー/モデルのクライアント間でリミッターを共有する
+	if limit := RateLimitFromConfig(config); limit.Enabled() {
+		c = newRateLimitedClient(c, m.limiters.get(config.Provider, config.Model, limit))
 	}
 
+	// 連続した一時エラーで回路を開き、リミッター待ちに入る前に送信を止める
+	c = newCircuitBreakerClient(c, m.breakers.get(config, CircuitBreakerSettingsFromConfig(config)))
+
+	// 構造化出力の再依頼もリミッターと回路を通るよう最も外側で検証する
+	c = newStructuredOutputClient(m.logger, c, config)
+
 	m.logger.DebugContext(ctx, "EXIT GetClient", "provider", config.Provider)
 	return c, nil
 }
 
+// ProviderHealth は config の接続先の回路状態を返す。Endpoint や Model が空なら、そのプロバイダーの
+// いずれかの接続先の回路が開いていれば開いている回路を返す。まだ使われていない接続先は closed とみなす。
+func (m *Manager) ProviderHealth(ctx context.Context, config LLMConfig) ProviderHealth {
+	filter := circuitKeyFromConfig(config)
+	matched := m.breakers.matching(filter)
+	if len(matched) == 0 {
+		return ProviderHealth{Provider: filter.provider, Endpoint: filter.endpoint, Model: filter.model, State: CircuitClosed}
+	}
+	for _, breaker := range matched {
+		if health := breaker.Health(ctx); !health.Available() {
+			return health
+		}
+	}
+	return matched[0].Health(ctx)
+}
+
+// ProviderHealthAll はこれまでに使われた全接続先の回路状態をプロバイダー・エンドポイント・モデル順で返す。
+func (m *Manager) ProviderHealthAll(ctx context.Context) []ProviderHealth {
+	breakers := m.breakers.matching(circuitKey{})
+	out := make([]ProviderHealth, 0, len(breakers))
+	for _, breaker := range breakers {
+		out = append(out, breaker.Health(ctx))
+	}
+	return out
+}
+
 // GetBatchClient は LLMConfig に基づいて BatchClient を返す。
-// Batch サポート: "gemini", "xai"
+// Batch サポート: "gemini", "xai", "openai_compatible"（OpenAI の /files + /batches プロトコル）
 func (m *Manager) GetBatchClient(ctx context.Context, config LLMConfig) (BatchClient, error) {
 	config.Provider = NormalizeProvider(config.Provider)
 	m.logger.DebugContext(ctx, "ENTER GetBatchClient", "provider", config.Provider, "model", config.Model)
@@ -65,19 +118,26 @@ func (m *Manager) GetBatchClient(ctx context.Context, config LLMConfig) (BatchCl
 		}
 		m.logger.DebugContext(ctx, "EXIT GetBatchClient", "provider", "gemini")
 		return bc, nil
-	case "lmstudio":
+	case "openai_compatible":
+		bc, err := NewOpenAIBatchClient(m.logger, config)
+		if err != nil {
+			return nil, fmt.Errorf("llm_manager: openai_compatible BatchClient creation failed: %w", err)
+		}
+		m.logger.DebugContext(ctx, "EXIT GetBatchClient", "provider", "openai_compatible")
+		return bc, nil
+	case "lmstudio", "replay":
 		return nil, fmt.Errorf("llm_manager: provider %q does not support Batch API", config.Provider)
 	default:
 		return nil, fmt.Errorf("llm_manager: unknown provider %q", config.Provider)
 	}
 }
 
-// ResolveBulkStrategy は BulkStrategy を受け取り、プロバイダーに応じた有効なバルク戦略を返す。
-func (m *Manager) ResolveBulkStrategy(ctx context.Context, strategy BulkStrategy, provider string) BulkStrategy {
-	provider = NormalizeProvider(provider)
+// ResolveBulkStrategy は BulkStrategy を受け取り、プロバイダー設定に応じた有効なバルク戦略を返す。
+func (m *Manager) ResolveBulkStrategy(ctx context.Context, strategy BulkStrategy, config LLMConfig) BulkStrategy {
+	provider := NormalizeProvider(config.Provider)
 	m.logger.DebugContext(ctx, "ENTER ResolveBulkStrategy", "strategy", strategy, "provider", provider)
 
-	if strategy == BulkStrategyBatch && !ProviderSupportsBatch(provider) {
+	if strategy == BulkStrategyBatch && !ConfigSupportsBatch(config) {
 		m.logger.WarnContext(ctx, "ResolveBulkStrategy: provider does not support batch strategy; falling back to sync",
 			"provider", provider,
 		)
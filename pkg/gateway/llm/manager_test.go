@@ -32,6 +32,16 @@ func TestLLMManager_GetClient(t *testing.T) {
 			config:  LLMConfig{Provider: "xai", APIKey: "test-key", Model: "grok-3"},
 			wantErr: false,
 		},
+		{
+			name:    "正常系: OpenAI 互換クライアントが返る",
+			config:  LLMConfig{Provider: "openai-compatible", Endpoint: "http://localhost:8000/v1", Model: "llama3"},
+			wantErr: false,
+		},
+		{
+			name:    "異常系: OpenAI 互換はエンドポイント未指定でエラー",
+			config:  LLMConfig{Provider: "openai_compatible", Model: "llama3"},
+			wantErr: true,
+		},
 		{
 			name:    "異常系: 不明なプロバイダーはエラー",
 			config:  LLMConfig{Provider: "unknown"},
@@ -68,15 +78,22 @@ func TestLLMManager_ResolveBulkStrategy(t *testing.T) {
 	manager := NewLLMManager(logger)
 	ctx := context.Background()
 
-	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, "lmstudio"); got != BulkStrategySync {
+	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, LLMConfig{Provider: "lmstudio"}); got != BulkStrategySync {
 		t.Fatalf("lmstudio batch must fallback to sync: got=%s", got)
 	}
-	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, "gemini"); got != BulkStrategyBatch {
+	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, LLMConfig{Provider: "gemini"}); got != BulkStrategyBatch {
 		t.Fatalf("gemini batch should stay batch: got=%s", got)
 	}
-	if got := manager.ResolveBulkStrategy(ctx, BulkStrategy(""), "xai"); got != BulkStrategySync {
+	if got := manager.ResolveBulkStrategy(ctx, BulkStrategy(""), LLMConfig{Provider: "xai"}); got != BulkStrategySync {
 		t.Fatalf("empty strategy must default to sync: got=%s", got)
 	}
+	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, LLMConfig{Provider: "openai_compatible"}); got != BulkStrategySync {
+		t.Fatalf("openai_compatible batch without batch_api must fallback to sync: got=%s", got)
+	}
+	openAIBatch := LLMConfig{Provider: "openai_compatible", Parameters: map[string]interface{}{OpenAICompatibleBatchAPIParam: "true"}}
+	if got := manager.ResolveBulkStrategy(ctx, BulkStrategyBatch, openAIBatch); got != BulkStrategyBatch {
+		t.Fatalf("openai_compatible batch with batch_api should stay batch: got=%s", got)
+	}
 }
 
 func TestLLMManager_GetBatchClient(t *testing.T) {
@@ -99,6 +116,16 @@ func TestLLMManager_GetBatchClient(t *testing.T) {
 			config:  LLMConfig{Provider: "xai", APIKey: "test-key", Model: "grok-3"},
 			wantErr: false,
 		},
+		{
+			name:    "正常系: OpenAI 互換 BatchClient が返る",
+			config:  LLMConfig{Provider: "openai-compatible", Endpoint: "https://api.openai.com/v1", APIKey: "test-key", Model: "gpt-4o-mini"},
+			wantErr: false,
+		},
+		{
+			name:    "異常系: OpenAI 互換 Batch はエンドポイント未指定でエラー",
+			config:  LLMConfig{Provider: "openai_compatible", Model: "gpt-4o-mini"},
+			wantErr: true,
+		},
 		{
 			name:    "異常系: lmstudio は Batch 非対応",
 			config:  LLMConfig{Provider: "lmstudio", Endpoint: "http://localhost:1234", Model: "llama3"},
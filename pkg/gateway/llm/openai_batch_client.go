@@ -0,0 +1,441 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+const (
+	openAIBatchTimeout          = 5 * time.Minute
+	openAIBatchFilesEndpoint    = "/files"
+	openAIBatchesEndpoint       = "/batches"
+	openAIBatchChatURL          = "/v1/chat/completions"
+	openAIBatchCompletionWindow = "24h"
+	openAIBatchFilePurpose      = "batch"
+	// openAIBatchMaxRequests は 1 つの入力ファイルに入れられるリクエスト数の上限。
+	openAIBatchMaxRequests = 50000
+)
+
+// openAIBatchClient は OpenAI の /files + /batches (JSONL) プロトコルによる BatchClient 実装。
+// OpenAI 本体と同じプロトコルを実装した互換ゲートウェイでも使える。
+type openAIBatchClient struct {
+	api        *openAICompatibleClient
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewOpenAIBatchClient は OpenAI 互換 BatchClient を返す。Endpoint は /v1 までを含む API のベース URL。
+func NewOpenAIBatchClient(logger *slog.Logger, config LLMConfig) (BatchClient, error) {
+	if strings.TrimSpace(config.Model) == "" {
+		return nil, ErrModelRequired
+	}
+	if strings.TrimSpace(config.Endpoint) == "" {
+		return nil, ErrEndpointRequired
+	}
+	api, ok := NewOpenAICompatibleClient(logger, config).(*openAICompatibleClient)
+	if !ok {
+		return nil, fmt.Errorf("openai_batch: unexpected openai_compatible client type")
+	}
+	return &openAIBatchClient{
+		api:        api,
+		httpClient: &http.Client{Timeout: openAIBatchTimeout},
+		logger:     logger.With("component", "openai_batch_client", "endpoint", api.baseURL, "model", config.Model),
+	}, nil
+}
+
+// openAIBatchRequestLine は入力 JSONL の 1 行。
+type openAIBatchRequestLine struct {
+	CustomID string                `json:"custom_id"`
+	Method   string                `json:"method"`
+	URL      string                `json:"url"`
+	Body     openAIChatRequestBody `json:"body"`
+}
+
+// SubmitBatch はリクエストを JSONL としてアップロードし、バッチを作成して BatchJobID を返す。
+func (b *openAIBatchClient) SubmitBatch(ctx context.Context, reqs []Request) (BatchJobID, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	if len(reqs) == 0 {
+		return BatchJobID{}, fmt.Errorf("openai_batch: no requests to submit")
+	}
+	if len(reqs) > openAIBatchMaxRequests {
+		return BatchJobID{}, fmt.Errorf("openai_batch: %d requests exceed the per-batch limit of %d", len(reqs), openAIBatchMaxRequests)
+	}
+
+	var input bytes.Buffer
+	encoder := json.NewEncoder(&input)
+	for idx, req := range reqs {
+		logFinalPrompt(ctx, b.logger, "openai_compatible", "batch", req, requestIndexAttr(idx))
+		customID, err := buildOpenAIBatchCustomID(req.Metadata, idx)
+		if err != nil {
+			return BatchJobID{}, fmt.Errorf("openai_batch: prepare request metadata failed: %w", err)
+		}
+		// Correlation must stay in custom_id and never rely on LLM output text.
+		line := openAIBatchRequestLine{
+			CustomID: customID,
+			Method:   http.MethodPost,
+			URL:      openAIBatchChatURL,
+			Body:     newOpenAIChatRequestBody(b.api.config.Model, req, len(req.ResponseSchema) > 0, false),
+		}
+		if err := encoder.Encode(line); err != nil {
+			return BatchJobID{}, fmt.Errorf("openai_batch: encode request index=%d: %w", idx, err)
+		}
+	}
+
+	fileID, err := b.uploadInputFile(ctx, input.Bytes())
+	if err != nil {
+		return BatchJobID{}, fmt.Errorf("openai_batch: upload input file failed: %w", err)
+	}
+	batchID, err := b.createBatch(ctx, fileID)
+	if err != nil {
+		return BatchJobID{}, fmt.Errorf("openai_batch: create batch input_file_id=%s: %w", fileID, err)
+	}
+
+	b.logger.InfoContext(ctx, "OpenAI batch submitted",
+		slog.String("batch_id", batchID),
+		slog.String("input_file_id", fileID),
+		slog.Int("request_count", len(reqs)),
+	)
+	return BatchJobID{ID: batchID, Provider: "openai_compatible"}, nil
+}
+
+// GetBatchStatus は status と request_counts から共通 BatchStatus を返す。
+func (b *openAIBatchClient) GetBatchStatus(ctx context.Context, id BatchJobID) (BatchStatus, error) {
+	batch, err := b.getBatch(ctx, id.ID)
+	if err != nil {
+		return BatchStatus{}, fmt.Errorf("openai_batch: get batch status batch_id=%s: %w", id.ID, err)
+	}
+	return batch.toStatus(), nil
+}
+
+// GetBatchResults は出力ファイルとエラーファイルの JSONL を共通 Response へ変換して返す。
+func (b *openAIBatchClient) GetBatchResults(ctx context.Context, id BatchJobID) ([]Response, error) {
+	batch, err := b.getBatch(ctx, id.ID)
+	if err != nil {
+		return nil, fmt.Errorf("openai_batch: get batch results batch_id=%s: %w", id.ID, err)
+	}
+
+	results := make([]Response, 0, batch.RequestCounts.Total)
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if strings.TrimSpace(fileID) == "" {
+			continue
+		}
+		content, err := b.downloadFile(ctx, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("openai_batch: download result file batch_id=%s file_id=%s: %w", id.ID, fileID, err)
+		}
+		parsed, err := parseOpenAIBatchResults(content)
+		if err != nil {
+			return nil, fmt.Errorf("openai_batch: parse result file batch_id=%s file_id=%s: %w", id.ID, fileID, err)
+		}
+		results = append(results, parsed...)
+	}
+
+	b.logger.DebugContext(ctx, "EXIT GetBatchResults",
+		slog.String("batch_id", id.ID),
+		slog.Int("results", len(results)),
+	)
+	return results, nil
+}
+
+// uploadInputFile は JSONL を purpose=batch の multipart でアップロードし、file id を返す。
+func (b *openAIBatchClient) uploadInputFile(ctx context.Context, jsonl []byte) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("purpose", openAIBatchFilePurpose); err != nil {
+		return "", fmt.Errorf("write purpose field: %w", err)
+	}
+	part, err := writer.CreateFormFile("file", "batch.jsonl")
+	if err != nil {
+		return "", fmt.Errorf("create file part: %w", err)
+	}
+	if _, err := part.Write(jsonl); err != nil {
+		return "", fmt.Errorf("write file part: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("close multipart body: %w", err)
+	}
+
+	respBody, err := b.do(ctx, http.MethodPost, openAIBatchFilesEndpoint, writer.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	var file struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &file); err != nil {
+		return "", fmt.Errorf("unmarshal file response: %w", err)
+	}
+	if file.ID == "" {
+		return "", fmt.Errorf("no file id in response: %s", string(respBody))
+	}
+	return file.ID, nil
+}
+
+func (b *openAIBatchClient) createBatch(ctx context.Context, inputFileID string) (string, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"input_file_id":     inputFileID,
+		"endpoint":          openAIBatchChatURL,
+		"completion_window": openAIBatchCompletionWindow,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal create batch request: %w", err)
+	}
+	respBody, err := b.do(ctx, http.MethodPost, openAIBatchesEndpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	var batch openAIBatchResource
+	if err := json.Unmarshal(respBody, &batch); err != nil {
+		return "", fmt.Errorf("unmarshal create batch response: %w", err)
+	}
+	if batch.ID == "" {
+		return "", fmt.Errorf("no batch id in response: %s", string(respBody))
+	}
+	return batch.ID, nil
+}
+
+func (b *openAIBatchClient) getBatch(ctx context.Context, batchID string) (openAIBatchResource, error) {
+	respBody, err := b.do(ctx, http.MethodGet, openAIBatchesEndpoint+"/"+batchID, "", nil)
+	if err != nil {
+		return openAIBatchResource{}, err
+	}
+	var batch openAIBatchResource
+	if err := json.Unmarshal(respBody, &batch); err != nil {
+		return openAIBatchResource{}, fmt.Errorf("unmarshal batch: %w", err)
+	}
+	return batch, nil
+}
+
+func (b *openAIBatchClient) downloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	return b.do(ctx, http.MethodGet, openAIBatchFilesEndpoint+"/"+fileID+"/content", "", nil)
+}
+
+func (b *openAIBatchClient) do(ctx context.Context, method string, path string, contentType string, body io.Reader) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, b.api.resolveURL(path), body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s request creation failed: %w", method, path, err)
+	}
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	b.api.setAuthHeader(httpReq)
+
+	httpResp, err := b.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s request failed: %w", method, path, err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s response read failed: %w", method, path, err)
+	}
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s %s error %d: %s", method, path, httpResp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
+
+// openAIBatchResource は GET /batches/{id} のレスポンス。
+type openAIBatchResource struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	OutputFileID  string `json:"output_file_id"`
+	ErrorFileID   string `json:"error_file_id"`
+	RequestCounts struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed"`
+	} `json:"request_counts"`
+}
+
+func (r openAIBatchResource) toStatus() BatchStatus {
+	counts := r.RequestCounts
+	status := BatchStatus{ID: r.ID, State: normalizeOpenAIBatchState(r.Status, counts.Completed, counts.Failed)}
+	if counts.Total > 0 {
+		progress := float32(counts.Completed+counts.Failed) / float32(counts.Total)
+		if progress > 1 {
+			progress = 1
+		}
+		status.Progress = progress
+	}
+	if status.State == BatchStateCompleted || status.State == BatchStatePartialFailed {
+		status.Progress = 1
+	}
+	return status
+}
+
+// normalizeOpenAIBatchState は OpenAI の status を共通 BatchState に変換する。
+// expired でも完了済みリクエストの結果は出力ファイルに残るため partial_failed とする。
+func normalizeOpenAIBatchState(status string, completed int, failed int) BatchState {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "validating":
+		return BatchStateQueued
+	case "in_progress", "finalizing":
+		return BatchStateRunning
+	case "completed":
+		switch {
+		case failed == 0:
+			return BatchStateCompleted
+		case completed == 0:
+			return BatchStateFailed
+		default:
+			return BatchStatePartialFailed
+		}
+	case "expired":
+		if completed > 0 {
+			return BatchStatePartialFailed
+		}
+		return BatchStateFailed
+	case "cancelling", "cancelled":
+		return BatchStateCancelled
+	case "failed":
+		return BatchStateFailed
+	default:
+		return BatchStateRunning
+	}
+}
+
+// buildOpenAIBatchCustomID は queue_request_seq と queue_job_id を "<seq>:<queue_job_id>" 形式の custom_id に載せる。
+// OpenAI の入力行には任意のメタデータを持たせられないため、相関情報は custom_id だけで往復させる。
+func buildOpenAIBatchCustomID(metadata map[string]interface{}, requestIndex int) (string, error) {
+	queueJobID, ok := readBatchMetadataString(metadata, BatchMetadataQueueJobIDKey)
+	if !ok {
+		return "", fmt.Errorf("%s is required for batch request index=%d", BatchMetadataQueueJobIDKey, requestIndex)
+	}
+	seq := requestIndex
+	if raw, ok := readBatchMetadataString(metadata, BatchMetadataQueueRequestSeqKey); ok {
+		if parsed, err := strconv.Atoi(raw); err == nil {
+			seq = parsed
+		}
+	}
+	return fmt.Sprintf("%d:%s", seq, queueJobID), nil
+}
+
+// openAIBatchMetadataFromCustomID は custom_id から相関メタデータを復元する。
+func openAIBatchMetadataFromCustomID(customID string) map[string]interface{} {
+	trimmed := strings.TrimSpace(customID)
+	if trimmed == "" {
+		return nil
+	}
+	if rawSeq, queueJobID, found := strings.Cut(trimmed, ":"); found && queueJobID != "" {
+		if seq, err := strconv.Atoi(rawSeq); err == nil {
+			return map[string]interface{}{
+				BatchMetadataQueueJobIDKey:      queueJobID,
+				BatchMetadataQueueRequestSeqKey: seq,
+			}
+		}
+	}
+	return map[string]interface{}{BatchMetadataQueueJobIDKey: trimmed}
+}
+
+// parseOpenAIBatchResults は出力/エラーファイルの JSONL を行ごとに Response へ変換する。
+func parseOpenAIBatchResults(content []byte) ([]Response, error) {
+	var results []Response
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var raw openAIBatchResultLine
+		if err := json.Unmarshal(line, &raw); err != nil {
+			return nil, fmt.Errorf("unmarshal line=%d: %w", lineNo, err)
+		}
+		results = append(results, raw.toResponse())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read result lines: %w", err)
+	}
+	return results, nil
+}
+
+// openAIBatchResultLine は出力/エラーファイルの 1 行。
+type openAIBatchResultLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (l openAIBatchResultLine) toResponse() Response {
+	metadata := openAIBatchMetadataFromCustomID(l.CustomID)
+	if metadata == nil {
+		return Response{Success: false, Error: "openai_batch: batch result missing custom_id"}
+	}
+	if l.Error != nil {
+		return Response{Success: false, Error: firstNonEmpty(l.Error.Message, l.Error.Code, "openai_batch: batch request failed"), Metadata: metadata}
+	}
+	response := l.Response
+	if response == nil {
+		return Response{Success: false, Error: "openai_batch: batch result has no response", Metadata: metadata}
+	}
+
+	var body struct {
+		Choices []struct {
+			Message struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(response.Body, &body); err != nil {
+		return Response{Success: false, Error: fmt.Sprintf("openai_batch: decode response body: %v", err), Metadata: metadata}
+	}
+	if response.StatusCode != http.StatusOK {
+		message := fmt.Sprintf("openai_batch: request failed with status %d", response.StatusCode)
+		if body.Error != nil && strings.TrimSpace(body.Error.Message) != "" {
+			message = body.Error.Message
+		}
+		return Response{
+			Success:   false,
+			Error:     message,
+			Metadata:  metadata,
+			Transient: IsRetryableStatusCode(response.StatusCode),
+		}
+	}
+	if len(body.Choices) == 0 {
+		return Response{Success: false, Error: "openai_batch: empty choices in response", Metadata: metadata}
+	}
+	content, err := parseLMStudioMessageContent(body.Choices[0].Message.Content)
+	if err != nil {
+		return Response{Success: false, Error: fmt.Sprintf("openai_batch: decode message content: %v", err), Metadata: metadata}
+	}
+	return Response{
+		Content:  content,
+		Success:  true,
+		Metadata: metadata,
+		Usage: TokenUsage{
+			PromptTokens:     body.Usage.PromptTokens,
+			CompletionTokens: body.Usage.CompletionTokens,
+			TotalTokens:      body.Usage.TotalTokens,
+		},
+	}
+}
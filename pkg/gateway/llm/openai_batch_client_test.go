@@ -0,0 +1,217 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeOpenAIBatchServer は /v1/files と /v1/batches を最小限に実装し、
+// 入力 JSONL の各行に custom_id をそのまま返す応答を作る。
+type fakeOpenAIBatchServer struct {
+	t      *testing.T
+	mu     sync.Mutex
+	files  map[string][]byte
+	lines  []openAIBatchRequestLine
+	status string
+}
+
+func newFakeOpenAIBatchServer(t *testing.T) (*fakeOpenAIBatchServer, *httptest.Server) {
+	fake := &fakeOpenAIBatchServer{t: t, files: map[string][]byte{}, status: "in_progress"}
+	srv := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(srv.Close)
+	return fake, srv
+}
+
+func (f *fakeOpenAIBatchServer) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+		f.t.Errorf("unexpected Authorization header: %q", got)
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/files":
+		if r.FormValue("purpose") != "batch" {
+			f.t.Errorf("unexpected purpose: %q", r.FormValue("purpose"))
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			f.t.Errorf("missing file part: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line openAIBatchRequestLine
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				f.t.Errorf("invalid input line: %v", err)
+			}
+			f.lines = append(f.lines, line)
+		}
+		writeFakeBatchJSON(w, map[string]any{"id": "file-input", "purpose": "batch"})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/batches":
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["input_file_id"] != "file-input" || body["endpoint"] != "/v1/chat/completions" || body["completion_window"] != "24h" {
+			f.t.Errorf("unexpected create batch body: %+v", body)
+		}
+		writeFakeBatchJSON(w, map[string]any{"id": "batch_1", "status": "validating"})
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/batches/batch_1":
+		resource := map[string]any{
+			"id":             "batch_1",
+			"status":         f.status,
+			"request_counts": map[string]int{"total": len(f.lines), "completed": len(f.lines) - 1, "failed": 1},
+		}
+		if f.status == "completed" {
+			resource["output_file_id"] = "file-output"
+			resource["error_file_id"] = "file-error"
+			f.files["file-output"], f.files["file-error"] = f.buildResultFiles()
+		}
+		writeFakeBatchJSON(w, resource)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/files/") && strings.HasSuffix(r.URL.Path, "/content"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/files/"), "/content")
+		_, _ = w.Write(f.files[id])
+	default:
+		f.t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// buildResultFiles は最後の行だけを 429 で失敗させ、出力は入力と逆順に並べる。
+func (f *fakeOpenAIBatchServer) buildResultFiles() ([]byte, []byte) {
+	var output, failures strings.Builder
+	for i := len(f.lines) - 1; i >= 0; i-- {
+		line := f.lines[i]
+		if i == len(f.lines)-1 {
+			fmt.Fprintf(&failures, `{"id":"r%d","custom_id":%q,"response":{"status_code":429,"body":{"error":{"message":"rate limited"}}},"error":null}`+"\n", i, line.CustomID)
+			continue
+		}
+		fmt.Fprintf(&output, `{"id":"r%d","custom_id":%q,"response":{"status_code":200,"body":{"choices":[{"message":{"content":"訳:%s"}}],"usage":{"prompt_tokens":10,"completion_tokens":4,"total_tokens":14}}},"error":null}`+"\n",
+			i, line.CustomID, line.Body.Messages[len(line.Body.Messages)-1].Content)
+	}
+	return []byte(output.String()), []byte(failures.String())
+}
+
+func writeFakeBatchJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestOpenAIBatchClient_RoundTripCorrelatesByQueueMetadata(t *testing.T) {
+	fake, srv := newFakeOpenAIBatchServer(t)
+	client, err := NewOpenAIBatchClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL + "/v1",
+		APIKey:   "secret",
+		Model:    "gpt-4o-mini",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIBatchClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	reqs := []Request{
+		{SystemPrompt: "sys", UserPrompt: "Whiterun", Metadata: map[string]interface{}{BatchMetadataQueueJobIDKey: "job:a", BatchMetadataQueueRequestSeqKey: 0}},
+		{UserPrompt: "Riften", ResponseSchema: map[string]interface{}{"type": "object"}, Metadata: map[string]interface{}{BatchMetadataQueueJobIDKey: "job:b", BatchMetadataQueueRequestSeqKey: 1}},
+		{UserPrompt: "Solitude", Metadata: map[string]interface{}{BatchMetadataQueueJobIDKey: "job:c", BatchMetadataQueueRequestSeqKey: 2}},
+	}
+	id, err := client.SubmitBatch(ctx, reqs)
+	if err != nil {
+		t.Fatalf("SubmitBatch failed: %v", err)
+	}
+	if id.ID != "batch_1" || id.Provider != "openai_compatible" {
+		t.Fatalf("unexpected batch id: %+v", id)
+	}
+	if len(fake.lines) != 3 || fake.lines[0].CustomID != "0:job:a" || fake.lines[0].URL != "/v1/chat/completions" || fake.lines[0].Body.Model != "gpt-4o-mini" {
+		t.Fatalf("unexpected input lines: %+v", fake.lines)
+	}
+	if fake.lines[0].Body.ResponseFormat != nil || fake.lines[1].Body.ResponseFormat == nil {
+		t.Fatalf("response_format must follow ResponseSchema: %+v", fake.lines)
+	}
+
+	status, err := client.GetBatchStatus(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBatchStatus failed: %v", err)
+	}
+	if status.State != BatchStateRunning {
+		t.Fatalf("expected running, got %+v", status)
+	}
+
+	fake.mu.Lock()
+	fake.status = "completed"
+	fake.mu.Unlock()
+	status, err = client.GetBatchStatus(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBatchStatus failed: %v", err)
+	}
+	if status.State != BatchStatePartialFailed || status.Progress != 1 {
+		t.Fatalf("expected partial_failed, got %+v", status)
+	}
+
+	results, err := client.GetBatchResults(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBatchResults failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	byJob := make(map[string]Response, len(results))
+	for _, res := range results {
+		jobID, _ := readBatchMetadataString(res.Metadata, BatchMetadataQueueJobIDKey)
+		byJob[jobID] = res
+	}
+	if got := byJob["job:a"]; !got.Success || got.Content != "訳:Whiterun" || got.Usage.TotalTokens != 14 || got.Metadata[BatchMetadataQueueRequestSeqKey] != 0 {
+		t.Fatalf("unexpected result for job:a: %+v", got)
+	}
+	if got := byJob["job:b"]; !got.Success || got.Content != "訳:Riften" || got.Metadata[BatchMetadataQueueRequestSeqKey] != 1 {
+		t.Fatalf("unexpected result for job:b: %+v", got)
+	}
+	if got := byJob["job:c"]; got.Success || got.Error != "rate limited" || !got.Transient {
+		t.Fatalf("expected transient failure for job:c: %+v", got)
+	}
+}
+
+func TestOpenAIBatchClient_RequiresQueueJobID(t *testing.T) {
+	_, srv := newFakeOpenAIBatchServer(t)
+	client, err := NewOpenAIBatchClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Endpoint: srv.URL + "/v1",
+		APIKey:   "secret",
+		Model:    "gpt-4o-mini",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIBatchClient failed: %v", err)
+	}
+	if _, err := client.SubmitBatch(context.Background(), []Request{{UserPrompt: "x"}}); err == nil || !strings.Contains(err.Error(), BatchMetadataQueueJobIDKey) {
+		t.Fatalf("expected missing queue_job_id error, got %v", err)
+	}
+}
+
+func TestNormalizeOpenAIBatchState(t *testing.T) {
+	tests := []struct {
+		status    string
+		completed int
+		failed    int
+		want      BatchState
+	}{
+		{status: "validating", want: BatchStateQueued},
+		{status: "finalizing", completed: 3, want: BatchStateRunning},
+		{status: "completed", completed: 3, want: BatchStateCompleted},
+		{status: "completed", failed: 3, want: BatchStateFailed},
+		{status: "expired", completed: 1, failed: 2, want: BatchStatePartialFailed},
+		{status: "expired", want: BatchStateFailed},
+		{status: "cancelling", want: BatchStateCancelled},
+	}
+	for _, tc := range tests {
+		if got := normalizeOpenAIBatchState(tc.status, tc.completed, tc.failed); got != tc.want {
+			t.Errorf("normalizeOpenAIBatchState(%q, %d, %d) = %q, want %q", tc.status, tc.completed, tc.failed, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+const (
+	openAICompatibleDefaultTimeout        = 120 * time.Second
+	openAICompatibleDefaultAuthHeader     = "Authorization"
+	openAICompatibleDefaultAuthScheme     = "Bearer"
+	openAICompatibleDefaultModelsEndpoint = "/models"
+	openAICompatibleChatEndpoint          = "/chat/completions"
+	openAICompatibleEmbeddingsEndpoint    = "/embeddings"
+)
+
+// openAICompatibleClient talks to any server exposing the OpenAI chat completions API
+// (vLLM, llama.cpp server, Ollama /v1, OpenRouter, ...).
+type openAICompatibleClient struct {
+	config         LLMConfig
+	baseURL        string
+	authHeader     string
+	authScheme     string
+	modelsEndpoint string
+	httpClient     *http.Client
+	logger         *slog.Logger
+	retryCfg       RetryConfig
+}
+
+// NewOpenAICompatibleClient returns a client for a generic OpenAI-compatible endpoint.
+// Endpoint is the API base URL including the version prefix (e.g. http://localhost:8000/v1).
+func NewOpenAICompatibleClient(logger *slog.Logger, config LLMConfig) LLMClient {
+	baseURL := strings.TrimRight(strings.TrimSpace(config.Endpoint), "/")
+	authHeader := parameterString(config.Parameters, OpenAICompatibleAuthHeaderParam)
+	if authHeader == "" {
+		authHeader = openAICompatibleDefaultAuthHeader
+	}
+	authScheme, hasScheme := lookupParameterString(config.Parameters, OpenAICompatibleAuthSchemeParam)
+	if !hasScheme && strings.EqualFold(authHeader, openAICompatibleDefaultAuthHeader) {
+		authScheme = openAICompatibleDefaultAuthScheme
+	}
+	if strings.EqualFold(authScheme, "none") {
+		authScheme = ""
+	}
+	modelsEndpoint := parameterString(config.Parameters, OpenAICompatibleModelsEndpointParam)
+	if modelsEndpoint == "" {
+		modelsEndpoint = openAICompatibleDefaultModelsEndpoint
+	}
+	config.Endpoint = baseURL
+	return &openAICompatibleClient{
+		config:         config,
+		baseURL:        baseURL,
+		authHeader:     authHeader,
+		authScheme:     authScheme,
+		modelsEndpoint: modelsEndpoint,
+		httpClient:     &http.Client{Timeout: openAICompatibleDefaultTimeout},
+		logger:         logger.With("component", "openai_compatible_client", "endpoint", baseURL),
+		retryCfg:       DefaultRetryConfig(),
+	}
+}
+
+func (c *openAICompatibleClient) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.resolveURL(c.modelsEndpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: list models request creation failed: %w", err)
+	}
+	c.setAuthHeader(httpReq)
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: list models request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: list models read failed: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai_compatible: list models error %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var raw struct {
+		Data []struct {
+			ID            string `json:"id"`
+			Name          string `json:"name"`
+			ContextLength int    `json:"context_length"`
+			MaxModelLen   int    `json:"max_model_len"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("openai_compatible: list models unmarshal failed: %w", err)
+	}
+
+	models := make([]ModelInfo, 0, len(raw.Data))
+	for _, m := range raw.Data {
+		if strings.TrimSpace(m.ID) == "" {
+			continue
+		}
+		displayName := m.Name
+		if displayName == "" {
+			displayName = m.ID
+		}
+		// OpenRouter reports context_length, vLLM reports max_model_len.
+		maxContext := m.ContextLength
+		if maxContext == 0 {
+			maxContext = m.MaxModelLen
+		}
+		models = append(models, ModelInfo{
+			ID:               m.ID,
+			DisplayName:      displayName,
+			MaxContextLength: maxContext,
+			// Batch goes through the OpenAI /files + /batches protocol, which only some gateways implement.
+			SupportsBatch: openAICompatibleBatchAPIEnabled(c.config.Parameters),
+		})
+	}
+	return models, nil
+}
+
+// Complete performs plain text completion.
+func (c *openAICompatibleClient) Complete(ctx context.Context, req Request) (Response, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	if c.config.Model == "" {
+		return Response{}, ErrModelRequired
+	}
+	logFinalPrompt(ctx, c.logger, "openai_compatible", requestModeAttr(false), req)
+
+	var resp Response
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		resp, innerErr = c.doChatCompletion(ctx, req, false)
+		return innerErr
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: complete request failed: %w", err)
+	}
+	resp.Metadata = req.Metadata
+	return resp, nil
+}
+
+// GenerateStructured performs completion constrained by response_format json_schema.
+func (c *openAICompatibleClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	if c.config.Model == "" {
+		return Response{}, ErrModelRequired
+	}
+	if len(req.ResponseSchema) == 0 {
+		return Response{}, fmt.Errorf("openai_compatible: response schema is required for structured generation")
+	}
+	logFinalPrompt(ctx, c.logger, "openai_compatible", requestModeAttr(true), req)
+
+	var resp Response
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		resp, innerErr = c.doChatCompletion(ctx, req, true)
+		return innerErr
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: structured request failed: %w", err)
+	}
+	resp.Metadata = req.Metadata
+	return resp, nil
+}
+
+// StreamComplete streams chat completion deltas over SSE.
+func (c *openAICompatibleClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	if c.config.Model == "" {
+		return nil, ErrModelRequired
+	}
+	logFinalPrompt(ctx, c.logger, "openai_compatible", requestModeAttr(false), req)
+
+	body, err := openSSEStream(ctx, streamingHTTPClient(c.httpClient), c.retryCfg, func() (*http.Request, error) {
+		return c.newChatRequest(ctx, req, false, true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: stream request failed: %w", err)
+	}
+	return newSSEStreamResponse(ctx, body, decodeOpenAIStreamData, req.Metadata), nil
+}
+
+// GetEmbedding returns the embedding vector from the /embeddings endpoint.
+func (c *openAICompatibleClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vector []float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vector, innerErr = doOpenAIEmbedding(ctx, c.httpClient, c.resolveURL(openAICompatibleEmbeddingsEndpoint), model, text, c.setAuthHeader)
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: embedding model=%s: %w", model, err)
+	}
+	return vector, nil
+}
+
+// GetEmbeddings embeds texts in one /embeddings request.
+func (c *openAICompatibleClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vectors [][]float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vectors, innerErr = doOpenAIEmbeddings(ctx, c.httpClient, c.resolveURL(openAICompatibleEmbeddingsEndpoint), model, texts, c.setAuthHeader)
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: embeddings model=%s count=%d: %w", model, len(texts), err)
+	}
+	return vectors, nil
+}
+
+func (c *openAICompatibleClient) HealthCheck(ctx context.Context) error {
+	_, err := c.ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("openai_compatible: health check failed: %w", err)
+	}
+	return nil
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponseFormat struct {
+	Type       string                 `json:"type"`
+	JSONSchema map[string]interface{} `json:"json_schema,omitempty"`
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// openAIChatRequestBody is the /chat/completions payload, shared with Batch API request lines.
+type openAIChatRequestBody struct {
+	Model          string                `json:"model"`
+	Messages       []openAIChatMessage   `json:"messages"`
+	Temperature    float32               `json:"temperature,omitempty"`
+	StopSequences  []string              `json:"stop,omitempty"`
+	Stream         bool                  `json:"stream"`
+	StreamOptions  *openAIStreamOptions  `json:"stream_options,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+func newOpenAIChatRequestBody(model string, req Request, structured bool, stream bool) openAIChatRequestBody {
+	msgs := make([]openAIChatMessage, 0, 2)
+	if req.SystemPrompt != "" {
+		msgs = append(msgs, openAIChatMessage{Role: "system", Content: req.SystemPrompt})
+	}
+	msgs = append(msgs, openAIChatMessage{Role: "user", Content: req.UserPrompt})
+
+	body := openAIChatRequestBody{
+		Model:         model,
+		Messages:      msgs,
+		Temperature:   req.Temperature,
+		StopSequences: req.StopSequences,
+		Stream:        stream,
+	}
+	if stream {
+		body.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+	if structured {
+		body.ResponseFormat = &openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: map[string]interface{}{
+				"name":   "structured_output",
+				"strict": true,
+				"schema": req.ResponseSchema,
+			},
+		}
+	}
+	return body
+}
+
+func (c *openAICompatibleClient) newChatRequest(ctx context.Context, req Request, structured bool, stream bool) (*http.Request, error) {
+	body := newOpenAIChatRequestBody(c.config.Model, req, structured, stream)
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: marshal request failed: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.resolveURL(openAICompatibleChatEndpoint), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("openai_compatible: request creation failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	c.setAuthHeader(httpReq)
+	return httpReq, nil
+}
+
+func (c *openAICompatibleClient) doChatCompletion(ctx context.Context, req Request, structured bool) (Response, error) {
+	httpReq, err := c.newChatRequest(ctx, req, structured, false)
+	if err != nil {
+		return Response{}, err
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: response read failed: %w", err)
+	}
+
+	if IsRetryableStatusCode(httpResp.StatusCode) {
+		return Response{}, newRetryableError(httpResp, respBody)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return Response{}, fmt.Errorf("openai_compatible: chat completions error %d: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	var raw struct {
+		Choices []struct {
+			Message struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(respBody, &raw); err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: response unmarshal failed: %w", err)
+	}
+	if len(raw.Choices) == 0 {
+		return Response{}, fmt.Errorf("openai_compatible: empty choices in response")
+	}
+	content, err := parseLMStudioMessageContent(raw.Choices[0].Message.Content)
+	if err != nil {
+		return Response{}, fmt.Errorf("openai_compatible: response content decode failed: %w", err)
+	}
+	return Response{
+		Content: content,
+		Success: true,
+		Usage: TokenUsage{
+			PromptTokens:     raw.Usage.PromptTokens,
+			CompletionTokens: raw.Usage.CompletionTokens,
+			TotalTokens:      raw.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+// resolveURL joins a path onto the base URL; absolute URLs are used as-is.
+func (c *openAICompatibleClient) resolveURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return c.baseURL + "/" + strings.TrimLeft(path, "/")
+}
+
+func (c *openAICompatibleClient) setAuthHeader(req *http.Request) {
+	if c.config.APIKey == "" {
+		return
+	}
+	value := c.config.APIKey
+	if c.authScheme != "" {
+		value = c.authScheme + " " + c.config.APIKey
+	}
+	req.Header.Set(c.authHeader, value)
+}
+
+func parameterString(params map[string]interface{}, key string) string {
+	value, _ := lookupParameterString(params, key)
+	return value
+}
+
+func lookupParameterString(params map[string]interface{}, key string) (string, bool) {
+	raw, ok := params[key]
+	if !ok {
+		return "", false
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestOpenAICompatibleClient_ListModels_DefaultBearerAuth(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Fatalf("unexpected Authorization header: %q", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"id": "meta-llama/Llama-3-8B", "max_model_len": 8192},
+				{"id": "openai/gpt-4o", "name": "GPT-4o", "context_length": 128000},
+				{"id": ""},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(os.Stdout, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL + "/v1/",
+		APIKey:   "secret",
+		Model:    "m1",
+	})
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("expected 2 models, got %d", len(models))
+	}
+	if models[0].DisplayName != "meta-llama/Llama-3-8B" || models[0].MaxContextLength != 8192 {
+		t.Fatalf("unexpected vLLM model: %+v", models[0])
+	}
+	if models[1].DisplayName != "GPT-4o" || models[1].MaxContextLength != 128000 || models[1].SupportsBatch {
+		t.Fatalf("unexpected OpenRouter model: %+v", models[1])
+	}
+
+	batchClient := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(os.Stdout, nil)), LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL + "/v1/",
+		APIKey:     "secret",
+		Model:      "m1",
+		Parameters: map[string]interface{}{OpenAICompatibleBatchAPIParam: "true"},
+	})
+	batchModels, err := batchClient.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+	if !batchModels[0].SupportsBatch {
+		t.Fatalf("batch_api must mark models as batch capable: %+v", batchModels[0])
+	}
+}
+
+func TestOpenAICompatibleClient_CustomAuthHeaderAndModelsEndpoint(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Api-Key"); got != "secret" {
+			t.Fatalf("expected raw key in custom header, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Fatalf("expected no Authorization header, got %q", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"id": "qwen2.5"}}})
+	}))
+	defer srv.Close()
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(os.Stdout, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL + "/v1",
+		APIKey:   "secret",
+		Model:    "qwen2.5",
+		Parameters: map[string]interface{}{
+			OpenAICompatibleAuthHeaderParam:     "X-Api-Key",
+			OpenAICompatibleModelsEndpointParam: srv.URL + "/api/tags",
+		},
+	})
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+}
+
+func TestOpenAICompatibleClient_GenerateStructured_SendsJSONSchema(t *testing.T) {
+	t.Parallel()
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": `{"ok":true}`}}},
+			"usage":   map[string]any{"prompt_tokens": 3, "completion_tokens": 2, "total_tokens": 5},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(os.Stdout, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL + "/v1",
+		Model:    "m1",
+	})
+	resp, err := client.GenerateStructured(context.Background(), Request{
+		SystemPrompt:   "sys",
+		UserPrompt:     "test",
+		ResponseSchema: map[string]interface{}{"type": "object"},
+		Metadata:       map[string]interface{}{"id": "r1"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateStructured failed: %v", err)
+	}
+	if resp.Content != `{"ok":true}` || resp.Usage.TotalTokens != 5 || resp.Metadata["id"] != "r1" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	rf, ok := payload["response_format"].(map[string]any)
+	if !ok || rf["type"] != "json_schema" {
+		t.Fatalf("expected json_schema response_format, got %v", payload["response_format"])
+	}
+	js, _ := rf["json_schema"].(map[string]any)
+	if js["strict"] != true {
+		t.Fatalf("expected strict=true, got %v", js["strict"])
+	}
+	if schema, _ := js["schema"].(map[string]any); schema["type"] != "object" {
+		t.Fatalf("expected schema to be forwarded, got %v", js["schema"])
+	}
+	if msgs, _ := payload["messages"].([]any); len(msgs) != 2 {
+		t.Fatalf("expected system+user messages, got %v", payload["messages"])
+	}
+
+	if _, err := client.GenerateStructured(context.Background(), Request{UserPrompt: "test"}); err == nil {
+		t.Fatalf("expected error when response schema is missing")
+	}
+}
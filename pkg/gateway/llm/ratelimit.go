@@ -0,0 +1,412 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// レート制限の設定キー（LLMConfig.Parameters）。0 または未設定は無制限。
+const (
+	RateLimitRPMParam = "rate_limit_rpm"
+	RateLimitTPMParam = "rate_limit_tpm"
+	RateLimitRPDParam = "rate_limit_rpd"
+	RateLimitTPDParam = "rate_limit_tpd"
+)
+
+// RateLimitParams は設定ストアから LLMConfig.Parameters へ引き継ぐレート制限キーの一覧。
+var RateLimitParams = []string{RateLimitRPMParam, RateLimitTPMParam, RateLimitRPDParam, RateLimitTPDParam}
+
+// RateLimit はプロバイダー/モデル単位の送信上限を表す。
+type RateLimit struct {
+	RequestsPerMinute int
+	TokensPerMinute   int
+	RequestsPerDay    int
+	TokensPerDay      int
+}
+
+// Enabled はいずれかの上限が設定されているかを返す。
+func (l RateLimit) Enabled() bool {
+	return l.RequestsPerMinute > 0 || l.TokensPerMinute > 0 || l.RequestsPerDay > 0 || l.TokensPerDay > 0
+}
+
+// RateLimitFromConfig は LLMConfig.Parameters からレート制限を読み取る。
+func RateLimitFromConfig(config LLMConfig) RateLimit {
+	return RateLimit{
+		RequestsPerMinute: parameterInt(config.Parameters, RateLimitRPMParam),
+		TokensPerMinute:   parameterInt(config.Parameters, RateLimitTPMParam),
+		RequestsPerDay:    parameterInt(config.Parameters, RateLimitRPDParam),
+		TokensPerDay:      parameterInt(config.Parameters, RateLimitTPDParam),
+	}
+}
+
+// RateLimiter はリクエスト数とトークン数のトークンバケットで送信を律速する。
+// 同じプロバイダー/モデルを使うクライアント間で共有される。
+type RateLimiter struct {
+	mu    sync.Mutex
+	limit RateLimit
+	now   func() time.Time
+
+	requestBucket float64
+	tokenBucket   float64
+	refilledAt    time.Time
+	pausedUntil   time.Time
+
+	day         string
+	dayRequests int
+	dayTokens   int
+}
+
+// NewRateLimiter は満タンのバケットでリミッターを作成する。
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	return newRateLimiterWithClock(limit, time.Now)
+}
+
+func newRateLimiterWithClock(limit RateLimit, now func() time.Time) *RateLimiter {
+	return &RateLimiter{
+		limit:         limit,
+		now:           now,
+		requestBucket: float64(limit.RequestsPerMinute),
+		tokenBucket:   float64(limit.TokensPerMinute),
+		refilledAt:    now(),
+	}
+}
+
+// SetLimit は上限を差し替える。バケット残量は新しい容量に切り詰める。
+func (l *RateLimiter) SetLimit(limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit == limit {
+		return
+	}
+	l.limit = limit
+	l.requestBucket = math.Min(l.requestBucket, float64(limit.RequestsPerMinute))
+	l.tokenBucket = math.Min(l.tokenBucket, float64(limit.TokensPerMinute))
+}
+
+// Wait は推定トークン数ぶんの枠が確保できるまで待機する。
+// 日次上限に達した場合は待たずに ErrDailyQuotaExceeded を返す。
+func (l *RateLimiter) Wait(ctx context.Context, estimatedTokens int) error {
+	for {
+		l.mu.Lock()
+		wait, err := l.reserve(estimatedTokens)
+		l.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("rate limit wait cancelled: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Record は推定値と実際の TokenUsage の差分をバケットと日次カウンターに反映する。
+func (l *RateLimiter) Record(estimatedTokens int, actualTokens int) {
+	if actualTokens <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	diff := actualTokens - estimatedTokens
+	if l.limit.TokensPerMinute > 0 {
+		// 実績が推定を上回った分は借りとして負の残量を許容する
+		l.tokenBucket = math.Min(l.tokenBucket-float64(diff), float64(l.limit.TokensPerMinute))
+	}
+	l.dayTokens += diff
+}
+
+// PauseFor は 429 の Retry-After に従い、共有する全クライアントの送信を一時停止する。
+func (l *RateLimiter) PauseFor(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// reserve は枠を確保できれば 0 を、できなければ次に試すまでの待機時間を返す。
+func (l *RateLimiter) reserve(estimatedTokens int) (time.Duration, error) {
+	now := l.now()
+	l.refill(now)
+	l.rollDay(now)
+
+	if l.limit.RequestsPerDay > 0 && l.dayRequests >= l.limit.RequestsPerDay {
+		return 0, fmt.Errorf("%w: requests=%d/%d", ErrDailyQuotaExceeded, l.dayRequests, l.limit.RequestsPerDay)
+	}
+	if l.limit.TokensPerDay > 0 && l.dayTokens+estimatedTokens > l.limit.TokensPerDay {
+		return 0, fmt.Errorf("%w: tokens=%d+%d/%d", ErrDailyQuotaExceeded, l.dayTokens, estimatedTokens, l.limit.TokensPerDay)
+	}
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now), nil
+	}
+
+	var wait time.Duration
+	if rpm := l.limit.RequestsPerMinute; rpm > 0 && l.requestBucket < 1 {
+		wait = maxDuration(wait, bucketWait(1-l.requestBucket, rpm))
+	}
+	// 1 リクエストが TPM を超える場合でも永久に待たないよう容量で頭打ちにする
+	need := float64(estimatedTokens)
+	if tpm := l.limit.TokensPerMinute; tpm > 0 {
+		need = math.Min(need, float64(tpm))
+		if l.tokenBucket < need {
+			wait = maxDuration(wait, bucketWait(need-l.tokenBucket, tpm))
+		}
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+
+	if l.limit.RequestsPerMinute > 0 {
+		l.requestBucket--
+	}
+	if l.limit.TokensPerMinute > 0 {
+		l.tokenBucket -= need
+	}
+	l.dayRequests++
+	l.dayTokens += estimatedTokens
+	return 0, nil
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.refilledAt)
+	if elapsed <= 0 {
+		return
+	}
+	l.refilledAt = now
+	minutes := elapsed.Minutes()
+	if rpm := float64(l.limit.RequestsPerMinute); rpm > 0 {
+		l.requestBucket = math.Min(rpm, l.requestBucket+minutes*rpm)
+	}
+	if tpm := float64(l.limit.TokensPerMinute); tpm > 0 {
+		l.tokenBucket = math.Min(tpm, l.tokenBucket+minutes*tpm)
+	}
+}
+
+// rollDay は UTC の日付が変わったら日次カウンターをリセットする。
+func (l *RateLimiter) rollDay(now time.Time) {
+	day := now.UTC().Format(time.DateOnly)
+	if day != l.day {
+		l.day = day
+		l.dayRequests = 0
+		l.dayTokens = 0
+	}
+}
+
+func bucketWait(deficit float64, perMinute int) time.Duration {
+	wait := time.Duration(deficit / float64(perMinute) * float64(time.Minute))
+	if wait < time.Millisecond {
+		wait = time.Millisecond
+	}
+	return wait
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// EstimateTokens はプロンプトのトークン数を概算する。
+// ASCII はおよそ 4 バイトで 1 トークン、日本語などの非 ASCII は 1 文字 1 トークンとみなす。
+func EstimateTokens(text string) int {
+	asciiBytes := 0
+	others := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			asciiBytes++
+		} else {
+			others++
+		}
+	}
+	return (asciiBytes+3)/4 + others
+}
+
+// EstimateRequestTokens は送信前に使うリクエスト全体の推定プロンプトトークン数を返す。
+func EstimateRequestTokens(req Request) int {
+	return EstimateTokens(req.SystemPrompt) + EstimateTokens(req.UserPrompt)
+}
+
+type rateLimiterContextKey struct{}
+
+func withRateLimiter(ctx context.Context, limiter *RateLimiter) context.Context {
+	return context.WithValue(ctx, rateLimiterContextKey{}, limiter)
+}
+
+func rateLimiterFromContext(ctx context.Context) *RateLimiter {
+	limiter, _ := ctx.Value(rateLimiterContextKey{}).(*RateLimiter)
+	return limiter
+}
+
+// rateLimiterRegistry はプロバイダー/モデルごとのリミッターを保持し、クライアント間で共有する。
+type rateLimiterRegistry struct {
+	mu       sync.Mutex
+	limiters map[string]*RateLimiter
+}
+
+func (r *rateLimiterRegistry) get(provider, model string, limit RateLimit) *RateLimiter {
+	key := provider + "/" + model
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.limiters == nil {
+		r.limiters = make(map[string]*RateLimiter)
+	}
+	if limiter, ok := r.limiters[key]; ok {
+		limiter.SetLimit(limit)
+		return limiter
+	}
+	limiter := NewRateLimiter(limit)
+	r.limiters[key] = limiter
+	return limiter
+}
+
+// rateLimitedClient は送信前にリミッターの枠を確保し、完了後に実績トークンを記録する。
+type rateLimitedClient struct {
+	inner   LLMClient
+	limiter *RateLimiter
+}
+
+// newRateLimitedClient は inner を包む。LM Studio のモデル管理インターフェースは維持する。
+func newRateLimitedClient(inner LLMClient, limiter *RateLimiter) LLMClient {
+	limited := &rateLimitedClient{inner: inner, limiter: limiter}
+	if lifecycle, ok := inner.(ModelLifecycleClient); ok {
+		return &rateLimitedLifecycleClient{rateLimitedClient: limited, lifecycle: lifecycle}
+	}
+	return limited
+}
+
+func (c *rateLimitedClient) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	return c.inner.ListModels(ctx)
+}
+
+func (c *rateLimitedClient) HealthCheck(ctx context.Context) error {
+	return c.inner.HealthCheck(ctx)
+}
+
+func (c *rateLimitedClient) Complete(ctx context.Context, req Request) (Response, error) {
+	return c.do(ctx, req, c.inner.Complete)
+}
+
+func (c *rateLimitedClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	return c.do(ctx, req, c.inner.GenerateStructured)
+}
+
+func (c *rateLimitedClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
+	estimated := EstimateRequestTokens(req)
+	if err := c.limiter.Wait(ctx, estimated); err != nil {
+		return nil, err
+	}
+	stream, err := c.inner.StreamComplete(withRateLimiter(ctx, c.limiter), req)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitedStream{inner: stream, limiter: c.limiter, estimated: estimated}, nil
+}
+
+func (c *rateLimitedClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if err := c.limiter.Wait(ctx, EstimateTokens(text)); err != nil {
+		return nil, err
+	}
+	return c.inner.GetEmbedding(withRateLimiter(ctx, c.limiter), text)
+}
+
+func (c *rateLimitedClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	estimated := 0
+	for _, text := range texts {
+		estimated += EstimateTokens(text)
+	}
+	if err := c.limiter.Wait(ctx, estimated); err != nil {
+		return nil, err
+	}
+	return GetEmbeddings(withRateLimiter(ctx, c.limiter), c.inner, texts)
+}
+
+func (c *rateLimitedClient) do(ctx context.Context, req Request, call func(context.Context, Request) (Response, error)) (Response, error) {
+	estimated := EstimateRequestTokens(req)
+	if err := c.limiter.Wait(ctx, estimated); err != nil {
+		return Response{}, err
+	}
+	resp, err := call(withRateLimiter(ctx, c.limiter), req)
+	c.limiter.Record(estimated, resp.Usage.TotalTokens)
+	return resp, err
+}
+
+type rateLimitedLifecycleClient struct {
+	*rateLimitedClient
+	lifecycle ModelLifecycleClient
+}
+
+func (c *rateLimitedLifecycleClient) LoadModel(ctx context.Context, model string, contextLength int) (string, error) {
+	return c.lifecycle.LoadModel(ctx, model, contextLength)
+}
+
+func (c *rateLimitedLifecycleClient) UnloadModel(ctx context.Context, instanceID string) error {
+	return c.lifecycle.UnloadModel(ctx, instanceID)
+}
+
+// rateLimitedStream はストリーム終了時に最終 usage をリミッターへ記録する。
+type rateLimitedStream struct {
+	inner     StreamResponse
+	limiter   *RateLimiter
+	estimated int
+	usage     int
+	recorded  bool
+}
+
+func (s *rateLimitedStream) Next() (Response, bool) {
+	chunk, ok := s.inner.Next()
+	if chunk.Usage.TotalTokens > 0 {
+		s.usage = chunk.Usage.TotalTokens
+	}
+	if !ok {
+		s.record()
+	}
+	return chunk, ok
+}
+
+func (s *rateLimitedStream) Close() error {
+	s.record()
+	return s.inner.Close()
+}
+
+func (s *rateLimitedStream) record() {
+	if s.recorded {
+		return
+	}
+	s.recorded = true
+	s.limiter.Record(s.estimated, s.usage)
+}
+
+// parameterInt は数値または数値文字列のパラメーターを int として読み取る。
+func parameterInt(params map[string]interface{}, key string) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
+}
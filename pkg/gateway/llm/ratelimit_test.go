@@ -0,0 +1,177 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestRateLimiter_RequestsPerMinute(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiterWithClock(RateLimit{RequestsPerMinute: 2}, clock.now)
+
+	for i := 0; i < 2; i++ {
+		if wait, err := limiter.reserve(10); err != nil || wait != 0 {
+			t.Fatalf("request %d should pass immediately: wait=%v err=%v", i, wait, err)
+		}
+	}
+	wait, err := limiter.reserve(10)
+	if err != nil || wait != 30*time.Second {
+		t.Fatalf("expected 30s wait for the third request, got wait=%v err=%v", wait, err)
+	}
+	clock.advance(30 * time.Second)
+	if wait, err := limiter.reserve(10); err != nil || wait != 0 {
+		t.Fatalf("expected refilled request slot: wait=%v err=%v", wait, err)
+	}
+}
+
+func TestRateLimiter_TokensPerMinuteUsesActualUsage(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiterWithClock(RateLimit{TokensPerMinute: 1000}, clock.now)
+
+	if wait, _ := limiter.reserve(400); wait != 0 {
+		t.Fatalf("first request should fit, wait=%v", wait)
+	}
+	// 推定 400 に対して実績 900 だったので残量は 100
+	limiter.Record(400, 900)
+	wait, err := limiter.reserve(400)
+	if err != nil || wait != 18*time.Second {
+		t.Fatalf("expected 18s wait for 300 missing tokens, got wait=%v err=%v", wait, err)
+	}
+	// TPM を超える単発リクエストも容量で頭打ちにして通す
+	clock.advance(time.Minute)
+	if wait, _ := limiter.reserve(5000); wait != 0 {
+		t.Fatalf("oversized request should be capped at bucket capacity, wait=%v", wait)
+	}
+}
+
+func TestRateLimiter_DailyCapsResetAtUTCMidnight(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 23, 59, 0, 0, time.UTC)}
+	limiter := newRateLimiterWithClock(RateLimit{RequestsPerDay: 1, TokensPerDay: 100}, clock.now)
+
+	if _, err := limiter.reserve(50); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	if _, err := limiter.reserve(10); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("expected daily quota error, got %v", err)
+	}
+	clock.advance(2 * time.Minute)
+	if _, err := limiter.reserve(120); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("expected token cap to reject oversized request, got %v", err)
+	}
+	if _, err := limiter.reserve(60); err != nil {
+		t.Fatalf("expected counters to reset on the next UTC day: %v", err)
+	}
+}
+
+func TestRateLimiter_PauseForDelaysAllCallers(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiterWithClock(RateLimit{RequestsPerMinute: 100}, clock.now)
+	limiter.PauseFor(5 * time.Second)
+	if wait, _ := limiter.reserve(1); wait != 5*time.Second {
+		t.Fatalf("expected pause to be honored, wait=%v", wait)
+	}
+	clock.advance(5 * time.Second)
+	if wait, _ := limiter.reserve(1); wait != 0 {
+		t.Fatalf("expected pause to end, wait=%v", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"7":                             7 * time.Second,
+		"-1":                            0,
+		"Thu, 01 Jan 2026 12:00:30 GMT": 30 * time.Second,
+		"soon":                          0,
+	}
+	for value, want := range tests {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestRetryWithBackoff_HonorsRetryAfterAndPausesLimiter(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, `{"choices":[{"message":{"content":"ok"}}],"usage":{"total_tokens":3}}`)
+	}))
+	defer srv.Close()
+
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil))).(*Manager)
+	config := LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL,
+		Model:      "m1",
+		Parameters: map[string]interface{}{RateLimitRPMParam: "600"},
+	}
+	client, err := manager.GetClient(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	// InitialInterval は 1s なので Retry-After と区別するため短縮したクライアントを使う
+	limited := withoutCircuitBreaker(t, client).(*rateLimitedClient)
+	limited.inner.(*openAICompatibleClient).retryCfg = RetryConfig{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+
+	started := time.Now()
+	resp, err := client.Complete(context.Background(), Request{UserPrompt: "hi"})
+	if err != nil || resp.Content != "ok" {
+		t.Fatalf("Complete failed: resp=%+v err=%v", resp, err)
+	}
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Fatalf("expected Retry-After to override the short backoff, elapsed=%v", elapsed)
+	}
+
+	other, err := manager.GetClient(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	if withoutCircuitBreaker(t, other).(*rateLimitedClient).limiter != limited.limiter {
+		t.Fatalf("clients for the same provider/model must share a limiter")
+	}
+	if limited.limiter.pausedUntil.IsZero() {
+		t.Fatalf("expected Retry-After to pause the shared limiter")
+	}
+}
+
+func TestManager_GetClient_WithoutRateLimitReturnsRawClient(t *testing.T) {
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client, err := manager.GetClient(context.Background(), LLMConfig{Provider: "lmstudio", Endpoint: "http://localhost:1234", Model: "m1"})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	if _, ok := withoutCircuitBreaker(t, client).(*rateLimitedClient); ok {
+		t.Fatalf("client must not be wrapped when no limits are configured")
+	}
+
+	client, err = manager.GetClient(context.Background(), LLMConfig{
+		Provider:   "lmstudio",
+		Endpoint:   "http://localhost:1234",
+		Model:      "m1",
+		Parameters: map[string]interface{}{RateLimitTPMParam: 1000},
+	})
+	if err != nil {
+		t.Fatalf("GetClient failed: %v", err)
+	}
+	if _, ok := client.(ModelLifecycleClient); !ok {
+		t.Fatalf("rate limited LM Studio client must keep model lifecycle support")
+	}
+}
@@ -0,0 +1,410 @@
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Parameter keys for cassette based replay/record.
+const (
+	// ReplayCassetteParam is the cassette path served by the replay provider (falls back to Endpoint).
+	ReplayCassetteParam = "cassette"
+	// RecordCassetteParam makes GetClient wrap any real provider and append its responses to this cassette.
+	RecordCassetteParam = "record_cassette"
+)
+
+// Cassette entry kinds; completions and embeddings share one cassette file.
+const (
+	cassetteKindCompletion = "completion"
+	cassetteKindEmbedding  = "embedding"
+)
+
+// cassetteEntry is one JSON line of a cassette file.
+// SystemPrompt/UserPrompt are kept for human inspection only; lookups use Fingerprint.
+type cassetteEntry struct {
+	Kind         string    `json:"kind"`
+	Fingerprint  string    `json:"fingerprint"`
+	Provider     string    `json:"provider,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	SystemPrompt string    `json:"system_prompt,omitempty"`
+	UserPrompt   string    `json:"user_prompt,omitempty"`
+	Response     *Response `json:"response,omitempty"`
+	Embedding    []float32 `json:"embedding,omitempty"`
+	RecordedAt   time.Time `json:"recorded_at"`
+}
+
+// replayClient serves responses from a cassette without any network access.
+type replayClient struct {
+	logger *slog.Logger
+	config LLMConfig
+	path   string
+
+	once        sync.Once
+	loadErr     error
+	completions map[string]Response
+	embeddings  map[string][]float32
+	models      []string
+}
+
+// NewReplayClient returns a client that answers from the cassette at Parameters["cassette"] (or Endpoint).
+// Requests missing from the cassette fail with ErrCassetteMiss instead of being sent anywhere.
+func NewReplayClient(logger *slog.Logger, config LLMConfig) LLMClient {
+	path := parameterString(config.Parameters, ReplayCassetteParam)
+	if path == "" {
+		path = strings.TrimSpace(config.Endpoint)
+	}
+	return &replayClient{
+		logger: logger.With("provider", "replay"),
+		config: config,
+		path:   path,
+	}
+}
+
+func (c *replayClient) load() error {
+	c.once.Do(func() {
+		c.completions = make(map[string]Response)
+		c.embeddings = make(map[string][]float32)
+		if c.path == "" {
+			c.loadErr = ErrCassetteRequired
+			return
+		}
+		f, err := os.Open(c.path)
+		if err != nil {
+			c.loadErr = fmt.Errorf("open cassette path=%s: %w", c.path, err)
+			return
+		}
+		defer f.Close()
+
+		models := make(map[string]struct{})
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			raw := strings.TrimSpace(scanner.Text())
+			if raw == "" {
+				continue
+			}
+			var entry cassetteEntry
+			if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+				c.loadErr = fmt.Errorf("decode cassette path=%s line=%d: %w", c.path, line, err)
+				return
+			}
+			// Later entries win so a re-recorded cassette reflects the latest run.
+			switch entry.Kind {
+			case cassetteKindEmbedding:
+				c.embeddings[entry.Fingerprint] = entry.Embedding
+			default:
+				if entry.Response != nil {
+					c.completions[entry.Fingerprint] = *entry.Response
+				}
+			}
+			if entry.Model != "" {
+				models[entry.Model] = struct{}{}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			c.loadErr = fmt.Errorf("read cassette path=%s: %w", c.path, err)
+			return
+		}
+		for model := range models {
+			c.models = append(c.models, model)
+		}
+		sort.Strings(c.models)
+		c.logger.Info("cassette loaded",
+			slog.String("path", c.path),
+			slog.Int("completions", len(c.completions)),
+			slog.Int("embeddings", len(c.embeddings)),
+		)
+	})
+	return c.loadErr
+}
+
+func (c *replayClient) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	models := make([]ModelInfo, 0, len(c.models))
+	for _, model := range c.models {
+		models = append(models, ModelInfo{ID: model, DisplayName: model, Loaded: true})
+	}
+	return models, nil
+}
+
+func (c *replayClient) Complete(ctx context.Context, req Request) (Response, error) {
+	return c.replay(ctx, req)
+}
+
+func (c *replayClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	if len(req.ResponseSchema) == 0 {
+		return Response{}, fmt.Errorf("replay: response schema is required for structured output")
+	}
+	return c.replay(ctx, req)
+}
+
+func (c *replayClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
+	resp, err := c.replay(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &singleChunkStream{chunk: resp}, nil
+}
+
+func (c *replayClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	fingerprint := embeddingFingerprint(text)
+	vector, ok := c.embeddings[fingerprint]
+	if !ok {
+		return nil, fmt.Errorf("%w: kind=%s fingerprint=%s", ErrCassetteMiss, cassetteKindEmbedding, fingerprint)
+	}
+	return vector, nil
+}
+
+func (c *replayClient) HealthCheck(ctx context.Context) error {
+	if err := c.load(); err != nil {
+		return fmt.Errorf("replay: health check failed: %w", err)
+	}
+	return nil
+}
+
+func (c *replayClient) replay(ctx context.Context, req Request) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+	if err := c.load(); err != nil {
+		return Response{}, err
+	}
+	fingerprint := RequestFingerprint(req)
+	resp, ok := c.completions[fingerprint]
+	if !ok {
+		return Response{}, fmt.Errorf("%w: kind=%s fingerprint=%s", ErrCassetteMiss, cassetteKindCompletion, fingerprint)
+	}
+	resp.Metadata = req.Metadata
+	return resp, nil
+}
+
+// singleChunkStream yields one prepared response.
+type singleChunkStream struct {
+	chunk Response
+	done  bool
+}
+
+func (s *singleChunkStream) Next() (Response, bool) {
+	if s.done {
+		return Response{}, false
+	}
+	s.done = true
+	return s.chunk, true
+}
+
+func (s *singleChunkStream) Close() error {
+	s.done = true
+	return nil
+}
+
+// cassetteWriter appends entries to a cassette file; one writer is shared per path.
+type cassetteWriter struct {
+	mu   sync.Mutex
+	path string
+}
+
+var (
+	cassetteWritersMu sync.Mutex
+	cassetteWriters   = map[string]*cassetteWriter{}
+)
+
+func sharedCassetteWriter(path string) *cassetteWriter {
+	cassetteWritersMu.Lock()
+	defer cassetteWritersMu.Unlock()
+	if w, ok := cassetteWriters[path]; ok {
+		return w
+	}
+	w := &cassetteWriter{path: path}
+	cassetteWriters[path] = w
+	return w
+}
+
+func (w *cassetteWriter) append(entry cassetteEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode cassette entry: %w", err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return fmt.Errorf("create cassette directory path=%s: %w", w.path, err)
+	}
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open cassette path=%s: %w", w.path, err)
+	}
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write cassette path=%s: %w", w.path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close cassette path=%s: %w", w.path, err)
+	}
+	return nil
+}
+
+// recordingClient forwards to a real client and appends every successful response to a cassette.
+type recordingClient struct {
+	LLMClient
+	logger   *slog.Logger
+	writer   *cassetteWriter
+	provider string
+	model    string
+}
+
+// newRecordingClient wraps inner so its responses can later be served by the replay provider.
+// The LM Studio model lifecycle interface is kept.
+func newRecordingClient(logger *slog.Logger, inner LLMClient, config LLMConfig, path string) LLMClient {
+	recorder := &recordingClient{
+		LLMClient: inner,
+		logger:    logger.With("cassette", path),
+		writer:    sharedCassetteWriter(path),
+		provider:  config.Provider,
+		model:     config.Model,
+	}
+	if lifecycle, ok := inner.(ModelLifecycleClient); ok {
+		return &recordingLifecycleClient{recordingClient: recorder, lifecycle: lifecycle}
+	}
+	return recorder
+}
+
+func (c *recordingClient) Complete(ctx context.Context, req Request) (Response, error) {
+	resp, err := c.LLMClient.Complete(ctx, req)
+	if err == nil {
+		c.record(req, resp)
+	}
+	return resp, err
+}
+
+func (c *recordingClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	resp, err := c.LLMClient.GenerateStructured(ctx, req)
+	if err == nil {
+		c.record(req, resp)
+	}
+	return resp, err
+}
+
+func (c *recordingClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
+	stream, err := c.LLMClient.StreamComplete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingStream{StreamResponse: stream, client: c, req: req}, nil
+}
+
+func (c *recordingClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	vector, err := c.LLMClient.GetEmbedding(ctx, text)
+	if err == nil {
+		c.append(cassetteEntry{
+			Kind:        cassetteKindEmbedding,
+			Fingerprint: embeddingFingerprint(text),
+			Provider:    c.provider,
+			Model:       c.model,
+			UserPrompt:  text,
+			Embedding:   vector,
+		})
+	}
+	return vector, err
+}
+
+func (c *recordingClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, err := GetEmbeddings(ctx, c.LLMClient, texts)
+	if err != nil {
+		return nil, err
+	}
+	for i, text := range texts {
+		c.append(cassetteEntry{
+			Kind:        cassetteKindEmbedding,
+			Fingerprint: embeddingFingerprint(text),
+			Provider:    c.provider,
+			Model:       c.model,
+			UserPrompt:  text,
+			Embedding:   vectors[i],
+		})
+	}
+	return vectors, nil
+}
+
+func (c *recordingClient) record(req Request, resp Response) {
+	if !resp.Success {
+		return
+	}
+	resp.Metadata = nil
+	c.append(cassetteEntry{
+		Kind:         cassetteKindCompletion,
+		Fingerprint:  RequestFingerprint(req),
+		Provider:     c.provider,
+		Model:        c.model,
+		SystemPrompt: req.SystemPrompt,
+		UserPrompt:   req.UserPrompt,
+		Response:     &resp,
+	})
+}
+
+// append never fails the request: a broken cassette must not break a real translation run.
+func (c *recordingClient) append(entry cassetteEntry) {
+	entry.RecordedAt = time.Now().UTC()
+	if err := c.writer.append(entry); err != nil {
+		c.logger.Warn("failed to record cassette entry", slog.String("error", err.Error()))
+	}
+}
+
+type recordingLifecycleClient struct {
+	*recordingClient
+	lifecycle ModelLifecycleClient
+}
+
+func (c *recordingLifecycleClient) LoadModel(ctx context.Context, model string, contextLength int) (string, error) {
+	return c.lifecycle.LoadModel(ctx, model, contextLength)
+}
+
+func (c *recordingLifecycleClient) UnloadModel(ctx context.Context, instanceID string) error {
+	return c.lifecycle.UnloadModel(ctx, instanceID)
+}
+
+// recordingStream records the joined body once the stream ends without an error chunk.
+type recordingStream struct {
+	StreamResponse
+	client   *recordingClient
+	req      Request
+	content  strings.Builder
+	usage    TokenUsage
+	failed   bool
+	recorded bool
+}
+
+func (s *recordingStream) Next() (Response, bool) {
+	chunk, ok := s.StreamResponse.Next()
+	if !ok {
+		if !s.failed && !s.recorded {
+			s.recorded = true
+			s.client.record(s.req, Response{Content: s.content.String(), Success: true, Usage: s.usage})
+		}
+		return chunk, ok
+	}
+	if !chunk.Success {
+		s.failed = true
+		return chunk, ok
+	}
+	s.content.WriteString(chunk.Content)
+	if chunk.Usage.TotalTokens > 0 {
+		s.usage = chunk.Usage
+	}
+	return chunk, ok
+}
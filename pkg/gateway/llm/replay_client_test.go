@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayClient_ReplaysRecordedRun(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			var payload struct {
+				Stream   bool `json:"stream"`
+				Messages []struct {
+					Content string `json:"content"`
+				} `json:"messages"`
+			}
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &payload)
+			content := "訳:" + payload.Messages[len(payload.Messages)-1].Content
+			if payload.Stream {
+				_, _ = io.WriteString(w, "data: {\"choices\":[{\"delta\":{\"content\":\""+content+"\"}}]}\n\ndata: [DONE]\n\n")
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]any{"content": content}}},
+				"usage":   map[string]any{"prompt_tokens": 3, "completion_tokens": 2, "total_tokens": 5},
+			})
+		case "/v1/embeddings":
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"embedding": []float32{1, 2}}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassettes", "run.jsonl")
+	manager := NewLLMManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	recorder, err := manager.GetClient(context.Background(), LLMConfig{
+		Provider:   "openai_compatible",
+		Endpoint:   srv.URL + "/v1",
+		Model:      "m1",
+		Parameters: map[string]interface{}{RecordCassetteParam: cassette},
+	})
+	if err != nil {
+		t.Fatalf("GetClient(record) failed: %v", err)
+	}
+	if _, err := recorder.Complete(context.Background(), Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"id": "live"}}); err != nil {
+		t.Fatalf("recorded Complete failed: %v", err)
+	}
+	if _, err := CollectStream(context.Background(), recorder, Request{UserPrompt: "Book"}, nil); err != nil {
+		t.Fatalf("recorded stream failed: %v", err)
+	}
+	if _, err := recorder.GetEmbedding(context.Background(), "Jarl"); err != nil {
+		t.Fatalf("recorded embedding failed: %v", err)
+	}
+	srv.Close()
+
+	raw, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("cassette was not written: %v", err)
+	}
+	if lines := strings.Count(string(raw), "\n"); lines != 3 {
+		t.Fatalf("expected 3 cassette entries, got %d:\n%s", lines, raw)
+	}
+	if strings.Contains(string(raw), `"live"`) {
+		t.Fatalf("request metadata must not be recorded:\n%s", raw)
+	}
+
+	replay, err := manager.GetClient(context.Background(), LLMConfig{Provider: "replay", Model: "m1", Endpoint: cassette})
+	if err != nil {
+		t.Fatalf("GetClient(replay) failed: %v", err)
+	}
+	resp, err := replay.Complete(context.Background(), Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"id": "replayed"}})
+	if err != nil {
+		t.Fatalf("replayed Complete failed: %v", err)
+	}
+	if resp.Content != "訳:Whiterun" || resp.Usage.TotalTokens != 5 || resp.Metadata["id"] != "replayed" {
+		t.Fatalf("unexpected replayed response: %+v", resp)
+	}
+	streamed, err := CollectStream(context.Background(), replay, Request{UserPrompt: "Book"}, nil)
+	if err != nil || streamed.Content != "訳:Book" {
+		t.Fatalf("unexpected replayed stream: resp=%+v err=%v", streamed, err)
+	}
+	if vector, err := replay.GetEmbedding(context.Background(), "Jarl"); err != nil || len(vector) != 2 {
+		t.Fatalf("unexpected replayed embedding: vector=%v err=%v", vector, err)
+	}
+	if models, err := replay.ListModels(context.Background()); err != nil || len(models) != 1 || models[0].ID != "m1" {
+		t.Fatalf("unexpected replay models: %v err=%v", models, err)
+	}
+
+	if _, err := replay.Complete(context.Background(), Request{UserPrompt: "Riften"}); !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("expected cassette miss, got %v", err)
+	}
+}
+
+func TestReplayClient_RequiresCassette(t *testing.T) {
+	t.Parallel()
+	client := NewReplayClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{Provider: "replay", Model: "m1"})
+	if err := client.HealthCheck(context.Background()); !errors.Is(err, ErrCassetteRequired) {
+		t.Fatalf("expected ErrCassetteRequired, got %v", err)
+	}
+}
+
+func TestRequestFingerprint_IgnoresMetadata(t *testing.T) {
+	t.Parallel()
+	a := Request{SystemPrompt: "sys", UserPrompt: "Whiterun", Metadata: map[string]interface{}{"task_id": "t1"}}
+	b := Request{SystemPrompt: "sys", UserPrompt: "Whiterun", Metadata: map[string]interface{}{"task_id": "t2"}}
+	if RequestFingerprint(a) != RequestFingerprint(b) {
+		t.Fatalf("metadata must not change the fingerprint")
+	}
+	b.ResponseSchema = map[string]interface{}{"type": "object"}
+	if RequestFingerprint(a) == RequestFingerprint(b) {
+		t.Fatalf("response schema must change the fingerprint")
+	}
+}
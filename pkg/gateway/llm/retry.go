@@ -6,20 +6,52 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // RetryableError はリトライ対象のHTTPエラーを表す。
+// RetryAfter はサーバーが Retry-After ヘッダーで指定した待機時間（未指定なら 0）。
 type RetryableError struct {
 	StatusCode int
 	Message    string
+	RetryAfter time.Duration
 }
 
 func (e *RetryableError) Error() string {
 	return fmt.Sprintf("retryable HTTP error %d: %s", e.StatusCode, e.Message)
 }
 
+// newRetryableError はレスポンスから RetryableError を組み立て、Retry-After を読み取る。
+func newRetryableError(resp *http.Response, body []byte) *RetryableError {
+	return &RetryableError{
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter は秒数または HTTP-date 形式の Retry-After を待機時間に変換する。
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
 // IsRetryableStatusCode は与えられたHTTPステータスコードがリトライ対象かを返す。
 // リトライ対象: 429 / 500 / 502 / 503 / 504
 // 非リトライ対象: 4xx系（429を除く）
@@ -35,6 +67,20 @@ func IsRetryableStatusCode(code int) bool {
 	return false
 }
 
+// IsTransientError はリトライを使い切った 5xx/429、日次上限、回路遮断、ネットワークエラーかを返す。
+// これらは別プロバイダーへフェイルオーバーすれば成功し得る。キャンセルは対象外。
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var retryErr *RetryableError
+	if errors.As(err, &retryErr) || errors.Is(err, ErrDailyQuotaExceeded) || errors.Is(err, ErrProviderUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // RetryConfig はリトライ動作の設定を保持する。
 type RetryConfig struct {
 	MaxAttempts     int
@@ -91,6 +137,15 @@ func RetryWithBackoff(ctx context.Context, cfg RetryConfig, fn func() error) err
 		if wait > cfg.MaxInterval {
 			wait = cfg.MaxInterval
 		}
+		// Retry-After が指定されていればバックオフより優先し、共有リミッターにも反映する
+		if retryErr.RetryAfter > 0 {
+			if retryErr.RetryAfter > wait {
+				wait = retryErr.RetryAfter
+			}
+			if limiter := rateLimiterFromContext(ctx); limiter != nil {
+				limiter.PauseFor(retryErr.RetryAfter)
+			}
+		}
 
 		select {
 		case <-ctx.Done():
@@ -3,6 +3,8 @@ package llm
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -177,3 +179,23 @@ func TestRetryMockHTTPServer(t *testing.T) {
 		t.Errorf("expected 3 calls, got %d", callCount)
 	}
 }
+
+func TestIsTransientError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "retry exhausted", err: fmt.Errorf("gemini: %w", &RetryableError{StatusCode: 503}), want: true},
+		{name: "daily quota", err: fmt.Errorf("xai: %w", ErrDailyQuotaExceeded), want: true},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "bad request", err: errors.New("gemini: status 400"), want: false},
+	}
+	for _, tc := range cases {
+		if got := IsTransientError(tc.err); got != tc.want {
+			t.Errorf("%s: IsTransientError()=%v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// BookStreamMinSourceChars is the source length from which book bodies are streamed.
+	BookStreamMinSourceChars = 1000
+	// BookStreamProgressChars throttles streaming progress callbacks to one per this many received chars.
+	BookStreamProgressChars = 200
+)
+
+// sseDecodeFunc converts one SSE data payload into a partial response.
+// ok=false skips payloads that carry neither text nor usage; done=true ends the stream.
+type sseDecodeFunc func(data []byte) (chunk Response, ok bool, done bool, err error)
+
+// sseStreamResponse yields partial responses decoded from a server-sent-event body.
+// A failure is surfaced once as a chunk with Success=false and Error set, after which Next returns false.
+type sseStreamResponse struct {
+	ctx      context.Context
+	body     io.ReadCloser
+	reader   *bufio.Reader
+	decode   sseDecodeFunc
+	metadata map[string]interface{}
+	finished bool
+}
+
+func newSSEStreamResponse(ctx context.Context, body io.ReadCloser, decode sseDecodeFunc, metadata map[string]interface{}) *sseStreamResponse {
+	return &sseStreamResponse{
+		ctx:      ctx,
+		body:     body,
+		reader:   bufio.NewReader(body),
+		decode:   decode,
+		metadata: metadata,
+	}
+}
+
+func (s *sseStreamResponse) Next() (Response, bool) {
+	for !s.finished {
+		data, err := s.readEvent()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				s.finish()
+				return Response{}, false
+			}
+			return s.fail(err), true
+		}
+		if len(data) == 0 {
+			continue
+		}
+		chunk, ok, done, err := s.decode(data)
+		if err != nil {
+			return s.fail(err), true
+		}
+		if done {
+			s.finish()
+		}
+		if ok {
+			chunk.Success = true
+			chunk.Metadata = s.metadata
+			return chunk, true
+		}
+	}
+	return Response{}, false
+}
+
+func (s *sseStreamResponse) Close() error {
+	s.finished = true
+	return s.body.Close()
+}
+
+// readEvent returns the joined data lines of the next event; io.EOF means no event remains.
+func (s *sseStreamResponse) readEvent() ([]byte, error) {
+	var data [][]byte
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("read stream: %w", err)
+		}
+		trimmed := bytes.TrimRight(line, "\r\n")
+		if payload, found := bytes.CutPrefix(trimmed, []byte("data:")); found {
+			data = append(data, bytes.TrimPrefix(payload, []byte(" ")))
+		}
+		if len(trimmed) == 0 && len(data) > 0 {
+			return bytes.Join(data, []byte("\n")), nil
+		}
+		if errors.Is(err, io.EOF) {
+			if len(data) > 0 {
+				return bytes.Join(data, []byte("\n")), nil
+			}
+			return nil, io.EOF
+		}
+	}
+}
+
+func (s *sseStreamResponse) fail(err error) Response {
+	s.finish()
+	return Response{Success: false, Error: err.Error(), Metadata: s.metadata}
+}
+
+func (s *sseStreamResponse) finish() {
+	if s.finished {
+		return
+	}
+	s.finished = true
+	_ = s.body.Close()
+}
+
+// openSSEStream sends the request built by build and returns the open response body.
+// Retries only cover establishing the stream; once bytes flow, failures surface through Next.
+func openSSEStream(ctx context.Context, client *http.Client, retryCfg RetryConfig, build func() (*http.Request, error)) (io.ReadCloser, error) {
+	var body io.ReadCloser
+	err := RetryWithBackoff(ctx, retryCfg, func() error {
+		httpReq, err := build()
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set("Accept", "text/event-stream")
+		httpResp, err := client.Do(httpReq)
+		if err != nil {
+			return fmt.Errorf("stream request failed: %w", err)
+		}
+		if httpResp.StatusCode == http.StatusOK {
+			body = httpResp.Body
+			return nil
+		}
+		defer httpResp.Body.Close()
+		respBody, readErr := io.ReadAll(httpResp.Body)
+		if readErr != nil {
+			return fmt.Errorf("stream error response read failed: %w", readErr)
+		}
+		if IsRetryableStatusCode(httpResp.StatusCode) {
+			return newRetryableError(httpResp, respBody)
+		}
+		return fmt.Errorf("stream API error %d: %s", httpResp.StatusCode, string(respBody))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// streamingHTTPClient drops the whole-exchange timeout so long generations are bounded by ctx instead.
+func streamingHTTPClient(base *http.Client) *http.Client {
+	client := *base
+	client.Timeout = 0
+	return &client
+}
+
+// decodeOpenAIStreamData decodes an OpenAI-compatible chat.completion.chunk payload.
+func decodeOpenAIStreamData(data []byte) (Response, bool, bool, error) {
+	if strings.TrimSpace(string(data)) == "[DONE]" {
+		return Response{}, false, true, nil
+	}
+	var raw struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+		Usage *struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Response{}, false, false, fmt.Errorf("decode stream chunk: %w", err)
+	}
+	if raw.Error != nil {
+		return Response{}, false, false, fmt.Errorf("stream error: %s", raw.Error.Message)
+	}
+	var chunk Response
+	for _, choice := range raw.Choices {
+		chunk.Content += choice.Delta.Content
+	}
+	if raw.Usage != nil {
+		chunk.Usage = TokenUsage{
+			PromptTokens:     raw.Usage.PromptTokens,
+			CompletionTokens: raw.Usage.CompletionTokens,
+			TotalTokens:      raw.Usage.TotalTokens,
+		}
+	}
+	return chunk, chunk.Content != "" || raw.Usage != nil, false, nil
+}
+
+// decodeGeminiStreamData decodes one GenerateContentResponse emitted by streamGenerateContent?alt=sse.
+func decodeGeminiStreamData(data []byte) (Response, bool, bool, error) {
+	var raw struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+		UsageMetadata *struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+			TotalTokenCount      int `json:"totalTokenCount"`
+		} `json:"usageMetadata"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Response{}, false, false, fmt.Errorf("decode stream chunk: %w", err)
+	}
+	if raw.Error != nil {
+		return Response{}, false, false, fmt.Errorf("stream error: %s", raw.Error.Message)
+	}
+	var chunk Response
+	if len(raw.Candidates) > 0 {
+		for _, part := range raw.Candidates[0].Content.Parts {
+			chunk.Content += part.Text
+		}
+	}
+	if raw.UsageMetadata != nil {
+		chunk.Usage = TokenUsage{
+			PromptTokens:     raw.UsageMetadata.PromptTokenCount,
+			CompletionTokens: raw.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:      raw.UsageMetadata.TotalTokenCount,
+		}
+	}
+	return chunk, chunk.Content != "" || raw.UsageMetadata != nil, false, nil
+}
+
+// CollectStream drains StreamComplete into one Response, reporting the received character count after each chunk.
+// The returned usage is the last non-zero usage seen, since providers report cumulative totals.
+func CollectStream(ctx context.Context, client LLMClient, req Request, onChunk func(receivedChars int)) (Response, error) {
+	stream, err := client.StreamComplete(ctx, req)
+	if err != nil {
+		return Response{}, err
+	}
+	defer stream.Close()
+
+	var content strings.Builder
+	var usage TokenUsage
+	for {
+		chunk, ok := stream.Next()
+		if !ok {
+			break
+		}
+		if !chunk.Success {
+			return Response{}, fmt.Errorf("stream interrupted after %d chars: %s", content.Len(), chunk.Error)
+		}
+		content.WriteString(chunk.Content)
+		if chunk.Usage.TotalTokens > 0 {
+			usage = chunk.Usage
+		}
+		if onChunk != nil && chunk.Content != "" {
+			onChunk(content.Len())
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+	return Response{
+		Content:  content.String(),
+		Success:  true,
+		Usage:    usage,
+		Metadata: req.Metadata,
+	}, nil
+}
+
+// IsLongBookRequest reports whether a request translates book body text that benefits from streaming.
+func IsLongBookRequest(req Request) bool {
+	recordType, _ := req.Metadata["record_type"].(string)
+	recordType = strings.ToUpper(strings.TrimSpace(recordType))
+	if !strings.HasPrefix(recordType, "BOOK") || !strings.Contains(recordType, "DESC") {
+		return false
+	}
+	sourceText, _ := req.Metadata["source_text"].(string)
+	return len(sourceText) >= BookStreamMinSourceChars
+}
+
+// bookStreamingClient generates long book bodies over StreamComplete so partial output is reported while it arrives.
+type bookStreamingClient struct {
+	LLMClient
+	onProgress func(receivedChars int)
+}
+
+// NewBookStreamingClient wraps client so long book body requests are streamed instead of completed in one call.
+// Cancelling ctx then aborts the in-flight generation instead of waiting for the full answer.
+// onProgress receives the received character count at most once per BookStreamProgressChars; it may be nil.
+func NewBookStreamingClient(client LLMClient, onProgress func(receivedChars int)) LLMClient {
+	return &bookStreamingClient{LLMClient: client, onProgress: onProgress}
+}
+
+func (c *bookStreamingClient) Complete(ctx context.Context, req Request) (Response, error) {
+	if !IsLongBookRequest(req) {
+		return c.LLMClient.Complete(ctx, req)
+	}
+	lastReported := 0
+	resp, err := CollectStream(ctx, c.LLMClient, req, func(receivedChars int) {
+		if c.onProgress == nil || receivedChars-lastReported < BookStreamProgressChars {
+			return
+		}
+		lastReported = receivedChars
+		c.onProgress(receivedChars)
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("stream book translation: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLMStudioClient_StreamComplete_YieldsDeltas(t *testing.T) {
+	t.Parallel()
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &payload)
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for _, line := range []string{
+			`data: {"choices":[{"delta":{"role":"assistant"}}]}`,
+			`data: {"choices":[{"delta":{"content":"こん"}}]}`,
+			`data: {"choices":[{"delta":{"content":"にちは"}}]}`,
+			`data: {"choices":[],"usage":{"prompt_tokens":4,"completion_tokens":2,"total_tokens":6}}`,
+			`data: [DONE]`,
+		} {
+			_, _ = io.WriteString(w, line+"\n\n")
+			flusher.Flush()
+		}
+	}))
+	defer srv.Close()
+
+	client := NewLMStudioClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider: "lmstudio",
+		Endpoint: srv.URL,
+		Model:    "m1",
+	})
+	stream, err := client.StreamComplete(context.Background(), Request{UserPrompt: "hi", Metadata: map[string]interface{}{"id": "r1"}})
+	if err != nil {
+		t.Fatalf("StreamComplete failed: %v", err)
+	}
+	defer stream.Close()
+
+	var contents []string
+	var usage TokenUsage
+	for {
+		chunk, ok := stream.Next()
+		if !ok {
+			break
+		}
+		if !chunk.Success {
+			t.Fatalf("unexpected failed chunk: %+v", chunk)
+		}
+		if chunk.Metadata["id"] != "r1" {
+			t.Fatalf("expected metadata on every chunk, got %v", chunk.Metadata)
+		}
+		if chunk.Content != "" {
+			contents = append(contents, chunk.Content)
+		}
+		if chunk.Usage.TotalTokens > 0 {
+			usage = chunk.Usage
+		}
+	}
+	if strings.Join(contents, "|") != "こん|にちは" {
+		t.Fatalf("unexpected deltas: %v", contents)
+	}
+	if usage.TotalTokens != 6 {
+		t.Fatalf("expected usage from final chunk, got %+v", usage)
+	}
+	if payload["stream"] != true {
+		t.Fatalf("expected stream=true in request, got %v", payload["stream"])
+	}
+	if opts, _ := payload["stream_options"].(map[string]any); opts["include_usage"] != true {
+		t.Fatalf("expected include_usage stream option, got %v", payload["stream_options"])
+	}
+}
+
+func TestGeminiClient_StreamComplete_UsesSSEEndpoint(t *testing.T) {
+	t.Parallel()
+	var gotPath, gotAlt string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotPath = req.URL.Path
+		gotAlt = req.URL.Query().Get("alt")
+		body := strings.Join([]string{
+			`data: {"candidates":[{"content":{"parts":[{"text":"第一章"}]}}]}`,
+			``,
+			`data: {"candidates":[{"content":{"parts":[{"text":"の本文"}]}}],"usageMetadata":{"promptTokenCount":5,"candidatesTokenCount":3,"totalTokenCount":8}}`,
+			``,
+		}, "\r\n")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	rawClient := NewGeminiClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{APIKey: "k", Model: "gemini-2.0-flash"})
+	client := rawClient.(*geminiClient)
+	client.httpClient = &http.Client{Transport: transport}
+
+	resp, err := CollectStream(context.Background(), client, Request{UserPrompt: "book"}, nil)
+	if err != nil {
+		t.Fatalf("CollectStream failed: %v", err)
+	}
+	if gotPath != "/v1beta/models/gemini-2.0-flash:streamGenerateContent" || gotAlt != "sse" {
+		t.Fatalf("unexpected stream endpoint path=%q alt=%q", gotPath, gotAlt)
+	}
+	if resp.Content != "第一章の本文" || resp.Usage.TotalTokens != 8 {
+		t.Fatalf("unexpected collected response: %+v", resp)
+	}
+}
+
+func TestXAIClient_StreamComplete_SurfacesMidStreamError(t *testing.T) {
+	t.Parallel()
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := "data: {\"choices\":[{\"delta\":{\"content\":\"part\"}}]}\n\ndata: {\"error\":{\"message\":\"overloaded\"}}\n\n"
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	rawClient := NewXAIClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{APIKey: "k", Model: "grok-3"})
+	client := rawClient.(*xaiClient)
+	client.httpClient = &http.Client{Transport: transport}
+
+	var received []int
+	_, err := CollectStream(context.Background(), client, Request{UserPrompt: "book"}, func(chars int) {
+		received = append(received, chars)
+	})
+	if err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Fatalf("expected mid-stream error, got %v", err)
+	}
+	if len(received) != 1 || received[0] != len("part") {
+		t.Fatalf("expected progress for the chunk before the error, got %v", received)
+	}
+}
+
+func TestOpenAICompatibleClient_StreamComplete_CancelMidGeneration(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		_, _ = io.WriteString(w, "data: {\"choices\":[{\"delta\":{\"content\":\"first\"}}]}\n\n")
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL + "/v1",
+		Model:    "m1",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := CollectStream(ctx, client, Request{UserPrompt: "book"}, func(int) { cancel() })
+	if !errors.Is(err, context.Canceled) && (err == nil || !strings.Contains(err.Error(), context.Canceled.Error())) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+}
+
+func TestOpenSSEStream_RejectsErrorStatus(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"bad model"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	client := NewOpenAICompatibleClient(slog.New(slog.NewTextHandler(io.Discard, nil)), LLMConfig{
+		Provider: "openai_compatible",
+		Endpoint: srv.URL,
+		Model:    "m1",
+	})
+	if _, err := client.StreamComplete(context.Background(), Request{UserPrompt: "x"}); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
@@ -0,0 +1,581 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// StructuredMaxRepairAttemptsParam はスキーマ検証に失敗した応答をモデルへ再依頼する最大回数。
+	StructuredMaxRepairAttemptsParam = "structured_max_repair_attempts"
+	// DefaultStructuredMaxRepairAttempts は StructuredMaxRepairAttemptsParam 未設定時の再依頼回数。
+	DefaultStructuredMaxRepairAttempts = 2
+	// StructuredRepairAttemptsMetadataKey は再依頼した回数を記録する Response.Metadata のキー。
+	StructuredRepairAttemptsMetadataKey = "structured_repair_attempts"
+	// StructuredLenientRepairMetadataKey はコードフェンス除去などの補正で JSON を直したかを記録するキー。
+	StructuredLenientRepairMetadataKey = "structured_lenient_repair"
+)
+
+// structuredRepairPreviewChars は再依頼プロンプトに載せる前回応答の最大文字数。
+const structuredRepairPreviewChars = 2000
+
+const structuredRepairPrompt = `Your previous response did not satisfy the required JSON schema.
+Validation error: %s
+Previous response:
+%s
+
+Respond again with only a single JSON value that satisfies the schema. Do not add explanations or code fences.`
+
+// ValidateStructuredContent は content を JSON として読み、スキーマに照らして検証する。
+// そのままでは読めない場合は RepairJSON で補正してから検証し、使った JSON 文字列と補正有無を返す。
+func ValidateStructuredContent(content string, schema map[string]interface{}) (string, bool, error) {
+	candidate := strings.TrimSpace(content)
+	repaired := false
+	var value interface{}
+	if err := json.Unmarshal([]byte(candidate), &value); err != nil {
+		candidate = RepairJSON(content)
+		repaired = true
+		if err := json.Unmarshal([]byte(candidate), &value); err != nil {
+			return "", repaired, fmt.Errorf("response is not valid JSON: %w", err)
+		}
+	}
+	if err := ValidateJSONSchema(value, schema); err != nil {
+		return "", repaired, err
+	}
+	return candidate, repaired, nil
+}
+
+// ValidateJSONSchema は JSON Schema のうち構造化出力で使う部分集合
+// (type / enum / properties / required / additionalProperties / items / minItems / maxItems / minLength / maxLength / anyOf) を検証する。
+// Gemini 形式の大文字の type ("OBJECT" など) も受け付ける。
+func ValidateJSONSchema(value interface{}, schema map[string]interface{}) error {
+	return validateSchemaValue("$", value, schema)
+}
+
+func validateSchemaValue(path string, value interface{}, schema map[string]interface{}) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	if options, ok := schema["anyOf"].([]interface{}); ok && len(options) > 0 {
+		matched := false
+		var firstErr error
+		for _, option := range options {
+			optionSchema, _ := option.(map[string]interface{})
+			err := validateSchemaValue(path, value, optionSchema)
+			if err == nil {
+				matched = true
+				break
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: no anyOf alternative matched: %w", path, firstErr)
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !enumContains(enum, value) {
+		return fmt.Errorf("%s: value %v is not one of %v", path, value, enum)
+	}
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !matchesAnyType(value, types) {
+		return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, "|"), jsonTypeName(value))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateSchemaObject(path, v, schema)
+	case []interface{}:
+		if minItems, ok := schemaInt(schema["minItems"]); ok && len(v) < minItems {
+			return fmt.Errorf("%s: expected at least %d items, got %d", path, minItems, len(v))
+		}
+		if maxItems, ok := schemaInt(schema["maxItems"]); ok && len(v) > maxItems {
+			return fmt.Errorf("%s: expected at most %d items, got %d", path, maxItems, len(v))
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for idx, item := range v {
+			if err := validateSchemaValue(fmt.Sprintf("%s[%d]", path, idx), item, items); err != nil {
+				return err
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if minLength, ok := schemaInt(schema["minLength"]); ok && length < minLength {
+			return fmt.Errorf("%s: expected at least %d characters, got %d", path, minLength, length)
+		}
+		if maxLength, ok := schemaInt(schema["maxLength"]); ok && length > maxLength {
+			return fmt.Errorf("%s: expected at most %d characters, got %d", path, maxLength, length)
+		}
+	}
+	return nil
+}
+
+func validateSchemaObject(path string, object map[string]interface{}, schema map[string]interface{}) error {
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := path + "." + key
+		if propertySchema, ok := properties[key].(map[string]interface{}); ok {
+			if err := validateSchemaValue(childPath, object[key], propertySchema); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: unexpected property %q", path, key)
+			}
+		case map[string]interface{}:
+			if err := validateSchemaValue(childPath, object[key], additional); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func schemaTypes(raw interface{}) []string {
+	switch v := raw.(type) {
+	case string:
+		return []string{strings.ToLower(v)}
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				types = append(types, strings.ToLower(s))
+			}
+		}
+		return types
+	case []string:
+		types := make([]string, 0, len(v))
+		for _, s := range v {
+			types = append(types, strings.ToLower(s))
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+func schemaStrings(raw interface{}) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func schemaInt(raw interface{}) (int, bool) {
+	switch v := raw.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, typ := range types {
+		switch typ {
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	// Go リテラルのスキーマ (int) とデコード済みの値 (float64) を JSON 表現で比べる
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, candidate := range enum {
+		if raw, err := json.Marshal(candidate); err == nil && string(raw) == string(encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepairJSON はモデルがよく崩す JSON を寛容に補正する。
+// コードフェンスと前後の説明文を取り除き、末尾カンマを削除し、途中で切れた JSON は
+// 最後の区切りまで戻してから括弧を閉じる。書きかけの文字列値は採用しない。
+func RepairJSON(content string) string {
+	text := stripCodeFence(strings.TrimSpace(content))
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return text
+	}
+	scanned := scanJSON(text[start:])
+	if scanned.complete {
+		return scanned.text
+	}
+	if !scanned.inString {
+		if closed := closeJSON(scanned); json.Valid([]byte(closed)) {
+			return closed
+		}
+	}
+	// 区切りのカンマ位置・開き括弧の直後まで順に戻し、閉じられる最長の前半を採用する
+	for cut := len(scanned.text) - 1; cut > 0; cut-- {
+		prefix, ok := truncationPoint(scanned.text, cut)
+		if !ok {
+			continue
+		}
+		partial := scanJSON(prefix)
+		if partial.inString {
+			continue
+		}
+		if closed := closeJSON(partial); json.Valid([]byte(closed)) {
+			return closed
+		}
+	}
+	return scanned.text
+}
+
+// truncationPoint は cut が文字列外のカンマまたは開き括弧を指すとき、そこで切った前半を返す。
+func truncationPoint(text string, cut int) (string, bool) {
+	switch text[cut] {
+	case ',':
+		if scanJSON(text[:cut]).inString {
+			return "", false
+		}
+		return text[:cut], true
+	case '{', '[':
+		if scanJSON(text[:cut]).inString {
+			return "", false
+		}
+		return text[:cut+1], true
+	default:
+		return "", false
+	}
+}
+
+func stripCodeFence(text string) string {
+	open := strings.Index(text, "```")
+	if open < 0 {
+		return text
+	}
+	body := text[open+3:]
+	if newline := strings.IndexByte(body, '\n'); newline >= 0 {
+		body = body[newline+1:]
+	} else {
+		body = strings.TrimLeft(body, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	}
+	if closing := strings.Index(body, "```"); closing >= 0 {
+		body = body[:closing]
+	}
+	return strings.TrimSpace(body)
+}
+
+type jsonScan struct {
+	text     string
+	stack    []byte
+	inString bool
+	complete bool
+}
+
+// scanJSON は文字列外の末尾カンマを落とし、最上位の値が閉じた時点で後続の説明文を切り捨てる。
+func scanJSON(text string) jsonScan {
+	var (
+		out     strings.Builder
+		result  jsonScan
+		escaped bool
+	)
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if result.inString {
+			out.WriteByte(ch)
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				result.inString = false
+			}
+			continue
+		}
+		switch ch {
+		case '"':
+			result.inString = true
+		case '{':
+			result.stack = append(result.stack, '}')
+		case '[':
+			result.stack = append(result.stack, ']')
+		case '}', ']':
+			if len(result.stack) > 0 {
+				result.stack = result.stack[:len(result.stack)-1]
+			}
+		case ',':
+			if next := nextNonSpace(text, i+1); next == '}' || next == ']' {
+				continue
+			}
+		}
+		out.WriteByte(ch)
+		if (ch == '}' || ch == ']') && len(result.stack) == 0 {
+			result.complete = true
+			break
+		}
+	}
+	result.text = out.String()
+	if escaped {
+		// 末尾の単独バックスラッシュは閉じ引用符をエスケープしてしまうので落とす
+		result.text = result.text[:len(result.text)-1]
+	}
+	return result
+}
+
+func nextNonSpace(text string, from int) byte {
+	for i := from; i < len(text); i++ {
+		switch text[i] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		default:
+			return text[i]
+		}
+	}
+	return 0
+}
+
+func closeJSON(scanned jsonScan) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(strings.TrimSpace(scanned.text), ","))
+	for i := len(scanned.stack) - 1; i >= 0; i-- {
+		b.WriteByte(scanned.stack[i])
+	}
+	return b.String()
+}
+
+// structuredOutputClient は GenerateStructured の応答を ResponseSchema で検証し、
+// 寛容な補正でも直らなければ検証エラーを添えてモデルに再依頼する。
+type structuredOutputClient struct {
+	LLMClient
+	logger     *slog.Logger
+	native     bool
+	maxRepairs int
+}
+
+// newStructuredOutputClient は inner を包む。LM Studio のモデル管理インターフェースは維持する。
+func newStructuredOutputClient(logger *slog.Logger, inner LLMClient, config LLMConfig) LLMClient {
+	maxRepairs := parameterInt(config.Parameters, StructuredMaxRepairAttemptsParam)
+	if maxRepairs <= 0 {
+		maxRepairs = DefaultStructuredMaxRepairAttempts
+	}
+	validated := &structuredOutputClient{
+		LLMClient:  inner,
+		logger:     logger.With("component", "llm_structured_output", "provider", config.Provider),
+		native:     providerSupportsStructuredOutput(config.Provider),
+		maxRepairs: maxRepairs,
+	}
+	if lifecycle, ok := inner.(ModelLifecycleClient); ok {
+		return &structuredOutputLifecycleClient{structuredOutputClient: validated, lifecycle: lifecycle}
+	}
+	return validated
+}
+
+// providerSupportsStructuredOutput は json_schema 制約付き生成を持つプロバイダーかを返す。
+// 持たないプロバイダーは通常補完の応答を同じ検証にかける。
+func providerSupportsStructuredOutput(provider string) bool {
+	switch NormalizeProvider(provider) {
+	case "gemini", "xai":
+		return false
+	default:
+		return true
+	}
+}
+
+// GetEmbeddings は内側のクライアントの一括埋め込みをそのまま使う。
+func (c *structuredOutputClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return GetEmbeddings(ctx, c.LLMClient, texts)
+}
+
+func (c *structuredOutputClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	if len(req.ResponseSchema) == 0 {
+		return c.LLMClient.GenerateStructured(ctx, req)
+	}
+	var usage TokenUsage
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := c.generate(ctx, attemptReq)
+		usage = addTokenUsage(usage, resp.Usage)
+		if err != nil || !resp.Success {
+			resp.Usage = usage
+			return resp, err
+		}
+
+		content, repaired, validationErr := ValidateStructuredContent(resp.Content, req.ResponseSchema)
+		if validationErr == nil {
+			resp.Content = content
+			resp.Usage = usage
+			resp.Metadata = structuredMetadata(req.Metadata, attempt, repaired)
+			return resp, nil
+		}
+		if attempt >= c.maxRepairs {
+			c.logger.WarnContext(ctx, "structured output failed schema validation",
+				slog.Int("repair_attempts", attempt),
+				slog.String("error", validationErr.Error()),
+			)
+			resp.Success = false
+			resp.Error = validationErr.Error()
+			resp.Usage = usage
+			resp.Metadata = structuredMetadata(req.Metadata, attempt, repaired)
+			return resp, fmt.Errorf("%w: repair_attempts=%d: %v", ErrStructuredOutputInvalid, attempt, validationErr)
+		}
+		c.logger.InfoContext(ctx, "structured output invalid, asking model to repair",
+			slog.Int("attempt", attempt+1),
+			slog.String("error", validationErr.Error()),
+		)
+		attemptReq = c.repairRequest(req, resp.Content, validationErr)
+	}
+}
+
+func (c *structuredOutputClient) generate(ctx context.Context, req Request) (Response, error) {
+	if c.native {
+		return c.LLMClient.GenerateStructured(ctx, req)
+	}
+	return c.LLMClient.Complete(ctx, req)
+}
+
+// repairRequest は元の依頼に検証エラーと前回応答を添えた再依頼を作る。
+// スキーマで生成を制約できないプロバイダーにはスキーマ本体も示す。
+func (c *structuredOutputClient) repairRequest(req Request, previous string, validationErr error) Request {
+	preview := previous
+	if utf8.RuneCountInString(preview) > structuredRepairPreviewChars {
+		preview = string([]rune(preview)[:structuredRepairPreviewChars])
+	}
+	var b strings.Builder
+	b.WriteString(req.UserPrompt)
+	b.WriteString("\n\n")
+	fmt.Fprintf(&b, structuredRepairPrompt, validationErr.Error(), preview)
+	if !c.native {
+		if schema, err := json.Marshal(req.ResponseSchema); err == nil {
+			b.WriteString("\nJSON schema:\n")
+			b.Write(schema)
+		}
+	}
+	repair := req
+	repair.UserPrompt = b.String()
+	return repair
+}
+
+// ValidateBatchResponse は ResponseSchema 付きリクエストのバッチ結果を同期経路と同じ検証にかける。
+// バッチ結果はモデルへ再依頼できないため、寛容な補正でも直らない応答は失敗として返す。
+func ValidateBatchResponse(req Request, resp Response) Response {
+	if len(req.ResponseSchema) == 0 || !resp.Success {
+		return resp
+	}
+	content, repaired, err := ValidateStructuredContent(resp.Content, req.ResponseSchema)
+	resp.Metadata = structuredMetadata(resp.Metadata, 0, repaired)
+	if err != nil {
+		resp.Success = false
+		resp.Error = fmt.Sprintf("%s: %v", ErrStructuredOutputInvalid.Error(), err)
+		return resp
+	}
+	resp.Content = content
+	return resp
+}
+
+func structuredMetadata(metadata map[string]interface{}, attempts int, repaired bool) map[string]interface{} {
+	cloned := make(map[string]interface{}, len(metadata)+2)
+	for key, value := range metadata {
+		cloned[key] = value
+	}
+	cloned[StructuredRepairAttemptsMetadataKey] = attempts
+	cloned[StructuredLenientRepairMetadataKey] = repaired
+	return cloned
+}
+
+func addTokenUsage(total TokenUsage, usage TokenUsage) TokenUsage {
+	return TokenUsage{
+		PromptTokens:     total.PromptTokens + usage.PromptTokens,
+		CompletionTokens: total.CompletionTokens + usage.CompletionTokens,
+		TotalTokens:      total.TotalTokens + usage.TotalTokens,
+	}
+}
+
+type structuredOutputLifecycleClient struct {
+	*structuredOutputClient
+	lifecycle ModelLifecycleClient
+}
+
+func (c *structuredOutputLifecycleClient) LoadModel(ctx context.Context, model string, contextLength int) (string, error) {
+	return c.lifecycle.LoadModel(ctx, model, contextLength)
+}
+
+func (c *structuredOutputLifecycleClient) UnloadModel(ctx context.Context, instanceID string) error {
+	return c.lifecycle.UnloadModel(ctx, instanceID)
+}
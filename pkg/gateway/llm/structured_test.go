@@ -0,0 +1,229 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var translationSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"translation"},
+	"properties": map[string]interface{}{
+		"translation": map[string]interface{}{"type": "string", "minLength": 1},
+		"confidence":  map[string]interface{}{"type": "string", "enum": []interface{}{"high", "low"}},
+	},
+	"additionalProperties": false,
+}
+
+// scriptedStructuredClient は呼び出しごとに contents を順に返し、呼ばれたメソッドと依頼を記録する。
+type scriptedStructuredClient struct {
+	mockLLMClient
+	contents []string
+	methods  []string
+	requests []Request
+}
+
+func (c *scriptedStructuredClient) respond(method string, req Request) (Response, error) {
+	c.methods = append(c.methods, method)
+	c.requests = append(c.requests, req)
+	content := c.contents[len(c.requests)-1]
+	return Response{Content: content, Success: true, Usage: TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, Metadata: req.Metadata}, nil
+}
+
+func (c *scriptedStructuredClient) Complete(ctx context.Context, req Request) (Response, error) {
+	return c.respond("complete", req)
+}
+
+func (c *scriptedStructuredClient) GenerateStructured(ctx context.Context, req Request) (Response, error) {
+	return c.respond("structured", req)
+}
+
+func newTestStructuredClient(inner LLMClient, provider string) *structuredOutputClient {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return newStructuredOutputClient(logger, inner, LLMConfig{Provider: provider, Model: "m"}).(*structuredOutputClient)
+}
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "コードフェンスと前後の説明文を取り除く",
+			content: "Here you go:\n```json\n{\"translation\": \"ホワイトラン\"}\n```\nDone.",
+			want:    `{"translation": "ホワイトラン"}`,
+		},
+		{
+			name:    "末尾カンマを取り除く",
+			content: `{"items": ["a", "b",], "translation": "x",}`,
+			want:    `{"items": ["a", "b"], "translation": "x"}`,
+		},
+		{
+			name:    "閉じた値の後ろの説明文を切り捨てる",
+			content: `{"translation": "a}b"} I hope this helps`,
+			want:    `{"translation": "a}b"}`,
+		},
+		{
+			name:    "途中で切れた JSON は書きかけのキーを捨てて括弧を閉じる",
+			content: `{"terms": [{"source": "Whiterun", "target": "ホワイトラン"}, {"source": "Riften", "tar`,
+			want:    `{"terms": [{"source": "Whiterun", "target": "ホワイトラン"}, {"source": "Riften"}]}`,
+		},
+		{
+			name:    "書きかけの文字列値は採用しない",
+			content: `{"speaker": "Lydia", "translation": "従士様、私は`,
+			want:    `{"speaker": "Lydia"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RepairJSON(tc.content)
+			if got != tc.want {
+				t.Fatalf("RepairJSON() = %q, want %q", got, tc.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Fatalf("RepairJSON() returned invalid JSON: %q", got)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		schema  map[string]interface{}
+		wantErr string
+	}{
+		{name: "正常系", content: `{"translation": "衛兵", "confidence": "high"}`, schema: translationSchema},
+		{name: "必須プロパティ欠落", content: `{"confidence": "high"}`, schema: translationSchema, wantErr: `$: missing required property "translation"`},
+		{name: "型不一致", content: `{"translation": 1}`, schema: translationSchema, wantErr: "$.translation: expected string, got number"},
+		{name: "enum 外の値", content: `{"translation": "衛兵", "confidence": "medium"}`, schema: translationSchema, wantErr: "$.confidence: value medium is not one of"},
+		{name: "未定義プロパティ", content: `{"translation": "衛兵", "note": "x"}`, schema: translationSchema, wantErr: `$: unexpected property "note"`},
+		{name: "空文字列", content: `{"translation": ""}`, schema: translationSchema, wantErr: "$.translation: expected at least 1 characters"},
+		{
+			name:    "Gemini 形式の大文字 type",
+			content: `[{"id": 1.5}]`,
+			schema: map[string]interface{}{
+				"type":  "ARRAY",
+				"items": map[string]interface{}{"type": "OBJECT", "properties": map[string]interface{}{"id": map[string]interface{}{"type": "INTEGER"}}},
+			},
+			wantErr: "$[0].id: expected integer, got number",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(tc.content), &value); err != nil {
+				t.Fatalf("invalid test content: %v", err)
+			}
+			err := ValidateJSONSchema(value, tc.schema)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStructuredOutputClient_RepairsLocallyWithoutReasking(t *testing.T) {
+	inner := &scriptedStructuredClient{contents: []string{"```json\n{\"translation\": \"衛兵\",}\n```"}}
+	client := newTestStructuredClient(inner, "lmstudio")
+
+	resp, err := client.GenerateStructured(context.Background(), Request{UserPrompt: "Guard", ResponseSchema: translationSchema})
+	if err != nil {
+		t.Fatalf("GenerateStructured failed: %v", err)
+	}
+	if resp.Content != `{"translation": "衛兵"}` || len(inner.requests) != 1 {
+		t.Fatalf("expected local repair, got content=%q calls=%d", resp.Content, len(inner.requests))
+	}
+	if resp.Metadata[StructuredRepairAttemptsMetadataKey] != 0 || resp.Metadata[StructuredLenientRepairMetadataKey] != true {
+		t.Fatalf("unexpected metadata: %+v", resp.Metadata)
+	}
+}
+
+func TestStructuredOutputClient_ReasksWithValidationError(t *testing.T) {
+	inner := &scriptedStructuredClient{contents: []string{
+		`{"confidence": "high"}`,
+		`{"translation": "衛兵", "confidence": "high"}`,
+	}}
+	client := newTestStructuredClient(inner, "openai_compatible")
+	metadata := map[string]interface{}{"row_id": "npc:1"}
+
+	resp, err := client.GenerateStructured(context.Background(), Request{UserPrompt: "Guard", ResponseSchema: translationSchema, Metadata: metadata})
+	if err != nil {
+		t.Fatalf("GenerateStructured failed: %v", err)
+	}
+	if len(inner.requests) != 2 || inner.methods[1] != "structured" {
+		t.Fatalf("expected one structured re-ask, got methods=%v", inner.methods)
+	}
+	reask := inner.requests[1].UserPrompt
+	if !strings.HasPrefix(reask, "Guard\n\n") || !strings.Contains(reask, `missing required property "translation"`) {
+		t.Fatalf("re-ask prompt must carry the validation error: %q", reask)
+	}
+	if strings.Contains(reask, "JSON schema:") {
+		t.Fatalf("native structured providers must not receive the schema in the prompt: %q", reask)
+	}
+	if resp.Metadata[StructuredRepairAttemptsMetadataKey] != 1 || resp.Metadata["row_id"] != "npc:1" {
+		t.Fatalf("unexpected metadata: %+v", resp.Metadata)
+	}
+	if _, leaked := metadata[StructuredRepairAttemptsMetadataKey]; leaked {
+		t.Fatalf("request metadata must not be mutated: %+v", metadata)
+	}
+	if resp.Usage.TotalTokens != 30 {
+		t.Fatalf("expected usage of both attempts, got %+v", resp.Usage)
+	}
+}
+
+func TestStructuredOutputClient_GivesUpAfterMaxRepairs(t *testing.T) {
+	inner := &scriptedStructuredClient{contents: []string{"not json", "still not json", "nope"}}
+	client := newTestStructuredClient(inner, "gemini")
+
+	resp, err := client.GenerateStructured(context.Background(), Request{UserPrompt: "Guard", ResponseSchema: translationSchema})
+	if !errors.Is(err, ErrStructuredOutputInvalid) {
+		t.Fatalf("expected ErrStructuredOutputInvalid, got %v", err)
+	}
+	if len(inner.requests) != DefaultStructuredMaxRepairAttempts+1 || resp.Success {
+		t.Fatalf("expected %d attempts and failed response, got calls=%d resp=%+v", DefaultStructuredMaxRepairAttempts+1, len(inner.requests), resp)
+	}
+	for _, method := range inner.methods {
+		if method != "complete" {
+			t.Fatalf("providers without json_schema support must use Complete, got %v", inner.methods)
+		}
+	}
+	if !strings.Contains(inner.requests[1].UserPrompt, "JSON schema:") {
+		t.Fatalf("re-ask for unconstrained providers must include the schema: %q", inner.requests[1].UserPrompt)
+	}
+	if resp.Metadata[StructuredRepairAttemptsMetadataKey] != DefaultStructuredMaxRepairAttempts {
+		t.Fatalf("unexpected metadata: %+v", resp.Metadata)
+	}
+}
+
+func TestValidateBatchResponse(t *testing.T) {
+	req := Request{UserPrompt: "Guard", ResponseSchema: translationSchema}
+
+	repaired := ValidateBatchResponse(req, Response{Success: true, Content: "```json\n{\"translation\": \"衛兵\"}\n```"})
+	if !repaired.Success || repaired.Content != `{"translation": "衛兵"}` || repaired.Metadata[StructuredLenientRepairMetadataKey] != true {
+		t.Fatalf("expected leniently repaired batch result, got %+v", repaired)
+	}
+
+	invalid := ValidateBatchResponse(req, Response{Success: true, Content: `{"confidence": "high"}`})
+	if invalid.Success || !strings.Contains(invalid.Error, `missing required property "translation"`) {
+		t.Fatalf("expected schema violation to fail the batch result, got %+v", invalid)
+	}
+
+	plain := Response{Success: true, Content: "衛兵"}
+	if got := ValidateBatchResponse(Request{UserPrompt: "Guard"}, plain); !got.Success || got.Content != "衛兵" {
+		t.Fatalf("requests without schema must pass through, got %+v", got)
+	}
+}
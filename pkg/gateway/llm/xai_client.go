@@ -16,14 +16,15 @@ import (
 )
 
 const (
-	xaiBaseURL           = "https://api.x.ai/v1"
-	xaiChatEndpoint      = "/chat/completions"
-	xaiBatchesEndpoint   = "/batches"
-	xaiModelsEndpoint    = "/models"
-	xaiDefaultTimeout    = 300 * time.Second
-	xaiPollInterval      = 30 * time.Second
-	xaiMaxBatchChunkSize = 100
-	xaiResultsPageSize   = 100
+	xaiBaseURL            = "https://api.x.ai/v1"
+	xaiChatEndpoint       = "/chat/completions"
+	xaiBatchesEndpoint    = "/batches"
+	xaiModelsEndpoint     = "/models"
+	xaiEmbeddingsEndpoint = "/embeddings"
+	xaiDefaultTimeout     = 300 * time.Second
+	xaiPollInterval       = 30 * time.Second
+	xaiMaxBatchChunkSize  = 100
+	xaiResultsPageSize    = 100
 )
 
 // ─────────────────────────────────────────────
@@ -119,20 +120,53 @@ func (c *xaiClient) GenerateStructured(ctx context.Context, req Request) (Respon
 	return Response{}, ErrStructuredOutputNotSupported
 }
 
-// StreamComplete はストリーミングレスポンスを返す（フォールバック）。
+// StreamComplete は stream=true の SSE で部分テキストを逐次返す。
 func (c *xaiClient) StreamComplete(ctx context.Context, req Request) (StreamResponse, error) {
-	c.logger.DebugContext(ctx, "ENTER StreamComplete (non-streaming fallback)")
-	resp, err := c.Complete(ctx, req)
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionLLMRequest)()
+	logFinalPrompt(ctx, c.logger, "xai", requestModeAttr(false), req)
+
+	body, err := openSSEStream(ctx, streamingHTTPClient(c.httpClient), c.retryCfg, func() (*http.Request, error) {
+		return c.buildStreamRequest(ctx, req)
+	})
+	if err != nil {
+		c.logger.ErrorContext(ctx, "xAI stream request failed", telemetry2.ErrorAttrs(err)...)
+		return nil, fmt.Errorf("xai: stream request failed: %w", err)
+	}
+	return newSSEStreamResponse(ctx, body, decodeOpenAIStreamData, req.Metadata), nil
+}
+
+// GetEmbedding は OpenAI 互換の /embeddings でベクトルを返す。
+func (c *xaiClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vector []float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vector, innerErr = doOpenAIEmbedding(ctx, c.httpClient, xaiBaseURL+xaiEmbeddingsEndpoint, model, text, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+		})
+		return innerErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("xai: stream completion fallback failed: %w", err)
+		return nil, fmt.Errorf("xai: embedding model=%s: %w", model, err)
 	}
-	c.logger.DebugContext(ctx, "EXIT StreamComplete")
-	return &xaiStreamResponse{resp: resp}, nil
+	return vector, nil
 }
 
-// GetEmbedding はスタブ実装（xAI は現在 embedding 非対応）。
-func (c *xaiClient) GetEmbedding(_ context.Context, _ string) ([]float32, error) {
-	return nil, fmt.Errorf("xai: GetEmbedding not supported by xAI API")
+// GetEmbeddings は複数テキストを 1 回の /embeddings でベクトル化する。
+func (c *xaiClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	model := embeddingModel(c.config, c.config.Model)
+	var vectors [][]float32
+	err := RetryWithBackoff(ctx, c.retryCfg, func() error {
+		var innerErr error
+		vectors, innerErr = doOpenAIEmbeddings(ctx, c.httpClient, xaiBaseURL+xaiEmbeddingsEndpoint, model, texts, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+		})
+		return innerErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("xai: embeddings model=%s count=%d: %w", model, len(texts), err)
+	}
+	return vectors, nil
 }
 
 // HealthCheck は xAI API への疎通確認を行う。
@@ -179,10 +213,7 @@ func (c *xaiClient) doComplete(ctx context.Context, req Request) (Response, erro
 	}
 
 	if IsRetryableStatusCode(httpResp.StatusCode) {
-		return Response{}, &RetryableError{
-			StatusCode: httpResp.StatusCode,
-			Message:    string(body),
-		}
+		return Response{}, newRetryableError(httpResp, body)
 	}
 	if httpResp.StatusCode != http.StatusOK {
 		return Response{}, fmt.Errorf("xai: API error %d: %s", httpResp.StatusCode, string(body))
@@ -197,7 +228,16 @@ func (c *xaiClient) doComplete(ctx context.Context, req Request) (Response, erro
 
 // buildRequest は xAI OpenAI互換形式の *http.Request を構築する。
 func (c *xaiClient) buildRequest(ctx context.Context, req Request) (*http.Request, error) {
-	c.logger.DebugContext(ctx, "ENTER buildRequest", "model", c.config.Model)
+	return c.newChatRequest(ctx, req, false)
+}
+
+// buildStreamRequest は stream=true（usage 付き）の *http.Request を構築する。
+func (c *xaiClient) buildStreamRequest(ctx context.Context, req Request) (*http.Request, error) {
+	return c.newChatRequest(ctx, req, true)
+}
+
+func (c *xaiClient) newChatRequest(ctx context.Context, req Request, stream bool) (*http.Request, error) {
+	c.logger.DebugContext(ctx, "ENTER buildRequest", "model", c.config.Model, "stream", stream)
 
 	url := xaiBaseURL + xaiChatEndpoint
 
@@ -205,11 +245,15 @@ func (c *xaiClient) buildRequest(ctx context.Context, req Request) (*http.Reques
 		Role    string `json:"role"`
 		Content string `json:"content"`
 	}
+	type streamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	}
 	type requestBody struct {
-		Model       string    `json:"model"`
-		Messages    []message `json:"messages"`
-		Temperature float32   `json:"temperature,omitempty"`
-		Stream      bool      `json:"stream"`
+		Model         string         `json:"model"`
+		Messages      []message      `json:"messages"`
+		Temperature   float32        `json:"temperature,omitempty"`
+		Stream        bool           `json:"stream"`
+		StreamOptions *streamOptions `json:"stream_options,omitempty"`
 	}
 
 	messages := []message{}
@@ -222,7 +266,10 @@ func (c *xaiClient) buildRequest(ctx context.Context, req Request) (*http.Reques
 		Model:       c.config.Model,
 		Messages:    messages,
 		Temperature: req.Temperature,
-		Stream:      false,
+		Stream:      stream,
+	}
+	if stream {
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
 	}
 
 	bodyBytes, err := json.Marshal(body)
@@ -831,19 +878,3 @@ func (b *xaiBatchClient) parseResults(ctx context.Context, body []byte) ([]Respo
 	)
 	return responses, nextToken, nil
 }
-
-// xaiStreamResponse — フォールバック用
-type xaiStreamResponse struct {
-	resp Response
-	done bool
-}
-
-func (s *xaiStreamResponse) Next() (Response, bool) {
-	if s.done {
-		return Response{}, false
-	}
-	s.done = true
-	return s.resp, true
-}
-
-func (s *xaiStreamResponse) Close() error { return nil }
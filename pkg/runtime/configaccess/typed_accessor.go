@@ -66,6 +66,28 @@ func (t *TypedAccessor) GetBool(ctx context.Context, ns string, key string, defa
 	return parseBoolValue(val, defaultVal)
 }
 
+// ApplyProviderParameters copies each key set for the provider into params. A key is looked up in ns,
+// then in the "<ns>.<provider>" namespace, then as "<provider>_<key>" in ns; unset keys are left alone.
+// A nil accessor or empty namespace applies nothing.
+func (t *TypedAccessor) ApplyProviderParameters(ctx context.Context, ns string, provider string, keys []string, params map[string]interface{}) {
+	ns = strings.TrimSpace(ns)
+	if t == nil || ns == "" {
+		return
+	}
+	for _, key := range keys {
+		for _, candidate := range []struct{ ns, key string }{
+			{ns: ns, key: key},
+			{ns: ns + "." + provider, key: key},
+			{ns: ns, key: provider + "_" + key},
+		} {
+			if value := t.GetString(ctx, candidate.ns, candidate.key, ""); value != "" {
+				params[key] = value
+				break
+			}
+		}
+	}
+}
+
 func parseIntValue(val string, defaultVal int) int {
 	var i int
 	if _, err := fmt.Sscanf(val, "%d", &i); err != nil {
@@ -0,0 +1,292 @@
+package llmcache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+)
+
+// Config keys read from the caller's config namespace.
+const (
+	// EnabledKey set to "false"/"off"/"0" opts the namespace out of the response cache.
+	EnabledKey = "response_cache_enabled"
+	// TTLHoursKey overrides how long cached responses stay valid for the namespace.
+	TTLHoursKey = "response_cache_ttl_hours"
+)
+
+// DefaultTTL keeps responses long enough to cover re-running a mod or sharing vanilla names across mods.
+const DefaultTTL = 30 * 24 * time.Hour
+
+type configReader interface {
+	Get(ctx context.Context, namespace string, key string) (string, error)
+}
+
+// Key identifies one cached response. Fingerprint must come from Fingerprint so task metadata is ignored.
+type Key struct {
+	Provider    string
+	Model       string
+	Fingerprint string
+}
+
+// Stats summarizes cache effectiveness for one config namespace.
+type Stats struct {
+	Namespace string  `json:"namespace"`
+	Hits      int64   `json:"hits"`
+	Misses    int64   `json:"misses"`
+	HitRate   float64 `json:"hit_rate"`
+}
+
+// Cache persists successful LLM responses keyed by provider+model+request fingerprint.
+type Cache struct {
+	db     *sql.DB
+	config configReader
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewCache creates the cache tables and drops entries that have already expired.
+func NewCache(ctx context.Context, db *sql.DB, config configReader, logger *slog.Logger) (*Cache, error) {
+	c := &Cache{
+		db:     db,
+		config: config,
+		logger: logger.With("component", "llm_response_cache"),
+		now:    time.Now,
+	}
+	if err := c.initSchema(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := c.PurgeExpired(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cache) initSchema(ctx context.Context) error {
+	if _, err := c.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS llm_response_cache (
+			provider TEXT NOT NULL,
+			model TEXT NOT NULL,
+			fingerprint TEXT NOT NULL,
+			response_json TEXT NOT NULL,
+			hit_count INTEGER NOT NULL DEFAULT 0,
+			rejected INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			PRIMARY KEY (provider, model, fingerprint)
+		);
+		CREATE INDEX IF NOT EXISTS idx_llm_response_cache_expires_at ON llm_response_cache(expires_at);
+
+		CREATE TABLE IF NOT EXISTS llm_response_cache_stats (
+			namespace TEXT PRIMARY KEY,
+			hits INTEGER NOT NULL DEFAULT 0,
+			misses INTEGER NOT NULL DEFAULT 0,
+			updated_at DATETIME NOT NULL
+		);
+	`); err != nil {
+		return fmt.Errorf("create llm response cache tables: %w", err)
+	}
+	// Caches created before rejection tracking lack the column.
+	if _, err := c.db.ExecContext(ctx, `ALTER TABLE llm_response_cache ADD COLUMN rejected INTEGER NOT NULL DEFAULT 0`); err != nil && !isDuplicateColumnError(err) {
+		return fmt.Errorf("add llm response cache rejected column: %w", err)
+	}
+	return nil
+}
+
+func isDuplicateColumnError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "duplicate column")
+}
+
+// Fingerprint is the cache key of req. Unlike the queue's request_fingerprint, task metadata is ignored
+// so identical prompts from different tasks share an entry; replay cassettes use the same key.
+func Fingerprint(req gatewayllm.Request) string {
+	return gatewayllm.RequestFingerprint(req)
+}
+
+// Enabled reports whether namespace has not opted out of the cache.
+func (c *Cache) Enabled(ctx context.Context, namespace string) bool {
+	if c == nil {
+		return false
+	}
+	switch strings.ToLower(c.configValue(ctx, namespace, EnabledKey)) {
+	case "false", "off", "0", "no":
+		return false
+	}
+	return true
+}
+
+// TTL returns the namespace TTL, or DefaultTTL when unset or invalid.
+func (c *Cache) TTL(ctx context.Context, namespace string) time.Duration {
+	raw := c.configValue(ctx, namespace, TTLHoursKey)
+	if raw == "" {
+		return DefaultTTL
+	}
+	hours, err := strconv.ParseFloat(raw, 64)
+	if err != nil || hours <= 0 {
+		c.logger.WarnContext(ctx, "invalid response cache ttl; using default",
+			slog.String("namespace", namespace),
+			slog.String("value", raw),
+		)
+		return DefaultTTL
+	}
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// Lookup returns the cached response for key if it exists, has not expired and was not rejected.
+func (c *Cache) Lookup(ctx context.Context, key Key) (gatewayllm.Response, bool, error) {
+	var raw string
+	err := c.db.QueryRowContext(ctx, `
+		SELECT response_json FROM llm_response_cache
+		WHERE provider = ? AND model = ? AND fingerprint = ? AND expires_at > ? AND rejected = 0
+	`, key.Provider, key.Model, key.Fingerprint, c.now().UTC()).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gatewayllm.Response{}, false, nil
+	}
+	if err != nil {
+		return gatewayllm.Response{}, false, fmt.Errorf("lookup llm response cache fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	var resp gatewayllm.Response
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		return gatewayllm.Response{}, false, fmt.Errorf("decode llm response cache fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	if _, err := c.db.ExecContext(ctx, `
+		UPDATE llm_response_cache SET hit_count = hit_count + 1
+		WHERE provider = ? AND model = ? AND fingerprint = ?
+	`, key.Provider, key.Model, key.Fingerprint); err != nil {
+		return gatewayllm.Response{}, false, fmt.Errorf("update llm response cache hit fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	return resp, true, nil
+}
+
+// Store saves a successful response. Metadata is dropped because it belongs to the originating request.
+// A key whose response was rejected stays rejected until it expires, so the same output is never cached again.
+func (c *Cache) Store(ctx context.Context, key Key, resp gatewayllm.Response, ttl time.Duration) error {
+	if !resp.Success {
+		return nil
+	}
+	resp.Metadata = nil
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("encode llm response cache fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	now := c.now().UTC()
+	if _, err := c.db.ExecContext(ctx, `
+		INSERT INTO llm_response_cache (provider, model, fingerprint, response_json, hit_count, created_at, expires_at)
+		VALUES (?, ?, ?, ?, 0, ?, ?)
+		ON CONFLICT(provider, model, fingerprint) DO UPDATE SET
+			response_json = excluded.response_json,
+			created_at = excluded.created_at,
+			expires_at = excluded.expires_at
+		WHERE llm_response_cache.rejected = 0
+	`, key.Provider, key.Model, key.Fingerprint, string(raw), now, now.Add(ttl)); err != nil {
+		return fmt.Errorf("store llm response cache fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	return nil
+}
+
+// Invalidate marks key as rejected after its response failed the caller's validation.
+// The cached body is dropped and the key is neither served nor stored again until DefaultTTL passes,
+// so re-running the failed rows asks the LLM instead of replaying the rejected output.
+func (c *Cache) Invalidate(ctx context.Context, key Key) error {
+	now := c.now().UTC()
+	if _, err := c.db.ExecContext(ctx, `
+		INSERT INTO llm_response_cache (provider, model, fingerprint, response_json, hit_count, rejected, created_at, expires_at)
+		VALUES (?, ?, ?, '', 0, 1, ?, ?)
+		ON CONFLICT(provider, model, fingerprint) DO UPDATE SET
+			response_json = '',
+			rejected = 1,
+			created_at = excluded.created_at,
+			expires_at = excluded.expires_at
+	`, key.Provider, key.Model, key.Fingerprint, now, now.Add(DefaultTTL)); err != nil {
+		return fmt.Errorf("invalidate llm response cache fingerprint=%s: %w", key.Fingerprint, err)
+	}
+	return nil
+}
+
+// RejectResponse invalidates the cache entry resp was served from or stored under.
+// Responses that did not pass through a cache client carry no key and are ignored.
+func (c *Cache) RejectResponse(ctx context.Context, resp llmio.Response) error {
+	if c == nil {
+		return nil
+	}
+	key, ok := KeyFromMetadata(resp.Metadata)
+	if !ok {
+		return nil
+	}
+	return c.Invalidate(ctx, key)
+}
+
+// PurgeExpired deletes expired entries and returns how many were removed.
+func (c *Cache) PurgeExpired(ctx context.Context) (int64, error) {
+	result, err := c.db.ExecContext(ctx, `DELETE FROM llm_response_cache WHERE expires_at <= ?`, c.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("purge expired llm response cache: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("count purged llm response cache rows: %w", err)
+	}
+	return removed, nil
+}
+
+// RecordLookups adds hit/miss counts for namespace.
+func (c *Cache) RecordLookups(ctx context.Context, namespace string, hits, misses int64) error {
+	if hits == 0 && misses == 0 {
+		return nil
+	}
+	if _, err := c.db.ExecContext(ctx, `
+		INSERT INTO llm_response_cache_stats (namespace, hits, misses, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(namespace) DO UPDATE SET
+			hits = hits + excluded.hits,
+			misses = misses + excluded.misses,
+			updated_at = excluded.updated_at
+	`, namespace, hits, misses, c.now().UTC()); err != nil {
+		return fmt.Errorf("record llm response cache stats namespace=%s: %w", namespace, err)
+	}
+	return nil
+}
+
+// Stats returns per-namespace hit/miss counters ordered by namespace.
+func (c *Cache) Stats(ctx context.Context) ([]Stats, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT namespace, hits, misses FROM llm_response_cache_stats ORDER BY namespace`)
+	if err != nil {
+		return nil, fmt.Errorf("query llm response cache stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]Stats, 0)
+	for rows.Next() {
+		var s Stats
+		if err := rows.Scan(&s.Namespace, &s.Hits, &s.Misses); err != nil {
+			return nil, fmt.Errorf("scan llm response cache stats: %w", err)
+		}
+		if total := s.Hits + s.Misses; total > 0 {
+			s.HitRate = float64(s.Hits) / float64(total)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate llm response cache stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (c *Cache) configValue(ctx context.Context, namespace, key string) string {
+	if c.config == nil || strings.TrimSpace(namespace) == "" {
+		return ""
+	}
+	val, err := c.config.Get(ctx, namespace, key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(val)
+}
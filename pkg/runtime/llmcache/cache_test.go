@@ -0,0 +1,209 @@
+package llmcache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+	_ "modernc.org/sqlite"
+)
+
+type stubConfig map[string]string
+
+func (s stubConfig) Get(_ context.Context, namespace string, key string) (string, error) {
+	if v, ok := s[namespace+"/"+key]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
+type countingClient struct {
+	gatewayllm.LLMClient
+	calls int
+}
+
+func (c *countingClient) Complete(_ context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
+	c.calls++
+	return gatewayllm.Response{
+		Content:  "訳:" + req.UserPrompt,
+		Success:  true,
+		Usage:    gatewayllm.TokenUsage{TotalTokens: 12},
+		Metadata: req.Metadata,
+	}, nil
+}
+
+func newTestCache(t *testing.T, config stubConfig) *Cache {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	cache, err := NewCache(context.Background(), db, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	return cache
+}
+
+func TestFingerprint_IgnoresMetadata(t *testing.T) {
+	a := gatewayllm.Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"task_id": "t1"}}
+	b := gatewayllm.Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"task_id": "t2"}}
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Fatalf("metadata must not change the fingerprint")
+	}
+	b.Temperature = 0.7
+	if Fingerprint(a) == Fingerprint(b) {
+		t.Fatalf("temperature must change the fingerprint")
+	}
+}
+
+func TestClient_ServesRepeatedRequestFromCache(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestCache(t, stubConfig{})
+	inner := &countingClient{}
+	config := gatewayllm.LLMConfig{Provider: "gemini", Model: "gemini-2.0-flash"}
+
+	first := cache.Wrap(ctx, inner, config, "translation_flow.terminology.llm")
+	miss, err := first.Complete(ctx, gatewayllm.Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"id": "mod-a"}})
+	if err != nil {
+		t.Fatalf("first Complete failed: %v", err)
+	}
+	if IsHit(miss.Metadata) {
+		t.Fatalf("provider response must not be marked as a hit: %+v", miss.Metadata)
+	}
+	Flush(ctx, first)
+
+	second := cache.Wrap(ctx, inner, config, "translation_flow.terminology.llm")
+	resp, err := second.Complete(ctx, gatewayllm.Request{UserPrompt: "Whiterun", Metadata: map[string]interface{}{"id": "mod-b"}})
+	if err != nil {
+		t.Fatalf("second Complete failed: %v", err)
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected the second request to be served from cache, calls=%d", inner.calls)
+	}
+	if !IsHit(resp.Metadata) {
+		t.Fatalf("cached response must be marked as a hit: %+v", resp.Metadata)
+	}
+	if resp.Content != "訳:Whiterun" || resp.Metadata["id"] != "mod-b" || resp.Usage.TotalTokens != 0 {
+		t.Fatalf("unexpected cached response: %+v", resp)
+	}
+	if hits, misses := Flush(ctx, second); hits != 1 || misses != 0 {
+		t.Fatalf("unexpected counters hits=%d misses=%d", hits, misses)
+	}
+
+	other := cache.Wrap(ctx, inner, gatewayllm.LLMConfig{Provider: "gemini", Model: "gemini-2.5-pro"}, "translation_flow.terminology.llm")
+	if _, err := other.Complete(ctx, gatewayllm.Request{UserPrompt: "Whiterun"}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if inner.calls != 2 {
+		t.Fatalf("a different model must not reuse the cached response, calls=%d", inner.calls)
+	}
+	Flush(ctx, other)
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Hits != 1 || stats[0].Misses != 2 || stats[0].HitRate < 0.33 || stats[0].HitRate > 0.34 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCache_WrapBypassesCacheWhileRecordingCassette(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestCache(t, stubConfig{})
+	inner := &countingClient{}
+	config := gatewayllm.LLMConfig{
+		Provider:   "gemini",
+		Model:      "gemini-2.0-flash",
+		Parameters: map[string]interface{}{gatewayllm.RecordCassetteParam: "testdata/run.jsonl"},
+	}
+
+	for i := 0; i < 2; i++ {
+		client := cache.Wrap(ctx, inner, config, "translation_flow.terminology.llm")
+		if client != gatewayllm.LLMClient(inner) {
+			t.Fatalf("recording must not be wrapped by the cache")
+		}
+		if _, err := client.Complete(ctx, gatewayllm.Request{UserPrompt: "Whiterun"}); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Fatalf("every recorded request must reach the recorder, calls=%d", inner.calls)
+	}
+}
+
+func TestCache_NamespaceOptOutAndTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestCache(t, stubConfig{
+		"translation_flow.translation/" + EnabledKey:      "false",
+		"translation_flow.terminology.llm/" + TTLHoursKey: "1",
+	})
+	inner := &countingClient{}
+	config := gatewayllm.LLMConfig{Provider: "xai", Model: "grok-3"}
+
+	if wrapped := cache.Wrap(ctx, inner, config, "translation_flow.translation"); wrapped != gatewayllm.LLMClient(inner) {
+		t.Fatalf("opted-out namespace must get the raw client")
+	}
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return clock }
+	client := cache.Wrap(ctx, inner, config, "translation_flow.terminology.llm")
+	req := gatewayllm.Request{UserPrompt: "Riverwood"}
+	_, _ = client.Complete(ctx, req)
+	_, _ = client.Complete(ctx, req)
+	if inner.calls != 1 {
+		t.Fatalf("expected cache hit within ttl, calls=%d", inner.calls)
+	}
+
+	clock = clock.Add(61 * time.Minute)
+	_, _ = client.Complete(ctx, req)
+	if inner.calls != 2 {
+		t.Fatalf("expected expired entry to be refetched, calls=%d", inner.calls)
+	}
+	clock = clock.Add(2 * time.Hour)
+	if removed, err := cache.PurgeExpired(ctx); err != nil || removed != 1 {
+		t.Fatalf("expected one expired entry to be purged: removed=%d err=%v", removed, err)
+	}
+}
+
+func TestCache_RejectedResponseIsNeitherServedNorStoredAgain(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestCache(t, stubConfig{})
+	inner := &countingClient{}
+	config := gatewayllm.LLMConfig{Provider: "gemini", Model: "gemini-2.0-flash"}
+	client := cache.Wrap(ctx, inner, config, "translation_flow.main_translation.llm")
+	req := gatewayllm.Request{UserPrompt: "Talk to the [TAG_0]Jarl[TAG_1].", Metadata: map[string]interface{}{"row_id": "r1"}}
+
+	resp, err := client.Complete(ctx, req)
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if _, ok := KeyFromMetadata(resp.Metadata); !ok {
+		t.Fatalf("expected the response to carry its cache key, got %+v", resp.Metadata)
+	}
+	if _, tagged := req.Metadata[metadataFingerprintKey]; tagged {
+		t.Fatalf("the request metadata must not be modified")
+	}
+
+	if err := cache.RejectResponse(ctx, llmio.Response{Content: resp.Content, Success: true, Metadata: resp.Metadata}); err != nil {
+		t.Fatalf("RejectResponse failed: %v", err)
+	}
+	_, _ = client.Complete(ctx, req)
+	_, _ = client.Complete(ctx, req)
+	if inner.calls != 3 {
+		t.Fatalf("a rejected key must always reach the LLM, calls=%d", inner.calls)
+	}
+
+	if err := cache.RejectResponse(ctx, llmio.Response{Success: true, Metadata: map[string]interface{}{"row_id": "r2"}}); err != nil {
+		t.Fatalf("responses without a cache key must be ignored: %v", err)
+	}
+}
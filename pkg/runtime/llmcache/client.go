@@ -0,0 +1,218 @@
+package llmcache
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+)
+
+// Response metadata keys that carry the cache key, so a caller that rejects the output can invalidate it.
+// metadataHitKey marks responses served from the cache, which spent no tokens.
+const (
+	metadataProviderKey    = "response_cache_provider"
+	metadataModelKey       = "response_cache_model"
+	metadataFingerprintKey = "response_cache_fingerprint"
+	metadataHitKey         = "response_cache_hit"
+)
+
+// Client serves Complete/GenerateStructured/StreamComplete from the cache before dispatching to the wrapped client.
+type Client struct {
+	gatewayllm.LLMClient
+	cache     *Cache
+	namespace string
+	provider  string
+	model     string
+	ttl       time.Duration
+	hits      atomic.Int64
+	misses    atomic.Int64
+}
+
+// Wrap returns client decorated with the cache, or client itself when the cache is nil or namespace opted out.
+// Recording a cassette also bypasses the cache, since cache hits would never reach the recorder.
+// Model lifecycle (LM Studio load/unload) must be handled on the original client before wrapping.
+func (c *Cache) Wrap(ctx context.Context, client gatewayllm.LLMClient, config gatewayllm.LLMConfig, namespace string) gatewayllm.LLMClient {
+	if c == nil || !c.Enabled(ctx, namespace) || isRecordingCassette(config) {
+		return client
+	}
+	return &Client{
+		LLMClient: client,
+		cache:     c,
+		namespace: namespace,
+		provider:  gatewayllm.NormalizeProvider(config.Provider),
+		model:     strings.TrimSpace(config.Model),
+		ttl:       c.TTL(ctx, namespace),
+	}
+}
+
+func isRecordingCassette(config gatewayllm.LLMConfig) bool {
+	path, _ := config.Parameters[gatewayllm.RecordCassetteParam].(string)
+	return strings.TrimSpace(path) != ""
+}
+
+func (c *Client) Complete(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
+	return c.do(ctx, req, c.LLMClient.Complete)
+}
+
+func (c *Client) GenerateStructured(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
+	return c.do(ctx, req, c.LLMClient.GenerateStructured)
+}
+
+// StreamComplete replays a cached body as a single chunk; a fresh stream is stored once it finishes cleanly.
+func (c *Client) StreamComplete(ctx context.Context, req gatewayllm.Request) (gatewayllm.StreamResponse, error) {
+	key := c.key(req)
+	if resp, ok := c.lookup(ctx, key); ok {
+		resp.Metadata = withKeyMetadata(req.Metadata, key)
+		resp.Metadata[metadataHitKey] = true
+		return &replayStream{chunk: resp}, nil
+	}
+	stream, err := c.LLMClient.StreamComplete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingStream{StreamResponse: stream, client: c, ctx: ctx, key: key}, nil
+}
+
+// Flush persists this client's hit/miss counters and returns them.
+func (c *Client) Flush(ctx context.Context) (hits int64, misses int64) {
+	hits = c.hits.Swap(0)
+	misses = c.misses.Swap(0)
+	if err := c.cache.RecordLookups(ctx, c.namespace, hits, misses); err != nil {
+		c.cache.logger.WarnContext(ctx, "failed to record response cache stats", slog.String("error", err.Error()))
+	}
+	return hits, misses
+}
+
+func (c *Client) do(ctx context.Context, req gatewayllm.Request, call func(context.Context, gatewayllm.Request) (gatewayllm.Response, error)) (gatewayllm.Response, error) {
+	key := c.key(req)
+	if resp, ok := c.lookup(ctx, key); ok {
+		resp.Metadata = withKeyMetadata(req.Metadata, key)
+		resp.Metadata[metadataHitKey] = true
+		return resp, nil
+	}
+	resp, err := call(ctx, req)
+	if err == nil && resp.Success {
+		c.store(ctx, key, resp)
+		resp.Metadata = withKeyMetadata(resp.Metadata, key)
+	}
+	return resp, err
+}
+
+// withKeyMetadata returns a copy of metadata tagged with key; the request's own map is shared and left untouched.
+func withKeyMetadata(metadata map[string]interface{}, key Key) map[string]interface{} {
+	tagged := make(map[string]interface{}, len(metadata)+3)
+	for k, v := range metadata {
+		tagged[k] = v
+	}
+	tagged[metadataProviderKey] = key.Provider
+	tagged[metadataModelKey] = key.Model
+	tagged[metadataFingerprintKey] = key.Fingerprint
+	return tagged
+}
+
+// KeyFromMetadata returns the cache key a cache client attached to a response's metadata.
+func KeyFromMetadata(metadata map[string]interface{}) (Key, bool) {
+	fingerprint, _ := metadata[metadataFingerprintKey].(string)
+	if fingerprint == "" {
+		return Key{}, false
+	}
+	provider, _ := metadata[metadataProviderKey].(string)
+	model, _ := metadata[metadataModelKey].(string)
+	return Key{Provider: provider, Model: model, Fingerprint: fingerprint}, true
+}
+
+// IsHit reports whether a response was served from the cache rather than by the provider.
+func IsHit(metadata map[string]interface{}) bool {
+	hit, _ := metadata[metadataHitKey].(bool)
+	return hit
+}
+
+func (c *Client) key(req gatewayllm.Request) Key {
+	return Key{Provider: c.provider, Model: c.model, Fingerprint: Fingerprint(req)}
+}
+
+// lookup treats cache errors as misses so a broken cache never blocks translation.
+func (c *Client) lookup(ctx context.Context, key Key) (gatewayllm.Response, bool) {
+	resp, ok, err := c.cache.Lookup(ctx, key)
+	if err != nil {
+		c.cache.logger.WarnContext(ctx, "response cache lookup failed", slog.String("error", err.Error()))
+	}
+	if ok {
+		c.hits.Add(1)
+		// Tokens were not spent for this request.
+		resp.Usage = gatewayllm.TokenUsage{}
+		return resp, true
+	}
+	c.misses.Add(1)
+	return gatewayllm.Response{}, false
+}
+
+func (c *Client) store(ctx context.Context, key Key, resp gatewayllm.Response) {
+	if !resp.Success || resp.Content == "" {
+		return
+	}
+	if err := c.cache.Store(ctx, key, resp, c.ttl); err != nil {
+		c.cache.logger.WarnContext(ctx, "response cache store failed", slog.String("error", err.Error()))
+	}
+}
+
+// Flush persists hit/miss counters when client is a cache client; other clients are ignored.
+func Flush(ctx context.Context, client gatewayllm.LLMClient) (hits int64, misses int64) {
+	cached, ok := client.(*Client)
+	if !ok {
+		return 0, 0
+	}
+	return cached.Flush(ctx)
+}
+
+type replayStream struct {
+	chunk gatewayllm.Response
+	done  bool
+}
+
+func (s *replayStream) Next() (gatewayllm.Response, bool) {
+	if s.done {
+		return gatewayllm.Response{}, false
+	}
+	s.done = true
+	return s.chunk, true
+}
+
+func (s *replayStream) Close() error {
+	s.done = true
+	return nil
+}
+
+type recordingStream struct {
+	gatewayllm.StreamResponse
+	client  *Client
+	ctx     context.Context
+	key     Key
+	content strings.Builder
+	usage   gatewayllm.TokenUsage
+	failed  bool
+	stored  bool
+}
+
+func (s *recordingStream) Next() (gatewayllm.Response, bool) {
+	chunk, ok := s.StreamResponse.Next()
+	if !ok {
+		if !s.failed && !s.stored && s.ctx.Err() == nil {
+			s.stored = true
+			s.client.store(s.ctx, s.key, gatewayllm.Response{Content: s.content.String(), Success: true, Usage: s.usage})
+		}
+		return chunk, ok
+	}
+	if !chunk.Success {
+		s.failed = true
+		return chunk, ok
+	}
+	s.content.WriteString(chunk.Content)
+	if chunk.Usage.TotalTokens > 0 {
+		s.usage = chunk.Usage
+	}
+	return chunk, ok
+}
@@ -0,0 +1,114 @@
+package llmexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+)
+
+// EmbeddingConfigNamespace holds the provider, model, endpoint and api_key used for embeddings.
+const EmbeddingConfigNamespace = "embedding"
+
+// ErrEmbeddingNotConfigured is returned when no embedding provider/model has been selected.
+var ErrEmbeddingNotConfigured = errors.New("embedding provider is not configured")
+
+type embeddingConfigReader interface {
+	Get(ctx context.Context, namespace string, key string) (string, error)
+}
+
+type embeddingSecretReader interface {
+	GetSecret(ctx context.Context, namespace string, key string) (string, error)
+}
+
+// Embedder adapts gateway embedding clients to the slice-level TextEmbedder contract.
+// Settings are read on every call so a changed embedding model takes effect without restart.
+type Embedder struct {
+	llmManager gatewayllm.LLMManager
+	config     embeddingConfigReader
+	secrets    embeddingSecretReader
+}
+
+// NewEmbedder creates an embedding adapter backed by the shared LLM manager.
+func NewEmbedder(llmManager gatewayllm.LLMManager, config embeddingConfigReader, secrets embeddingSecretReader) *Embedder {
+	return &Embedder{llmManager: llmManager, config: config, secrets: secrets}
+}
+
+// EmbeddingModel returns "provider:model", or "" when embeddings are disabled.
+func (e *Embedder) EmbeddingModel() string {
+	cfg, err := e.resolveConfig(context.Background())
+	if err != nil {
+		return ""
+	}
+	return cfg.Provider + ":" + cfg.Model
+}
+
+// Embed returns the embedding vector of text using the configured provider.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	cfg, err := e.resolveConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := e.llmManager.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create embedding client provider=%s: %w", cfg.Provider, err)
+	}
+	vector, err := client.GetEmbedding(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("get embedding provider=%s model=%s: %w", cfg.Provider, cfg.Model, err)
+	}
+	return vector, nil
+}
+
+// EmbedBatch returns one embedding vector per text, using the provider's batch endpoint when available.
+func (e *Embedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	cfg, err := e.resolveConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := e.llmManager.GetClient(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create embedding client provider=%s: %w", cfg.Provider, err)
+	}
+	vectors, err := gatewayllm.GetEmbeddings(ctx, client, texts)
+	if err != nil {
+		return nil, fmt.Errorf("get embeddings provider=%s model=%s count=%d: %w", cfg.Provider, cfg.Model, len(texts), err)
+	}
+	return vectors, nil
+}
+
+func (e *Embedder) resolveConfig(ctx context.Context) (gatewayllm.LLMConfig, error) {
+	provider := gatewayllm.NormalizeProvider(e.get(ctx, "provider"))
+	model := e.get(ctx, "model")
+	if provider == "" || model == "" {
+		return gatewayllm.LLMConfig{}, ErrEmbeddingNotConfigured
+	}
+	apiKey := e.get(ctx, "api_key")
+	if apiKey == "" && provider != "lmstudio" && e.secrets != nil {
+		if val, err := e.secrets.GetSecret(ctx, EmbeddingConfigNamespace, provider+"_api_key"); err == nil {
+			apiKey = strings.TrimSpace(val)
+		}
+	}
+	return gatewayllm.LLMConfig{
+		Provider: provider,
+		APIKey:   apiKey,
+		Endpoint: e.get(ctx, "endpoint"),
+		Model:    model,
+		Parameters: map[string]interface{}{
+			gatewayllm.LLMEmbeddingModelParam: model,
+		},
+	}, nil
+}
+
+func (e *Embedder) get(ctx context.Context, key string) string {
+	if e.config == nil {
+		return ""
+	}
+	val, err := e.config.Get(ctx, EmbeddingConfigNamespace, key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(val)
+}
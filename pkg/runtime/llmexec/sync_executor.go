@@ -4,19 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
 	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/configaccess"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmcache"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 )
 
 const batchPollingInterval = 1 * time.Second
 
+type configReader interface {
+	Get(ctx context.Context, namespace string, key string) (string, error)
+}
+
 // SyncExecutor runs workflow-level synchronous LLM requests through the gateway layer.
 type SyncExecutor struct {
-	llmManager gatewayllm.LLMManager
+	llmManager     gatewayllm.LLMManager
+	configAccessor *configaccess.TypedAccessor
+	responseCache  *llmcache.Cache
+	usageLedger    *llmusage.Ledger
 }
 
 // NewSyncExecutor creates a runtime adapter for synchronous LLM execution.
@@ -24,6 +35,21 @@ func NewSyncExecutor(llmManager gatewayllm.LLMManager) *SyncExecutor {
 	return &SyncExecutor{llmManager: llmManager}
 }
 
+// SetConfigReader enables resolving provider settings such as rate limits from the execution's config namespace.
+func (e *SyncExecutor) SetConfigReader(reader configReader) {
+	e.configAccessor = configaccess.NewTypedAccessor(reader)
+}
+
+// SetResponseCache enables serving repeated sync requests from the persistent response cache.
+func (e *SyncExecutor) SetResponseCache(cache *llmcache.Cache) {
+	e.responseCache = cache
+}
+
+// SetUsageLedger enables recording per-request token usage for cost accounting.
+func (e *SyncExecutor) SetUsageLedger(ledger *llmusage.Ledger) {
+	e.usageLedger = ledger
+}
+
 // Execute resolves a client and executes requests in input order.
 func (e *SyncExecutor) Execute(ctx context.Context, config llmio.ExecutionConfig, requests []llmio.Request) ([]llmio.Response, error) {
 	return e.ExecuteWithProgress(ctx, config, requests, nil)
@@ -35,6 +61,18 @@ func (e *SyncExecutor) ExecuteWithProgress(
 	config llmio.ExecutionConfig,
 	requests []llmio.Request,
 	progress func(completed, total int),
+) ([]llmio.Response, error) {
+	return e.ExecuteWithStreamProgress(ctx, config, requests, progress, nil)
+}
+
+// ExecuteWithStreamProgress behaves like ExecuteWithProgress and additionally reports the received
+// character count of long book bodies, which the sync strategy streams instead of completing in one call.
+func (e *SyncExecutor) ExecuteWithStreamProgress(
+	ctx context.Context,
+	config llmio.ExecutionConfig,
+	requests []llmio.Request,
+	progress func(completed, total int),
+	streamProgress func(receivedChars int),
 ) ([]llmio.Response, error) {
 	llmConfig := gatewayllm.LLMConfig{
 		Provider: config.Provider,
@@ -46,19 +84,64 @@ func (e *SyncExecutor) ExecuteWithProgress(
 		},
 		Concurrency: config.SyncConcurrency,
 	}
+	// Resolve the same optional provider settings as the worker path (cassette recording, rate limits,
+	// circuit breaker, openai_compatible auth and batch API), so sync and batch clients behave the same.
+	provider := gatewayllm.NormalizeProvider(llmConfig.Provider)
+	e.configAccessor.ApplyProviderParameters(ctx, config.ConfigNamespace, provider, gatewayllm.ProviderParameterKeys(provider), llmConfig.Parameters)
 	strategy := resolveBulkStrategy(config.BulkStrategy)
-	resolvedStrategy := e.llmManager.ResolveBulkStrategy(ctx, strategy, config.Provider)
+	resolvedStrategy := e.llmManager.ResolveBulkStrategy(ctx, strategy, llmConfig)
+	var responses []llmio.Response
+	var err error
+	usageStrategy := llmusage.BulkStrategySync
 	if resolvedStrategy == gatewayllm.BulkStrategyBatch {
-		return e.executeBatch(ctx, llmConfig, requests, progress)
+		usageStrategy = llmusage.BulkStrategyBatch
+		responses, err = e.executeBatch(ctx, llmConfig, config.Phase, requests, progress)
+	} else {
+		responses, err = e.executeSync(ctx, llmConfig, config.ConfigNamespace, requests, progress, streamProgress)
+	}
+	e.recordUsage(context.WithoutCancel(ctx), config, usageStrategy, responses)
+	return responses, err
+}
+
+// recordUsage stores usage of the responses that came back; accounting never fails the run.
+func (e *SyncExecutor) recordUsage(ctx context.Context, config llmio.ExecutionConfig, strategy string, responses []llmio.Response) {
+	if e.usageLedger == nil || strings.TrimSpace(config.TaskID) == "" || len(responses) == 0 {
+		return
+	}
+	records := make([]llmusage.Record, 0, len(responses))
+	for _, response := range responses {
+		if !response.Success && response.Error == "" {
+			// Not dispatched before cancellation.
+			continue
+		}
+		if llmcache.IsHit(response.Metadata) {
+			// Served from the response cache without spending tokens.
+			continue
+		}
+		records = append(records, llmusage.Record{
+			TaskID:           config.TaskID,
+			Phase:            config.Phase,
+			Provider:         gatewayllm.NormalizeProvider(config.Provider),
+			Model:            config.Model,
+			BulkStrategy:     strategy,
+			PromptTokens:     response.Usage.PromptTokens,
+			CompletionTokens: response.Usage.CompletionTokens,
+			TotalTokens:      response.Usage.TotalTokens,
+			Success:          response.Success,
+		})
+	}
+	if err := e.usageLedger.Record(ctx, records); err != nil {
+		slog.WarnContext(ctx, "failed to record llm usage", slog.String("task_id", config.TaskID), slog.String("error", err.Error()))
 	}
-	return e.executeSync(ctx, llmConfig, requests, progress)
 }
 
 func (e *SyncExecutor) executeSync(
 	ctx context.Context,
 	llmConfig gatewayllm.LLMConfig,
+	namespace string,
 	requests []llmio.Request,
 	progress func(completed, total int),
+	streamProgress func(receivedChars int),
 ) ([]llmio.Response, error) {
 	client, err := e.llmManager.GetClient(ctx, llmConfig)
 	if err != nil {
@@ -79,7 +162,11 @@ func (e *SyncExecutor) executeSync(
 
 	gatewayReqs := toGatewayRequests(requests, false)
 
+	// Stream inside the cache so cached book bodies are served without opening a stream.
+	client = gatewayllm.NewBookStreamingClient(client, streamProgress)
+	client = e.responseCache.Wrap(ctx, client, llmConfig, namespace)
 	responses, err := gatewayllm.ExecuteBulkSyncWithProgress(ctx, client, gatewayReqs, llmConfig.Concurrency, progress)
+	llmcache.Flush(context.WithoutCancel(ctx), client)
 	if lifecycleClient != nil {
 		unloadCtx := context.WithoutCancel(ctx)
 		if unloadErr := lifecycleClient.UnloadModel(unloadCtx, instanceID); unloadErr != nil {
@@ -122,15 +209,16 @@ func extractContextLength(parameters map[string]interface{}) int {
 func (e *SyncExecutor) executeBatch(
 	ctx context.Context,
 	llmConfig gatewayllm.LLMConfig,
+	phase string,
 	requests []llmio.Request,
 	progress func(completed, total int),
 ) ([]llmio.Response, error) {
 	batchClient, err := e.llmManager.GetBatchClient(ctx, llmConfig)
 	if err != nil {
-		return nil, fmt.Errorf("create terminology batch client: %w", err)
+		return nil, fmt.Errorf("create batch client phase=%s: %w", phase, err)
 	}
 
-	gatewayReqs, requestOrder := toBatchGatewayRequests(requests)
+	gatewayReqs, requestOrder := toBatchGatewayRequests(phase, requests)
 	jobID, err := batchClient.SubmitBatch(ctx, gatewayReqs)
 	if err != nil {
 		return nil, fmt.Errorf("submit batch: %w", err)
@@ -166,7 +254,11 @@ func (e *SyncExecutor) executeBatch(
 		progress(len(requests), len(requests))
 	}
 
-	return toExecutionResponses(reorderBatchResponses(responses, requests, requestOrder)), nil
+	ordered := reorderBatchResponses(responses, requests, requestOrder)
+	for idx := range ordered {
+		ordered[idx] = gatewayllm.ValidateBatchResponse(gatewayllm.Request{ResponseSchema: requests[idx].ResponseSchema}, ordered[idx])
+	}
+	return toExecutionResponses(ordered), nil
 }
 
 func toGatewayRequests(requests []llmio.Request, attachSequence bool) []gatewayllm.Request {
@@ -188,14 +280,14 @@ func toGatewayRequests(requests []llmio.Request, attachSequence bool) []gatewayl
 	return gatewayReqs
 }
 
-func toBatchGatewayRequests(requests []llmio.Request) ([]gatewayllm.Request, []string) {
+func toBatchGatewayRequests(phase string, requests []llmio.Request) ([]gatewayllm.Request, []string) {
 	gatewayReqs := make([]gatewayllm.Request, 0, len(requests))
 	requestOrder := make([]string, 0, len(requests))
 	for idx, request := range requests {
 		metadata := copyMetadata(request.Metadata)
 		queueJobID := readQueueJobID(metadata)
 		if queueJobID == "" {
-			queueJobID = buildTerminologyQueueJobID(idx)
+			queueJobID = buildBatchQueueJobID(phase, idx)
 		}
 		metadata[gatewayllm.BatchMetadataQueueJobIDKey] = queueJobID
 		metadata[gatewayllm.BatchMetadataQueueRequestSeqKey] = idx
@@ -223,8 +315,13 @@ func copyMetadata(metadata map[string]interface{}) map[string]interface{} {
 	return cloned
 }
 
-func buildTerminologyQueueJobID(idx int) string {
-	return fmt.Sprintf("terminology-%d", idx)
+// buildBatchQueueJobID names a sync batch request after the phase it belongs to, e.g. "main_translation-3".
+func buildBatchQueueJobID(phase string, idx int) string {
+	phase = strings.TrimSpace(phase)
+	if phase == "" {
+		phase = "sync"
+	}
+	return fmt.Sprintf("%s-%d", phase, idx)
 }
 
 func readQueueJobID(metadata map[string]interface{}) string {
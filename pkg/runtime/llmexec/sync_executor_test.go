@@ -2,6 +2,8 @@ package llmexec
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
@@ -17,12 +19,12 @@ func TestSyncExecutorExecuteWithProgress_UsesQueueJobIDContractAndBatchClient(t
 			{
 				Success:  true,
 				Content:  "res-b",
-				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-1"},
+				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "main_translation-1"},
 			},
 			{
 				Success:  true,
 				Content:  "res-a",
-				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-0"},
+				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "main_translation-0"},
 			},
 		},
 	}
@@ -41,6 +43,7 @@ func TestSyncExecutorExecuteWithProgress_UsesQueueJobIDContractAndBatchClient(t
 		Provider:     "xai",
 		Model:        "grok-3",
 		BulkStrategy: "batch",
+		Phase:        "main_translation",
 	}, requests, func(completed, total int) {
 		_ = total
 		progressLog = append(progressLog, completed)
@@ -60,11 +63,11 @@ func TestSyncExecutorExecuteWithProgress_UsesQueueJobIDContractAndBatchClient(t
 	if len(batchClient.submittedRequests) != 2 {
 		t.Fatalf("submitted requests = %d, want 2", len(batchClient.submittedRequests))
 	}
-	if got := batchClient.submittedRequests[0].Metadata[gatewayllm.BatchMetadataQueueJobIDKey]; got != "terminology-0" {
-		t.Fatalf("request[0] queue_job_id = %v, want terminology-0", got)
+	if got := batchClient.submittedRequests[0].Metadata[gatewayllm.BatchMetadataQueueJobIDKey]; got != "main_translation-0" {
+		t.Fatalf("request[0] queue_job_id = %v, want main_translation-0", got)
 	}
-	if got := batchClient.submittedRequests[1].Metadata[gatewayllm.BatchMetadataQueueJobIDKey]; got != "terminology-1" {
-		t.Fatalf("request[1] queue_job_id = %v, want terminology-1", got)
+	if got := batchClient.submittedRequests[1].Metadata[gatewayllm.BatchMetadataQueueJobIDKey]; got != "main_translation-1" {
+		t.Fatalf("request[1] queue_job_id = %v, want main_translation-1", got)
 	}
 	if got := batchClient.submittedRequests[0].Metadata[gatewayllm.BatchMetadataQueueRequestSeqKey]; got != 0 {
 		t.Fatalf("request[0] queue_request_seq = %v, want 0", got)
@@ -104,7 +107,7 @@ func TestSyncExecutorExecuteWithProgress_BatchTerminalStatesAlwaysCompleteProgre
 					{
 						Success:  true,
 						Content:  "ok",
-						Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-0"},
+						Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "sync-0"},
 					},
 				},
 			}
@@ -187,12 +190,12 @@ func TestSyncExecutorExecuteWithProgress_BatchDuplicateQueueJobIDFallsBackToRema
 			{
 				Success:  true,
 				Content:  "first-for-0",
-				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-0"},
+				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "sync-0"},
 			},
 			{
 				Success:  true,
 				Content:  "dup-for-0",
-				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-0"},
+				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "sync-0"},
 			},
 		},
 	}
@@ -240,7 +243,7 @@ func TestSyncExecutorExecuteWithProgress_BatchMissingResultCreatesFailureStub(t
 			{
 				Success:  true,
 				Content:  "only-first",
-				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-0"},
+				Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "sync-0"},
 			},
 		},
 	}
@@ -282,6 +285,43 @@ func TestSyncExecutorExecuteWithProgress_BatchMissingResultCreatesFailureStub(t
 	assertProgressEndsAt(t, progressLog, 2)
 }
 
+func TestSyncExecutorExecuteWithProgress_BatchValidatesStructuredResults(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"translation"},
+		"properties": map[string]interface{}{"translation": map[string]interface{}{"type": "string"}},
+	}
+	batchClient := &stubBatchClient{
+		statuses: []gatewayllm.BatchStatus{{State: gatewayllm.BatchStateCompleted, Progress: 1.0}},
+		results: []gatewayllm.Response{
+			{Success: true, Content: `{"translation": "鉄の剣",}`, Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "sync-0"}},
+			{Success: true, Content: "TL: |鉄の剣|", Metadata: map[string]interface{}{gatewayllm.BatchMetadataQueueJobIDKey: "terminology-1"}},
+		},
+	}
+	manager := &stubLLMManager{bulkStrategy: gatewayllm.BulkStrategyBatch, batchClient: batchClient}
+	executor := NewSyncExecutor(manager)
+	responses, err := executor.ExecuteWithProgress(context.Background(), llmio.ExecutionConfig{
+		Provider:     "xai",
+		Model:        "grok-3",
+		BulkStrategy: "batch",
+	}, []llmio.Request{
+		{ResponseSchema: schema, Metadata: map[string]interface{}{"source_text": "Iron Sword"}},
+		{ResponseSchema: schema, Metadata: map[string]interface{}{"source_text": "Steel Sword"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("ExecuteWithProgress failed: %v", err)
+	}
+	if !responses[0].Success || responses[0].Content != `{"translation": "鉄の剣"}` {
+		t.Fatalf("expected leniently repaired response[0], got %+v", responses[0])
+	}
+	if responses[1].Success || !strings.Contains(responses[1].Error, "structured output") {
+		t.Fatalf("expected schema violation to fail response[1], got %+v", responses[1])
+	}
+	if got := responses[1].Metadata["source_text"]; got != "Steel Sword" {
+		t.Fatalf("response[1] must keep request metadata, got %v", got)
+	}
+}
+
 func TestSyncExecutorExecuteWithProgress_FallsBackToSyncWhenResolvedStrategyIsSync(t *testing.T) {
 	manager := &stubLLMManager{
 		bulkStrategy: gatewayllm.BulkStrategySync,
@@ -317,6 +357,124 @@ func TestSyncExecutorExecuteWithProgress_FallsBackToSyncWhenResolvedStrategyIsSy
 	}
 }
 
+func TestSyncExecutorExecuteWithProgress_ResolvesProviderParametersFromConfigNamespace(t *testing.T) {
+	newManager := func() *stubLLMManager {
+		return &stubLLMManager{
+			bulkStrategy: gatewayllm.BulkStrategySync,
+			client: &stubLLMClient{
+				completeFn: func(req gatewayllm.Request) gatewayllm.Response {
+					return gatewayllm.Response{Success: true, Content: "ok", Metadata: req.Metadata}
+				},
+			},
+		}
+	}
+	execConfig := llmio.ExecutionConfig{
+		Provider:        "gemini",
+		Model:           "gemini-2.5-flash",
+		SyncConcurrency: 1,
+		ConfigNamespace: "translation_flow.translation",
+	}
+	requests := []llmio.Request{{Metadata: map[string]interface{}{"source_text": "A"}}}
+
+	t.Run("名前空間のレート制限と記録先をクライアント設定へ引き継ぐ", func(t *testing.T) {
+		manager := newManager()
+		executor := NewSyncExecutor(manager)
+		executor.SetConfigReader(stubConfigReader{
+			"translation_flow.translation":        {gatewayllm.RateLimitRPMParam: "30", gatewayllm.RecordCassetteParam: "testdata/main.jsonl"},
+			"translation_flow.translation.gemini": {gatewayllm.RateLimitTPMParam: "100000"},
+		})
+		if _, err := executor.ExecuteWithProgress(context.Background(), execConfig, requests, nil); err != nil {
+			t.Fatalf("ExecuteWithProgress failed: %v", err)
+		}
+		if len(manager.clientConfigs) != 1 {
+			t.Fatalf("expected one client config, got %d", len(manager.clientConfigs))
+		}
+		limit := gatewayllm.RateLimitFromConfig(manager.clientConfigs[0])
+		if !limit.Enabled() || limit.RequestsPerMinute != 30 || limit.TokensPerMinute != 100000 {
+			t.Fatalf("unexpected rate limit: %+v", limit)
+		}
+		if got := manager.clientConfigs[0].Parameters[gatewayllm.RecordCassetteParam]; got != "testdata/main.jsonl" {
+			t.Fatalf("record_cassette must be propagated, got %v", got)
+		}
+	})
+
+	t.Run("openai_compatible の認証設定を引き継ぐ", func(t *testing.T) {
+		manager := newManager()
+		executor := NewSyncExecutor(manager)
+		executor.SetConfigReader(stubConfigReader{
+			"translation_flow.translation": {
+				"openai_compatible_" + gatewayllm.OpenAICompatibleAuthHeaderParam: "api-key",
+				gatewayllm.OpenAICompatibleAuthSchemeParam:                        "Token",
+			},
+			"translation_flow.translation.openai_compatible": {
+				gatewayllm.OpenAICompatibleModelsEndpointParam: "/openai/models",
+				gatewayllm.OpenAICompatibleBatchAPIParam:       "true",
+			},
+		})
+		compatibleConfig := execConfig
+		compatibleConfig.Provider = "openai_compatible"
+		compatibleConfig.Endpoint = "https://example.invalid/v1"
+		if _, err := executor.ExecuteWithProgress(context.Background(), compatibleConfig, requests, nil); err != nil {
+			t.Fatalf("ExecuteWithProgress failed: %v", err)
+		}
+		params := manager.clientConfigs[0].Parameters
+		if params[gatewayllm.OpenAICompatibleAuthHeaderParam] != "api-key" || params[gatewayllm.OpenAICompatibleAuthSchemeParam] != "Token" {
+			t.Fatalf("auth_header and auth_scheme must be propagated, got %v", params)
+		}
+		if params[gatewayllm.OpenAICompatibleModelsEndpointParam] != "/openai/models" || params[gatewayllm.OpenAICompatibleBatchAPIParam] != "true" {
+			t.Fatalf("models_endpoint and batch_api must be propagated, got %v", params)
+		}
+		if !gatewayllm.ConfigSupportsBatch(manager.clientConfigs[0]) {
+			t.Fatalf("batch client must see the same openai_compatible settings")
+		}
+	})
+
+	t.Run("設定がなければ無制限のまま", func(t *testing.T) {
+		manager := newManager()
+		executor := NewSyncExecutor(manager)
+		executor.SetConfigReader(stubConfigReader{})
+		if _, err := executor.ExecuteWithProgress(context.Background(), execConfig, requests, nil); err != nil {
+			t.Fatalf("ExecuteWithProgress failed: %v", err)
+		}
+		if limit := gatewayllm.RateLimitFromConfig(manager.clientConfigs[0]); limit.Enabled() {
+			t.Fatalf("expected no rate limit, got %+v", limit)
+		}
+	})
+}
+
+func TestSyncExecutorExecuteWithStreamProgress_StreamsLongBookBodies(t *testing.T) {
+	chunk := strings.Repeat("あ", gatewayllm.BookStreamProgressChars/3+1)
+	client := &stubLLMClient{streamChunks: []string{chunk, chunk, "。"}}
+	manager := &stubLLMManager{bulkStrategy: gatewayllm.BulkStrategySync, client: client}
+	executor := NewSyncExecutor(manager)
+
+	bookText := strings.Repeat("a", gatewayllm.BookStreamMinSourceChars)
+	requests := []llmio.Request{
+		{Metadata: map[string]interface{}{"record_type": "BOOK DESC", "source_text": bookText}},
+		{Metadata: map[string]interface{}{"record_type": "BOOK FULL", "source_text": "The Lusty Argonian Maid"}},
+	}
+	streamed := make([]int, 0)
+	responses, err := executor.ExecuteWithStreamProgress(context.Background(), llmio.ExecutionConfig{
+		Provider:        "gemini",
+		Model:           "gemini-2.5-flash",
+		SyncConcurrency: 1,
+	}, requests, nil, func(receivedChars int) {
+		streamed = append(streamed, receivedChars)
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithStreamProgress failed: %v", err)
+	}
+	if client.streamCalls != 1 || client.completeCalls != 1 {
+		t.Fatalf("expected only the book body to stream, stream=%d complete=%d", client.streamCalls, client.completeCalls)
+	}
+	if !responses[0].Success || responses[0].Content != chunk+chunk+"。" {
+		t.Fatalf("unexpected streamed response: %+v", responses[0])
+	}
+	if len(streamed) != 2 {
+		t.Fatalf("expected 2 throttled stream progress reports, got %v", streamed)
+	}
+}
+
 func TestSyncExecutorExecuteWithProgress_LoadsAndUnloadsLifecycleClient(t *testing.T) {
 	lifecycleClient := &stubLifecycleLLMClient{
 		stubLLMClient: stubLLMClient{
@@ -409,17 +567,25 @@ func assertProgressEndsAt(t *testing.T, progressLog []int, want int) {
 	}
 }
 
+type stubConfigReader map[string]map[string]string
+
+func (s stubConfigReader) Get(ctx context.Context, namespace string, key string) (string, error) {
+	_ = ctx
+	return s[namespace][key], nil
+}
+
 type stubLLMManager struct {
 	client              gatewayllm.LLMClient
 	batchClient         gatewayllm.BatchClient
 	bulkStrategy        gatewayllm.BulkStrategy
 	getClientCalls      int
 	getBatchClientCalls int
+	clientConfigs       []gatewayllm.LLMConfig
 }
 
 func (s *stubLLMManager) GetClient(ctx context.Context, config gatewayllm.LLMConfig) (gatewayllm.LLMClient, error) {
 	_ = ctx
-	_ = config
+	s.clientConfigs = append(s.clientConfigs, config)
 	s.getClientCalls++
 	return s.client, nil
 }
@@ -431,10 +597,10 @@ func (s *stubLLMManager) GetBatchClient(ctx context.Context, config gatewayllm.L
 	return s.batchClient, nil
 }
 
-func (s *stubLLMManager) ResolveBulkStrategy(ctx context.Context, strategy gatewayllm.BulkStrategy, provider string) gatewayllm.BulkStrategy {
+func (s *stubLLMManager) ResolveBulkStrategy(ctx context.Context, strategy gatewayllm.BulkStrategy, config gatewayllm.LLMConfig) gatewayllm.BulkStrategy {
 	_ = ctx
 	_ = strategy
-	_ = provider
+	_ = config
 	return s.bulkStrategy
 }
 
@@ -476,6 +642,9 @@ func (s *stubBatchClient) GetBatchResults(ctx context.Context, id gatewayllm.Bat
 type stubLLMClient struct {
 	completeFn       func(req gatewayllm.Request) gatewayllm.Response
 	completeResultFn func(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error)
+	streamChunks     []string
+	completeCalls    int32
+	streamCalls      int32
 }
 
 func (s *stubLLMClient) ListModels(ctx context.Context) ([]gatewayllm.ModelInfo, error) {
@@ -484,6 +653,7 @@ func (s *stubLLMClient) ListModels(ctx context.Context) ([]gatewayllm.ModelInfo,
 }
 
 func (s *stubLLMClient) Complete(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
+	atomic.AddInt32(&s.completeCalls, 1)
 	if s.completeResultFn != nil {
 		return s.completeResultFn(ctx, req)
 	}
@@ -501,9 +671,25 @@ func (s *stubLLMClient) GenerateStructured(ctx context.Context, req gatewayllm.R
 func (s *stubLLMClient) StreamComplete(ctx context.Context, req gatewayllm.Request) (gatewayllm.StreamResponse, error) {
 	_ = ctx
 	_ = req
-	return nil, nil
+	atomic.AddInt32(&s.streamCalls, 1)
+	return &stubStreamResponse{chunks: append([]string(nil), s.streamChunks...)}, nil
+}
+
+type stubStreamResponse struct {
+	chunks []string
 }
 
+func (s *stubStreamResponse) Next() (gatewayllm.Response, bool) {
+	if len(s.chunks) == 0 {
+		return gatewayllm.Response{}, false
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return gatewayllm.Response{Success: true, Content: chunk}, true
+}
+
+func (s *stubStreamResponse) Close() error { return nil }
+
 func (s *stubLLMClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
 	_ = ctx
 	_ = text
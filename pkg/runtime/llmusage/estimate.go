@@ -0,0 +1,263 @@
+package llmusage
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/tokenizer"
+	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+)
+
+const (
+	// messageOverheadTokens covers role markers and chat template tokens of system + user messages.
+	messageOverheadTokens = 8
+	// defaultGenerativeOutputTokens is used for requests without source text (e.g. persona generation).
+	defaultGenerativeOutputTokens = 500
+	// batchCompletionWindow is the provider SLA for Batch API jobs; real runs usually finish sooner.
+	batchCompletionWindow = 24 * time.Hour
+)
+
+// latencyProfile approximates per-request latency of a provider.
+type latencyProfile struct {
+	base               time.Duration
+	outputTokensPerSec float64
+	inputTokensPerSec  float64
+}
+
+var (
+	cloudLatency = latencyProfile{base: 1500 * time.Millisecond, outputTokensPerSec: 60, inputTokensPerSec: 0}
+	localLatency = latencyProfile{base: 300 * time.Millisecond, outputTokensPerSec: 25, inputTokensPerSec: 500}
+)
+
+// ProviderCost is the price of the same workload on one priced provider/model.
+type ProviderCost struct {
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// PhaseEstimate is the dry-run forecast of one phase: nothing is enqueued or sent.
+type PhaseEstimate struct {
+	TaskID           string  `json:"task_id"`
+	Phase            string  `json:"phase"`
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	BulkStrategy     string  `json:"bulk_strategy"`
+	Tokenizer        string  `json:"tokenizer"`
+	Requests         int     `json:"requests"`
+	InputTokens      int     `json:"input_tokens"`
+	OutputTokens     int     `json:"output_tokens"`
+	Concurrency      int     `json:"concurrency"`
+	EstimatedSeconds float64 `json:"estimated_seconds"`
+	// RateLimited is true when the configured RPM/TPM/RPD/TPD, not latency, bounds the wall-clock time.
+	RateLimited bool `json:"rate_limited"`
+	// WallClockUpperBound is true for batch runs, whose duration is the provider's completion window.
+	WallClockUpperBound bool    `json:"wall_clock_upper_bound"`
+	CostUSD             float64 `json:"cost_usd"`
+	Priced              bool    `json:"priced"`
+	// ProviderCosts prices the same workload on every provider/model of the price table for comparison.
+	ProviderCosts []ProviderCost `json:"provider_costs"`
+}
+
+type tokenTotals struct {
+	input  int
+	output int
+}
+
+// EstimatePhase forecasts tokens, wall-clock time and price of running requests with config.
+func (s *Service) EstimatePhase(ctx context.Context, config llmio.ExecutionConfig, requests []llmio.Request) PhaseEstimate {
+	provider := gatewayllm.NormalizeProvider(config.Provider)
+	strategy := BulkStrategySync
+	if strings.EqualFold(strings.TrimSpace(config.BulkStrategy), string(gatewayllm.BulkStrategyBatch)) && gatewayllm.ProviderSupportsBatch(provider) {
+		strategy = BulkStrategyBatch
+	}
+	tok := tokenizer.ForModel(provider, config.Model)
+	totals := countTokens(tok, requests)
+
+	estimate := PhaseEstimate{
+		TaskID:        config.TaskID,
+		Phase:         config.Phase,
+		Provider:      provider,
+		Model:         config.Model,
+		BulkStrategy:  strategy,
+		Tokenizer:     string(tok.Family()),
+		Requests:      len(requests),
+		InputTokens:   totals.input,
+		OutputTokens:  totals.output,
+		ProviderCosts: make([]ProviderCost, 0),
+	}
+
+	prices := s.PriceTable(ctx)
+	if price, ok := prices.Lookup(provider, config.Model); ok {
+		estimate.Priced = true
+		estimate.CostUSD = price.Cost(totals.input, totals.output, strategy == BulkStrategyBatch)
+	}
+	estimate.ProviderCosts = providerCosts(prices, requests, strategy == BulkStrategyBatch)
+
+	if strategy == BulkStrategyBatch {
+		estimate.Concurrency = len(requests)
+		estimate.WallClockUpperBound = len(requests) > 0
+		if len(requests) > 0 {
+			estimate.EstimatedSeconds = batchCompletionWindow.Seconds()
+		}
+		return estimate
+	}
+
+	estimate.Concurrency = s.syncConcurrency(ctx, config, provider)
+	latency := cloudLatency
+	if provider == "lmstudio" {
+		latency = localLatency
+	}
+	var latencySeconds float64
+	for _, req := range requests {
+		in, out := countRequest(tok, req)
+		latencySeconds += latency.base.Seconds() + float64(out)/latency.outputTokensPerSec
+		if latency.inputTokensPerSec > 0 {
+			latencySeconds += float64(in) / latency.inputTokensPerSec
+		}
+	}
+	latencySeconds /= float64(estimate.Concurrency)
+
+	limitSeconds := s.rateLimitSeconds(ctx, config, provider, len(requests), totals.input+totals.output)
+	estimate.EstimatedSeconds = math.Ceil(math.Max(latencySeconds, limitSeconds))
+	estimate.RateLimited = limitSeconds > latencySeconds
+	return estimate
+}
+
+func countTokens(tok tokenizer.Tokenizer, requests []llmio.Request) tokenTotals {
+	var totals tokenTotals
+	for _, req := range requests {
+		in, out := countRequest(tok, req)
+		totals.input += in
+		totals.output += out
+	}
+	return totals
+}
+
+// countRequest estimates input tokens from the prompts and output tokens from the Japanese translation of
+// metadata source_text (split evenly across chunks), or a fixed budget for generative requests.
+func countRequest(tok tokenizer.Tokenizer, req llmio.Request) (int, int) {
+	in := tok.Count(req.SystemPrompt) + tok.Count(req.UserPrompt) + messageOverheadTokens
+	source, _ := req.Metadata["source_text"].(string)
+	if strings.TrimSpace(source) == "" {
+		return in, defaultGenerativeOutputTokens
+	}
+	out := tok.EstimateJapaneseTranslation(source)
+	if chunks := metadataInt(req.Metadata["chunk_count"]); chunks > 1 {
+		out = int(math.Ceil(float64(out) / float64(chunks)))
+	}
+	return in, out + messageOverheadTokens
+}
+
+func providerCosts(prices PriceTable, requests []llmio.Request, batch bool) []ProviderCost {
+	keys := make([]string, 0, len(prices))
+	for key := range prices {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	byFamily := make(map[tokenizer.Family]tokenTotals)
+	costs := make([]ProviderCost, 0, len(keys))
+	for _, key := range keys {
+		provider, model, ok := strings.Cut(key, ":")
+		if !ok {
+			continue
+		}
+		tok := tokenizer.ForModel(provider, model)
+		totals, counted := byFamily[tok.Family()]
+		if !counted {
+			totals = countTokens(tok, requests)
+			byFamily[tok.Family()] = totals
+		}
+		costs = append(costs, ProviderCost{
+			Provider:     provider,
+			Model:        model,
+			InputTokens:  totals.input,
+			OutputTokens: totals.output,
+			CostUSD:      prices[key].Cost(totals.input, totals.output, batch),
+		})
+	}
+	return costs
+}
+
+func (s *Service) syncConcurrency(ctx context.Context, config llmio.ExecutionConfig, provider string) int {
+	if config.SyncConcurrency > 0 {
+		return config.SyncConcurrency
+	}
+	if value, ok := s.lookupProviderConfig(ctx, config.ConfigNamespace, provider, gatewayllm.LLMSyncConcurrencyKeySuffix+"."+provider); ok {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return gatewayllm.DefaultConcurrency(provider)
+}
+
+// rateLimitSeconds returns the minimum duration the configured rate limits allow for the workload.
+func (s *Service) rateLimitSeconds(ctx context.Context, config llmio.ExecutionConfig, provider string, requests, tokens int) float64 {
+	limit := func(key string) int {
+		value, ok := s.lookupProviderConfig(ctx, config.ConfigNamespace, provider, key)
+		if !ok {
+			return 0
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0
+		}
+		return parsed
+	}
+	perMinute := func(amount, ceiling int) float64 {
+		if ceiling <= 0 || amount <= 0 {
+			return 0
+		}
+		return float64(amount) / float64(ceiling) * time.Minute.Seconds()
+	}
+	// Work beyond a daily quota waits for the next day.
+	perDay := func(amount, ceiling int) float64 {
+		if ceiling <= 0 || amount <= ceiling {
+			return 0
+		}
+		return float64((amount-1)/ceiling) * (24 * time.Hour).Seconds()
+	}
+	return math.Max(
+		math.Max(perMinute(requests, limit(gatewayllm.RateLimitRPMParam)), perMinute(tokens, limit(gatewayllm.RateLimitTPMParam))),
+		math.Max(perDay(requests, limit(gatewayllm.RateLimitRPDParam)), perDay(tokens, limit(gatewayllm.RateLimitTPDParam))),
+	)
+}
+
+// lookupProviderConfig mirrors the queue worker's lookup order: namespace, provider sub-namespace, provider-prefixed key.
+func (s *Service) lookupProviderConfig(ctx context.Context, ns, provider, key string) (string, bool) {
+	if s.config == nil || strings.TrimSpace(ns) == "" {
+		return "", false
+	}
+	for _, candidate := range []struct{ ns, key string }{
+		{ns: ns, key: key},
+		{ns: ns + "." + provider, key: key},
+		{ns: ns, key: provider + "_" + key},
+	} {
+		value, err := s.config.Get(ctx, candidate.ns, candidate.key)
+		if err == nil && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+func metadataInt(raw interface{}) int {
+	switch v := raw.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
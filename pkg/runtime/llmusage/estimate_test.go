@@ -0,0 +1,103 @@
+package llmusage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+)
+
+func translationRequests(n int) []llmio.Request {
+	requests := make([]llmio.Request, 0, n)
+	for i := 0; i < n; i++ {
+		requests = append(requests, llmio.Request{
+			SystemPrompt: "Translate the Skyrim text into natural Japanese. Keep tags unchanged.",
+			UserPrompt:   "Whiterun Guard",
+			Metadata:     map[string]interface{}{"source_text": "Whiterun Guard"},
+		})
+	}
+	return requests
+}
+
+func TestService_EstimatePhase_SyncUsesConcurrencyAndPrice(t *testing.T) {
+	config := stubConfig{
+		PricingNamespace + "/" + PriceTableKey:    `{"gemini:gemini-2.0-flash": {"input_per_million": 1, "output_per_million": 2}, "openai:gpt-4-turbo": {"input_per_million": 10, "output_per_million": 30}}`,
+		"translation.llm/sync_concurrency.gemini": "10",
+	}
+	service := NewService(newTestLedger(t), config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	requests := translationRequests(20)
+	got := service.EstimatePhase(context.Background(), llmio.ExecutionConfig{
+		Provider:        "gemini",
+		Model:           "gemini-2.0-flash",
+		ConfigNamespace: "translation.llm",
+		TaskID:          "task-1",
+		Phase:           "terminology",
+	}, requests)
+
+	if got.Requests != 20 || got.Concurrency != 10 || got.BulkStrategy != BulkStrategySync || got.Tokenizer != "gemini" {
+		t.Fatalf("unexpected estimate header: %+v", got)
+	}
+	if got.InputTokens <= 0 || got.OutputTokens <= 0 {
+		t.Fatalf("expected token estimates, got %+v", got)
+	}
+	if !got.Priced || !almostEqual(got.CostUSD, (float64(got.InputTokens)*1+float64(got.OutputTokens)*2)/1_000_000) {
+		t.Fatalf("unexpected cost: %+v", got)
+	}
+	// 20 requests / 10 parallel, each at least 1.5s base latency.
+	if got.EstimatedSeconds < 3 || got.RateLimited || got.WallClockUpperBound {
+		t.Fatalf("unexpected wall clock: %+v", got)
+	}
+	if len(got.ProviderCosts) != 2 || got.ProviderCosts[1].Provider != "openai" {
+		t.Fatalf("expected comparison for every price entry, got %+v", got.ProviderCosts)
+	}
+	if got.ProviderCosts[1].OutputTokens <= got.OutputTokens {
+		t.Fatalf("cl100k comparison must count more japanese output tokens: %+v", got.ProviderCosts)
+	}
+}
+
+func TestService_EstimatePhase_RateLimitBoundsWallClock(t *testing.T) {
+	config := stubConfig{
+		"translation.llm.gemini/rate_limit_rpm": "10",
+	}
+	service := NewService(newTestLedger(t), config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	got := service.EstimatePhase(context.Background(), llmio.ExecutionConfig{
+		Provider:        "gemini",
+		Model:           "gemini-2.0-flash",
+		SyncConcurrency: 50,
+		ConfigNamespace: "translation.llm",
+	}, translationRequests(30))
+
+	if !got.RateLimited || got.EstimatedSeconds != 180 {
+		t.Fatalf("expected 30 requests at 10 rpm to take 180s, got %+v", got)
+	}
+	if got.Priced {
+		t.Fatalf("expected unpriced estimate without a price table, got %+v", got)
+	}
+}
+
+func TestService_EstimatePhase_BatchUsesCompletionWindowAndDiscount(t *testing.T) {
+	config := stubConfig{
+		PricingNamespace + "/" + PriceTableKey: `{"gemini:*": {"input_per_million": 1, "output_per_million": 1, "batch_discount": 0.5}}`,
+	}
+	service := NewService(newTestLedger(t), config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	got := service.EstimatePhase(context.Background(), llmio.ExecutionConfig{
+		Provider:     "gemini",
+		Model:        "gemini-2.0-flash",
+		BulkStrategy: "batch",
+	}, []llmio.Request{{SystemPrompt: "persona", UserPrompt: "NPC Profile"}})
+
+	if got.BulkStrategy != BulkStrategyBatch || !got.WallClockUpperBound || got.EstimatedSeconds != 24*60*60 {
+		t.Fatalf("unexpected batch estimate: %+v", got)
+	}
+	if got.OutputTokens != defaultGenerativeOutputTokens {
+		t.Fatalf("expected generative output budget, got %d", got.OutputTokens)
+	}
+	if !almostEqual(got.CostUSD, float64(got.InputTokens+got.OutputTokens)/1_000_000*0.5) {
+		t.Fatalf("expected batch discount, got %+v", got)
+	}
+}
@@ -0,0 +1,156 @@
+package llmusage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bulk strategies recorded with each usage row; batch rows get the provider's batch discount.
+const (
+	BulkStrategySync  = "sync"
+	BulkStrategyBatch = "batch"
+)
+
+// Record is the token usage of one LLM request attributed to a task phase.
+type Record struct {
+	TaskID           string
+	Phase            string
+	Provider         string
+	Model            string
+	BulkStrategy     string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Success          bool
+}
+
+// Total aggregates usage rows sharing task phase, provider, model and bulk strategy.
+type Total struct {
+	Phase            string `json:"phase"`
+	Provider         string `json:"provider"`
+	Model            string `json:"model"`
+	BulkStrategy     string `json:"bulk_strategy"`
+	Requests         int    `json:"requests"`
+	FailedRequests   int    `json:"failed_requests"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+}
+
+// Ledger persists per-request usage for every execution path (queue worker and sync executor).
+type Ledger struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// NewLedger creates the usage table if needed.
+func NewLedger(ctx context.Context, db *sql.DB) (*Ledger, error) {
+	l := &Ledger{db: db, now: time.Now}
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS llm_usage (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id TEXT NOT NULL,
+			phase TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			model TEXT NOT NULL,
+			bulk_strategy TEXT NOT NULL,
+			prompt_tokens INTEGER NOT NULL DEFAULT 0,
+			completion_tokens INTEGER NOT NULL DEFAULT 0,
+			total_tokens INTEGER NOT NULL DEFAULT 0,
+			success INTEGER NOT NULL DEFAULT 1,
+			created_at DATETIME NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_llm_usage_task_id ON llm_usage(task_id);
+	`); err != nil {
+		return nil, fmt.Errorf("create llm usage table: %w", err)
+	}
+	return l, nil
+}
+
+// Record appends usage rows in one transaction. Rows without a task id are skipped.
+func (l *Ledger) Record(ctx context.Context, records []Record) error {
+	if l == nil || len(records) == 0 {
+		return nil
+	}
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin llm usage tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO llm_usage (
+			task_id, phase, provider, model, bulk_strategy,
+			prompt_tokens, completion_tokens, total_tokens, success, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare llm usage insert: %w", err)
+	}
+	defer stmt.Close()
+
+	now := l.now().UTC()
+	for _, r := range records {
+		taskID := strings.TrimSpace(r.TaskID)
+		if taskID == "" {
+			continue
+		}
+		strategy := r.BulkStrategy
+		if strategy != BulkStrategyBatch {
+			strategy = BulkStrategySync
+		}
+		total := r.TotalTokens
+		if total == 0 {
+			total = r.PromptTokens + r.CompletionTokens
+		}
+		if _, err := stmt.ExecContext(ctx,
+			taskID, r.Phase, r.Provider, r.Model, strategy,
+			r.PromptTokens, r.CompletionTokens, total, r.Success, now,
+		); err != nil {
+			return fmt.Errorf("insert llm usage task_id=%s: %w", taskID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit llm usage: %w", err)
+	}
+	return nil
+}
+
+// Totals returns usage for taskID grouped by phase, provider, model and bulk strategy.
+func (l *Ledger) Totals(ctx context.Context, taskID string) ([]Total, error) {
+	rows, err := l.db.QueryContext(ctx, `
+		SELECT phase, provider, model, bulk_strategy,
+			COUNT(*), SUM(CASE WHEN success THEN 0 ELSE 1 END),
+			SUM(prompt_tokens), SUM(completion_tokens), SUM(total_tokens)
+		FROM llm_usage
+		WHERE task_id = ?
+		GROUP BY phase, provider, model, bulk_strategy
+		ORDER BY MIN(id)
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query llm usage task_id=%s: %w", taskID, err)
+	}
+	defer rows.Close()
+
+	totals := make([]Total, 0)
+	for rows.Next() {
+		var t Total
+		if err := rows.Scan(
+			&t.Phase, &t.Provider, &t.Model, &t.BulkStrategy,
+			&t.Requests, &t.FailedRequests,
+			&t.PromptTokens, &t.CompletionTokens, &t.TotalTokens,
+		); err != nil {
+			return nil, fmt.Errorf("scan llm usage task_id=%s: %w", taskID, err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate llm usage task_id=%s: %w", taskID, err)
+	}
+	return totals, nil
+}
@@ -0,0 +1,75 @@
+package llmusage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Config location of the price table.
+const (
+	// PricingNamespace holds pricing settings shared by every phase.
+	PricingNamespace = "llm.pricing"
+	// PriceTableKey stores a JSON object keyed by "provider:model" (or "provider:*") with Price values, e.g.
+	// {"gemini:gemini-2.0-flash": {"input_per_million": 0.1, "output_per_million": 0.4, "batch_discount": 0.5}}.
+	PriceTableKey = "price_table"
+)
+
+// Price is the USD cost per million tokens of one provider/model.
+// BatchDiscount is the fraction taken off for Batch API requests (0.5 = half price).
+type Price struct {
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+	BatchDiscount    float64 `json:"batch_discount,omitempty"`
+}
+
+// Cost returns the USD cost of the given token counts.
+func (p Price) Cost(promptTokens, completionTokens int, batch bool) float64 {
+	cost := (float64(promptTokens)*p.InputPerMillion + float64(completionTokens)*p.OutputPerMillion) / 1_000_000
+	if batch && p.BatchDiscount > 0 && p.BatchDiscount < 1 {
+		cost *= 1 - p.BatchDiscount
+	}
+	return cost
+}
+
+// PriceTable maps "provider:model" or "provider:*" to a Price.
+type PriceTable map[string]Price
+
+// localProviders run on the user's machine and are always free.
+var localProviders = map[string]struct{}{
+	"lmstudio": {},
+	"replay":   {},
+}
+
+// ParsePriceTable decodes the JSON price table; an empty string yields an empty table.
+func ParsePriceTable(raw string) (PriceTable, error) {
+	table := PriceTable{}
+	if strings.TrimSpace(raw) == "" {
+		return table, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &table); err != nil {
+		return nil, fmt.Errorf("decode price table: %w", err)
+	}
+	normalized := make(PriceTable, len(table))
+	for key, price := range table {
+		normalized[strings.ToLower(strings.TrimSpace(key))] = price
+	}
+	return normalized, nil
+}
+
+// Lookup returns the price of provider/model, preferring an exact entry over the provider wildcard.
+// Local providers are free without configuration; ok is false when no price is known.
+func (t PriceTable) Lookup(provider, model string) (Price, bool) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	model = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(model), "models/"))
+	if price, ok := t[provider+":"+model]; ok {
+		return price, true
+	}
+	if price, ok := t[provider+":*"]; ok {
+		return price, true
+	}
+	if _, ok := localProviders[provider]; ok {
+		return Price{}, true
+	}
+	return Price{}, false
+}
@@ -0,0 +1,105 @@
+package llmusage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type configReader interface {
+	Get(ctx context.Context, namespace string, key string) (string, error)
+}
+
+// PricedTotal is a usage total with its cost under the current price table.
+type PricedTotal struct {
+	Total
+	CostUSD float64 `json:"cost_usd"`
+	Priced  bool    `json:"priced"`
+}
+
+// TaskUsage is the token and cost summary of one task across all phases.
+type TaskUsage struct {
+	TaskID           string        `json:"task_id"`
+	Totals           []PricedTotal `json:"totals"`
+	Requests         int           `json:"requests"`
+	PromptTokens     int           `json:"prompt_tokens"`
+	CompletionTokens int           `json:"completion_tokens"`
+	TotalTokens      int           `json:"total_tokens"`
+	CostUSD          float64       `json:"cost_usd"`
+	// Unpriced is true when some provider/model has no price entry, so CostUSD is a lower bound.
+	Unpriced bool `json:"unpriced"`
+}
+
+// Service applies the configured price table to ledger totals.
+type Service struct {
+	ledger *Ledger
+	config configReader
+	logger *slog.Logger
+}
+
+// NewService creates the usage/cost reporting service.
+func NewService(ledger *Ledger, config configReader, logger *slog.Logger) *Service {
+	return &Service{
+		ledger: ledger,
+		config: config,
+		logger: logger.With("component", "llm_usage_service"),
+	}
+}
+
+// TaskUsage returns per phase/provider/model totals and costs of taskID.
+func (s *Service) TaskUsage(ctx context.Context, taskID string) (TaskUsage, error) {
+	taskID = strings.TrimSpace(taskID)
+	if taskID == "" {
+		return TaskUsage{}, fmt.Errorf("task_id is required")
+	}
+	totals, err := s.ledger.Totals(ctx, taskID)
+	if err != nil {
+		return TaskUsage{}, err
+	}
+	prices := s.PriceTable(ctx)
+
+	usage := TaskUsage{TaskID: taskID, Totals: make([]PricedTotal, 0, len(totals))}
+	for _, total := range totals {
+		priced := PricedTotal{Total: total}
+		if price, ok := prices.Lookup(total.Provider, total.Model); ok {
+			priced.Priced = true
+			priced.CostUSD = price.Cost(total.PromptTokens, total.CompletionTokens, total.BulkStrategy == BulkStrategyBatch)
+		} else {
+			usage.Unpriced = true
+		}
+		usage.Totals = append(usage.Totals, priced)
+		usage.Requests += total.Requests
+		usage.PromptTokens += total.PromptTokens
+		usage.CompletionTokens += total.CompletionTokens
+		usage.TotalTokens += total.TotalTokens
+		usage.CostUSD += priced.CostUSD
+	}
+	return usage, nil
+}
+
+// EstimateCost prices a planned run; ok is false when provider/model has no price entry.
+func (s *Service) EstimateCost(ctx context.Context, provider, model, bulkStrategy string, promptTokens, completionTokens int) (float64, bool) {
+	price, ok := s.PriceTable(ctx).Lookup(provider, model)
+	if !ok {
+		return 0, false
+	}
+	return price.Cost(promptTokens, completionTokens, bulkStrategy == BulkStrategyBatch), true
+}
+
+// PriceTable loads the configured price table; an invalid table is logged and treated as empty.
+func (s *Service) PriceTable(ctx context.Context) PriceTable {
+	if s.config == nil {
+		return PriceTable{}
+	}
+	raw, err := s.config.Get(ctx, PricingNamespace, PriceTableKey)
+	if err != nil {
+		return PriceTable{}
+	}
+	table, err := ParsePriceTable(raw)
+	if err != nil {
+		s.logger.WarnContext(ctx, "invalid llm price table; costs are not applied", slog.String("error", err.Error()))
+		return PriceTable{}
+	}
+	return table
+}
@@ -0,0 +1,117 @@
+package llmusage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+type stubConfig map[string]string
+
+func (s stubConfig) Get(_ context.Context, namespace string, key string) (string, error) {
+	if v, ok := s[namespace+"/"+key]; ok {
+		return v, nil
+	}
+	return "", errors.New("not found")
+}
+
+func newTestLedger(t *testing.T) *Ledger {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	ledger, err := NewLedger(context.Background(), db)
+	if err != nil {
+		t.Fatalf("NewLedger failed: %v", err)
+	}
+	return ledger
+}
+
+func TestService_TaskUsage_GroupsAndPricesByPhaseProviderAndStrategy(t *testing.T) {
+	ctx := context.Background()
+	ledger := newTestLedger(t)
+	err := ledger.Record(ctx, []Record{
+		{TaskID: "task-1", Phase: "terminology", Provider: "gemini", Model: "gemini-2.0-flash", BulkStrategy: BulkStrategySync, PromptTokens: 600_000, CompletionTokens: 100_000, Success: true},
+		{TaskID: "task-1", Phase: "terminology", Provider: "gemini", Model: "gemini-2.0-flash", BulkStrategy: BulkStrategySync, PromptTokens: 400_000, CompletionTokens: 100_000, Success: false},
+		{TaskID: "task-1", Phase: "main_translation", Provider: "gemini", Model: "gemini-2.0-flash", BulkStrategy: BulkStrategyBatch, PromptTokens: 1_000_000, CompletionTokens: 1_000_000, Success: true},
+		{TaskID: "task-1", Phase: "persona", Provider: "xai", Model: "grok-3", PromptTokens: 10, CompletionTokens: 5, Success: true},
+		{TaskID: "task-1", Phase: "persona", Provider: "lmstudio", Model: "local", PromptTokens: 10, CompletionTokens: 5, Success: true},
+		{TaskID: "task-2", Phase: "terminology", Provider: "gemini", Model: "gemini-2.0-flash", PromptTokens: 999, Success: true},
+		{TaskID: " ", Phase: "terminology", Provider: "gemini", Model: "gemini-2.0-flash", PromptTokens: 999, Success: true},
+	})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	config := stubConfig{
+		PricingNamespace + "/" + PriceTableKey: `{"Gemini:gemini-2.0-flash": {"input_per_million": 0.1, "output_per_million": 0.4, "batch_discount": 0.5}}`,
+	}
+	service := NewService(ledger, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	usage, err := service.TaskUsage(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("TaskUsage failed: %v", err)
+	}
+	if len(usage.Totals) != 4 {
+		t.Fatalf("expected 4 groups, got %+v", usage.Totals)
+	}
+
+	terminology := usage.Totals[0]
+	if terminology.Phase != "terminology" || terminology.Requests != 2 || terminology.FailedRequests != 1 {
+		t.Fatalf("unexpected terminology total: %+v", terminology)
+	}
+	if terminology.TotalTokens != 1_200_000 || !terminology.Priced || !almostEqual(terminology.CostUSD, 0.18) {
+		t.Fatalf("unexpected terminology cost: %+v", terminology)
+	}
+
+	batch := usage.Totals[1]
+	if batch.BulkStrategy != BulkStrategyBatch || !almostEqual(batch.CostUSD, 0.25) {
+		t.Fatalf("expected batch discount to halve the cost, got %+v", batch)
+	}
+	if usage.Totals[2].Priced {
+		t.Fatalf("expected xai without price entry to be unpriced, got %+v", usage.Totals[2])
+	}
+	if !usage.Totals[3].Priced || usage.Totals[3].CostUSD != 0 {
+		t.Fatalf("expected local provider to be free, got %+v", usage.Totals[3])
+	}
+
+	if !usage.Unpriced || usage.Requests != 5 || !almostEqual(usage.CostUSD, 0.43) {
+		t.Fatalf("unexpected task summary: %+v", usage)
+	}
+}
+
+func TestService_EstimateCost(t *testing.T) {
+	config := stubConfig{
+		PricingNamespace + "/" + PriceTableKey: `{"openai:*": {"input_per_million": 2, "output_per_million": 8, "batch_discount": 0.5}}`,
+	}
+	service := NewService(newTestLedger(t), config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	cost, ok := service.EstimateCost(context.Background(), "openai", "gpt-4.1", BulkStrategyBatch, 1_000_000, 1_000_000)
+	if !ok || !almostEqual(cost, 5) {
+		t.Fatalf("expected wildcard batch price 5, got %v ok=%v", cost, ok)
+	}
+	if _, ok := service.EstimateCost(context.Background(), "gemini", "gemini-2.0-flash", BulkStrategySync, 1, 1); ok {
+		t.Fatalf("expected missing price entry to report ok=false")
+	}
+}
+
+func TestService_PriceTable_InvalidJSONIsIgnored(t *testing.T) {
+	config := stubConfig{PricingNamespace + "/" + PriceTableKey: `{not json`}
+	service := NewService(newTestLedger(t), config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if table := service.PriceTable(context.Background()); len(table) != 0 {
+		t.Fatalf("expected empty table, got %+v", table)
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
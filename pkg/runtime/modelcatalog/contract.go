@@ -5,4 +5,5 @@ import "context"
 // Service provides model catalog listing for UI without exposing LLM internals.
 type Service interface {
 	ListModels(ctx context.Context, input ListModelsInput) ([]ModelOption, error)
+	ProviderHealth(ctx context.Context, input ProviderHealthInput) (ProviderStatus, error)
 }
@@ -22,3 +22,23 @@ type ModelOption struct {
 	Loaded           bool            `json:"loaded"`
 	Capability       ModelCapability `json:"capability"`
 }
+
+// ProviderHealthInput selects the provider whose availability is shown before starting a task.
+// Provider falls back to the namespace's configured provider when empty.
+type ProviderHealthInput struct {
+	Namespace string `json:"namespace"`
+	Provider  string `json:"provider"`
+}
+
+// ProviderStatus is the UI-facing circuit breaker state of one provider endpoint and model.
+type ProviderStatus struct {
+	Provider            string `json:"provider"`
+	Endpoint            string `json:"endpoint,omitempty"`
+	Model               string `json:"model,omitempty"`
+	State               string `json:"state"`
+	Available           bool   `json:"available"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	NextProbeAt         string `json:"next_probe_at,omitempty"`
+	Message             string `json:"message,omitempty"`
+}
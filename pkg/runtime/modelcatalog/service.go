@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/configstore"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
@@ -55,10 +56,11 @@ func (s *ModelCatalogService) ListModels(ctx context.Context, input ListModelsIn
 	)
 
 	client, err := s.llmManager.GetClient(ctx, llm.LLMConfig{
-		Provider: provider,
-		Endpoint: endpoint,
-		APIKey:   apiKey,
-		Model:    model,
+		Provider:   provider,
+		Endpoint:   endpoint,
+		APIKey:     apiKey,
+		Model:      model,
+		Parameters: s.providerParameters(ctx, ns, provider),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("get llm client provider=%s namespace=%s: %w", provider, ns, err)
@@ -90,6 +92,66 @@ func (s *ModelCatalogService) ListModels(ctx context.Context, input ListModelsIn
 	return out, nil
 }
 
+// ProviderHealth reports whether the circuit breaker of the provider's endpoint and model currently accepts requests.
+// With a namespace the endpoint and model configured there narrow the check; a bare provider reports any open endpoint.
+// Managers that do not track health always report the provider as available.
+func (s *ModelCatalogService) ProviderHealth(ctx context.Context, input ProviderHealthInput) (ProviderStatus, error) {
+	ns := strings.TrimSpace(input.Namespace)
+	provider := llm.NormalizeProvider(input.Provider)
+	if provider == "" && ns != "" {
+		provider = s.resolveProvider(ctx, ns, "")
+	}
+	if provider == "" {
+		return ProviderStatus{}, fmt.Errorf("provider is required")
+	}
+
+	reporter, ok := s.llmManager.(llm.ProviderHealthReporter)
+	if !ok {
+		return ProviderStatus{Provider: provider, State: string(llm.CircuitClosed), Available: true}, nil
+	}
+	config := llm.LLMConfig{Provider: provider}
+	if ns != "" {
+		config.Endpoint = s.getConfig(ctx, ns, "endpoint")
+		config.Model = firstNonEmpty(s.getConfig(ctx, ns, "model"), s.getConfig(ctx, ns, provider+"_"+llm.LLMModelIDKeySuffix))
+	}
+	health := reporter.ProviderHealth(ctx, config)
+	status := ProviderStatus{
+		Provider:            provider,
+		Endpoint:            health.Endpoint,
+		Model:               health.Model,
+		State:               string(health.State),
+		Available:           health.Available(),
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastError:           health.LastError,
+	}
+	if !health.Available() {
+		status.NextProbeAt = health.NextProbeAt.UTC().Format(time.RFC3339)
+		status.Message = fmt.Sprintf("%s は現在利用できません（連続 %d 件失敗）", providerDisplayName(provider), health.ConsecutiveFailures)
+		s.logger.WarnContext(ctx, "provider unavailable",
+			slog.String("provider", provider),
+			slog.String("endpoint", health.Endpoint),
+			slog.String("model", health.Model),
+			slog.String("last_error", health.LastError),
+		)
+	}
+	return status, nil
+}
+
+func providerDisplayName(provider string) string {
+	switch provider {
+	case "gemini":
+		return "Gemini"
+	case "xai":
+		return "xAI"
+	case "lmstudio":
+		return "LM Studio"
+	case "openai_compatible":
+		return "OpenAI 互換プロバイダー"
+	default:
+		return provider
+	}
+}
+
 func (s *ModelCatalogService) resolveProvider(ctx context.Context, namespace string, override string) string {
 	if normalized := llm.NormalizeProvider(override); normalized != "" {
 		return normalized
@@ -106,6 +168,29 @@ func (s *ModelCatalogService) resolveProvider(ctx context.Context, namespace str
 	return ""
 }
 
+// providerParameters collects provider-specific client options such as custom auth headers.
+func (s *ModelCatalogService) providerParameters(ctx context.Context, namespace, provider string) map[string]interface{} {
+	params := map[string]interface{}{}
+	if provider != "openai_compatible" {
+		return params
+	}
+	for _, key := range []string{
+		llm.OpenAICompatibleAuthHeaderParam,
+		llm.OpenAICompatibleAuthSchemeParam,
+		llm.OpenAICompatibleModelsEndpointParam,
+	} {
+		value := firstNonEmpty(
+			s.getConfig(ctx, namespace, key),
+			s.getConfig(ctx, namespace+"."+provider, key),
+			s.getConfig(ctx, namespace, provider+"_"+key),
+		)
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return params
+}
+
 func (s *ModelCatalogService) getConfig(ctx context.Context, namespace, key string) string {
 	if s.configAccessor == nil {
 		return ""
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/configstore"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
@@ -79,7 +80,7 @@ func (m *mockLLMManager) GetClient(ctx context.Context, config llm.LLMConfig) (l
 func (m *mockLLMManager) GetBatchClient(ctx context.Context, config llm.LLMConfig) (llm.BatchClient, error) {
 	return nil, nil
 }
-func (m *mockLLMManager) ResolveBulkStrategy(ctx context.Context, strategy llm.BulkStrategy, provider string) llm.BulkStrategy {
+func (m *mockLLMManager) ResolveBulkStrategy(ctx context.Context, strategy llm.BulkStrategy, config llm.LLMConfig) llm.BulkStrategy {
 	return strategy
 }
 
@@ -147,3 +148,58 @@ func TestModelCatalogService_ListModels_OverrideWins(t *testing.T) {
 		t.Fatalf("expected api key override")
 	}
 }
+
+type healthReportingLLMManager struct {
+	mockLLMManager
+	health map[string]llm.ProviderHealth
+}
+
+func (m *healthReportingLLMManager) ProviderHealth(ctx context.Context, config llm.LLMConfig) llm.ProviderHealth {
+	m.lastConfig = config
+	if health, ok := m.health[config.Provider]; ok {
+		return health
+	}
+	return llm.ProviderHealth{Provider: config.Provider, State: llm.CircuitClosed}
+}
+func (m *healthReportingLLMManager) ProviderHealthAll(ctx context.Context) []llm.ProviderHealth {
+	return nil
+}
+
+func TestModelCatalogService_ProviderHealth_ReportsOpenCircuit(t *testing.T) {
+	manager := &healthReportingLLMManager{health: map[string]llm.ProviderHealth{
+		"gemini": {
+			Provider:            "gemini",
+			State:               llm.CircuitOpen,
+			ConsecutiveFailures: 5,
+			LastError:           "retryable HTTP error 503: overloaded",
+			NextProbeAt:         time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC),
+		},
+	}}
+	service := NewModelCatalogService(
+		&mockConfigStore{values: map[string]map[string]string{
+			"translation_flow.translation": {"provider": "gemini", "model": "gemini-2.0-flash"},
+		}},
+		&mockSecretStore{},
+		manager,
+		slog.Default(),
+	)
+
+	got, err := service.ProviderHealth(context.Background(), ProviderHealthInput{Namespace: "translation_flow.translation"})
+	if err != nil {
+		t.Fatalf("ProviderHealth failed: %v", err)
+	}
+	if manager.lastConfig.Provider != "gemini" || manager.lastConfig.Model != "gemini-2.0-flash" {
+		t.Fatalf("expected the namespace model to narrow the health check, got %+v", manager.lastConfig)
+	}
+	if got.Available || got.State != "open" || got.NextProbeAt != "2026-01-01T00:00:30Z" || got.Message == "" {
+		t.Fatalf("unexpected provider status: %+v", got)
+	}
+
+	got, err = service.ProviderHealth(context.Background(), ProviderHealthInput{Provider: "xai"})
+	if err != nil {
+		t.Fatalf("ProviderHealth failed: %v", err)
+	}
+	if !got.Available || got.Message != "" {
+		t.Fatalf("expected xai to be available, got %+v", got)
+	}
+}
@@ -2,16 +2,20 @@ package queue
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
 	gatewayconfig "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/config"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmcache"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 )
 
 // mockLLMClient simulates LLM completion
@@ -105,7 +109,7 @@ func (m *mockLLMManager) GetBatchClient(ctx context.Context, config llm.LLMConfi
 	m.lastConfig = config
 	return m.batchClient, nil
 }
-func (m *mockLLMManager) ResolveBulkStrategy(ctx context.Context, strategy llm.BulkStrategy, provider string) llm.BulkStrategy {
+func (m *mockLLMManager) ResolveBulkStrategy(ctx context.Context, strategy llm.BulkStrategy, config llm.LLMConfig) llm.BulkStrategy {
 	return strategy
 }
 
@@ -423,6 +427,61 @@ func TestWorker_ProcessBatch_PartialFailedPersistsSuccessfulResponses(t *testing
 	}
 }
 
+func TestWorker_ProcessBatch_ValidatesStructuredResults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	q, err := NewQueue(ctx, ":memory:", logger)
+	if err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+	defer q.Close()
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"persona"},
+		"properties": map[string]interface{}{"persona": map[string]interface{}{"type": "string"}},
+	}
+	processID := "batch-structured"
+	if err := q.SubmitTaskRequests(ctx, processID, "persona_extraction", []llm.Request{
+		{UserPrompt: "q1", ResponseSchema: schema},
+		{UserPrompt: "q2", ResponseSchema: schema},
+	}); err != nil {
+		t.Fatalf("SubmitTaskRequests failed: %v", err)
+	}
+
+	batchMock := &mockBatchClient{
+		status: llm.BatchStateCompleted,
+		results: []llm.Response{
+			{Success: true, Content: "```json\n{\"persona\": \"寡黙な衛兵\"}\n```"},
+			{Success: true, Content: "TL: |寡黙な衛兵|"},
+		},
+	}
+	manager := &mockLLMManager{batchClient: batchMock}
+	worker := NewWorker(q, manager, &mockConfigStore{}, &mockSecretStore{}, progress.NewNoopNotifier(), logger)
+	worker.SetPollingInterval(10 * time.Millisecond)
+
+	if err := worker.processBatch(ctx, processID, llm.LLMConfig{Provider: "xai", Model: "xai-model"}, ProcessOptions{}); err != nil {
+		t.Fatalf("processBatch failed: %v", err)
+	}
+
+	completedJobs, err := q.GetJobsByStatus(ctx, processID, StatusCompleted)
+	if err != nil {
+		t.Fatalf("GetJobsByStatus completed failed: %v", err)
+	}
+	if len(completedJobs) != 1 || completedJobs[0].ResponseJSON == nil || !strings.Contains(*completedJobs[0].ResponseJSON, `{\"persona\": \"寡黙な衛兵\"}`) {
+		t.Fatalf("expected the repaired JSON result to be stored, got %+v", completedJobs)
+	}
+	failedJobs, err := q.GetJobsByStatus(ctx, processID, StatusFailed)
+	if err != nil {
+		t.Fatalf("GetJobsByStatus failed failed: %v", err)
+	}
+	if len(failedJobs) != 1 || failedJobs[0].ErrorMessage == nil || !strings.Contains(*failedJobs[0].ErrorMessage, "structured output") {
+		t.Fatalf("expected the schema violation to fail the job, got %+v", failedJobs)
+	}
+}
+
 func TestWorker_ProcessBatch_CorrelatesByQueueJobIDOnShuffledResults(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -1150,3 +1209,390 @@ func TestWorker_ValidateExecutionProfile_AllowsSupportedBatchProvider(t *testing
 		t.Fatalf("unexpected execution profile: %+v", profile)
 	}
 }
+
+type streamingStubClient struct {
+	mockLLMClient
+	chunks      []string
+	streamCalls int
+}
+
+func (c *streamingStubClient) StreamComplete(ctx context.Context, req llm.Request) (llm.StreamResponse, error) {
+	c.streamCalls++
+	return &sliceStreamResponse{chunks: c.chunks}, nil
+}
+
+type sliceStreamResponse struct {
+	chunks []string
+}
+
+func (s *sliceStreamResponse) Next() (llm.Response, bool) {
+	if len(s.chunks) == 0 {
+		return llm.Response{}, false
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return llm.Response{Success: true, Content: chunk}, true
+}
+
+func (s *sliceStreamResponse) Close() error { return nil }
+
+type recordingNotifier struct {
+	events []progress.ProgressEvent
+}
+
+func (n *recordingNotifier) OnProgress(ctx context.Context, event progress.ProgressEvent) {
+	n.events = append(n.events, event)
+}
+
+func TestProgressReportingClient_StreamsLongBookBodies(t *testing.T) {
+	longText := make([]byte, llm.BookStreamMinSourceChars)
+	for i := range longText {
+		longText[i] = 'a'
+	}
+	chunk := string(longText[:llm.BookStreamProgressChars])
+	stub := &streamingStubClient{chunks: []string{chunk, chunk, "tail"}}
+	notifier := &recordingNotifier{}
+	client := &progressReportingClient{
+		LLMClient: stub,
+		notifier:  notifier,
+		processID: "proc-1",
+		total:     2,
+		completed: new(int32),
+	}
+
+	bookReq := llm.Request{Metadata: map[string]interface{}{"record_type": "BOOK DESC", "source_text": string(longText)}}
+	resp, err := client.Complete(context.Background(), bookReq)
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if stub.streamCalls != 1 || stub.count != 0 {
+		t.Fatalf("expected streamed book request, stream=%d complete=%d", stub.streamCalls, stub.count)
+	}
+	if resp.Content != chunk+chunk+"tail" || !resp.Success {
+		t.Fatalf("unexpected collected content len=%d", len(resp.Content))
+	}
+	streamEvents := 0
+	for _, event := range notifier.events {
+		if event.Completed == 0 && event.Message != "" && event.Message != "Processing..." {
+			streamEvents++
+		}
+	}
+	if streamEvents != 2 {
+		t.Fatalf("expected 2 throttled streaming progress events, got %d (%+v)", streamEvents, notifier.events)
+	}
+
+	shortReq := llm.Request{Metadata: map[string]interface{}{"record_type": "BOOK FULL", "source_text": "Title"}}
+	if _, err := client.Complete(context.Background(), shortReq); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if stub.streamCalls != 1 || stub.count != 1 {
+		t.Fatalf("expected short request to use Complete, stream=%d complete=%d", stub.streamCalls, stub.count)
+	}
+}
+
+// transientFailClient fails every request as if retries on a 503 had been exhausted.
+type transientFailClient struct {
+	mockLLMClient
+}
+
+func (c *transientFailClient) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	c.count++
+	return llm.Response{}, &llm.RetryableError{StatusCode: 503, Message: "overloaded"}
+}
+
+type providerLLMManager struct {
+	mockLLMManager
+	clients map[string]llm.LLMClient
+}
+
+func (m *providerLLMManager) GetClient(ctx context.Context, config llm.LLMConfig) (llm.LLMClient, error) {
+	m.clientCalls++
+	m.lastConfig = config
+	return m.clients[config.Provider], nil
+}
+
+func TestWorker_FailsOverTransientFailuresToFallbackProvider(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	q, err := NewQueue(ctx, ":memory:", logger)
+	if err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+	defer q.Close()
+
+	processID := "failover"
+	if err := q.SubmitTaskRequests(ctx, processID, "translation", []llm.Request{{UserPrompt: "a"}, {UserPrompt: "b"}}); err != nil {
+		t.Fatalf("SubmitTaskRequests failed: %v", err)
+	}
+
+	primary := &transientFailClient{}
+	fallback := &mockLLMClient{}
+	manager := &providerLLMManager{clients: map[string]llm.LLMClient{"gemini": primary, "lmstudio": fallback}}
+	cfg := &mapConfigStore{
+		values: map[string]string{
+			"translation.llm::selected_provider":              "gemini",
+			"translation.llm.gemini::model":                   "gemini-2.0-flash",
+			"translation.llm.lmstudio::endpoint":              "http://localhost:1234",
+			"translation.llm::" + llm.LLMFallbackProvidersKey: "xai, lmstudio:qwen2.5-7b",
+		},
+	}
+	worker := NewWorker(q, manager, cfg, &mockSecretStore{}, progress.NewNoopNotifier(), logger)
+
+	err = worker.ProcessProcessIDWithOptions(ctx, processID, ProcessOptions{
+		ConfigNamespace:        "translation.llm",
+		UseConfigProviderModel: true,
+		ConfigRead: ConfigReadOptions{
+			Namespace:           "translation.llm",
+			DefaultProvider:     "gemini",
+			SelectedProviderKey: "selected_provider",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ProcessProcessIDWithOptions failed: %v", err)
+	}
+	if primary.count != 2 || fallback.count != 2 {
+		t.Fatalf("expected both requests on primary then fallback, primary=%d fallback=%d", primary.count, fallback.count)
+	}
+	if fallback.loadCnt != 1 || fallback.unloadCnt != 1 {
+		t.Fatalf("expected fallback model to be loaded/unloaded once, load=%d unload=%d", fallback.loadCnt, fallback.unloadCnt)
+	}
+
+	results, err := q.GetResults(ctx, processID)
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, job := range results {
+		if job.Status != StatusCompleted {
+			t.Fatalf("expected job %s completed, got %s", job.ID, job.Status)
+		}
+		if job.Provider != "lmstudio" || job.Model != "qwen2.5-7b" {
+			t.Fatalf("expected job %s produced by lmstudio/qwen2.5-7b, got %s/%s", job.ID, job.Provider, job.Model)
+		}
+	}
+}
+
+func TestWorker_ProcessBatch_FailsOverFailedResultsToFallbackProvider(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	q, err := NewQueue(ctx, ":memory:", logger)
+	if err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+	defer q.Close()
+
+	processID := "batch-failover"
+	if err := q.SubmitTaskRequests(ctx, processID, "translation", []llm.Request{{UserPrompt: "q1"}, {UserPrompt: "q2"}}); err != nil {
+		t.Fatalf("SubmitTaskRequests failed: %v", err)
+	}
+
+	fallback := &mockLLMClient{}
+	manager := &providerLLMManager{
+		mockLLMManager: mockLLMManager{batchClient: &mockBatchClient{
+			status: llm.BatchStatePartialFailed,
+			results: []llm.Response{
+				{Success: true, Content: "ok"},
+				{Success: false, Error: "provider error"},
+			},
+		}},
+		clients: map[string]llm.LLMClient{"lmstudio": fallback},
+	}
+	worker := NewWorker(q, manager, &mockConfigStore{}, &mockSecretStore{}, progress.NewNoopNotifier(), logger)
+	worker.SetPollingInterval(10 * time.Millisecond)
+
+	llmConfig := llm.LLMConfig{
+		Provider:  "xai",
+		Model:     "xai-model",
+		Fallbacks: []llm.LLMConfig{{Provider: "lmstudio", Model: "qwen2.5-7b", Endpoint: "http://localhost:1234"}},
+	}
+	if err := worker.processBatch(ctx, processID, llmConfig, ProcessOptions{}); err != nil {
+		t.Fatalf("processBatch failed: %v", err)
+	}
+	if fallback.count != 1 {
+		t.Fatalf("expected only the failed batch result to be retried on the fallback, got %d calls", fallback.count)
+	}
+
+	results, err := q.GetResults(ctx, processID)
+	if err != nil {
+		t.Fatalf("GetResults failed: %v", err)
+	}
+	producedByFallback := 0
+	for _, job := range results {
+		if job.Status != StatusCompleted {
+			t.Fatalf("expected job %s completed, got %s", job.ID, job.Status)
+		}
+		if job.Provider == "lmstudio" && job.Model == "qwen2.5-7b" {
+			producedByFallback++
+		}
+	}
+	if producedByFallback != 1 {
+		t.Fatalf("expected exactly one job produced by the fallback, got %d", producedByFallback)
+	}
+}
+
+type usageClient struct {
+	mockLLMClient
+}
+
+func (c *usageClient) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	resp, err := c.mockLLMClient.Complete(ctx, req)
+	resp.Usage = llm.TokenUsage{PromptTokens: 30, CompletionTokens: 10, TotalTokens: 40}
+	return resp, err
+}
+
+// usageFailClient spends tokens and then fails transiently, like a structured output repair loop that gave up.
+type usageFailClient struct {
+	mockLLMClient
+}
+
+func (c *usageFailClient) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	c.count++
+	return llm.Response{Usage: llm.TokenUsage{PromptTokens: 20, TotalTokens: 20}}, &llm.RetryableError{StatusCode: 503, Message: "overloaded"}
+}
+
+func newTestUsageLedger(t *testing.T, ctx context.Context) *llmusage.Ledger {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open usage db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	ledger, err := llmusage.NewLedger(ctx, db)
+	if err != nil {
+		t.Fatalf("NewLedger failed: %v", err)
+	}
+	return ledger
+}
+
+func TestWorker_RecordsTokenUsagePerTaskAndSkipsCacheHits(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	q, err := NewQueue(ctx, ":memory:", logger)
+	if err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+	defer q.Close()
+	ledger := newTestUsageLedger(t, ctx)
+
+	cacheDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open cache db: %v", err)
+	}
+	cacheDB.SetMaxOpenConns(1)
+	defer cacheDB.Close()
+
+	client := &usageClient{}
+	manager := &providerLLMManager{clients: map[string]llm.LLMClient{"gemini": client}}
+	cfg := &mapConfigStore{values: map[string]string{
+		"persona.llm::selected_provider": "gemini",
+		"persona.llm.gemini::model":      "gemini-2.0-flash",
+	}}
+	cache, err := llmcache.NewCache(ctx, cacheDB, cfg, logger)
+	if err != nil {
+		t.Fatalf("NewCache failed: %v", err)
+	}
+	worker := NewWorker(q, manager, cfg, &mockSecretStore{}, progress.NewNoopNotifier(), logger)
+	worker.SetUsageLedger(ledger)
+	worker.SetResponseCache(cache)
+	opts := ProcessOptions{
+		ConfigNamespace:        "persona.llm",
+		UseConfigProviderModel: true,
+		ConfigRead: ConfigReadOptions{
+			Namespace:           "persona.llm",
+			DefaultProvider:     "gemini",
+			SelectedProviderKey: "selected_provider",
+		},
+	}
+
+	for _, processID := range []string{"usage", "usage-rerun"} {
+		if err := q.SubmitTaskRequests(ctx, processID, "persona", []llm.Request{{UserPrompt: "a"}, {UserPrompt: "b"}}); err != nil {
+			t.Fatalf("SubmitTaskRequests failed: %v", err)
+		}
+		if err := worker.ProcessProcessIDWithOptions(ctx, processID, opts); err != nil {
+			t.Fatalf("ProcessProcessIDWithOptions failed: %v", err)
+		}
+	}
+	if client.count != 2 {
+		t.Fatalf("expected the rerun to be served from cache, calls=%d", client.count)
+	}
+
+	totals, err := ledger.Totals(ctx, "usage")
+	if err != nil {
+		t.Fatalf("Totals failed: %v", err)
+	}
+	if len(totals) != 1 {
+		t.Fatalf("expected one usage group, got %+v", totals)
+	}
+	got := totals[0]
+	if got.Phase != "persona" || got.Provider != "gemini" || got.Model != "gemini-2.0-flash" || got.BulkStrategy != llmusage.BulkStrategySync {
+		t.Fatalf("unexpected usage attribution: %+v", got)
+	}
+	if got.Requests != 2 || got.TotalTokens != 80 {
+		t.Fatalf("expected 2 requests / 80 tokens, got %+v", got)
+	}
+
+	rerun, err := ledger.Totals(ctx, "usage-rerun")
+	if err != nil {
+		t.Fatalf("Totals failed: %v", err)
+	}
+	if len(rerun) != 0 {
+		t.Fatalf("cache hits must not be recorded as usage, got %+v", rerun)
+	}
+}
+
+func TestWorker_RecordsUsageOfFailedPrimaryAttempts(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	q, err := NewQueue(ctx, ":memory:", logger)
+	if err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+	defer q.Close()
+	ledger := newTestUsageLedger(t, ctx)
+
+	processID := "failover-usage"
+	if err := q.SubmitTaskRequests(ctx, processID, "translation", []llm.Request{{UserPrompt: "a"}, {UserPrompt: "b"}}); err != nil {
+		t.Fatalf("SubmitTaskRequests failed: %v", err)
+	}
+	manager := &providerLLMManager{clients: map[string]llm.LLMClient{"gemini": &usageFailClient{}, "lmstudio": &usageClient{}}}
+	cfg := &mapConfigStore{values: map[string]string{
+		"translation.llm::selected_provider":              "gemini",
+		"translation.llm.gemini::model":                   "gemini-2.0-flash",
+		"translation.llm.lmstudio::endpoint":              "http://localhost:1234",
+		"translation.llm::" + llm.LLMFallbackProvidersKey: "lmstudio:qwen2.5-7b",
+	}}
+	worker := NewWorker(q, manager, cfg, &mockSecretStore{}, progress.NewNoopNotifier(), logger)
+	worker.SetUsageLedger(ledger)
+
+	err = worker.ProcessProcessIDWithOptions(ctx, processID, ProcessOptions{
+		ConfigNamespace:        "translation.llm",
+		UseConfigProviderModel: true,
+		ConfigRead: ConfigReadOptions{
+			Namespace:           "translation.llm",
+			DefaultProvider:     "gemini",
+			SelectedProviderKey: "selected_provider",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ProcessProcessIDWithOptions failed: %v", err)
+	}
+
+	totals, err := ledger.Totals(ctx, processID)
+	if err != nil {
+		t.Fatalf("Totals failed: %v", err)
+	}
+	if len(totals) != 2 {
+		t.Fatalf("expected primary and fallback usage groups, got %+v", totals)
+	}
+	primary, fallback := totals[0], totals[1]
+	if primary.Provider != "gemini" || primary.Requests != 2 || primary.FailedRequests != 2 || primary.TotalTokens != 40 {
+		t.Fatalf("expected the failed primary attempts to be billed to gemini, got %+v", primary)
+	}
+	if fallback.Provider != "lmstudio" || fallback.Model != "qwen2.5-7b" || fallback.Requests != 2 || fallback.FailedRequests != 0 || fallback.TotalTokens != 80 {
+		t.Fatalf("unexpected fallback usage: %+v", fallback)
+	}
+}
@@ -647,14 +647,28 @@ func (q *Queue) UpdateProcessMetadata(ctx context.Context, processID, provider,
 	_, err := q.db.ExecContext(ctx, `
 		UPDATE llm_jobs
 		SET provider = ?, model = ?, updated_at = ?
-		WHERE process_id = ?
-	`, provider, model, time.Now().UTC(), processID)
+		WHERE process_id = ? AND status != ?
+	`, provider, model, time.Now().UTC(), processID, StatusCompleted)
 	if err != nil {
 		return fmt.Errorf("UpdateProcessMetadata failed: %w", err)
 	}
 	return nil
 }
 
+// UpdateJobProducer records the provider/model that actually produced a job's response,
+// which differs from the process metadata when the job was failed over to a fallback provider.
+func (q *Queue) UpdateJobProducer(ctx context.Context, jobID, provider, model string) error {
+	_, err := q.db.ExecContext(ctx, `
+		UPDATE llm_jobs
+		SET provider = ?, model = ?, updated_at = ?
+		WHERE id = ?
+	`, provider, model, time.Now().UTC(), jobID)
+	if err != nil {
+		return fmt.Errorf("UpdateJobProducer failed job_id=%s: %w", jobID, err)
+	}
+	return nil
+}
+
 // PrepareTaskResume moves non-completed task requests back to pending so only unfinished requests are retried.
 func (q *Queue) PrepareTaskResume(ctx context.Context, taskID string) error {
 	_, err := q.db.ExecContext(ctx, `
@@ -14,6 +14,8 @@ import (
 	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
 	gatewayllm "github.com/ishibata91/ai-translation-engine-2/pkg/gateway/llm"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/configaccess"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmcache"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
 )
 
 type configReader interface {
@@ -32,6 +34,8 @@ type Worker struct {
 	secretStore     secretReader
 	configAccessor  *configaccess.TypedAccessor
 	notifier        runtimeprogress.ProgressNotifier
+	responseCache   *llmcache.Cache
+	usageLedger     *llmusage.Ledger
 	logger          *slog.Logger
 	pollingInterval time.Duration
 }
@@ -93,6 +97,16 @@ func NewWorker(
 	}
 }
 
+// SetResponseCache enables serving repeated sync requests from the persistent response cache.
+func (w *Worker) SetResponseCache(cache *llmcache.Cache) {
+	w.responseCache = cache
+}
+
+// SetUsageLedger enables recording per-request token usage for cost accounting.
+func (w *Worker) SetUsageLedger(ledger *llmusage.Ledger) {
+	w.usageLedger = ledger
+}
+
 // SetPollingInterval overrides the default polling interval (useful for tests).
 func (w *Worker) SetPollingInterval(d time.Duration) {
 	w.pollingInterval = d
@@ -131,7 +145,7 @@ func (w *Worker) ProcessProcessIDWithOptions(ctx context.Context, processID stri
 	}
 
 	cfgNamespace := resolveConfigNamespace(opts)
-	strategy := w.resolveBulkStrategy(ctx, cfgNamespace, llmConfig.Provider)
+	strategy := w.resolveBulkStrategy(ctx, cfgNamespace, llmConfig)
 
 	if strategy == gatewayllm.BulkStrategySync {
 		err = w.processSync(ctx, processID, llmConfig, opts)
@@ -148,31 +162,36 @@ func (w *Worker) ProcessProcessIDWithOptions(ctx context.Context, processID stri
 
 // ResolveExecutionProfile resolves provider/model/strategy for one execution attempt.
 func (w *Worker) ResolveExecutionProfile(ctx context.Context, opts ProcessOptions) (ExecutionProfile, error) {
+	profile, _, err := w.resolveExecutionProfile(ctx, opts)
+	return profile, err
+}
+
+func (w *Worker) resolveExecutionProfile(ctx context.Context, opts ProcessOptions) (ExecutionProfile, gatewayllm.LLMConfig, error) {
 	llmConfig, err := w.fetchLLMConfig(ctx, opts)
 	if err != nil {
-		return ExecutionProfile{}, fmt.Errorf("resolve execution profile fetch config: %w", err)
+		return ExecutionProfile{}, gatewayllm.LLMConfig{}, fmt.Errorf("resolve execution profile fetch config: %w", err)
 	}
 
 	ns := resolveConfigNamespace(opts)
 	requested := w.resolveConfiguredBulkStrategy(ctx, ns, llmConfig.Provider)
-	resolved := w.llmManager.ResolveBulkStrategy(ctx, requested, llmConfig.Provider)
+	resolved := w.llmManager.ResolveBulkStrategy(ctx, requested, llmConfig)
 
 	return ExecutionProfile{
 		Provider:              llmConfig.Provider,
 		Model:                 llmConfig.Model,
 		RequestedBulkStrategy: requested,
 		BulkStrategy:          resolved,
-	}, nil
+	}, llmConfig, nil
 }
 
 // ValidateExecutionProfile validates unsupported profile combinations before runtime execution.
 func (w *Worker) ValidateExecutionProfile(ctx context.Context, opts ProcessOptions) (ExecutionProfile, error) {
-	profile, err := w.ResolveExecutionProfile(ctx, opts)
+	profile, llmConfig, err := w.resolveExecutionProfile(ctx, opts)
 	if err != nil {
 		return ExecutionProfile{}, fmt.Errorf("validate execution profile resolve: %w", err)
 	}
 
-	if profile.RequestedBulkStrategy == gatewayllm.BulkStrategyBatch && !gatewayllm.ProviderSupportsBatch(profile.Provider) {
+	if profile.RequestedBulkStrategy == gatewayllm.BulkStrategyBatch && !gatewayllm.ConfigSupportsBatch(llmConfig) {
 		return ExecutionProfile{}, fmt.Errorf("batch execution is not supported for provider=%s", profile.Provider)
 	}
 	return profile, nil
@@ -241,28 +260,23 @@ func (w *Worker) processSync(ctx context.Context, processID string, llmConfig ga
 	}
 
 	// Load model once per job process.
-	instanceID := ""
-	if lifecycleClient, ok := client.(gatewayllm.ModelLifecycleClient); ok {
-		ctxLen := 0
-		if v, ok := llmConfig.Parameters["context_length"]; ok {
-			switch n := v.(type) {
-			case int:
-				ctxLen = n
-			case float64:
-				ctxLen = int(n)
-			}
-		}
-		instanceID, err = lifecycleClient.LoadModel(ctx, llmConfig.Model, ctxLen)
-		if err != nil {
-			return fmt.Errorf("failed to load model: %w", err)
-		}
-		defer func() {
-			unloadCtx := context.WithoutCancel(ctx)
-			if unloadErr := lifecycleClient.UnloadModel(unloadCtx, instanceID); unloadErr != nil {
-				w.logger.ErrorContext(ctx, "failed to unload model", slog.String("instance_id", instanceID), slog.String("error", unloadErr.Error()))
-			}
-		}()
+	unload, err := w.loadModel(ctx, client, llmConfig)
+	if err != nil {
+		return err
 	}
+	defer unload()
+
+	client = w.responseCache.Wrap(ctx, client, llmConfig, resolveConfigNamespace(opts))
+	defer func() {
+		hits, misses := llmcache.Flush(context.WithoutCancel(ctx), client)
+		if hits+misses > 0 {
+			w.logger.InfoContext(ctx, "response cache lookups",
+				slog.String("process_id", processID),
+				slog.Int64("hits", hits),
+				slog.Int64("misses", misses),
+			)
+		}
+	}()
 
 	// Wrap the client to report progress periodically
 	progressNotifier := w.notifier
@@ -348,10 +362,26 @@ func (w *Worker) processSync(ctx context.Context, processID string, llmConfig ga
 		return fmt.Errorf("execute bulk sync: %w", err)
 	}
 
+	producers, superseded, err := w.failover(ctx, processID, llmConfig, opts, reqs, responses)
+	if err != nil {
+		return err
+	}
+
 	// Update DB with results
 	failedCount := 0
+	usageRecords := supersededUsage(jobs, superseded)
+	defer func() {
+		w.recordUsage(context.WithoutCancel(ctx), usageRecords)
+	}()
 	for i, res := range responses {
 		jobID := jobs[i].ID
+		producer := llmConfig
+		if fallback, ok := producers[i]; ok {
+			producer = fallback
+		}
+		if record, ok := usageRecord(jobs[i], res, producer, llmusage.BulkStrategySync); ok {
+			usageRecords = append(usageRecords, record)
+		}
 		if opts.Hooks != nil && opts.Hooks.OnSaving != nil {
 			opts.Hooks.OnSaving(i+1, len(jobs))
 		}
@@ -364,6 +394,11 @@ func (w *Worker) processSync(ctx context.Context, processID string, llmConfig ga
 			if err := w.queue.UpdateJob(ctx, jobID, StatusCompleted, &respStr, nil, nil); err != nil {
 				return fmt.Errorf("failed to store completed job %s: %w", jobID, err)
 			}
+			if _, ok := producers[i]; ok {
+				if err := w.queue.UpdateJobProducer(ctx, jobID, producer.Provider, producer.Model); err != nil {
+					return err
+				}
+			}
 		} else {
 			errMsg := res.Error
 			if err := w.queue.UpdateJob(ctx, jobID, StatusFailed, nil, &errMsg, nil); err != nil {
@@ -395,6 +430,179 @@ func (w *Worker) processSync(ctx context.Context, processID string, llmConfig ga
 	return nil
 }
 
+// loadModel loads the model once for LM Studio style clients and returns the matching unload.
+// Clients without a model lifecycle get a no-op.
+func (w *Worker) loadModel(ctx context.Context, client gatewayllm.LLMClient, llmConfig gatewayllm.LLMConfig) (func(), error) {
+	lifecycleClient, ok := client.(gatewayllm.ModelLifecycleClient)
+	if !ok {
+		return func() {}, nil
+	}
+	ctxLen := 0
+	if v, ok := llmConfig.Parameters["context_length"]; ok {
+		switch n := v.(type) {
+		case int:
+			ctxLen = n
+		case float64:
+			ctxLen = int(n)
+		}
+	}
+	instanceID, err := lifecycleClient.LoadModel(ctx, llmConfig.Model, ctxLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load model: %w", err)
+	}
+	return func() {
+		unloadCtx := context.WithoutCancel(ctx)
+		if unloadErr := lifecycleClient.UnloadModel(unloadCtx, instanceID); unloadErr != nil {
+			w.logger.ErrorContext(ctx, "failed to unload model", slog.String("instance_id", instanceID), slog.String("error", unloadErr.Error()))
+		}
+	}, nil
+}
+
+// supersededAttempt is a failed response that a fallback attempt replaced.
+// The provider still billed its tokens, so it is kept for the usage ledger.
+type supersededAttempt struct {
+	index    int
+	producer gatewayllm.LLMConfig
+	response gatewayllm.Response
+}
+
+// failover re-runs requests whose failure was transient on each fallback provider of primary in order.
+// responses is updated in place; the returned map records which fallback produced each replaced response,
+// and the returned attempts are the failed responses those replacements superseded.
+func (w *Worker) failover(
+	ctx context.Context,
+	processID string,
+	primary gatewayllm.LLMConfig,
+	opts ProcessOptions,
+	reqs []gatewayllm.Request,
+	responses []gatewayllm.Response,
+) (map[int]gatewayllm.LLMConfig, []supersededAttempt, error) {
+	producers := make(map[int]gatewayllm.LLMConfig)
+	var superseded []supersededAttempt
+	for _, fallback := range primary.Fallbacks {
+		var pending []int
+		for i, res := range responses {
+			if !res.Success && res.Transient {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		w.logger.WarnContext(ctx, "failing over transient failures to fallback provider",
+			slog.String("process_id", processID),
+			slog.String("provider", fallback.Provider),
+			slog.String("model", fallback.Model),
+			slog.Int("requests", len(pending)),
+		)
+		subset := make([]gatewayllm.Request, 0, len(pending))
+		for _, i := range pending {
+			subset = append(subset, reqs[i])
+		}
+		results, err := w.executeFallback(ctx, fallback, opts, subset)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, nil, fmt.Errorf("execute fallback provider=%s: %w", fallback.Provider, ctxErr)
+			}
+			w.logger.WarnContext(ctx, "fallback provider unavailable",
+				slog.String("process_id", processID),
+				slog.String("provider", fallback.Provider),
+				slog.String("model", fallback.Model),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		for j, i := range pending {
+			if j >= len(results) {
+				break
+			}
+			producer, ok := producers[i]
+			if !ok {
+				producer = primary
+			}
+			superseded = append(superseded, supersededAttempt{index: i, producer: producer, response: responses[i]})
+			responses[i] = results[j]
+			producers[i] = fallback
+		}
+	}
+	return producers, superseded, nil
+}
+
+func (w *Worker) executeFallback(ctx context.Context, fallback gatewayllm.LLMConfig, opts ProcessOptions, reqs []gatewayllm.Request) ([]gatewayllm.Response, error) {
+	client, err := w.llmManager.GetClient(ctx, fallback)
+	if err != nil {
+		return nil, fmt.Errorf("get fallback client provider=%s: %w", fallback.Provider, err)
+	}
+	unload, err := w.loadModel(ctx, client, fallback)
+	if err != nil {
+		return nil, err
+	}
+	defer unload()
+
+	client = w.responseCache.Wrap(ctx, client, fallback, resolveConfigNamespace(opts))
+	defer llmcache.Flush(context.WithoutCancel(ctx), client)
+	responses, err := gatewayllm.ExecuteBulkSync(ctx, client, reqs, fallback.Concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("execute fallback provider=%s: %w", fallback.Provider, err)
+	}
+	return responses, nil
+}
+
+// failoverBatch re-runs the jobs a batch left failed on each fallback provider through the sync path.
+// Batch results do not say whether a failure was transient, so every failed job is retried.
+// It returns how many jobs a fallback recovered.
+func (w *Worker) failoverBatch(ctx context.Context, processID string, llmConfig gatewayllm.LLMConfig, opts ProcessOptions, failed []JobRequest) (int, error) {
+	if len(llmConfig.Fallbacks) == 0 || len(failed) == 0 {
+		return 0, nil
+	}
+	reqs := make([]gatewayllm.Request, len(failed))
+	responses := make([]gatewayllm.Response, len(failed))
+	for i, job := range failed {
+		if err := json.Unmarshal([]byte(job.RequestJSON), &reqs[i]); err != nil {
+			return 0, fmt.Errorf("unmarshal batch request job_id=%s: %w", job.ID, err)
+		}
+		responses[i] = gatewayllm.Response{Success: false, Transient: true, Metadata: reqs[i].Metadata}
+	}
+	producers, superseded, err := w.failover(ctx, processID, llmConfig, opts, reqs, responses)
+	if err != nil {
+		return 0, err
+	}
+
+	// The batch attempts were already recorded when their results were applied;
+	// their placeholder responses here were never sent, so usageRecord drops them.
+	usageRecords := supersededUsage(failed, superseded)
+	defer func() {
+		w.recordUsage(context.WithoutCancel(ctx), usageRecords)
+	}()
+	recovered := 0
+	for i, job := range failed {
+		producer, ok := producers[i]
+		if !ok {
+			continue
+		}
+		res := responses[i]
+		if record, ok := usageRecord(job, res, producer, llmusage.BulkStrategySync); ok {
+			usageRecords = append(usageRecords, record)
+		}
+		if !res.Success {
+			continue
+		}
+		respJSON, err := json.Marshal(res)
+		if err != nil {
+			return recovered, fmt.Errorf("marshal fallback response job_id=%s: %w", job.ID, err)
+		}
+		respStr := string(respJSON)
+		if err := w.queue.UpdateJob(ctx, job.ID, StatusCompleted, &respStr, nil, nil); err != nil {
+			return recovered, fmt.Errorf("store fallback response job_id=%s: %w", job.ID, err)
+		}
+		if err := w.queue.UpdateJobProducer(ctx, job.ID, producer.Provider, producer.Model); err != nil {
+			return recovered, err
+		}
+		recovered++
+	}
+	return recovered, nil
+}
+
 func (w *Worker) processBatch(ctx context.Context, processID string, llmConfig gatewayllm.LLMConfig, opts ProcessOptions) error {
 	w.logger.DebugContext(ctx, "ENTER processBatch", slog.String("process_id", processID))
 
@@ -475,17 +683,23 @@ func (w *Worker) processBatch(ctx context.Context, processID string, llmConfig g
 				return fmt.Errorf("get batch results failed: %w", err)
 			}
 
-			completedCount, failedCount, err := w.applyBatchResults(ctx, jobs, results, opts)
+			completedCount, failedJobs, err := w.applyBatchResults(ctx, jobs, results, opts)
 			if err != nil {
 				return err
 			}
+			recovered, err := w.failoverBatch(ctx, processID, llmConfig, opts, failedJobs)
+			if err != nil {
+				return err
+			}
+			completedCount += recovered
+			failedCount := len(failedJobs) - recovered
 
 			if opts.Hooks != nil && opts.Hooks.OnComplete != nil {
 				opts.Hooks.OnComplete(completedCount, len(jobs), failedCount)
 			}
 			if progressNotifier != nil {
 				msgStatus := runtimeprogress.StatusCompleted
-				if status.State == gatewayllm.BatchStateFailed || status.State == gatewayllm.BatchStateCancelled {
+				if failedCount > 0 && (status.State == gatewayllm.BatchStateFailed || status.State == gatewayllm.BatchStateCancelled) {
 					msgStatus = runtimeprogress.StatusFailed
 				}
 				progressNotifier.OnProgress(ctx, runtimeprogress.ProgressEvent{
@@ -611,6 +825,14 @@ func extractBatchQueueJobID(metadata map[string]interface{}) (string, bool) {
 }
 
 func (w *Worker) applySingleBatchResult(ctx context.Context, job JobRequest, res gatewayllm.Response) (bool, error) {
+	if record, ok := usageRecord(job, res, gatewayllm.LLMConfig{Provider: job.Provider, Model: job.Model}, llmusage.BulkStrategyBatch); ok {
+		w.recordUsage(ctx, []llmusage.Record{record})
+	}
+	var req gatewayllm.Request
+	if err := json.Unmarshal([]byte(job.RequestJSON), &req); err != nil {
+		return false, fmt.Errorf("unmarshal batch request job_id=%s: %w", job.ID, err)
+	}
+	res = gatewayllm.ValidateBatchResponse(req, res)
 	if res.Success {
 		respJSON, marshalErr := json.Marshal(res)
 		if marshalErr != nil {
@@ -633,9 +855,10 @@ func (w *Worker) applySingleBatchResult(ctx context.Context, job JobRequest, res
 	return false, nil
 }
 
-func (w *Worker) applyBatchResults(ctx context.Context, jobs []JobRequest, results []gatewayllm.Response, opts ProcessOptions) (int, int, error) {
+// applyBatchResults stores each batch result on its job and returns the completed count and the jobs left failed.
+func (w *Worker) applyBatchResults(ctx context.Context, jobs []JobRequest, results []gatewayllm.Response, opts ProcessOptions) (int, []JobRequest, error) {
 	completedCount := 0
-	failedCount := 0
+	var failedJobs []JobRequest
 
 	jobsByID := make(map[string]JobRequest, len(jobs))
 	for _, job := range jobs {
@@ -691,21 +914,21 @@ func (w *Worker) applyBatchResults(ctx context.Context, jobs []JobRequest, resul
 		if _, duplicated := duplicateJobIDs[job.ID]; duplicated {
 			errMsg := "duplicate queue_job_id in batch results"
 			if err := w.queue.UpdateJob(ctx, job.ID, StatusFailed, nil, &errMsg, nil); err != nil {
-				return 0, 0, fmt.Errorf("store duplicate queue_job_id failure job_id=%s: %w", job.ID, err)
+				return 0, nil, fmt.Errorf("store duplicate queue_job_id failure job_id=%s: %w", job.ID, err)
 			}
-			failedCount++
+			failedJobs = append(failedJobs, job)
 			continue
 		}
 
 		if res, exists := resultsByJobID[job.ID]; exists {
 			succeeded, err := w.applySingleBatchResult(ctx, job, res)
 			if err != nil {
-				return 0, 0, err
+				return 0, nil, err
 			}
 			if succeeded {
 				completedCount++
 			} else {
-				failedCount++
+				failedJobs = append(failedJobs, job)
 			}
 			continue
 		}
@@ -719,21 +942,21 @@ func (w *Worker) applyBatchResults(ctx context.Context, jobs []JobRequest, resul
 			fallbackIndex++
 			succeeded, err := w.applySingleBatchResult(ctx, job, res)
 			if err != nil {
-				return 0, 0, err
+				return 0, nil, err
 			}
 			if succeeded {
 				completedCount++
 			} else {
-				failedCount++
+				failedJobs = append(failedJobs, job)
 			}
 			continue
 		}
 
 		errMsg := "batch result missing for request"
 		if err := w.queue.UpdateJob(ctx, job.ID, StatusFailed, nil, &errMsg, nil); err != nil {
-			return 0, 0, fmt.Errorf("mark missing batch result as failed job_id=%s: %w", job.ID, err)
+			return 0, nil, fmt.Errorf("mark missing batch result as failed job_id=%s: %w", job.ID, err)
 		}
-		failedCount++
+		failedJobs = append(failedJobs, job)
 	}
 
 	if unknownIDCount > 0 {
@@ -753,7 +976,48 @@ func (w *Worker) applyBatchResults(ctx context.Context, jobs []JobRequest, resul
 		)
 	}
 
-	return completedCount, failedCount, nil
+	return completedCount, failedJobs, nil
+}
+
+// usageRecord builds the ledger record for one response.
+// Responses served from the response cache and placeholders for requests that were never sent
+// spent no tokens, so they report false and are left out of the request counts.
+func usageRecord(job JobRequest, res gatewayllm.Response, producer gatewayllm.LLMConfig, strategy string) (llmusage.Record, bool) {
+	if llmcache.IsHit(res.Metadata) || (!res.Success && res.Error == "") {
+		return llmusage.Record{}, false
+	}
+	taskID := job.TaskID
+	if taskID == "" {
+		taskID = job.ProcessID
+	}
+	return llmusage.Record{
+		TaskID:           taskID,
+		Phase:            job.TaskType,
+		Provider:         gatewayllm.NormalizeProvider(producer.Provider),
+		Model:            producer.Model,
+		BulkStrategy:     strategy,
+		PromptTokens:     res.Usage.PromptTokens,
+		CompletionTokens: res.Usage.CompletionTokens,
+		TotalTokens:      res.Usage.TotalTokens,
+		Success:          res.Success,
+	}, true
+}
+
+// supersededUsage builds the ledger records for failed attempts that a fallback replaced.
+func supersededUsage(jobs []JobRequest, superseded []supersededAttempt) []llmusage.Record {
+	records := make([]llmusage.Record, 0, len(jobs)+len(superseded))
+	for _, attempt := range superseded {
+		if record, ok := usageRecord(jobs[attempt.index], attempt.response, attempt.producer, llmusage.BulkStrategySync); ok {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func (w *Worker) recordUsage(ctx context.Context, records []llmusage.Record) {
+	if err := w.usageLedger.Record(ctx, records); err != nil {
+		w.logger.WarnContext(ctx, "failed to record llm usage", slog.String("error", err.Error()))
+	}
 }
 
 func isBatchTerminalState(state gatewayllm.BatchState) bool {
@@ -850,7 +1114,19 @@ type progressReportingClient struct {
 }
 
 func (c *progressReportingClient) Complete(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
-	resp, err := c.LLMClient.Complete(ctx, req)
+	// Long book bodies are streamed so partial output is reported while it arrives.
+	resp, err := gatewayllm.NewBookStreamingClient(c.LLMClient, func(receivedChars int) {
+		c.reportStream(ctx, receivedChars)
+	}).Complete(ctx, req)
+	return c.report(ctx, resp, err)
+}
+
+func (c *progressReportingClient) GenerateStructured(ctx context.Context, req gatewayllm.Request) (gatewayllm.Response, error) {
+	resp, err := c.LLMClient.GenerateStructured(ctx, req)
+	return c.report(ctx, resp, err)
+}
+
+func (c *progressReportingClient) report(ctx context.Context, resp gatewayllm.Response, err error) (gatewayllm.Response, error) {
 	comp := atomic.AddInt32(c.completed, 1)
 	if c.onEach != nil {
 		c.onEach(int(comp), c.total)
@@ -870,7 +1146,67 @@ func (c *progressReportingClient) Complete(ctx context.Context, req gatewayllm.R
 	return resp, nil
 }
 
+// reportStream emits partial output progress for a streamed book body.
+func (c *progressReportingClient) reportStream(ctx context.Context, receivedChars int) {
+	if c.notifier == nil {
+		return
+	}
+	c.notifier.OnProgress(ctx, runtimeprogress.ProgressEvent{
+		CorrelationID: c.processID,
+		Total:         c.total,
+		Completed:     int(atomic.LoadInt32(c.completed)),
+		Status:        runtimeprogress.StatusInProgress,
+		Message:       fmt.Sprintf("書籍本文を生成中... %d 文字受信", receivedChars),
+	})
+}
+
+// fetchLLMConfig resolves the primary provider config and attaches the configured fallback chain.
 func (w *Worker) fetchLLMConfig(ctx context.Context, opts ProcessOptions) (gatewayllm.LLMConfig, error) {
+	config, err := w.resolveLLMConfig(ctx, opts)
+	if err != nil {
+		return gatewayllm.LLMConfig{}, err
+	}
+	config.Fallbacks = w.resolveFallbackConfigs(ctx, opts, config)
+	return config, nil
+}
+
+// resolveFallbackConfigs parses "provider:model,provider:model" from the namespace and resolves each entry
+// with the same lookups as the primary provider. Entries that cannot be resolved are skipped with a warning.
+func (w *Worker) resolveFallbackConfigs(ctx context.Context, opts ProcessOptions, primary gatewayllm.LLMConfig) []gatewayllm.LLMConfig {
+	ns := resolveConfigNamespace(opts)
+	raw := strings.TrimSpace(w.getConfigString(ctx, ns, gatewayllm.LLMFallbackProvidersKey, ""))
+	if raw == "" {
+		return nil
+	}
+	var fallbacks []gatewayllm.LLMConfig
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		provider, model, _ := strings.Cut(entry, ":")
+		fallbackOpts := opts
+		fallbackOpts.ProviderOverride = provider
+		fallbackOpts.ModelOverride = strings.TrimSpace(model)
+		fallbackOpts.EndpointOverride = ""
+		config, err := w.resolveLLMConfig(ctx, fallbackOpts)
+		if err != nil {
+			w.logger.WarnContext(ctx, "skipping unresolvable fallback provider",
+				slog.String("namespace", ns),
+				slog.String("fallback", entry),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if config.Provider == primary.Provider && config.Model == primary.Model {
+			continue
+		}
+		fallbacks = append(fallbacks, config)
+	}
+	return fallbacks
+}
+
+func (w *Worker) resolveLLMConfig(ctx context.Context, opts ProcessOptions) (gatewayllm.LLMConfig, error) {
 	read := opts.ConfigRead
 	ns := resolveConfigNamespace(opts)
 	defaultProvider := strings.TrimSpace(read.DefaultProvider)
@@ -993,6 +1329,10 @@ func (w *Worker) fetchLLMConfig(ctx context.Context, opts ProcessOptions) (gatew
 	if contextLength > 0 {
 		params["context_length"] = contextLength
 	}
+	if provider == "openai_compatible" && strings.TrimSpace(endpoint) == "" {
+		return gatewayllm.LLMConfig{}, gatewayllm.ErrEndpointRequired
+	}
+	w.configAccessor.ApplyProviderParameters(ctx, ns, provider, gatewayllm.ProviderParameterKeys(provider), params)
 
 	return gatewayllm.LLMConfig{
 		Provider:    provider,
@@ -1037,9 +1377,9 @@ func (w *Worker) resolveResumeProviderModel(ctx context.Context, jobs []JobReque
 	return provider, model, nil
 }
 
-func (w *Worker) resolveBulkStrategy(ctx context.Context, ns, provider string) gatewayllm.BulkStrategy {
-	configured := w.resolveConfiguredBulkStrategy(ctx, ns, provider)
-	return w.llmManager.ResolveBulkStrategy(ctx, configured, provider)
+func (w *Worker) resolveBulkStrategy(ctx context.Context, ns string, llmConfig gatewayllm.LLMConfig) gatewayllm.BulkStrategy {
+	configured := w.resolveConfiguredBulkStrategy(ctx, ns, llmConfig.Provider)
+	return w.llmManager.ResolveBulkStrategy(ctx, configured, llmConfig)
 }
 
 func (w *Worker) resolveConfiguredBulkStrategy(ctx context.Context, ns, provider string) gatewayllm.BulkStrategy {
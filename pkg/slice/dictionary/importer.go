@@ -2,11 +2,11 @@ package dictionary
 
 import (
 	"context"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"log/slog"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/xtranslator"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
 )
 
@@ -86,57 +86,38 @@ func (i *xmlImporter) ImportXML(ctx context.Context, sourceID int64, fileName st
 
 // parseAndSave は XML を読み込み、バッチ単位で保存して合計件数を返す。
 func (i *xmlImporter) parseAndSave(ctx context.Context, sourceID int64, correlationID string, file io.Reader) (int, error) {
-	decoder := xml.NewDecoder(file)
-
-	var addonName string
 	const batchSize = 1000
 	batch := make([]DictTerm, 0, batchSize)
 	totalImported := 0
 
-	for {
-		t, err := decoder.Token()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			return totalImported, fmt.Errorf("error reading xml token: %w", err)
+	err := xtranslator.ReadStrings(ctx, file, i.logger, func(s xtranslator.String) error {
+		term, ok := i.toTerm(s, sourceID)
+		if !ok {
+			return nil
 		}
-		if t == nil {
-			break
+		batch = append(batch, term)
+		if len(batch) < batchSize {
+			return nil
 		}
 
-		se, ok := t.(xml.StartElement)
-		if !ok {
-			continue
+		flushed, err := i.flushBatch(ctx, batch)
+		if err != nil {
+			return err
 		}
+		totalImported += flushed
+		batch = batch[:0]
 
-		switch se.Name.Local {
-		case "Addon":
-			addonName = i.handleAddonElement(ctx, decoder, &se, addonName)
-		case "String":
-			term, ok := i.handleStringElement(ctx, decoder, &se, sourceID)
-			if !ok {
-				continue
-			}
-			batch = append(batch, term)
-
-			if len(batch) >= batchSize {
-				flushed, err := i.flushBatch(ctx, batch)
-				if err != nil {
-					return totalImported, err
-				}
-				totalImported += flushed
-				batch = batch[:0]
-
-				// バッチ完了ごとに進捗通知
-				i.notifier.OnProgress(ctx, progress.ProgressEvent{
-					CorrelationID: correlationID,
-					Completed:     totalImported,
-					Status:        progress.StatusInProgress,
-					Message:       fmt.Sprintf("インポート中: %d 件処理済み", totalImported),
-				})
-			}
-		}
+		// バッチ完了ごとに進捗通知
+		i.notifier.OnProgress(ctx, progress.ProgressEvent{
+			CorrelationID: correlationID,
+			Completed:     totalImported,
+			Status:        progress.StatusInProgress,
+			Message:       fmt.Sprintf("インポート中: %d 件処理済み", totalImported),
+		})
+		return nil
+	})
+	if err != nil {
+		return totalImported, err
 	}
 
 	// 残りのバッチをフラッシュ
@@ -151,46 +132,19 @@ func (i *xmlImporter) parseAndSave(ctx context.Context, sourceID int64, correlat
 	return totalImported, nil
 }
 
-// handleAddonElement は Addon 要素をデコードしてアドオン名を返す。
-func (i *xmlImporter) handleAddonElement(ctx context.Context, decoder *xml.Decoder, se *xml.StartElement, currentAddon string) string {
-	i.logger.DebugContext(ctx, "ENTER DictionaryImporter.handleAddonElement")
-
-	var addon string
-	err := decoder.DecodeElement(&addon, se)
-	if err == nil && currentAddon == "" {
-		return addon
-	}
-	return currentAddon
-}
-
-// handleStringElement は String 要素をデコードして DictTerm を返す。
+// toTerm は String 要素を DictTerm に変換する。
 // REC タイプが許可されていない場合は (DictTerm{}, false) を返す。
-func (i *xmlImporter) handleStringElement(ctx context.Context, decoder *xml.Decoder, se *xml.StartElement, sourceID int64) (DictTerm, bool) {
-	i.logger.DebugContext(ctx, "ENTER DictionaryImporter.handleStringElement")
-
-	var strElem struct {
-		EDID   string `xml:"EDID"`
-		REC    string `xml:"REC"`
-		Source string `xml:"Source"`
-		Dest   string `xml:"Dest"`
-	}
-
-	err := decoder.DecodeElement(&strElem, se)
-	if err != nil {
-		i.logger.WarnContext(ctx, "failed to decode String element, skipping", "error", err)
-		return DictTerm{}, false
-	}
-
-	if !i.config.IsAllowedREC(strElem.REC) {
+func (i *xmlImporter) toTerm(s xtranslator.String, sourceID int64) (DictTerm, bool) {
+	if !i.config.IsAllowedREC(s.REC) {
 		return DictTerm{}, false
 	}
 
 	return DictTerm{
 		SourceID:   sourceID,
-		EDID:       strElem.EDID,
-		RecordType: strElem.REC,
-		Source:     strElem.Source,
-		Dest:       strElem.Dest,
+		EDID:       s.EDID,
+		RecordType: s.REC,
+		Source:     s.Source,
+		Dest:       s.Dest,
 	}, true
 }
 
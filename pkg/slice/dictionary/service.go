@@ -17,6 +17,8 @@ type DictionaryService struct {
 	store    DictionaryStore
 	importer DictionaryImporter
 	logger   *slog.Logger
+
+	entriesChanged func(ctx context.Context)
 }
 
 // NewDictionaryService は DictionaryService の新しいインスタンスを生成する。
@@ -28,6 +30,18 @@ func NewDictionaryService(store DictionaryStore, importer DictionaryImporter, lo
 	}
 }
 
+// SetEntriesChangedHook はインポート完了やエントリ更新で辞書内容が変わった後に呼ぶ処理を登録する。
+// 埋め込みインデックスの再構築など、辞書スライスが知らない後処理を外側から差し込むために使う。
+func (s *DictionaryService) SetEntriesChangedHook(hook func(ctx context.Context)) {
+	s.entriesChanged = hook
+}
+
+func (s *DictionaryService) notifyEntriesChanged(ctx context.Context) {
+	if s.entriesChanged != nil {
+		s.entriesChanged(ctx)
+	}
+}
+
 // GetSources は登録済みの辞書ソース一覧を返す。
 func (s *DictionaryService) GetSources(ctx context.Context) ([]DictSource, error) {
 	defer telemetry2.StartSpan(ctx, telemetry2.ActionDBQuery)()
@@ -84,7 +98,12 @@ func (s *DictionaryService) SearchAll(ctx context.Context, query string, filters
 func (s *DictionaryService) UpdateEntry(ctx context.Context, term DictTerm) error {
 	defer telemetry2.StartSpan(ctx, telemetry2.ActionDBQuery)()
 	s.logger.InfoContext(ctx, "updating dictionary entry", slog.Int64("id", term.ID))
-	return s.store.UpdateEntry(ctx, term)
+	if err := s.store.UpdateEntry(ctx, term); err != nil {
+		return err
+	}
+	// 更新されたエントリの埋め込みは削除されるため、再索引を促す
+	s.notifyEntriesChanged(ctx)
+	return nil
 }
 
 // DeleteEntry は指定エントリを削除する。
@@ -146,6 +165,7 @@ func (s *DictionaryService) StartImport(ctx context.Context, filePath string) (i
 		} else {
 			s.logger.InfoContext(bgCtx, "import process completed",
 				slog.Int64("source_id", sourceID), slog.Int("processed_count", count))
+			s.notifyEntriesChanged(bgCtx)
 		}
 	}()
 
@@ -12,6 +12,8 @@ type PersonaGenInput struct {
 	Dialogues         []PersonaDialogue
 	SourceJSONPath    string
 	OverwriteExisting bool
+	// DryRun builds requests for estimation without saving persona base rows, dialogues or generation requests.
+	DryRun bool
 }
 
 type PersonaNPC struct {
@@ -2,6 +2,7 @@ package persona
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"regexp"
@@ -85,7 +86,7 @@ func (g *DefaultPersonaGenerator) PreparePrompts(
 		npcData.SourcePlugin = normalizeSourcePlugin(npcData.SourcePlugin, npcData.SourceHint)
 
 		// Persist base NPC metadata and dialogues before request generation.
-		saveState, err := g.Store.SavePersonaBase(ctx, npcData, data.OverwriteExisting)
+		saveState, err := g.loadPersonaState(ctx, npcData, data)
 		if err != nil {
 			slog.WarnContext(ctx, "failed to save persona base data",
 				slog.String("speaker_id", npcData.SpeakerID),
@@ -93,7 +94,7 @@ func (g *DefaultPersonaGenerator) PreparePrompts(
 			)
 			continue
 		}
-		if strings.TrimSpace(saveState.PersonaText) == "" || data.OverwriteExisting {
+		if !data.DryRun && (strings.TrimSpace(saveState.PersonaText) == "" || data.OverwriteExisting) {
 			if err := g.Store.ReplaceDialogues(ctx, saveState.PersonaID, npcData.SourcePlugin, npcData.SpeakerID, npcData.Dialogues); err != nil {
 				slog.WarnContext(ctx, "failed to save persona dialogues",
 					slog.String("speaker_id", npcData.SpeakerID),
@@ -136,6 +137,16 @@ func (g *DefaultPersonaGenerator) PreparePrompts(
 				"overwrite_existing": data.OverwriteExisting,
 			},
 		}
+		// A saved custom prompt that still asks for the legacy TL format is sent without a schema,
+		// otherwise the gateway would reject (or repair away) the format the prompt asks for.
+		if !usesLegacyTLFormat(promptCfg.SystemPrompt) {
+			request.ResponseSchema = personaResponseSchema()
+			request.Metadata["structured_output_schema_version"] = personaResponseSchemaVersion
+		}
+		if data.DryRun {
+			requests = append(requests, request)
+			continue
+		}
 		if err := g.Store.SaveGenerationRequest(ctx, npcData.SourcePlugin, npcData.SpeakerID, formatGenerationRequest(request)); err != nil {
 			slog.WarnContext(ctx, "failed to save persona generation request",
 				slog.String("speaker_id", npcData.SpeakerID),
@@ -162,6 +173,18 @@ func (g *DefaultPersonaGenerator) PreparePrompts(
 	return requests, nil
 }
 
+// loadPersonaState saves the persona base row, or only reads the existing persona text on dry runs.
+func (g *DefaultPersonaGenerator) loadPersonaState(ctx context.Context, npcData NPCDialogueData, data PersonaGenInput) (PersonaSaveState, error) {
+	if !data.DryRun {
+		return g.Store.SavePersonaBase(ctx, npcData, data.OverwriteExisting)
+	}
+	personaText, err := g.Store.GetPersona(ctx, npcData.SourcePlugin, npcData.SpeakerID)
+	if err != nil {
+		return PersonaSaveState{}, err
+	}
+	return PersonaSaveState{PersonaText: personaText}, nil
+}
+
 func formatGenerationRequest(request llmio.Request) string {
 	return strings.TrimSpace(fmt.Sprintf("System Prompt:\n%s\n\nUser Prompt:\n%s", request.SystemPrompt, request.UserPrompt))
 }
@@ -209,10 +232,11 @@ func (g *DefaultPersonaGenerator) SaveResultsWithSummary(
 			continue
 		}
 
-		personaText := g.extractPersona(resp.Content)
-		if personaText == "" {
-			slog.WarnContext(ctx, "failed to extract persona text from response",
+		personaText, err := parsePersonaResponse(resp.Content)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to parse persona response",
 				slog.String("speaker_id", speakerID),
+				slog.String("error", err.Error()),
 			)
 			failCount++
 			continue
@@ -282,9 +306,44 @@ func (g *DefaultPersonaGenerator) CleanupTaskArtifacts(ctx context.Context, task
 	return nil
 }
 
+const personaResponseSchemaVersion = "persona.v1"
+
+// personaResponseSchema is the JSON schema the gateway validates (and asks the model to repair) persona responses against.
+func personaResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"persona"},
+		"properties": map[string]interface{}{
+			"persona": map[string]interface{}{"type": "string", "minLength": 6},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// parsePersonaResponse reads the persona text from a {"persona": "..."} response.
+// Responses that are not JSON fall back to the legacy "TL: |...|" format, which old queued jobs and
+// custom prompts saved before the structured output change still produce.
+func parsePersonaResponse(content string) (string, error) {
+	var payload struct {
+		Persona string `json:"persona"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &payload); err != nil {
+		if personaText := extractLegacyPersona(content); personaText != "" {
+			return personaText, nil
+		}
+		return "", fmt.Errorf("decode persona response: %w", err)
+	}
+	personaText := strings.TrimSpace(payload.Persona)
+	if personaText == "" {
+		return "", fmt.Errorf("persona response has empty persona")
+	}
+	return personaText, nil
+}
+
 var personaRegex = regexp.MustCompile(`TL:\s*\|(.*?)\|`)
 
-func (g *DefaultPersonaGenerator) extractPersona(content string) string {
+// extractLegacyPersona extracts the persona text from the legacy "TL: |...|" response format.
+func extractLegacyPersona(content string) string {
 	// 1. Regex search for TL: |...|
 	match := personaRegex.FindStringSubmatch(content)
 	if len(match) > 1 {
@@ -311,3 +370,8 @@ func (g *DefaultPersonaGenerator) extractPersona(content string) string {
 
 	return ""
 }
+
+// usesLegacyTLFormat reports whether a system prompt asks for the legacy "TL: |...|" response format.
+func usesLegacyTLFormat(systemPrompt string) bool {
+	return strings.Contains(systemPrompt, "TL:")
+}
@@ -109,13 +109,13 @@ func TestPersonaGenSlice_TableDriven(t *testing.T) {
 				MaxOutputTokens:      500,
 			},
 			mockLLMOutput: []string{
-				"TL: |Personality: Brave, habits: direct|",
+				`{"persona": "Personality: Brave, habits: direct"}`,
 			},
 			expectedRequestCount: 1,
 			expectedDBCount:      1,
 		},
 		{
-			name: "Phase 2: Fallback parsing - simple TL: prefix",
+			name: "Phase 2: Legacy TL format falls back",
 			input: PersonaGenInput{
 				NPCs: map[string]PersonaNPC{
 					"NPC002": {ID: "NPC002", Name: "Farkas", Type: "Nord"},
@@ -125,13 +125,13 @@ func TestPersonaGenSlice_TableDriven(t *testing.T) {
 				},
 			},
 			mockLLMOutput: []string{
-				"TL: Personality: Simple and loyal.",
+				"TL: |Personality: Simple and loyal.|",
 			},
 			expectedRequestCount: 1,
 			expectedDBCount:      1,
 		},
 		{
-			name: "Phase 2: Fallback parsing - just pipes",
+			name: "Phase 2: Failure - empty persona",
 			input: PersonaGenInput{
 				NPCs: map[string]PersonaNPC{
 					"NPC003": {ID: "NPC003", Name: "Vilkas", Type: "Nord"},
@@ -141,10 +141,10 @@ func TestPersonaGenSlice_TableDriven(t *testing.T) {
 				},
 			},
 			mockLLMOutput: []string{
-				"Here is the persona: |Personality: Smart and tactical|",
+				`{"persona": "  "}`,
 			},
 			expectedRequestCount: 1,
-			expectedDBCount:      1,
+			expectedDBCount:      0,
 		},
 		{
 			name: "Phase 2: Failure - content too short",
@@ -157,7 +157,7 @@ func TestPersonaGenSlice_TableDriven(t *testing.T) {
 				},
 			},
 			mockLLMOutput: []string{
-				"TL: |Old|",
+				`{"persona": "Old"}`,
 			},
 			expectedRequestCount: 1,
 			expectedDBCount:      0,
@@ -193,6 +193,11 @@ func TestPersonaGenSlice_TableDriven(t *testing.T) {
 			if len(requests) != tc.expectedRequestCount {
 				t.Errorf("Expected %d requests, got %d", tc.expectedRequestCount, len(requests))
 			}
+			for _, request := range requests {
+				if len(request.ResponseSchema) == 0 {
+					t.Errorf("persona request must carry a response schema")
+				}
+			}
 
 			// Simulate JobQueue/Pipeline calling LLM
 			llmResponses := make([]llmio.Response, 0, len(requests))
@@ -282,3 +287,76 @@ func TestPersonaGenSlice_UsesConfiguredPromptSplit(t *testing.T) {
 	}
 
 }
+
+func TestPersonaGenSlice_LegacyTLPromptIsSentWithoutSchema(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	require.NoError(t, master_persona_artifact.Migrate(ctx, db))
+	store := NewPersonaStore(master_persona_artifact.NewRepository(db))
+	require.NoError(t, store.InitSchema(ctx))
+
+	configStore := &mockConfigStore{
+		values: map[string]map[string]string{
+			masterPersonaPromptNamespace: {
+				masterPersonaSystemPromptKey: "Your response MUST be formatted strictly as: TL: |...|",
+			},
+		},
+	}
+	evaluator := NewDefaultContextEvaluator(NewDefaultScorer(), NewSimpleTokenEstimator())
+	generator := NewPersonaGenerator(NewDefaultDialogueCollector(), evaluator, store, configStore, &mockSecretStore{})
+
+	requests, err := generator.PreparePrompts(ctx, PersonaGenInput{
+		NPCs: map[string]PersonaNPC{
+			"NPC001": {ID: "NPC001", Name: "Aela", Race: "Nord"},
+		},
+		Dialogues: []PersonaDialogue{
+			{ID: "D1", SpeakerID: strPtr("NPC001"), Text: strPtr("We hunt as one."), Order: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Empty(t, requests[0].ResponseSchema)
+	require.NotContains(t, requests[0].Metadata, "structured_output_schema_version")
+
+	require.NoError(t, generator.SaveResults(ctx, []llmio.Response{{
+		Content:  "TL: |Personality: Fierce huntress, speaks bluntly.|",
+		Success:  true,
+		Metadata: requests[0].Metadata,
+	}}))
+	personaText, err := store.GetPersona(ctx, "", "NPC001")
+	require.NoError(t, err)
+	require.Equal(t, "Personality: Fierce huntress, speaks bluntly.", personaText)
+}
+
+func TestPersonaGenSlice_DryRunDoesNotPersist(t *testing.T) {
+	ctx := WithTaskID(context.Background(), "task-dry-run")
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	require.NoError(t, master_persona_artifact.Migrate(ctx, db))
+	store := NewPersonaStore(master_persona_artifact.NewRepository(db))
+	require.NoError(t, store.InitSchema(ctx))
+
+	evaluator := NewDefaultContextEvaluator(NewDefaultScorer(), NewSimpleTokenEstimator())
+	generator := NewPersonaGenerator(NewDefaultDialogueCollector(), evaluator, store, &mockConfigStore{}, &mockSecretStore{})
+
+	requests, err := generator.PreparePrompts(ctx, PersonaGenInput{
+		NPCs: map[string]PersonaNPC{
+			"NPC001": {ID: "NPC001", Name: "Aela", Race: "Nord", VoiceType: "FemaleYoungEager"},
+		},
+		Dialogues: []PersonaDialogue{
+			{ID: "D1", SpeakerID: strPtr("NPC001"), Text: strPtr("We hunt as one."), Order: 1},
+		},
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	for _, table := range []string{"artifact_master_persona_temp", "artifact_master_persona_final"} {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count))
+		require.Zero(t, count, "dry run must not write %s", table)
+	}
+}
@@ -19,6 +19,7 @@ type Summary interface {
 	SaveResults(ctx context.Context, responses []llmio.Response) error
 
 	// GetSummary retrieves a single summary by record ID. Used by Pass 2.
+	// For TypeQuest a bare quest ID resolves to the latest summarized stage of that quest.
 	GetSummary(ctx context.Context, recordID string, summaryType string) (*SummaryResult, error)
 }
 
@@ -33,6 +34,9 @@ type SummaryStore interface {
 	// GetByRecordID retrieves the latest record for a given record ID and type.
 	GetByRecordID(ctx context.Context, recordID string, summaryType string) (*SummaryRecord, error)
 
+	// GetLatestQuestStage retrieves the quest stage summary covering the most stages of a quest.
+	GetLatestQuestStage(ctx context.Context, questID string) (*SummaryRecord, error)
+
 	// Upsert inserts or updates a summary record.
 	Upsert(ctx context.Context, record SummaryRecord) error
 
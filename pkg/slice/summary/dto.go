@@ -16,6 +16,15 @@ const (
 	TypeQuest    = "Quest"
 )
 
+// QuestStageRecordID returns the record ID of the cumulative summary up to one quest stage.
+func QuestStageRecordID(questID string, stageIndex int) string {
+	return fmt.Sprintf("%s%d", questStageRecordIDPrefix(questID), stageIndex)
+}
+
+func questStageRecordIDPrefix(questID string) string {
+	return questID + "_stage_"
+}
+
 // SummaryConfig holds configuration for the Summary.
 type SummaryConfig struct {
 	// Concurrency controls the number of parallel goroutines for cache lookups in ProposeJobs.
@@ -218,7 +218,7 @@ func (g *summaryGenerator) proposeQuestJobs(ctx context.Context, items []QuestIt
 				}
 				currentLines = append(currentLines, stage.Text)
 
-				stageRecordID := fmt.Sprintf("%s_stage_%d", item.QuestID, stage.Index)
+				stageRecordID := QuestStageRecordID(item.QuestID, stage.Index)
 				cacheKey, inputHash := hasher.BuildCacheKey(stageRecordID, currentLines)
 
 				record, err := g.store.Get(egCtx, cacheKey)
@@ -336,6 +336,12 @@ func (g *summaryGenerator) GetSummary(ctx context.Context, recordID string, summ
 	if err != nil {
 		return nil, fmt.Errorf("get summary record record_id=%s type=%s: %w", recordID, summaryType, err)
 	}
+	if record == nil && summaryType == TypeQuest {
+		record, err = g.store.GetLatestQuestStage(ctx, recordID)
+		if err != nil {
+			return nil, fmt.Errorf("get latest quest stage summary quest_id=%s: %w", recordID, err)
+		}
+	}
 	if record == nil {
 		return nil, nil
 	}
@@ -457,6 +463,28 @@ func (s *summaryStore) GetByRecordID(ctx context.Context, recordID string, summa
 	return &r, nil
 }
 
+func (s *summaryStore) GetLatestQuestStage(ctx context.Context, questID string) (*SummaryRecord, error) {
+	// Stage summaries are cumulative, so the one built from the most lines is the latest.
+	query := `
+	SELECT id, record_id, summary_type, cache_key, input_hash, summary_text, input_line_count, created_at, updated_at
+	FROM summaries
+	WHERE summary_type = ? AND substr(record_id, 1, length(?)) = ?
+	ORDER BY input_line_count DESC, updated_at DESC
+	LIMIT 1
+	`
+	prefix := questStageRecordIDPrefix(questID)
+	row := s.db.QueryRowContext(ctx, query, TypeQuest, prefix, prefix)
+	var r SummaryRecord
+	err := row.Scan(&r.ID, &r.RecordID, &r.SummaryType, &r.CacheKey, &r.InputHash, &r.SummaryText, &r.InputLineCount, &r.CreatedAt, &r.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query latest quest stage summary quest_id=%s: %w", questID, err)
+	}
+	return &r, nil
+}
+
 func (s *summaryStore) Upsert(ctx context.Context, record SummaryRecord) error {
 	query := `
 	INSERT INTO summaries (record_id, summary_type, cache_key, input_hash, summary_text, input_line_count, updated_at)
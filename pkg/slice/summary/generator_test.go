@@ -113,6 +113,43 @@ func TestSummaryGenerator_Slice(t *testing.T) {
 		assert.Equal(t, "A friendly greeting.", result.SummaryText)
 	})
 
+	t.Run("GetSummary - Quest ID resolves to the latest stage", func(t *testing.T) {
+		input := SummaryInput{
+			QuestItems: []QuestItem{
+				{
+					QuestID: "QST_001",
+					StageTexts: []QuestStage{
+						{Index: 10, Text: "Stage 1 content"},
+						{Index: 20, Text: "Stage 2 content"},
+					},
+				},
+			},
+		}
+		jobs, err := gen.PreparePrompts(ctx, input)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		responses := []llmio.Response{
+			{Success: true, Content: "Stage 10 summary.", Metadata: jobs[0].Metadata},
+			{Success: true, Content: "Stage 20 summary.", Metadata: jobs[1].Metadata},
+		}
+		require.NoError(t, gen.SaveResults(ctx, responses))
+
+		latest, err := gen.GetSummary(ctx, "QST_001", TypeQuest)
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, QuestStageRecordID("QST_001", 20), latest.RecordID)
+		assert.Equal(t, "Stage 20 summary.", latest.SummaryText)
+
+		stage, err := gen.GetSummary(ctx, QuestStageRecordID("QST_001", 10), TypeQuest)
+		require.NoError(t, err)
+		require.NotNil(t, stage)
+		assert.Equal(t, "Stage 10 summary.", stage.SummaryText)
+
+		missing, err := gen.GetSummary(ctx, "QST_UNKNOWN", TypeQuest)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
 	t.Run("Skip empty Dialogue lines", func(t *testing.T) {
 		input := SummaryInput{
 			DialogueItems: []DialogueItem{
@@ -56,6 +56,13 @@ type PreviewTranslation struct {
 	TranslationState string
 }
 
+// TranslatedEntry pairs one terminology target row with its persisted translation.
+type TranslatedEntry struct {
+	TerminologyEntry
+	TranslatedText   string
+	TranslationState string
+}
+
 // PhaseSummary reports the persisted terminology phase state.
 type PhaseSummary struct {
 	TaskID          string `json:"task_id"`
@@ -77,6 +84,10 @@ type Terminology interface {
 	// PreparePrompts (Phase 1) generates LLM requests from task-scoped artifact input.
 	PreparePrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error)
 
+	// BuildPrompts builds the PreparePrompts requests for dry-run estimation without persisting anything
+	// or calling the embedding provider; reference terms come from lexical search only.
+	BuildPrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error)
+
 	// SaveResults (Phase 2) persists LLM responses for one task.
 	SaveResults(ctx context.Context, taskID string, responses []llmio.Response) error
 
@@ -89,6 +100,9 @@ type Terminology interface {
 	// ListTargets returns normalized preview targets shared by preview/execute.
 	ListTargets(ctx context.Context, taskID string) ([]TerminologyEntry, error)
 
+	// ListTranslations returns every target artifact row with its persisted translation.
+	ListTranslations(ctx context.Context, taskID string) ([]TranslatedEntry, error)
+
 	// UpdatePhaseSummary persists workflow-owned phase snapshot updates.
 	UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error
 }
@@ -107,6 +121,22 @@ type TermDictionarySearcher interface {
 	SearchKeywords(ctx context.Context, keywords []string) ([]ReferenceTerm, error)
 	SearchNPCPartial(ctx context.Context, keywords []string, consumedKeywords []string, isNPC bool) ([]ReferenceTerm, error)
 	SearchBatch(ctx context.Context, texts []string) (map[string][]ReferenceTerm, error)
+	SearchSemantic(ctx context.Context, text string, limit int) ([]ReferenceTerm, error)
+}
+
+// TextEmbedder turns text into embedding vectors for semantic dictionary lookup.
+// EmbeddingModel names the vector space so vectors from different models are never compared.
+// EmbedBatch returns one vector per input, in input order, from a single provider request.
+type TextEmbedder interface {
+	EmbeddingModel() string
+	Embed(ctx context.Context, text string) ([]float32, error)
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbeddingIndexer fills in missing dictionary vectors. It runs outside terminology
+// phases (after dictionary changes) so a translation run never waits on indexing.
+type EmbeddingIndexer interface {
+	IndexEmbeddings(ctx context.Context) (int, error)
 }
 
 // ModTermStore manages all operations on the Mod term SQLite database,
@@ -119,6 +149,7 @@ type ModTermStore interface {
 	UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error
 	GetPhaseSummary(ctx context.Context, taskID string) (PhaseSummary, error)
 	GetPreviewTranslations(ctx context.Context, entries []TerminologyEntry) (map[string]PreviewTranslation, error)
+	GetEntryTranslations(ctx context.Context, entries []TerminologyEntry) ([]PreviewTranslation, error)
 }
 
 // ProgressNotifier reports translation progress to the Process Manager.
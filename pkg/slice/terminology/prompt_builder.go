@@ -71,10 +71,10 @@ None
 Requirements:
 1. Translate the text idiomatically for Skyrim (e.g. Katakana for names, appropriate Kanji for titles).
 2. Be consistent with the Reference Terms provided.
-3. You MUST output the final translation in the following exact format and nothing else:
-TL: |translated_text|
+3. You MUST output a single JSON object with the final translation and nothing else:
+{"translation": "translated_text"}
 
 Example:
 If translating "Iron Sword", you should output:
-TL: |鉄の剣|
+{"translation": "鉄の剣"}
 `
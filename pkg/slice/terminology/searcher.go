@@ -12,11 +12,21 @@ import (
 
 // SQLiteTermDictionarySearcher implements TermDictionarySearcher via dictionary artifact repository.
 type SQLiteTermDictionarySearcher struct {
-	repo    dictionaryartifact.Repository
-	logger  *slog.Logger
-	stemmer KeywordStemmer
+	repo     dictionaryartifact.Repository
+	logger   *slog.Logger
+	stemmer  KeywordStemmer
+	embedder TextEmbedder
 }
 
+const (
+	// embeddingIndexBatchSize bounds how many entries are embedded per repository round trip.
+	embeddingIndexBatchSize = 64
+	// semanticMinScore is the cosine similarity below which neighbours are not worth showing to the LLM.
+	semanticMinScore = 0.8
+	// semanticReferenceLimit caps how many paraphrase matches are added per term prompt.
+	semanticReferenceLimit = 5
+)
+
 // NewSQLiteTermDictionarySearcher creates a new SQLiteTermDictionarySearcher.
 func NewSQLiteTermDictionarySearcher(repo dictionaryartifact.Repository, logger *slog.Logger, stemmer KeywordStemmer) *SQLiteTermDictionarySearcher {
 	return &SQLiteTermDictionarySearcher{
@@ -26,6 +36,11 @@ func NewSQLiteTermDictionarySearcher(repo dictionaryartifact.Repository, logger
 	}
 }
 
+// SetEmbedder enables semantic search; without an embedder (or with an empty model) SearchSemantic returns no terms.
+func (s *SQLiteTermDictionarySearcher) SetEmbedder(embedder TextEmbedder) {
+	s.embedder = embedder
+}
+
 // SearchExact searches for exact matches in the dictionary.
 func (s *SQLiteTermDictionarySearcher) SearchExact(ctx context.Context, text string) ([]ReferenceTerm, error) {
 	defer telemetry2.StartSpan(ctx, telemetry2.ActionDBQuery)()
@@ -156,6 +171,77 @@ func (s *SQLiteTermDictionarySearcher) SearchBatch(ctx context.Context, texts []
 	return resultMap, nil
 }
 
+// SearchSemantic returns dictionary terms whose source text is close to text in embedding space,
+// so paraphrases like "longhouse of the Jarl" still surface "Jarl's longhouse".
+func (s *SQLiteTermDictionarySearcher) SearchSemantic(ctx context.Context, text string, limit int) ([]ReferenceTerm, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionDBQuery)()
+	if s.embedder == nil || strings.TrimSpace(text) == "" || limit <= 0 {
+		return nil, nil
+	}
+	model := s.embedder.EmbeddingModel()
+	if model == "" {
+		return nil, nil
+	}
+	vector, err := s.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("embed semantic query model=%s: %w", model, err)
+	}
+	entries, err := s.repo.SearchByEmbedding(ctx, model, vector, limit, semanticMinScore)
+	if err != nil {
+		return nil, fmt.Errorf("semantic search model=%s: %w", model, err)
+	}
+	terms := make([]ReferenceTerm, 0, len(entries))
+	for _, entry := range entries {
+		terms = append(terms, ReferenceTerm{Source: entry.SourceText, Translation: entry.DestText})
+	}
+	s.logger.DebugContext(ctx, "semantic search completed", slog.Int("match_count", len(terms)))
+	return dedupeReferenceTerms(terms), nil
+}
+
+// IndexEmbeddings embeds every dictionary entry that has no vector for the current model yet.
+func (s *SQLiteTermDictionarySearcher) IndexEmbeddings(ctx context.Context) (int, error) {
+	if s.embedder == nil {
+		return 0, nil
+	}
+	model := s.embedder.EmbeddingModel()
+	if model == "" {
+		return 0, nil
+	}
+	indexed := 0
+	for {
+		entries, err := s.repo.ListEntriesWithoutEmbedding(ctx, model, embeddingIndexBatchSize)
+		if err != nil {
+			return indexed, fmt.Errorf("list entries without embedding model=%s: %w", model, err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		texts := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			texts = append(texts, entry.SourceText)
+		}
+		vectors, err := s.embedder.EmbedBatch(ctx, texts)
+		if err != nil {
+			return indexed, fmt.Errorf("embed dictionary entries first_id=%d count=%d: %w", entries[0].ID, len(entries), err)
+		}
+		if len(vectors) != len(entries) {
+			return indexed, fmt.Errorf("embed dictionary entries first_id=%d: got %d vectors for %d entries", entries[0].ID, len(vectors), len(entries))
+		}
+		embeddings := make([]dictionaryartifact.EntryEmbedding, 0, len(entries))
+		for i, entry := range entries {
+			embeddings = append(embeddings, dictionaryartifact.EntryEmbedding{EntryID: entry.ID, Model: model, Vector: vectors[i]})
+		}
+		if err := s.repo.SaveEmbeddings(ctx, embeddings); err != nil {
+			return indexed, fmt.Errorf("save dictionary embeddings model=%s: %w", model, err)
+		}
+		indexed += len(embeddings)
+	}
+	if indexed > 0 {
+		s.logger.InfoContext(ctx, "indexed dictionary embeddings", slog.String("model", model), slog.Int("count", indexed))
+	}
+	return indexed, nil
+}
+
 // Close closes the dictionary database connection.
 func (s *SQLiteTermDictionarySearcher) Close() error {
 	// No-op: lifecycle is owned by artifact repository provider.
@@ -109,6 +109,73 @@ func (s *SQLiteModTermStore) GetTerm(ctx context.Context, originalEN string) (st
 	return "", nil
 }
 
+// SearchTermsInText returns the translated terms found in text, longest non-overlapping matches first.
+// Terms of every source-file table are candidates so that main translation reuses the terminology phase.
+func (s *SQLiteModTermStore) SearchTermsInText(ctx context.Context, text string) ([]ReferenceTerm, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tableNames, err := s.modTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lowerText := strings.ToLower(text)
+	candidates := make([]ReferenceTerm, 0)
+	for _, tableName := range tableNames {
+		//nolint:gosec // tableName is restricted by validateModTableName and generated by modTableName.
+		query := fmt.Sprintf(`
+			SELECT original_en, translated_ja
+			FROM %s
+			WHERE status <> 'error' AND TRIM(translated_ja) <> '' AND instr(?, lower(original_en)) > 0
+		`, tableName)
+		if err := s.scanReferenceTerms(ctx, query, lowerText, &candidates); err != nil {
+			return nil, fmt.Errorf("query terminology terms in text table=%s: %w", tableName, err)
+		}
+	}
+	return NewGreedyLongestMatcher().Match(text, candidates), nil
+}
+
+func (s *SQLiteModTermStore) scanReferenceTerms(ctx context.Context, query string, arg string, out *[]ReferenceTerm) error {
+	rows, err := s.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var term ReferenceTerm
+		if err := rows.Scan(&term.Source, &term.Translation); err != nil {
+			return err
+		}
+		*out = append(*out, term)
+	}
+	return rows.Err()
+}
+
+// modTableNames lists the validated source-file terminology tables.
+func (s *SQLiteModTermStore) modTableNames(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type='table' AND name LIKE 'mod_terms_%' ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list terminology tables: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan terminology table name: %w", err)
+		}
+		if err := validateModTableName(name); err != nil {
+			return nil, fmt.Errorf("validate terminology table name table=%s: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate terminology tables: %w", err)
+	}
+	return names, nil
+}
+
 // Clear removes all source-file terminology tables and summary rows.
 func (s *SQLiteModTermStore) Clear(ctx context.Context) error {
 	rows, err := s.db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type='table' AND name LIKE 'mod_terms_%' ORDER BY name`)
@@ -201,66 +268,71 @@ func (s *SQLiteModTermStore) GetPhaseSummary(ctx context.Context, taskID string)
 func (s *SQLiteModTermStore) GetPreviewTranslations(ctx context.Context, entries []TerminologyEntry) (map[string]PreviewTranslation, error) {
 	translations := make(map[string]PreviewTranslation, len(entries))
 	for _, entry := range entries {
-		translations[entry.ID] = PreviewTranslation{
-			RowID:            entry.ID,
-			TranslationState: "missing",
-		}
-
-		tableName := modTableName(entry.SourceFile)
-		if err := validateModTableName(tableName); err != nil {
-			return nil, fmt.Errorf("validate preview translation table table=%s: %w", tableName, err)
+		translation, err := s.lookupEntryTranslation(ctx, entry)
+		if err != nil {
+			return nil, err
 		}
-		//nolint:gosec // tableName is restricted by validateModTableName and generated by modTableName.
-		query := fmt.Sprintf("SELECT translated_ja, status FROM %s WHERE original_en = ? AND record_type = ? LIMIT 1", tableName)
+		translations[entry.ID] = translation
+	}
+	return translations, nil
+}
 
-		var translatedText string
-		var status string
-		err := s.db.QueryRowContext(ctx, query, entry.SourceText, entry.RecordType).Scan(&translatedText, &status)
-		if err == sql.ErrNoRows {
-			translatedText, translationState, fallbackErr := s.lookupPreviewTranslationFallback(ctx, entry)
-			if fallbackErr != nil {
-				return nil, fallbackErr
-			}
-			if translationState == "missing" {
-				continue
-			}
-			translations[entry.ID] = PreviewTranslation{
-				RowID:            entry.ID,
-				TranslatedText:   translatedText,
-				TranslationState: translationState,
-			}
-			continue
-		}
+// GetEntryTranslations returns one translation per entry in input order.
+func (s *SQLiteModTermStore) GetEntryTranslations(ctx context.Context, entries []TerminologyEntry) ([]PreviewTranslation, error) {
+	translations := make([]PreviewTranslation, 0, len(entries))
+	for _, entry := range entries {
+		translation, err := s.lookupEntryTranslation(ctx, entry)
 		if err != nil {
-			if strings.Contains(err.Error(), "no such table") {
-				translatedText, translationState, fallbackErr := s.lookupPreviewTranslationFallback(ctx, entry)
-				if fallbackErr != nil {
-					return nil, fallbackErr
-				}
-				if translationState == "missing" {
-					continue
-				}
-				translations[entry.ID] = PreviewTranslation{
-					RowID:            entry.ID,
-					TranslatedText:   translatedText,
-					TranslationState: translationState,
-				}
-				continue
-			}
-			return nil, fmt.Errorf("query preview translation row_id=%s: %w", entry.ID, err)
+			return nil, err
 		}
+		translations = append(translations, translation)
+	}
+	return translations, nil
+}
+
+func (s *SQLiteModTermStore) lookupEntryTranslation(ctx context.Context, entry TerminologyEntry) (PreviewTranslation, error) {
+	missing := PreviewTranslation{
+		RowID:            entry.ID,
+		TranslationState: "missing",
+	}
+
+	tableName := modTableName(entry.SourceFile)
+	if err := validateModTableName(tableName); err != nil {
+		return PreviewTranslation{}, fmt.Errorf("validate preview translation table table=%s: %w", tableName, err)
+	}
+	//nolint:gosec // tableName is restricted by validateModTableName and generated by modTableName.
+	query := fmt.Sprintf("SELECT translated_ja, status FROM %s WHERE original_en = ? AND record_type = ? LIMIT 1", tableName)
 
-		translationState := "missing"
-		if strings.TrimSpace(translatedText) != "" && status != "error" {
-			translationState = "translated"
+	var translatedText string
+	var status string
+	err := s.db.QueryRowContext(ctx, query, entry.SourceText, entry.RecordType).Scan(&translatedText, &status)
+	if err == sql.ErrNoRows || (err != nil && strings.Contains(err.Error(), "no such table")) {
+		translatedText, translationState, fallbackErr := s.lookupPreviewTranslationFallback(ctx, entry)
+		if fallbackErr != nil {
+			return PreviewTranslation{}, fallbackErr
 		}
-		translations[entry.ID] = PreviewTranslation{
+		if translationState == "missing" {
+			return missing, nil
+		}
+		return PreviewTranslation{
 			RowID:            entry.ID,
 			TranslatedText:   translatedText,
 			TranslationState: translationState,
-		}
+		}, nil
 	}
-	return translations, nil
+	if err != nil {
+		return PreviewTranslation{}, fmt.Errorf("query preview translation row_id=%s: %w", entry.ID, err)
+	}
+
+	translationState := "missing"
+	if strings.TrimSpace(translatedText) != "" && status != "error" {
+		translationState = "translated"
+	}
+	return PreviewTranslation{
+		RowID:            entry.ID,
+		TranslatedText:   translatedText,
+		TranslationState: translationState,
+	}, nil
 }
 
 func (s *SQLiteModTermStore) lookupPreviewTranslationFallback(ctx context.Context, entry TerminologyEntry) (string, string, error) {
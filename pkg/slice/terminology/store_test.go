@@ -69,3 +69,42 @@ func TestGetPreviewTranslations_FallbackWithSingleConnection(t *testing.T) {
 		t.Fatalf("unexpected translated text: got=%q want=%q", got.TranslatedText, "こんにちは")
 	}
 }
+
+func TestSearchTermsInText_ReturnsTranslatedTermsContainedInText(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite", "file:terminology_search_test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open sqlite db: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	store := NewSQLiteModTermStore(db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	tableName := modTableName("skyrim.json")
+	if err := store.ensureModTable(ctx, tableName); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if err := store.upsertTerms(ctx, tableName, []TermTranslationResult{
+		{SourceText: "Whiterun", RecordType: "CELL:FULL", TranslatedText: "ホワイトラン", Status: "success", SourceFile: "skyrim.json"},
+		{SourceText: "Dragonsreach", RecordType: "CELL:FULL", TranslatedText: "ドラゴンズリーチ", Status: "success", SourceFile: "skyrim.json"},
+		{SourceText: "Jarl", RecordType: "NPC_:FULL", TranslatedText: "", Status: "error", SourceFile: "skyrim.json"},
+		{SourceText: "Run", RecordType: "MISC:FULL", TranslatedText: "走る", Status: "success", SourceFile: "skyrim.json"},
+	}); err != nil {
+		t.Fatalf("failed to seed terms: %v", err)
+	}
+
+	terms, err := store.SearchTermsInText(ctx, "Go to whiterun and speak to the Jarl.")
+	if err != nil {
+		t.Fatalf("SearchTermsInText returned error: %v", err)
+	}
+	if len(terms) != 1 {
+		t.Fatalf("unexpected terms: %+v", terms)
+	}
+	if terms[0].Source != "Whiterun" || terms[0].Translation != "ホワイトラン" {
+		t.Fatalf("unexpected term: %+v", terms[0])
+	}
+}
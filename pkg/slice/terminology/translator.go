@@ -2,6 +2,7 @@ package terminology
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -48,32 +49,48 @@ func (t *TermTranslatorImpl) ID() string {
 
 // PreparePrompts implementation for terminology phase.
 func (t *TermTranslatorImpl) PreparePrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error) {
-	requests, summary, err := t.preparePrompts(ctx, taskID, options)
+	requests, cachedResults, summary, err := t.preparePrompts(ctx, taskID, options, false)
 	if err != nil {
 		return nil, fmt.Errorf("prepare terminology prompts task_id=%s: %w", taskID, err)
 	}
+	if len(cachedResults) > 0 {
+		if err := t.store.SaveTerms(ctx, cachedResults); err != nil {
+			return nil, fmt.Errorf("save cached exact matches task_id=%s: %w", taskID, err)
+		}
+	}
 	if err := t.store.UpdatePhaseSummary(ctx, summary); err != nil {
 		return nil, fmt.Errorf("persist terminology phase running summary task_id=%s: %w", taskID, err)
 	}
 	return requests, nil
 }
 
-// preparePrompts builds LLM requests (Phase 1).
-func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, PhaseSummary, error) {
+// BuildPrompts builds the same requests as PreparePrompts without persisting cached matches or the summary.
+// It is a dry run: reference terms come from lexical search only, so no embedding calls are made.
+func (t *TermTranslatorImpl) BuildPrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error) {
+	requests, _, _, err := t.preparePrompts(ctx, taskID, options, true)
+	if err != nil {
+		return nil, fmt.Errorf("build terminology prompts task_id=%s: %w", taskID, err)
+	}
+	return requests, nil
+}
+
+// preparePrompts builds LLM requests (Phase 1) and the exact-match results that need no LLM call.
+// dryRun skips semantic reference search, which would call the embedding provider.
+func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string, options PhaseOptions, dryRun bool) ([]llmio.Request, []TermTranslationResult, PhaseSummary, error) {
 	t.logger.InfoContext(ctx, "ENTER TermTranslatorImpl.PreparePrompts")
 	defer t.logger.InfoContext(ctx, "EXIT TermTranslatorImpl.PreparePrompts")
 
 	artifactInput, err := t.inputRepo.LoadTerminologyInput(ctx, taskID)
 	if err != nil {
-		return nil, PhaseSummary{}, fmt.Errorf("load terminology artifact input task_id=%s: %w", taskID, err)
+		return nil, nil, PhaseSummary{}, fmt.Errorf("load terminology artifact input task_id=%s: %w", taskID, err)
 	}
 	data := toTerminologyInput(artifactInput)
 	requests, err := t.builder.BuildRequests(ctx, data)
 	if err != nil {
-		return nil, PhaseSummary{}, fmt.Errorf("failed to build requests: %w", err)
+		return nil, nil, PhaseSummary{}, fmt.Errorf("failed to build requests: %w", err)
 	}
 	if len(requests) == 0 {
-		return nil, PhaseSummary{
+		return nil, nil, PhaseSummary{
 			TaskID:       taskID,
 			Status:       "pending",
 			ProgressMode: "hidden",
@@ -87,7 +104,7 @@ func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string,
 	for _, req := range requests {
 		exactRefs, err := t.searcher.SearchExact(ctx, req.SourceText)
 		if err != nil {
-			return nil, PhaseSummary{}, fmt.Errorf("search exact references source=%q: %w", req.SourceText, err)
+			return nil, nil, PhaseSummary{}, fmt.Errorf("search exact references source=%q: %w", req.SourceText, err)
 		}
 		if len(exactRefs) > 0 {
 			cachedResult := TermTranslationResult{
@@ -108,20 +125,20 @@ func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string,
 		workingReq := req
 		replacedSourceText, consumedKeywords, err := t.buildReplacedSourceText(ctx, req.SourceText)
 		if err != nil {
-			return nil, PhaseSummary{}, fmt.Errorf("build replaced source text source=%q: %w", req.SourceText, err)
+			return nil, nil, PhaseSummary{}, fmt.Errorf("build replaced source text source=%q: %w", req.SourceText, err)
 		}
 		if strings.TrimSpace(replacedSourceText) == "" {
 			replacedSourceText = req.SourceText
 		}
 		workingReq.ReplacedSourceText = replacedSourceText
-		workingReq.ReferenceTerms = t.fetchReferenceTerms(ctx, req, replacedSourceText, consumedKeywords)
+		workingReq.ReferenceTerms = t.fetchReferenceTerms(ctx, req, replacedSourceText, consumedKeywords, dryRun)
 
 		prompt, err := t.buildPrompt(ctx, workingReq, options)
 		if err != nil {
-			return nil, PhaseSummary{}, fmt.Errorf("failed to build prompt for %s: %w", req.SourceText, err)
+			return nil, nil, PhaseSummary{}, fmt.Errorf("failed to build prompt for %s: %w", req.SourceText, err)
 		}
 
-		llmRequests = append(llmRequests, llmio.Request{
+		llmRequest := llmio.Request{
 			SystemPrompt: prompt,
 			UserPrompt:   userPromptOrDefault(options),
 			Temperature:  options.Request.Temperature,
@@ -136,13 +153,14 @@ func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string,
 				"source_file":          req.SourceFile,
 				"short_name":           req.ShortName,
 			},
-		})
-	}
-
-	if len(cachedResults) > 0 {
-		if err := t.store.SaveTerms(ctx, cachedResults); err != nil {
-			return nil, PhaseSummary{}, fmt.Errorf("save cached exact matches task_id=%s: %w", taskID, err)
 		}
+		// A saved custom prompt that still asks for the legacy TL format is sent without a schema,
+		// otherwise the gateway would reject (or repair away) the format the prompt asks for.
+		if !usesLegacyTLFormat(options.Prompt.SystemPrompt) {
+			llmRequest.ResponseSchema = translationResponseSchema()
+			llmRequest.Metadata["structured_output_schema_version"] = translationResponseSchemaVersion
+		}
+		llmRequests = append(llmRequests, llmRequest)
 	}
 
 	status := "running"
@@ -163,7 +181,7 @@ func (t *TermTranslatorImpl) preparePrompts(ctx context.Context, taskID string,
 	if status == "running" {
 		summary.ProgressMessage = buildProgressMessageWithRemaining(cachedGroupCount, targetCount)
 	}
-	return llmRequests, summary, nil
+	return llmRequests, cachedResults, summary, nil
 }
 
 // SaveResults implementation for terminology phase.
@@ -213,11 +231,12 @@ func (t *TermTranslatorImpl) SaveResults(ctx context.Context, taskID string, res
 			continue
 		}
 
-		translatedText := t.extractTranslationFromLLMResponse(res.Content)
-		if translatedText == res.Content && !strings.Contains(res.Content, "TL: |") {
+		translatedText, err := parseTranslationResponse(res.Content)
+		if err != nil {
 			t.logger.WarnContext(ctx, "LLM response missing expected format",
 				"index", i,
-				"term", sourceText)
+				"term", sourceText,
+				"error", err.Error())
 			failedCount++
 			continue
 		}
@@ -324,6 +343,58 @@ func (t *TermTranslatorImpl) ListTargets(ctx context.Context, taskID string) ([]
 	return targets, nil
 }
 
+// ListTranslations returns every target artifact row with its persisted translation.
+func (t *TermTranslatorImpl) ListTranslations(ctx context.Context, taskID string) ([]TranslatedEntry, error) {
+	artifactInput, err := t.inputRepo.LoadTerminologyInput(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load terminology artifact input task_id=%s: %w", taskID, err)
+	}
+	data := toTerminologyInput(artifactInput)
+
+	// Group rows the same way the request builder does so every row sharing a
+	// translated request (duplicates, NPC FULL/SHRT pairs) is reported individually.
+	grouped := make(map[string][]TerminologyEntry)
+	orderedKeys := make([]string, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		key := requestGroupKey(entry)
+		if _, exists := grouped[key]; !exists {
+			orderedKeys = append(orderedKeys, key)
+		}
+		grouped[key] = append(grouped[key], entry)
+	}
+
+	targets := make([]TerminologyEntry, 0, len(data.Entries))
+	for _, key := range orderedKeys {
+		group := grouped[key]
+		requests, err := t.builder.BuildRequests(ctx, TerminologyInput{TaskID: data.TaskID, Entries: group})
+		if err != nil {
+			return nil, fmt.Errorf("build terminology targets task_id=%s: %w", taskID, err)
+		}
+		if len(requests) == 0 {
+			continue
+		}
+		for _, entry := range group {
+			if requests[0].RecordType == "NPC_" || entry.RecordType == requests[0].RecordType {
+				targets = append(targets, entry)
+			}
+		}
+	}
+
+	translations, err := t.store.GetEntryTranslations(ctx, targets)
+	if err != nil {
+		return nil, fmt.Errorf("get terminology translations task_id=%s: %w", taskID, err)
+	}
+	results := make([]TranslatedEntry, 0, len(targets))
+	for i, entry := range targets {
+		results = append(results, TranslatedEntry{
+			TerminologyEntry: entry,
+			TranslatedText:   translations[i].TranslatedText,
+			TranslationState: translations[i].TranslationState,
+		})
+	}
+	return results, nil
+}
+
 // UpdatePhaseSummary persists a workflow-owned phase snapshot.
 func (t *TermTranslatorImpl) UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error {
 	if err := t.store.UpdatePhaseSummary(ctx, summary); err != nil {
@@ -378,12 +449,13 @@ func (t *TermTranslatorImpl) LegacySaveResults(ctx context.Context, data Termino
 			continue
 		}
 
-		translatedText := t.extractTranslationFromLLMResponse(res.Content)
-		if translatedText == res.Content && !strings.Contains(res.Content, "TL: |") {
+		translatedText, err := parseTranslationResponse(res.Content)
+		if err != nil {
 			t.logger.WarnContext(ctx, "LLM response missing expected format",
 				"index", i,
-				"term", req.SourceText)
-			// According to scenario: "TL: |...| 形式が含まれていない ... 安全にスキップ"
+				"term", req.SourceText,
+				"error", err.Error())
+			// According to scenario: 形式不正の応答は安全にスキップする
 			continue
 		}
 
@@ -405,7 +477,8 @@ func (t *TermTranslatorImpl) LegacySaveResults(ctx context.Context, data Termino
 }
 
 // fetchReferenceTerms retrieves context reference terms based on the record type.
-func (t *TermTranslatorImpl) fetchReferenceTerms(ctx context.Context, req TermTranslationRequest, replacedSourceText string, consumedKeywords []string) []ReferenceTerm {
+// lexicalOnly leaves out semantic search so the lookup has no provider calls.
+func (t *TermTranslatorImpl) fetchReferenceTerms(ctx context.Context, req TermTranslationRequest, replacedSourceText string, consumedKeywords []string, lexicalOnly bool) []ReferenceTerm {
 	keywords := extractKeywords(replacedSourceText)
 	contextRefs := make([]ReferenceTerm, 0)
 
@@ -419,6 +492,15 @@ func (t *TermTranslatorImpl) fetchReferenceTerms(ctx context.Context, req TermTr
 			contextRefs = append(contextRefs, npcRefs...)
 		}
 	}
+	if lexicalOnly {
+		return dedupeReferenceTerms(contextRefs)
+	}
+	semanticRefs, err := t.searcher.SearchSemantic(ctx, req.SourceText, semanticReferenceLimit)
+	if err != nil {
+		t.logger.WarnContext(ctx, "semantic reference search failed", slog.String("source_text", req.SourceText), slog.String("error", err.Error()))
+	} else {
+		contextRefs = append(contextRefs, semanticRefs...)
+	}
 	return dedupeReferenceTerms(contextRefs)
 }
 
@@ -459,19 +541,60 @@ func (t *TermTranslatorImpl) expandNPCResult(res TermTranslationResult, req Term
 	return []TermTranslationResult{fullRes, shortRes}
 }
 
-// extractTranslationFromLLMResponse extracts the translation text from "TL: |...|" format.
-func (t *TermTranslatorImpl) extractTranslationFromLLMResponse(content string) string {
+const translationResponseSchemaVersion = "terminology.v1"
+
+// translationResponseSchema is the JSON schema the gateway validates (and asks the model to repair) term translations against.
+func translationResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"translation"},
+		"properties": map[string]interface{}{
+			"translation": map[string]interface{}{"type": "string", "minLength": 1},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// parseTranslationResponse reads the translation from a {"translation": "..."} response.
+// Responses that are not JSON fall back to the legacy "TL: |...|" format, which old queued jobs and
+// custom prompts saved before the structured output change still produce.
+func parseTranslationResponse(content string) (string, error) {
+	var payload struct {
+		Translation string `json:"translation"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &payload); err != nil {
+		if translated, ok := extractLegacyTranslation(content); ok {
+			return translated, nil
+		}
+		return "", fmt.Errorf("decode translation response: %w", err)
+	}
+	translated := strings.TrimSpace(payload.Translation)
+	if translated == "" {
+		return "", fmt.Errorf("translation response has empty translation")
+	}
+	return translated, nil
+}
+
+// extractLegacyTranslation extracts the translation text from the legacy "TL: |...|" format.
+// Content without the TL marker is not a translation and reports false.
+func extractLegacyTranslation(content string) (string, bool) {
 	content = strings.TrimSpace(content)
 	startIdx := strings.Index(content, "TL: |")
-	if startIdx != -1 {
-		startIdx += 5 // length of "TL: |"
-		endIdx := strings.Index(content[startIdx:], "|")
-		if endIdx != -1 {
-			return strings.TrimSpace(content[startIdx : startIdx+endIdx])
-		}
-		return strings.TrimSpace(content[startIdx:])
+	if startIdx == -1 {
+		return "", false
 	}
-	return content
+	startIdx += 5 // length of "TL: |"
+	translated := content[startIdx:]
+	if endIdx := strings.Index(translated, "|"); endIdx != -1 {
+		translated = translated[:endIdx]
+	}
+	translated = strings.TrimSpace(translated)
+	return translated, translated != ""
+}
+
+// usesLegacyTLFormat reports whether a system prompt template asks for the legacy "TL: |...|" response format.
+func usesLegacyTLFormat(systemPrompt string) bool {
+	return strings.Contains(systemPrompt, "TL:")
 }
 
 func userPromptOrDefault(options PhaseOptions) string {
@@ -69,6 +69,7 @@ func TestTermTranslatorSlice(t *testing.T) {
 		expectedTerms map[string]string
 		expectedReqs  int
 		expectedTotal int
+		expectedRows  int
 	}{
 		{
 			name: "allowed recs and npc pair are translated",
@@ -120,10 +121,11 @@ func TestTermTranslatorSlice(t *testing.T) {
 				},
 			},
 			mockLLMOutput: []string{
-				"TL: |鋼鉄の鎧|",
+				`{"translation": "鋼鉄の鎧"}`,
 			},
 			expectedReqs:  1,
 			expectedTotal: 3,
+			expectedRows:  4,
 			expectedTerms: map[string]string{
 				"Iron Sword":           "鉄の剣",
 				"Steel Armor":          "鋼鉄の鎧",
@@ -163,15 +165,41 @@ func TestTermTranslatorSlice(t *testing.T) {
 				},
 			},
 			mockLLMOutput: []string{
-				"TL: |鋼鉄の鎧|",
+				`{"translation": "鋼鉄の鎧"}`,
 			},
 			expectedReqs:  1,
 			expectedTotal: 2,
+			expectedRows:  3,
 			expectedTerms: map[string]string{
 				"Iron Sword":  "鉄の剣",
 				"Steel Armor": "鋼鉄の鎧",
 			},
 		},
+		{
+			name: "legacy TL response from an old queued job is still saved",
+			input: TerminologyInput{
+				TaskID: "task-3",
+				Entries: []TerminologyEntry{
+					{
+						ID:         "401",
+						EditorID:   "EditorE",
+						RecordType: "ARMO:FULL",
+						SourceText: "Steel Armor",
+						SourceFile: "mod_legacy.json",
+						Variant:    "single",
+					},
+				},
+			},
+			mockLLMOutput: []string{
+				"TL: |鋼鉄の鎧|",
+			},
+			expectedReqs:  1,
+			expectedTotal: 1,
+			expectedRows:  1,
+			expectedTerms: map[string]string{
+				"Steel Armor": "鋼鉄の鎧",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -259,6 +287,23 @@ func TestTermTranslatorSlice(t *testing.T) {
 					t.Fatalf("unexpected missing translation state for row_id=%s: got=%q want=%q", entry.ID, preview.TranslationState, "missing")
 				}
 			}
+
+			translatedRows, err := translator.ListTranslations(ctx, tc.input.TaskID)
+			if err != nil {
+				t.Fatalf("ListTranslations failed: %v", err)
+			}
+			if len(translatedRows) != tc.expectedRows {
+				t.Fatalf("unexpected translated row count: got=%d want=%d", len(translatedRows), tc.expectedRows)
+			}
+			for _, row := range translatedRows {
+				expectedJA, exists := tc.expectedTerms[row.SourceText]
+				if !exists {
+					t.Fatalf("unexpected non-target row listed: %+v", row)
+				}
+				if row.TranslationState != "translated" || row.TranslatedText != expectedJA {
+					t.Fatalf("unexpected listed translation for row_id=%s: %+v", row.ID, row)
+				}
+			}
 		})
 	}
 }
@@ -303,6 +348,9 @@ func TestTermTranslator_PreparePrompts_AppliesPartialReplacement(t *testing.T) {
 	if len(requests) != 1 {
 		t.Fatalf("unexpected request count: got=%d want=%d", len(requests), 1)
 	}
+	if len(requests[0].ResponseSchema) == 0 {
+		t.Fatalf("terminology request must carry a response schema")
+	}
 	sourceText, _ := requests[0].Metadata["source_text"].(string)
 	replacedText, _ := requests[0].Metadata["replaced_source_text"].(string)
 	if sourceText != "Skeever Den" {
@@ -316,6 +364,132 @@ func TestTermTranslator_PreparePrompts_AppliesPartialReplacement(t *testing.T) {
 	}
 }
 
+func TestTermTranslator_PreparePrompts_LegacyTLPromptIsSentWithoutSchema(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dictDB, modDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	input := TerminologyInput{
+		TaskID: "task-legacy-prompt",
+		Entries: []TerminologyEntry{
+			{ID: "501", EditorID: "EditorF", RecordType: "ARMO:FULL", SourceText: "Steel Armor", SourceFile: "mod_legacy.json", Variant: "single"},
+		},
+	}
+	builder := NewTermRequestBuilder(&TermRecordConfig{TargetRecordTypes: append([]string(nil), foundation.DictionaryImportRECTypes...)})
+	searcher := NewSQLiteTermDictionarySearcher(dictionaryartifact.NewRepository(dictDB), logger, NewSnowballStemmer("english"))
+	promptBuilder, err := NewTermPromptBuilder("")
+	if err != nil {
+		t.Fatalf("failed to create prompt builder: %v", err)
+	}
+	translator := NewTermTranslator(&fakeTranslationInputRepository{input: input}, builder, searcher, NewSQLiteModTermStore(modDB, logger), promptBuilder, logger)
+
+	requests, err := translator.PreparePrompts(ctx, input.TaskID, PhaseOptions{
+		Prompt: PromptConfig{SystemPrompt: "Translate {{.SourceText}}. Output exactly: TL: |translated_text|"},
+	})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("unexpected request count: got=%d want=%d", len(requests), 1)
+	}
+	if len(requests[0].ResponseSchema) != 0 {
+		t.Fatalf("legacy TL prompt must not carry a response schema: %v", requests[0].ResponseSchema)
+	}
+	if _, ok := requests[0].Metadata["structured_output_schema_version"]; ok {
+		t.Fatalf("legacy TL prompt must not carry a schema version: %v", requests[0].Metadata)
+	}
+}
+
+func TestParseTranslationResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "json", content: `{"translation": "鉄の剣"}`, want: "鉄の剣"},
+		{name: "legacy TL", content: "TL: |鉄の剣|", want: "鉄の剣"},
+		{name: "legacy TL with preamble", content: "Here you go.\nTL: |鉄の剣|", want: "鉄の剣"},
+		{name: "legacy TL without closing pipe", content: "TL: |鉄の剣", want: "鉄の剣"},
+		{name: "plain text without TL marker", content: "鉄の剣", wantErr: true},
+		{name: "empty legacy TL", content: "TL: ||", wantErr: true},
+		{name: "empty json translation", content: `{"translation": " "}`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseTranslationResponse(tc.content)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTranslationResponse failed: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected translation: got=%q want=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTermTranslator_BuildPrompts_SkipsSemanticSearch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dictDB, modDB, cleanup := setupTestDB(t)
+	defer cleanup()
+	if err := dictionaryartifact.Migrate(ctx, dictDB); err != nil {
+		t.Fatalf("failed to migrate dict db: %v", err)
+	}
+
+	input := TerminologyInput{
+		TaskID: "task-dry-run",
+		Entries: []TerminologyEntry{
+			{ID: "401", EditorID: "EditorE", RecordType: "BOOK:FULL", SourceText: "Skeever Den", SourceFile: "mod_dry.json", Variant: "single"},
+		},
+	}
+	repo := &fakeTranslationInputRepository{input: input}
+	builder := NewTermRequestBuilder(&TermRecordConfig{TargetRecordTypes: append([]string(nil), foundation.DictionaryImportRECTypes...)})
+	searcher := NewSQLiteTermDictionarySearcher(dictionaryartifact.NewRepository(dictDB), logger, NewSnowballStemmer("english"))
+	embedder := &stubTextEmbedder{model: "stub:v1"}
+	searcher.SetEmbedder(embedder)
+	promptBuilder, err := NewTermPromptBuilder("")
+	if err != nil {
+		t.Fatalf("failed to create prompt builder: %v", err)
+	}
+	translator := NewTermTranslator(repo, builder, searcher, NewSQLiteModTermStore(modDB, logger), promptBuilder, logger)
+
+	requests, err := translator.BuildPrompts(ctx, "task-dry-run", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("BuildPrompts failed: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("unexpected request count: got=%d want=%d", len(requests), 1)
+	}
+	if embedder.calls != 0 || embedder.batchCalls != 0 {
+		t.Fatalf("見積もりでは埋め込みを呼び出さないこと: calls=%d batch_calls=%d", embedder.calls, embedder.batchCalls)
+	}
+	var embeddings int
+	if err := dictDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM artifact_dictionary_embeddings`).Scan(&embeddings); err != nil {
+		t.Fatalf("count dictionary embeddings: %v", err)
+	}
+	if embeddings != 0 {
+		t.Fatalf("見積もりでは埋め込みを保存しないこと: got=%d", embeddings)
+	}
+
+	if _, err := translator.PreparePrompts(ctx, "task-dry-run", PhaseOptions{}); err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if embedder.calls == 0 {
+		t.Fatalf("expected the real run to use semantic search")
+	}
+}
+
 func TestTermTranslator_PreparePrompts_PrefersLongestPhraseInOverlap(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -596,7 +770,7 @@ func TestTermTranslator_SaveResults_DoesNotCountCachedAsFailed_WhenRunningSummar
 
 	responses := []llmio.Response{
 		{
-			Content:  "TL: |鋼鉄の鎧|",
+			Content:  `{"translation": "鋼鉄の鎧"}`,
 			Success:  true,
 			Metadata: requests[0].Metadata,
 		},
@@ -744,3 +918,90 @@ func toArtifactInput(input TerminologyInput) translationinput.TerminologyInput {
 		Entries:   entries,
 	}
 }
+
+type stubTextEmbedder struct {
+	model      string
+	vectors    map[string][]float32
+	calls      int
+	batchCalls int
+}
+
+func (s *stubTextEmbedder) EmbeddingModel() string { return s.model }
+
+func (s *stubTextEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	s.calls++
+	if vector, ok := s.vectors[text]; ok {
+		return vector, nil
+	}
+	return []float32{0, 0, 1}, nil
+}
+
+func (s *stubTextEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	s.batchCalls++
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector, _ := s.Embed(ctx, text)
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+func TestTermDictionarySearcher_SearchSemantic_FindsParaphrasedTerm(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dictDB, _, cleanup := setupTestDB(t)
+	defer cleanup()
+	if err := dictionaryartifact.Migrate(ctx, dictDB); err != nil {
+		t.Fatalf("failed to migrate dict db: %v", err)
+	}
+	if _, err := dictDB.Exec(`INSERT INTO artifact_dictionary_entries (source_text, dest_text, record_type) VALUES ('Jarl''s Longhouse', '首長のロングハウス', 'CELL:FULL')`); err != nil {
+		t.Fatalf("failed to insert longhouse row: %v", err)
+	}
+
+	dictRepo := dictionaryartifact.NewRepository(dictDB)
+	searcher := NewSQLiteTermDictionarySearcher(dictRepo, logger, NewSnowballStemmer("english"))
+
+	terms, err := searcher.SearchSemantic(ctx, "longhouse of the Jarl", 5)
+	if err != nil || terms != nil {
+		t.Fatalf("semantic search without embedder must be a no-op: terms=%v err=%v", terms, err)
+	}
+
+	embedder := &stubTextEmbedder{
+		model: "stub:v1",
+		vectors: map[string][]float32{
+			"Jarl's Longhouse":      {1, 0, 0},
+			"longhouse of the Jarl": {0.95, 0.1, 0},
+		},
+	}
+	searcher.SetEmbedder(embedder)
+
+	indexed, err := searcher.IndexEmbeddings(ctx)
+	if err != nil {
+		t.Fatalf("IndexEmbeddings failed: %v", err)
+	}
+	if indexed != 6 {
+		t.Fatalf("expected every dictionary entry to be indexed, got %d", indexed)
+	}
+	if embedder.batchCalls != 1 {
+		t.Fatalf("expected dictionary entries to be embedded in one batch request, got %d", embedder.batchCalls)
+	}
+	if again, err := searcher.IndexEmbeddings(ctx); err != nil || again != 0 {
+		t.Fatalf("expected second index pass to be a no-op: indexed=%d err=%v", again, err)
+	}
+
+	terms, err = searcher.SearchSemantic(ctx, "longhouse of the Jarl", 5)
+	if err != nil {
+		t.Fatalf("SearchSemantic failed: %v", err)
+	}
+	if len(terms) != 1 || terms[0].Source != "Jarl's Longhouse" || terms[0].Translation != "首長のロングハウス" {
+		t.Fatalf("expected only the paraphrased longhouse term, got %v", terms)
+	}
+
+	embedder.model = "stub:v2"
+	terms, err = searcher.SearchSemantic(ctx, "longhouse of the Jarl", 5)
+	if err != nil || len(terms) != 0 {
+		t.Fatalf("vectors of another model must not be compared: terms=%v err=%v", terms, err)
+	}
+}
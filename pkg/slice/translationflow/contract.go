@@ -18,6 +18,22 @@ type LoadedFile struct {
 	PreviewRowCount int
 }
 
+// FileDiff summarizes how a reloaded file differs from the previous load of the same file name.
+type FileDiff struct {
+	HasPrevious bool
+	Added       int
+	Changed     int
+	Removed     int
+	Unchanged   int
+	Carries     []RowCarry
+}
+
+// RowCarry maps an unchanged row to the row it replaced so existing results can follow it.
+type RowCarry struct {
+	PreviousRowID string
+	RowID         string
+}
+
 // PreviewRow is one row displayed in translation-flow load preview tables.
 type PreviewRow struct {
 	ID         string
@@ -73,6 +89,32 @@ type PersonaDialogueExcerpt struct {
 	Order            int
 }
 
+// SummarySourceInput groups the dialogue groups and quests of one task for story summaries.
+type SummarySourceInput struct {
+	TaskID         string
+	DialogueGroups []SummaryDialogueGroup
+	Quests         []SummaryQuest
+}
+
+// SummaryDialogueGroup is one dialogue group with its response lines in conversation order.
+type SummaryDialogueGroup struct {
+	GroupID    string
+	PlayerText string
+	Lines      []string
+}
+
+// SummaryQuest is one quest with its stage log texts.
+type SummaryQuest struct {
+	QuestID string
+	Stages  []SummaryQuestStage
+}
+
+// SummaryQuestStage is one quest stage log entry.
+type SummaryQuestStage struct {
+	Index int
+	Text  string
+}
+
 // PersonaFinalSummary represents the minimum final persona details needed by translation workflow.
 type PersonaFinalSummary struct {
 	PersonaID    int64
@@ -91,9 +133,12 @@ type PersonaLookupKey struct {
 type Service interface {
 	EnsureTask(ctx context.Context, taskID string) error
 	SaveParsedOutput(ctx context.Context, taskID string, sourceFilePath string, output *skyrim.ParserOutput) (LoadedFile, error)
+	SaveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (LoadedFile, error)
+	ReloadParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (LoadedFile, FileDiff, error)
 	ListFiles(ctx context.Context, taskID string) ([]LoadedFile, error)
 	ListPreviewRows(ctx context.Context, fileID int64, page int, pageSize int) (PreviewPage, error)
 	LoadTerminologyInput(ctx context.Context, taskID string) (translationinput.TerminologyInput, error)
 	LoadPersonaCandidates(ctx context.Context, taskID string) (PersonaCandidateInput, error)
+	LoadSummarySource(ctx context.Context, taskID string) (SummarySourceInput, error)
 	FindPersonaFinal(ctx context.Context, key PersonaLookupKey) (PersonaFinalSummary, bool, error)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 
 	masterpersonaartifact "github.com/ishibata91/ai-translation-engine-2/pkg/artifact/master_persona_artifact"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/artifact/translationinput"
@@ -38,6 +39,42 @@ func (s *service) SaveParsedOutput(ctx context.Context, taskID string, sourceFil
 	if err != nil {
 		return LoadedFile{}, fmt.Errorf("save parsed translation input task_id=%s file=%s: %w", taskID, sourceFilePath, err)
 	}
+	return toLoadedFile(stored), nil
+}
+
+// SaveParsedOutputStream persists parser output chunks as they are produced under the task/file boundary.
+func (s *service) SaveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (LoadedFile, error) {
+	stored, err := s.inputRepo.SaveParsedOutputStream(ctx, taskID, sourceFilePath, stream)
+	if err != nil {
+		return LoadedFile{}, fmt.Errorf("save streamed translation input task_id=%s file=%s: %w", taskID, sourceFilePath, err)
+	}
+	return toLoadedFile(stored), nil
+}
+
+// ReloadParsedOutputStream replaces the previous load of the same file name and returns the row diff against it.
+func (s *service) ReloadParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (LoadedFile, FileDiff, error) {
+	stored, storedDiff, err := s.inputRepo.ReloadParsedOutputStream(ctx, taskID, sourceFilePath, stream)
+	if err != nil {
+		return LoadedFile{}, FileDiff{}, fmt.Errorf("reload translation input task_id=%s file=%s: %w", taskID, sourceFilePath, err)
+	}
+	diff := FileDiff{
+		HasPrevious: storedDiff.HasPrevious,
+		Added:       storedDiff.Added,
+		Changed:     storedDiff.Changed,
+		Removed:     storedDiff.Removed,
+		Unchanged:   storedDiff.Unchanged,
+		Carries:     make([]RowCarry, 0, storedDiff.Unchanged),
+	}
+	for _, row := range storedDiff.Rows {
+		if row.Status != translationinput.RowDiffUnchanged {
+			continue
+		}
+		diff.Carries = append(diff.Carries, RowCarry{PreviousRowID: row.PreviousRowID, RowID: row.RowID})
+	}
+	return toLoadedFile(stored), diff, nil
+}
+
+func toLoadedFile(stored translationinput.InputFile) LoadedFile {
 	return LoadedFile{
 		ID:              stored.ID,
 		TaskID:          stored.TaskID,
@@ -46,7 +83,7 @@ func (s *service) SaveParsedOutput(ctx context.Context, taskID string, sourceFil
 		SourceFileHash:  stored.SourceFileHash,
 		ParseStatus:     stored.ParseStatus,
 		PreviewRowCount: stored.PreviewRowCount,
-	}, nil
+	}
 }
 
 // ListFiles loads all saved files for one translation-flow task.
@@ -149,6 +186,59 @@ func (s *service) LoadPersonaCandidates(ctx context.Context, taskID string) (Per
 	}, nil
 }
 
+// LoadSummarySource projects persisted dialogue responses and quest stages into summary sources.
+func (s *service) LoadSummarySource(ctx context.Context, taskID string) (SummarySourceInput, error) {
+	input, err := s.inputRepo.LoadMainTranslationInput(ctx, taskID)
+	if err != nil {
+		return SummarySourceInput{}, fmt.Errorf("load summary source task_id=%s: %w", taskID, err)
+	}
+
+	type orderedLine struct {
+		order int
+		text  string
+	}
+	groupIndex := make(map[string]int)
+	groupLines := make([][]orderedLine, 0)
+	questIndex := make(map[string]int)
+	result := SummarySourceInput{TaskID: input.TaskID}
+	for _, entry := range input.Entries {
+		switch entry.Section {
+		case "dialogue_response":
+			if entry.ParentID == "" {
+				continue
+			}
+			idx, ok := groupIndex[entry.ParentID]
+			if !ok {
+				idx = len(result.DialogueGroups)
+				groupIndex[entry.ParentID] = idx
+				result.DialogueGroups = append(result.DialogueGroups, SummaryDialogueGroup{GroupID: entry.ParentID, PlayerText: entry.PlayerText})
+				groupLines = append(groupLines, nil)
+			}
+			groupLines[idx] = append(groupLines[idx], orderedLine{order: entry.Order, text: entry.SourceText})
+		case "quest_stage":
+			if entry.QuestID == "" {
+				continue
+			}
+			idx, ok := questIndex[entry.QuestID]
+			if !ok {
+				idx = len(result.Quests)
+				questIndex[entry.QuestID] = idx
+				result.Quests = append(result.Quests, SummaryQuest{QuestID: entry.QuestID})
+			}
+			result.Quests[idx].Stages = append(result.Quests[idx].Stages, SummaryQuestStage{Index: entry.Order, Text: entry.SourceText})
+		}
+	}
+	for i, lines := range groupLines {
+		sort.SliceStable(lines, func(a, b int) bool { return lines[a].order < lines[b].order })
+		texts := make([]string, 0, len(lines))
+		for _, line := range lines {
+			texts = append(texts, line.text)
+		}
+		result.DialogueGroups[i].Lines = texts
+	}
+	return result, nil
+}
+
 // FindPersonaFinal exposes final master persona lookup via translation-flow local contract.
 func (s *service) FindPersonaFinal(ctx context.Context, key PersonaLookupKey) (PersonaFinalSummary, bool, error) {
 	lookupKey := masterpersonaartifact.LookupKey{
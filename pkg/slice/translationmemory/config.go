@@ -0,0 +1,41 @@
+package translationmemory
+
+import "github.com/ishibata91/ai-translation-engine-2/pkg/foundation"
+
+// Config は翻訳メモリの取り込み対象とあいまい検索の設定を保持する。
+type Config struct {
+	// ExcludedRECTypes は翻訳メモリに取り込まない REC タイプ（例: "NPC_:FULL"）。
+	// 名詞は辞書スライスが扱うため、既定では DictionaryImportRECTypes を除外する。
+	ExcludedRECTypes []string `json:"excluded_rec_types" mapstructure:"excluded_rec_types"`
+	// FuzzyThreshold はあいまい一致として扱う類似度の下限（0〜1）。
+	FuzzyThreshold float64 `json:"fuzzy_threshold" mapstructure:"fuzzy_threshold"`
+	// MaxFuzzyMatches は 1 回の検索で返すあいまい一致の最大件数。
+	MaxFuzzyMatches int `json:"max_fuzzy_matches" mapstructure:"max_fuzzy_matches"`
+	// CandidateLimit は n-gram で絞り込んだ後に編集距離を計算する候補の最大件数。
+	CandidateLimit int `json:"candidate_limit" mapstructure:"candidate_limit"`
+	// MaxFuzzyRunes はあいまい検索の対象とする原文の最大文字数。これより長い原文は完全一致のみを検索する。
+	MaxFuzzyRunes int `json:"max_fuzzy_runes" mapstructure:"max_fuzzy_runes"`
+}
+
+// DefaultConfig は一般的な Skyrim Mod 翻訳向けの既定値を持つ Config を返す。
+func DefaultConfig() Config {
+	return Config{
+		ExcludedRECTypes: append([]string(nil), foundation.DictionaryImportRECTypes...),
+		FuzzyThreshold:   0.75,
+		MaxFuzzyMatches:  3,
+		CandidateLimit:   50,
+		MaxFuzzyRunes:    500,
+	}
+}
+
+// IsExcludedREC は recType が取り込み対象外かどうかを判定する。
+// メイン翻訳の "INFO NAM1" 形式と xTranslator の "INFO:NAM1" 形式のどちらも受け付ける。
+func (c *Config) IsExcludedREC(recType string) bool {
+	normalized := normalizeRecordType(recType)
+	for _, excluded := range c.ExcludedRECTypes {
+		if normalized == excluded {
+			return true
+		}
+	}
+	return false
+}
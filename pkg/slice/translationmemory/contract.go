@@ -0,0 +1,29 @@
+package translationmemory
+
+import (
+	"context"
+	"io"
+)
+
+// TranslationMemoryImporter は xTranslator XML のパースと翻訳メモリへの保存をオーケストレートする。
+type TranslationMemoryImporter interface {
+	// ImportXML は XML を読み込み、訳のある文レベルのエントリを保存して保存件数を返す。
+	// 進捗イベントには correlationID を付与する。
+	ImportXML(ctx context.Context, correlationID string, fileName string, file io.Reader) (int, error)
+}
+
+// TranslationMemoryStore は SQLite への翻訳メモリ永続化を担う。
+// このスライスはテーブル作成・UPSERT・n-gram 索引の管理すべてを所有する。
+type TranslationMemoryStore interface {
+	// InitSchema は翻訳メモリと n-gram 索引のテーブルを作成する。
+	InitSchema(ctx context.Context) error
+
+	// SaveEntries は複数エントリをバッチで保存する。同じ原文の既存エントリは後から保存した訳で上書きする。
+	SaveEntries(ctx context.Context, entries []Entry) error
+
+	// FindExact は原文が完全一致するエントリを返す。見つからない場合は nil を返す。
+	FindExact(ctx context.Context, sourceText string) (*Entry, error)
+
+	// FindCandidates は正規化済みの原文と共通する n-gram が多い順に、あいまい一致の候補を最大 limit 件返す。
+	FindCandidates(ctx context.Context, normalizedSource string, limit int) ([]Entry, error)
+}
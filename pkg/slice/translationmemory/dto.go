@@ -0,0 +1,40 @@
+package translationmemory
+
+import "time"
+
+// エントリの取り込み元
+const (
+	// OriginTask は翻訳タスクで確定した訳を表す。
+	OriginTask = "task"
+	// OriginXML は xTranslator XML から取り込んだ訳を表す。
+	OriginXML = "xml"
+)
+
+// ExactScore は原文が完全一致したときの類似度。
+const ExactScore = 1.0
+
+// Entry は翻訳メモリに保存された確定訳 1 件を表す。
+// translation_memory テーブルに対応し、原文ごとに最新の訳を 1 件だけ保持する。
+type Entry struct {
+	ID           int64     `json:"id"`
+	SourceText   string    `json:"source_text"`
+	DestText     string    `json:"dest_text"`
+	RecordType   string    `json:"record_type"`
+	EDID         string    `json:"edid"`
+	SourcePlugin string    `json:"source_plugin"`
+	Origin       string    `json:"origin"`     // task, xml
+	OriginRef    string    `json:"origin_ref"` // タスク ID または XML ファイル名
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Match は翻訳メモリの検索結果 1 件を表す。
+// Score は 0〜1 の類似度で、ExactScore は原文の完全一致を意味する。
+type Match struct {
+	Entry
+	Score float64 `json:"score"`
+}
+
+// IsExact は原文が完全一致した検索結果かどうかを返す。
+func (m Match) IsExact() bool {
+	return m.Score >= ExactScore
+}
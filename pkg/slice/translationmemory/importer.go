@@ -0,0 +1,120 @@
+package translationmemory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/xtranslator"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+)
+
+type xmlImporter struct {
+	config   Config
+	store    TranslationMemoryStore
+	notifier progress.ProgressNotifier
+	logger   *slog.Logger
+}
+
+// NewImporter は TranslationMemoryImporter の新しいインスタンスを生成する。
+func NewImporter(config Config, store TranslationMemoryStore, notifier progress.ProgressNotifier, logger *slog.Logger) TranslationMemoryImporter {
+	return &xmlImporter{
+		config:   config,
+		store:    store,
+		notifier: notifier,
+		logger:   logger.With("component", "TranslationMemoryImporter"),
+	}
+}
+
+// ImportXML は xTranslator XML を io.Reader からストリーミングパースし、
+// 訳のある文レベルのレコードを翻訳メモリに保存する。
+// 辞書が扱う名詞レコード・未翻訳・原文と同じ訳は取り込まない。
+// 進捗は correlationID を付けて通知する。
+func (i *xmlImporter) ImportXML(ctx context.Context, correlationID string, fileName string, file io.Reader) (int, error) {
+	i.logger.DebugContext(ctx, "ENTER TranslationMemoryImporter.ImportXML", "correlation_id", correlationID, "file_name", fileName)
+	defer i.logger.DebugContext(ctx, "EXIT TranslationMemoryImporter.ImportXML")
+
+	// 初回進捗通知
+	i.notifier.OnProgress(ctx, progress.ProgressEvent{
+		CorrelationID: correlationID,
+		Status:        progress.StatusInProgress,
+		Message:       fmt.Sprintf("翻訳メモリインポート開始: %s", fileName),
+	})
+
+	totalImported, err := i.parseAndSave(ctx, fileName, correlationID, file)
+	if err != nil {
+		i.notifier.OnProgress(ctx, progress.ProgressEvent{
+			CorrelationID: correlationID,
+			Completed:     totalImported,
+			Status:        progress.StatusFailed,
+			Message:       fmt.Sprintf("インポートエラー: %v", err),
+		})
+		return totalImported, fmt.Errorf("parse and save translation memory file=%s: %w", fileName, err)
+	}
+
+	// 完了通知
+	i.notifier.OnProgress(ctx, progress.ProgressEvent{
+		CorrelationID: correlationID,
+		Completed:     totalImported,
+		Status:        progress.StatusCompleted,
+		Message:       fmt.Sprintf("インポート完了: %d 件", totalImported),
+	})
+
+	i.logger.InfoContext(ctx, "Successfully imported translation memory", "total", totalImported, "file_name", fileName)
+	return totalImported, nil
+}
+
+// parseAndSave は XML を読み込み、バッチ単位で保存して合計件数を返す。
+func (i *xmlImporter) parseAndSave(ctx context.Context, fileName string, correlationID string, file io.Reader) (int, error) {
+	const batchSize = 1000
+	batch := make([]Entry, 0, batchSize)
+	totalImported := 0
+
+	err := xtranslator.ReadStrings(ctx, file, i.logger, func(s xtranslator.String) error {
+		entry, ok := acceptEntry(i.config, Entry{
+			SourceText:   s.Source,
+			DestText:     s.Dest,
+			RecordType:   s.REC,
+			EDID:         s.EDID,
+			SourcePlugin: s.Addon,
+			Origin:       OriginXML,
+			OriginRef:    fileName,
+		})
+		if !ok {
+			return nil
+		}
+		batch = append(batch, entry)
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		if err := i.store.SaveEntries(ctx, batch); err != nil {
+			return fmt.Errorf("error saving batch: %w", err)
+		}
+		totalImported += len(batch)
+		batch = batch[:0]
+
+		// バッチ完了ごとに進捗通知
+		i.notifier.OnProgress(ctx, progress.ProgressEvent{
+			CorrelationID: correlationID,
+			Completed:     totalImported,
+			Status:        progress.StatusInProgress,
+			Message:       fmt.Sprintf("インポート中: %d 件処理済み", totalImported),
+		})
+		return nil
+	})
+	if err != nil {
+		return totalImported, err
+	}
+
+	// 残りのバッチをフラッシュ
+	if len(batch) > 0 {
+		if err := i.store.SaveEntries(ctx, batch); err != nil {
+			return totalImported, fmt.Errorf("error saving batch: %w", err)
+		}
+		totalImported += len(batch)
+	}
+
+	return totalImported, nil
+}
@@ -0,0 +1,85 @@
+package translationmemory
+
+import "strings"
+
+// gramSize は候補の絞り込みに使う文字 n-gram の長さ。
+const gramSize = 3
+
+// nearExactScore は正規化後にのみ一致する（大文字小文字・空白だけが異なる）原文の類似度。
+// 強制訳に使われないよう、完全一致の ExactScore より必ず小さくする。
+const nearExactScore = 0.99
+
+// normalizeSource は保存・完全一致検索に使う原文の表記を返す。前後の空白のみを取り除く。
+func normalizeSource(text string) string {
+	return strings.TrimSpace(text)
+}
+
+// normalizeForMatch は大文字小文字と空白の差を無視するため、あいまい検索用に原文を正規化する。
+func normalizeForMatch(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// normalizeRecordType はメイン翻訳の "INFO NAM1" 形式を xTranslator の "INFO:NAM1" 形式に揃える。
+func normalizeRecordType(recType string) string {
+	return strings.Replace(strings.TrimSpace(recType), " ", ":", 1)
+}
+
+// ngrams は正規化済みテキストの文字 n-gram を重複なしで返す。
+// 両端に空白を補うため、n-gram より短いテキストも 1 件以上の n-gram を持つ。
+func ngrams(normalized string) []string {
+	if normalized == "" {
+		return nil
+	}
+	runes := []rune(" " + normalized + " ")
+	if len(runes) <= gramSize {
+		return []string{string(runes)}
+	}
+	seen := make(map[string]struct{}, len(runes))
+	grams := make([]string, 0, len(runes))
+	for i := 0; i+gramSize <= len(runes); i++ {
+		gram := string(runes[i : i+gramSize])
+		if _, ok := seen[gram]; ok {
+			continue
+		}
+		seen[gram] = struct{}{}
+		grams = append(grams, gram)
+	}
+	return grams
+}
+
+// similarity は正規化済みテキスト同士の編集距離を長い方の文字数で割り、1 から引いた類似度を返す。
+func similarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return ExactScore
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein は 2 行分のバッファで文字単位の編集距離を計算する。
+func levenshtein(a []rune, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,22 @@
+package translationmemory
+
+import "github.com/google/wire"
+
+// ProviderSet は翻訳メモリパッケージの Wire プロバイダセット。
+// Store・Importer・Service の実装をまとめる。
+var ProviderSet = wire.NewSet(
+	NewTranslationMemoryStore,
+	NewImporter,
+	NewTranslationMemoryService,
+)
+
+// ConfigProvider は DefaultConfig を提供する。
+func ConfigProvider() Config {
+	return DefaultConfig()
+}
+
+// DefaultProviderSet はデフォルト設定を含む完全なプロバイダセット。
+var DefaultProviderSet = wire.NewSet(
+	ProviderSet,
+	ConfigProvider,
+)
@@ -0,0 +1,164 @@
+package translationmemory
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	telemetry2 "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/telemetry"
+)
+
+// TranslationMemoryService は翻訳メモリの検索・確定訳の蓄積・XML インポートをまとめるサービス。
+// 完全一致は強制訳として、あいまい一致はプロンプトの参考訳として翻訳フローから参照される。
+type TranslationMemoryService struct {
+	config   Config
+	store    TranslationMemoryStore
+	importer TranslationMemoryImporter
+	logger   *slog.Logger
+}
+
+// NewTranslationMemoryService は TranslationMemoryService の新しいインスタンスを生成する。
+func NewTranslationMemoryService(config Config, store TranslationMemoryStore, importer TranslationMemoryImporter, logger *slog.Logger) *TranslationMemoryService {
+	return &TranslationMemoryService{
+		config:   config,
+		store:    store,
+		importer: importer,
+		logger:   logger.With("component", "TranslationMemoryService"),
+	}
+}
+
+// Lookup は sourceText に一致する確定訳を返す。
+// 完全一致があればその 1 件のみを ExactScore で返し、無ければ類似度の下限以上のあいまい一致を
+// 類似度の高い順に最大 MaxFuzzyMatches 件返す。
+func (s *TranslationMemoryService) Lookup(ctx context.Context, sourceText string) ([]Match, error) {
+	source := normalizeSource(sourceText)
+	if source == "" {
+		return nil, nil
+	}
+	exact, err := s.store.FindExact(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("find exact translation memory match: %w", err)
+	}
+	if exact != nil {
+		return []Match{{Entry: *exact, Score: ExactScore}}, nil
+	}
+
+	query := normalizeForMatch(source)
+	queryRunes := utf8.RuneCountInString(query)
+	if s.config.MaxFuzzyMatches <= 0 || (s.config.MaxFuzzyRunes > 0 && queryRunes > s.config.MaxFuzzyRunes) {
+		return nil, nil
+	}
+	candidates, err := s.store.FindCandidates(ctx, query, s.config.CandidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("find translation memory candidates: %w", err)
+	}
+
+	matches := make([]Match, 0, len(candidates))
+	for _, candidate := range candidates {
+		normalized := normalizeForMatch(candidate.SourceText)
+		// 文字数の比は類似度の上限になるため、届かない候補は編集距離を計算しない。
+		shorter, longer := queryRunes, utf8.RuneCountInString(normalized)
+		if shorter > longer {
+			shorter, longer = longer, shorter
+		}
+		if longer > 0 && float64(shorter)/float64(longer) < s.config.FuzzyThreshold {
+			continue
+		}
+		score := similarity(query, normalized)
+		if score >= ExactScore {
+			score = nearExactScore
+		}
+		if score < s.config.FuzzyThreshold {
+			continue
+		}
+		matches = append(matches, Match{Entry: candidate, Score: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > s.config.MaxFuzzyMatches {
+		matches = matches[:s.config.MaxFuzzyMatches]
+	}
+	return matches, nil
+}
+
+// Record は確定訳を翻訳メモリに保存し、保存件数を返す。
+// 同じ原文の既存エントリは新しい訳で上書きされ、取り込み対象外のエントリは無視する。
+func (s *TranslationMemoryService) Record(ctx context.Context, entries []Entry) (int, error) {
+	accepted := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if e, ok := acceptEntry(s.config, entry); ok {
+			accepted = append(accepted, e)
+		}
+	}
+	if err := s.store.SaveEntries(ctx, accepted); err != nil {
+		return 0, fmt.Errorf("save translation memory entries: %w", err)
+	}
+	s.logger.DebugContext(ctx, "recorded translation memory entries",
+		slog.Int("received", len(entries)),
+		slog.Int("saved", len(accepted)),
+	)
+	return len(accepted), nil
+}
+
+// StartImport は指定した xTranslator XML の翻訳メモリへの取り込みを非同期で開始する。
+// 戻り値は進捗イベントの相関 ID。
+func (s *TranslationMemoryService) StartImport(ctx context.Context, filePath string) (string, error) {
+	defer telemetry2.StartSpan(ctx, telemetry2.ActionImport)()
+	s.logger.InfoContext(ctx, "starting translation memory import", slog.String("file_path", filePath))
+
+	if _, err := os.Stat(filePath); err != nil {
+		s.logger.ErrorContext(ctx, "failed to stat file for import", telemetry2.ErrorAttrs(err)...)
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+	fileName := filepath.Base(filePath)
+	// 同名ファイルの取り込みが並行しても進捗が混ざらないよう、相関 ID は取り込みごとに採番する。
+	correlationID := "tm-import-" + uuid.NewString()
+
+	// 非同期でインポート実行
+	go func() {
+		// リクエストIDを引き継ぐ
+		bgCtx := telemetry2.WithAttrs(ctx, slog.String("request_id", "async-tm-import-"+uuid.New().String()))
+		defer telemetry2.StartSpan(bgCtx, telemetry2.ActionImport)()
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			s.logger.ErrorContext(bgCtx, "failed to open import file",
+				append(telemetry2.ErrorAttrs(err), slog.String("file_path", filePath))...)
+			return
+		}
+		defer file.Close()
+
+		count, err := s.importer.ImportXML(bgCtx, correlationID, fileName, file)
+		if err != nil {
+			s.logger.ErrorContext(bgCtx, "translation memory import failed",
+				append(telemetry2.ErrorAttrs(err), slog.String("file_name", fileName), slog.Int("processed_count", count))...)
+			return
+		}
+		s.logger.InfoContext(bgCtx, "translation memory import completed",
+			slog.String("file_name", fileName), slog.Int("processed_count", count))
+	}()
+
+	return correlationID, nil
+}
+
+// acceptEntry は取り込み対象のエントリを保存用に整えて返す。
+// 空の原文・訳、原文と同じ訳（未翻訳）、除外 REC タイプのエントリは (Entry{}, false) を返す。
+func acceptEntry(config Config, entry Entry) (Entry, bool) {
+	entry.SourceText = normalizeSource(entry.SourceText)
+	entry.DestText = strings.TrimSpace(entry.DestText)
+	if entry.SourceText == "" || entry.DestText == "" || entry.DestText == entry.SourceText {
+		return Entry{}, false
+	}
+	if config.IsExcludedREC(entry.RecordType) {
+		return Entry{}, false
+	}
+	entry.RecordType = normalizeRecordType(entry.RecordType)
+	return entry, true
+}
@@ -0,0 +1,185 @@
+package translationmemory
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T) (*TranslationMemoryService, TranslationMemoryStore) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	db.SetMaxOpenConns(1)
+
+	config := DefaultConfig()
+	store := NewTranslationMemoryStore(db, config)
+	require.NoError(t, store.InitSchema(context.Background()))
+	importer := NewImporter(config, store, progress.NewNoopNotifier(), slog.Default())
+	return NewTranslationMemoryService(config, store, importer, slog.Default()), store
+}
+
+func TestTranslationMemoryService_Lookup(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	saved, err := service.Record(ctx, []Entry{
+		{SourceText: "I used to be an adventurer like you.", DestText: "昔はお前のような冒険者だったのだが。", RecordType: "INFO NAM1", Origin: OriginTask, OriginRef: "task-1"},
+		{SourceText: "Then I took an arrow in the knee.", DestText: "膝に矢を受けてしまってな。", RecordType: "INFO NAM1", Origin: OriginTask, OriginRef: "task-1"},
+		{SourceText: "Iron Sword", DestText: "鉄の剣", RecordType: "WEAP FULL", Origin: OriginTask},
+		{SourceText: "Untranslated line.", DestText: "Untranslated line.", RecordType: "INFO NAM1", Origin: OriginTask},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved, "名詞と未翻訳の行は保存しない")
+
+	tests := []struct {
+		name      string
+		source    string
+		wantDest  []string
+		wantExact bool
+	}{
+		{name: "完全一致は強制訳として 1 件だけ返す", source: "  I used to be an adventurer like you. ", wantDest: []string{"昔はお前のような冒険者だったのだが。"}, wantExact: true},
+		{name: "言い回しの近い原文はあいまい一致として返す", source: "I used to be an adventurer like you!", wantDest: []string{"昔はお前のような冒険者だったのだが。"}},
+		{name: "大文字小文字だけの差は完全一致として扱わない", source: "i used to be an adventurer like you.", wantDest: []string{"昔はお前のような冒険者だったのだが。"}},
+		{name: "類似度の下限に届かない原文は返さない", source: "Let me guess, someone stole your sweetroll?"},
+		{name: "除外した名詞は検索されない", source: "Iron Sword"},
+		{name: "空の原文は検索しない", source: "  "},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := service.Lookup(ctx, tc.source)
+			require.NoError(t, err)
+			require.Len(t, matches, len(tc.wantDest))
+			for i, want := range tc.wantDest {
+				assert.Equal(t, want, matches[i].DestText)
+				assert.Equal(t, tc.wantExact, matches[i].IsExact())
+				assert.Equal(t, "INFO:NAM1", matches[i].RecordType)
+			}
+		})
+	}
+}
+
+func TestTranslationMemoryService_RecordOverwritesSameSource(t *testing.T) {
+	service, store := newTestService(t)
+	ctx := context.Background()
+
+	_, err := service.Record(ctx, []Entry{{SourceText: "Lead the way.", DestText: "先導してくれ。", RecordType: "INFO NAM1", Origin: OriginXML, OriginRef: "old.xml"}})
+	require.NoError(t, err)
+	_, err = service.Record(ctx, []Entry{{SourceText: "Lead the way.", DestText: "案内してくれ。", RecordType: "INFO NAM1", Origin: OriginTask, OriginRef: "task-2"}})
+	require.NoError(t, err)
+
+	entry, err := store.FindExact(ctx, "Lead the way.")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "案内してくれ。", entry.DestText)
+	assert.Equal(t, OriginTask, entry.Origin)
+	assert.Equal(t, "task-2", entry.OriginRef)
+
+	matches, err := service.Lookup(ctx, "Lead the way!")
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "上書きしても n-gram 索引は重複しない")
+}
+
+func TestTranslationMemoryService_LookupRanksFuzzyMatches(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+	_, err := service.Record(ctx, []Entry{
+		{SourceText: "Bring me the Dragonstone from Bleak Falls Barrow.", DestText: "ブリーク・フォール墓地からドラゴンストーンを持ってきてくれ。", RecordType: "QUST CNAM", Origin: OriginTask},
+		{SourceText: "Bring me the Dragonstone from Bleak Falls.", DestText: "ブリーク・フォールからドラゴンストーンを持ってきてくれ。", RecordType: "QUST CNAM", Origin: OriginTask},
+	})
+	require.NoError(t, err)
+
+	matches, err := service.Lookup(ctx, "Bring me the Dragonstone from Bleak Falls Barrow")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "ブリーク・フォール墓地からドラゴンストーンを持ってきてくれ。", matches[0].DestText)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+	assert.Less(t, matches[0].Score, ExactScore)
+}
+
+func TestImporter_ImportXML(t *testing.T) {
+	const xmlData = `<?xml version="1.0" encoding="utf-8"?>
+<SSTXMLRessources>
+  <Params>
+    <Addon>Skyrim.esm</Addon>
+  </Params>
+  <Content>
+    <String>
+      <EDID>Skyrim.esm|0x0001</EDID>
+      <REC>BOOK:FULL</REC>
+      <Source>The Lusty Argonian Maid</Source>
+      <Dest>アルゴニアンの侍女</Dest>
+    </String>
+    <String>
+      <EDID>Skyrim.esm|0x0003</EDID>
+      <REC>INFO:NAM1</REC>
+      <Source>I used to be an adventurer like you.</Source>
+      <Dest>昔はお前のような冒険者だったのだが。</Dest>
+    </String>
+    <String>
+      <EDID>Skyrim.esm|0x0004</EDID>
+      <REC>QUST:CNAM</REC>
+      <Source>Talk to the Jarl.</Source>
+      <Dest></Dest>
+    </String>
+  </Content>
+</SSTXMLRessources>
+`
+	service, store := newTestService(t)
+	ctx := context.Background()
+
+	count, err := service.importer.ImportXML(ctx, "tm-import-test", "Skyrim_english_japanese.xml", strings.NewReader(xmlData))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "名詞レコードと訳の無い行は取り込まない")
+
+	entry, err := store.FindExact(ctx, "I used to be an adventurer like you.")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "昔はお前のような冒険者だったのだが。", entry.DestText)
+	assert.Equal(t, "INFO:NAM1", entry.RecordType)
+	assert.Equal(t, "Skyrim.esm|0x0003", entry.EDID)
+	assert.Equal(t, "Skyrim.esm", entry.SourcePlugin)
+	assert.Equal(t, OriginXML, entry.Origin)
+	assert.Equal(t, "Skyrim_english_japanese.xml", entry.OriginRef)
+
+	missing, err := store.FindExact(ctx, "The Lusty Argonian Maid")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+type recordingImporter struct {
+	correlationIDs chan string
+}
+
+func (r *recordingImporter) ImportXML(_ context.Context, correlationID string, _ string, _ io.Reader) (int, error) {
+	r.correlationIDs <- correlationID
+	return 0, nil
+}
+
+func TestTranslationMemoryService_StartImport_IssuesUniqueCorrelationIDs(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "Skyrim_english_japanese.xml")
+	require.NoError(t, os.WriteFile(filePath, []byte("<SSTXMLRessources/>"), 0o600))
+
+	importer := &recordingImporter{correlationIDs: make(chan string, 2)}
+	service := NewTranslationMemoryService(DefaultConfig(), nil, importer, slog.Default())
+	ctx := context.Background()
+
+	first, err := service.StartImport(ctx, filePath)
+	require.NoError(t, err)
+	second, err := service.StartImport(ctx, filePath)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second, "同じファイルでも取り込みごとに別の相関 ID を発行する")
+	assert.True(t, strings.HasPrefix(first, "tm-import-"))
+	assert.ElementsMatch(t, []string{first, second}, []string{<-importer.correlationIDs, <-importer.correlationIDs}, "進捗は発行した相関 ID で通知する")
+}
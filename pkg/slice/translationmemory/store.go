@@ -0,0 +1,197 @@
+package translationmemory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const entryColumns = `id, source_text, dest_text, record_type, edid, source_plugin, origin, origin_ref, updated_at`
+
+type sqliteTranslationMemoryStore struct {
+	db *sql.DB
+	// maxIndexedRunes は n-gram 索引を作る原文の最大文字数。0 は無制限。
+	maxIndexedRunes int
+}
+
+// NewTranslationMemoryStore は translation_memory.db を扱う TranslationMemoryStore を生成する。
+// あいまい検索で類似度の下限に届きえない長文は n-gram 索引を作らず、完全一致でのみ参照される。
+func NewTranslationMemoryStore(db *sql.DB, config Config) TranslationMemoryStore {
+	maxIndexedRunes := 0
+	if config.MaxFuzzyRunes > 0 && config.FuzzyThreshold > 0 {
+		maxIndexedRunes = int(float64(config.MaxFuzzyRunes) / config.FuzzyThreshold)
+	}
+	return &sqliteTranslationMemoryStore{db: db, maxIndexedRunes: maxIndexedRunes}
+}
+
+func (s *sqliteTranslationMemoryStore) InitSchema(ctx context.Context) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS translation_memory (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			source_text TEXT NOT NULL UNIQUE,
+			dest_text TEXT NOT NULL,
+			record_type TEXT NOT NULL DEFAULT '',
+			edid TEXT NOT NULL DEFAULT '',
+			source_plugin TEXT NOT NULL DEFAULT '',
+			origin TEXT NOT NULL,
+			origin_ref TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME NOT NULL
+		);`,
+		// 原文は更新されないため、n-gram は INSERT 時に一度だけ作れば足りる。
+		`CREATE TABLE IF NOT EXISTS translation_memory_grams (
+			gram TEXT NOT NULL,
+			entry_id INTEGER NOT NULL,
+			PRIMARY KEY (gram, entry_id)
+		) WITHOUT ROWID;`,
+	}
+	for _, query := range queries {
+		if _, err := s.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("init translation memory schema: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *sqliteTranslationMemoryStore) SaveEntries(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin translation memory transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	upsertStmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO translation_memory (source_text, dest_text, record_type, edid, source_plugin, origin, origin_ref, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(source_text) DO UPDATE SET
+			dest_text = excluded.dest_text,
+			record_type = excluded.record_type,
+			edid = excluded.edid,
+			source_plugin = excluded.source_plugin,
+			origin = excluded.origin,
+			origin_ref = excluded.origin_ref,
+			updated_at = excluded.updated_at`)
+	if err != nil {
+		return fmt.Errorf("prepare translation memory upsert: %w", err)
+	}
+	defer upsertStmt.Close()
+	idStmt, err := tx.PrepareContext(ctx, `SELECT id FROM translation_memory WHERE source_text = ?`)
+	if err != nil {
+		return fmt.Errorf("prepare translation memory id lookup: %w", err)
+	}
+	defer idStmt.Close()
+	gramStmt, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO translation_memory_grams (gram, entry_id) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("prepare translation memory gram insert: %w", err)
+	}
+	defer gramStmt.Close()
+
+	now := time.Now()
+	for _, entry := range entries {
+		updatedAt := entry.UpdatedAt
+		if updatedAt.IsZero() {
+			updatedAt = now
+		}
+		if _, err := upsertStmt.ExecContext(ctx,
+			entry.SourceText,
+			entry.DestText,
+			entry.RecordType,
+			entry.EDID,
+			entry.SourcePlugin,
+			entry.Origin,
+			entry.OriginRef,
+			updatedAt,
+		); err != nil {
+			return fmt.Errorf("upsert translation memory entry source=%q: %w", entry.SourceText, err)
+		}
+
+		normalized := normalizeForMatch(entry.SourceText)
+		if s.maxIndexedRunes > 0 && utf8.RuneCountInString(normalized) > s.maxIndexedRunes {
+			continue
+		}
+		var id int64
+		if err := idStmt.QueryRowContext(ctx, entry.SourceText).Scan(&id); err != nil {
+			return fmt.Errorf("query translation memory entry id source=%q: %w", entry.SourceText, err)
+		}
+		for _, gram := range ngrams(normalized) {
+			if _, err := gramStmt.ExecContext(ctx, gram, id); err != nil {
+				return fmt.Errorf("insert translation memory gram entry_id=%d: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit translation memory entries: %w", err)
+	}
+	return nil
+}
+
+func (s *sqliteTranslationMemoryStore) FindExact(ctx context.Context, sourceText string) (*Entry, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+entryColumns+` FROM translation_memory WHERE source_text = ?`, sourceText)
+	entry, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query translation memory exact match: %w", err)
+	}
+	return &entry, nil
+}
+
+func (s *sqliteTranslationMemoryStore) FindCandidates(ctx context.Context, normalizedSource string, limit int) ([]Entry, error) {
+	grams := ngrams(normalizedSource)
+	if len(grams) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	args := make([]any, 0, len(grams)+1)
+	for _, gram := range grams {
+		args = append(args, gram)
+	}
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT m.id, m.source_text, m.dest_text, m.record_type, m.edid, m.source_plugin, m.origin, m.origin_ref, m.updated_at
+		FROM (
+			SELECT entry_id, COUNT(*) AS hits
+			FROM translation_memory_grams
+			WHERE gram IN (%s)
+			GROUP BY entry_id
+			ORDER BY hits DESC, entry_id
+			LIMIT ?
+		) g
+		JOIN translation_memory m ON m.id = g.entry_id
+		ORDER BY g.hits DESC, m.id`, strings.TrimSuffix(strings.Repeat("?,", len(grams)), ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query translation memory candidates: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0, limit)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan translation memory candidate: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate translation memory candidates: %w", err)
+	}
+	return entries, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row rowScanner) (Entry, error) {
+	var e Entry
+	err := row.Scan(&e.ID, &e.SourceText, &e.DestText, &e.RecordType, &e.EDID, &e.SourcePlugin, &e.Origin, &e.OriginRef, &e.UpdatedAt)
+	return e, err
+}
@@ -9,7 +9,7 @@ func NewPersonaLookupAdapter() PersonaLookup {
 
 type noopPersonaLookup struct{}
 
-func (a *noopPersonaLookup) FindBySpeakerID(ctx context.Context, speakerID string) (*string, error) {
+func (a *noopPersonaLookup) FindBySpeakerID(ctx context.Context, sourcePlugin string, speakerID string) (*string, error) {
 	return nil, nil
 }
 
@@ -39,6 +39,17 @@ func (a *noopTermLookup) Search(ctx context.Context, sourceText string) ([]Pass2
 	return nil, nil, nil
 }
 
+// NewTranslationMemoryLookupAdapter returns a boundary-safe default implementation.
+func NewTranslationMemoryLookupAdapter() TranslationMemoryLookup {
+	return &noopTranslationMemoryLookup{}
+}
+
+type noopTranslationMemoryLookup struct{}
+
+func (a *noopTranslationMemoryLookup) FindMatches(ctx context.Context, sourceText string) ([]Pass2MemoryMatch, error) {
+	return nil, nil
+}
+
 // defaultToneResolver implements ToneResolver with basic rules.
 type defaultToneResolver struct{}
 
@@ -1,76 +1,164 @@
 package translator
 
 import (
+	"regexp"
 	"strings"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/tokenizer"
+)
+
+// defaultBookChunkTokens is the source token budget of one book chunk.
+const defaultBookChunkTokens = 1000
+
+var (
+	pageBreakRegex = regexp.MustCompile(`(?i)\[PageBreak\]`)
+	paragraphRegex = regexp.MustCompile(`\n[ \t\r]*\n\s*`)
+	sentenceRegex  = regexp.MustCompile(`[.!?]["')\]]*\s+|[。！？」]+`)
+	spaceRegex     = regexp.MustCompile(`\s+`)
 )
 
-type bookChunker struct{}
+// bookSplitLevels lists split boundaries from the most to the least preferred.
+// Each level returns cut offsets; pages and paragraphs are kept whole whenever they fit.
+var bookSplitLevels = []func(text string) []int{
+	// Page breaks start a new chunk so the [PageBreak] token travels with its page.
+	func(text string) []int { return matchOffsets(pageBreakRegex, text, false) },
+	func(text string) []int { return matchOffsets(paragraphRegex, text, true) },
+	func(text string) []int { return lineOffsets(text) },
+	func(text string) []int { return matchOffsets(sentenceRegex, text, true) },
+	func(text string) []int { return matchOffsets(spaceRegex, text, true) },
+}
+
+type bookChunker struct {
+	count func(text string) int
+}
 
-// NewBookChunker creates a new BookChunker instance.
+// NewBookChunker creates a new BookChunker instance measuring chunks with the llama tokenizer,
+// the most conservative estimate across supported providers.
 func NewBookChunker() BookChunker {
-	return &bookChunker{}
+	return NewBookChunkerWithTokenizer(tokenizer.ForFamily(tokenizer.FamilyLlama))
+}
+
+// NewBookChunkerWithTokenizer creates a BookChunker measuring chunks with the given tokenizer.
+func NewBookChunkerWithTokenizer(t tokenizer.Tokenizer) BookChunker {
+	return &bookChunker{count: t.Count}
 }
 
-// Chunk splits a long text into multiple chunks, attempting to stay within maxChars limit.
-// It tries to split at line breaks or sentence ends.
-func (c *bookChunker) Chunk(text string, maxChars int) []string {
-	if len(text) <= maxChars {
+// Chunk splits a long text into chunks of at most maxTokens source tokens.
+// It prefers [PageBreak] and paragraph boundaries, then lines, sentences and spaces,
+// and never cuts inside markup or a placeholder. Chunks are lossless:
+// concatenating them yields the original text, including the whitespace between them.
+func (c *bookChunker) Chunk(text string, maxTokens int) []string {
+	if maxTokens <= 0 || c.count(text) <= maxTokens {
 		return []string{text}
 	}
+	return mergeBlankChunks(c.split(text, maxTokens, 0))
+}
 
-	var chunks []string
-	remaining := text
-
-	for len(remaining) > 0 {
-		if len(remaining) <= maxChars {
-			chunks = append(chunks, remaining)
-			break
+// mergeBlankChunks folds whitespace-only chunks into their neighbour; there is nothing to translate in them.
+func mergeBlankChunks(chunks []string) []string {
+	merged := make([]string, 0, len(chunks))
+	pending := ""
+	for _, chunk := range chunks {
+		if strings.TrimSpace(chunk) == "" {
+			if len(merged) > 0 {
+				merged[len(merged)-1] += chunk
+			} else {
+				pending += chunk
+			}
+			continue
 		}
+		merged = append(merged, pending+chunk)
+		pending = ""
+	}
+	if len(merged) == 0 {
+		return []string{pending}
+	}
+	return merged
+}
 
-		// Find a good split point within maxChars
-		splitPoint := maxChars
+func (c *bookChunker) split(text string, maxTokens int, level int) []string {
+	if level >= len(bookSplitLevels) {
+		// No boundary left; an oversized token run is sent as is.
+		return []string{text}
+	}
+	pieces := splitAtOffsets(text, bookSplitLevels[level](text))
+	if len(pieces) <= 1 {
+		return c.split(text, maxTokens, level+1)
+	}
 
-		// 1. Try to find the last newline within limits
-		lastNewline := strings.LastIndex(remaining[:maxChars], "\n")
-		if lastNewline > maxChars/2 {
-			splitPoint = lastNewline + 1
-		} else {
-			// 2. Try to find the last sentence end (., !, ?)
-			lastSentenceEnd := -1
-			ends := []string{". ", "! ", "? ", "。 ", "！ ", "？ "}
-			for _, end := range ends {
-				idx := strings.LastIndex(remaining[:maxChars], end)
-				if idx > lastSentenceEnd {
-					lastSentenceEnd = idx + len(end)
-				}
-			}
+	var chunks []string
+	var current strings.Builder
+	currentTokens := 0
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentTokens = 0
+		}
+	}
+	for _, piece := range pieces {
+		pieceTokens := c.count(piece)
+		if pieceTokens > maxTokens {
+			flush()
+			chunks = append(chunks, c.split(piece, maxTokens, level+1)...)
+			continue
+		}
+		if currentTokens+pieceTokens > maxTokens {
+			flush()
+		}
+		current.WriteString(piece)
+		currentTokens += pieceTokens
+	}
+	flush()
+	return chunks
+}
 
-			if lastSentenceEnd > maxChars/2 {
-				splitPoint = lastSentenceEnd
-			} else {
-				// 3. Just find the last space
-				lastSpace := strings.LastIndex(remaining[:maxChars], " ")
-				if lastSpace > maxChars/2 {
-					splitPoint = lastSpace + 1
-				}
-			}
+// matchOffsets returns the start (or end, when after is set) offsets of every match.
+func matchOffsets(re *regexp.Regexp, text string, after bool) []int {
+	matches := re.FindAllStringIndex(text, -1)
+	offsets := make([]int, 0, len(matches))
+	for _, m := range matches {
+		if after {
+			offsets = append(offsets, m[1])
+		} else {
+			offsets = append(offsets, m[0])
 		}
+	}
+	return offsets
+}
 
-		// Ensure we don't split in the middle of an HTML tag or placeholder
-		// Check if we are inside a tag at splitPoint
-		if isInsideTag(remaining, splitPoint) {
-			// Find the start of the tag/placeholder and split before it
-			tagStart := findStartOfBoundary(remaining, splitPoint)
-			if tagStart > 0 {
-				splitPoint = tagStart
-			}
+func lineOffsets(text string) []int {
+	var offsets []int
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			offsets = append(offsets, i+1)
 		}
+	}
+	return offsets
+}
 
-		chunks = append(chunks, strings.TrimSpace(remaining[:splitPoint]))
-		remaining = strings.TrimSpace(remaining[splitPoint:])
+// splitAtOffsets cuts text at the given offsets, skipping cuts at the edges or inside a tag.
+func splitAtOffsets(text string, offsets []int) []string {
+	var pieces []string
+	last := 0
+	for _, offset := range offsets {
+		if offset <= last || offset >= len(text) || isInsideTag(text, offset) {
+			continue
+		}
+		pieces = append(pieces, text[last:offset])
+		last = offset
 	}
+	return append(pieces, text[last:])
+}
 
-	return chunks
+// splitChunkSpace separates the leading and trailing whitespace of a chunk, which the LLM
+// does not reproduce reliably, so that reassembly restores the original separators.
+func splitChunkSpace(chunk string) (prefix string, body string, suffix string) {
+	body = strings.TrimLeft(chunk, " \t\r\n")
+	prefix = chunk[:len(chunk)-len(body)]
+	trimmed := strings.TrimRight(body, " \t\r\n")
+	suffix = body[len(trimmed):]
+	return prefix, trimmed, suffix
 }
 
 // isInsideTag checks if the position is inside an HTML tag <...> or a placeholder [TAG_N]
@@ -95,14 +183,3 @@ func isInsideTag(text string, pos int) bool {
 
 	return false
 }
-
-// findStartOfBoundary finds the start of the tag or placeholder at the current position
-func findStartOfBoundary(text string, pos int) int {
-	lastOpenAngle := strings.LastIndex(text[:pos], "<")
-	lastOpenBracket := strings.LastIndex(text[:pos], "[")
-
-	if lastOpenAngle > lastOpenBracket {
-		return lastOpenAngle
-	}
-	return lastOpenBracket
-}
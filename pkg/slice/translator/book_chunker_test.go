@@ -0,0 +1,55 @@
+package translator
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBookText = "The Lusty Argonian Maid, Volume 1\n\nAct one begins in the kitchen.[PageBreak]" +
+	"Lifts-Her-Tail: <font color='#FFFFFF'>Certainly not, kind sir!</font> I am here only to clean.\n\n" +
+	"Crantius: Of course.[PageBreak]End of act one."
+
+func TestBookChunker_Chunk(t *testing.T) {
+	chunker := NewBookChunker().(*bookChunker)
+
+	tests := []struct {
+		name      string
+		maxTokens int
+		wantCount int
+	}{
+		{name: "上限内なら分割しない", maxTokens: 200, wantCount: 1},
+		{name: "ページ区切りを優先して分割する", maxTokens: 60, wantCount: 3},
+		{name: "ページに収まらなければ段落や文で分割する", maxTokens: 12},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := chunker.Chunk(testBookText, tc.maxTokens)
+			if tc.wantCount > 0 && len(chunks) != tc.wantCount {
+				t.Fatalf("chunk count = %d, want %d: %q", len(chunks), tc.wantCount, chunks)
+			}
+			if joined := strings.Join(chunks, ""); joined != testBookText {
+				t.Fatalf("chunks must concatenate to the source, got %q", joined)
+			}
+			for i, chunk := range chunks {
+				if strings.TrimSpace(chunk) == "" {
+					t.Fatalf("chunk %d is blank: %q", i, chunks)
+				}
+				if strings.Count(chunk, "<") != strings.Count(chunk, ">") {
+					t.Fatalf("chunk %d cuts through markup: %q", i, chunk)
+				}
+			}
+		})
+	}
+
+	pages := chunker.Chunk(testBookText, 60)
+	if !strings.HasPrefix(pages[1], "[PageBreak]Lifts-Her-Tail") || pages[2] != "[PageBreak]End of act one." {
+		t.Fatalf("expected chunks to start at page breaks, got %q", pages)
+	}
+}
+
+func TestSplitChunkSpace(t *testing.T) {
+	prefix, body, suffix := splitChunkSpace("\n\n  Act two.\n\n")
+	if prefix != "\n\n  " || body != "Act two." || suffix != "\n\n" {
+		t.Fatalf("unexpected split: %q %q %q", prefix, body, suffix)
+	}
+}
@@ -2,8 +2,13 @@ package translator
 
 import (
 	"context"
+	"sort"
 )
 
+// defaultDialogueHistoryLines is the number of prior conversation lines included when
+// ContextEngineInput.DialogueHistoryLines is not set.
+const defaultDialogueHistoryLines = 3
+
 // ContextEngineInput is the input data required for building translation context.
 type ContextEngineInput struct {
 	NPCs      map[string]ContextNPC
@@ -12,6 +17,11 @@ type ContextEngineInput struct {
 	Items     []ContextItem
 	Magic     []ContextMagic
 	Locations []ContextLocation
+	// DialogueHistoryLines limits how many prior lines are included for a dialogue response.
+	// Zero uses defaultDialogueHistoryLines; a negative value disables the history.
+	DialogueHistoryLines int
+
+	dialogueIndex *dialogueIndex
 }
 
 type ContextNPC struct {
@@ -33,6 +43,13 @@ type ContextDialogue struct {
 	QuestID          *string
 	IsServicesBranch bool
 	Order            int
+	// GroupID is the source record ID of the owning dialogue group (DIAL).
+	GroupID    *string
+	PreviousID *string
+	TopicText  *string
+	PlayerText *string
+	// SourcePlugin is the plugin the response belongs to; personas are keyed by plugin and speaker.
+	SourcePlugin string
 }
 
 type ContextQuest struct {
@@ -67,6 +84,7 @@ type ContextItem struct {
 	Type     string
 	Name     *string
 	Text     *string
+	TypeHint *string
 }
 
 type ContextMagic struct {
@@ -85,7 +103,7 @@ type ContextLocation struct {
 
 // ContextEngine (Internal) handles context building logic moved from Lore slice.
 // It integrates dialogue tree analysis, speaker profiling, reference term lookup,
-// and summary lookup.
+// summary lookup, and translation memory lookup.
 type ContextEngine interface {
 	BuildTranslationContext(ctx context.Context, record interface{}, input *ContextEngineInput) (*Pass2Context, []Pass2ReferenceTerm, *string, error)
 }
@@ -95,9 +113,9 @@ type ToneResolver interface {
 	Resolve(race string, voiceType string, sex string) string
 }
 
-// PersonaLookup searches for an NPC's persona text by speaker ID.
+// PersonaLookup searches for an NPC's persona text by source plugin and speaker ID.
 type PersonaLookup interface {
-	FindBySpeakerID(ctx context.Context, speakerID string) (*string, error)
+	FindBySpeakerID(ctx context.Context, sourcePlugin string, speakerID string) (*string, error)
 }
 
 // TermLookup searches dictionary and Mod term databases for reference terms.
@@ -111,12 +129,19 @@ type SummaryLookup interface {
 	FindQuestSummary(ctx context.Context, questID string) (*string, error)
 }
 
+// TranslationMemoryLookup searches confirmed translations of past tasks.
+// A match scored 1 is an exact source match; lower scores are fuzzy matches, best first.
+type TranslationMemoryLookup interface {
+	FindMatches(ctx context.Context, sourceText string) ([]Pass2MemoryMatch, error)
+}
+
 // contextEngine implements ContextEngine interface.
 type contextEngine struct {
 	toneResolver  ToneResolver
 	personaLookup PersonaLookup
 	termLookup    TermLookup
 	summaryLookup SummaryLookup
+	memoryLookup  TranslationMemoryLookup
 }
 
 // NewContextEngine creates a new ContextEngine instance.
@@ -125,12 +150,14 @@ func NewContextEngine(
 	pl PersonaLookup,
 	tl TermLookup,
 	sl SummaryLookup,
+	ml TranslationMemoryLookup,
 ) ContextEngine {
 	return &contextEngine{
 		toneResolver:  tr,
 		personaLookup: pl,
 		termLookup:    tl,
 		summaryLookup: sl,
+		memoryLookup:  ml,
 	}
 }
 
@@ -138,6 +165,7 @@ func (e *contextEngine) BuildTranslationContext(ctx context.Context, record inte
 	pass2Ctx := &Pass2Context{}
 	var terms []Pass2ReferenceTerm
 	var forcedTranslation *string
+	var sourceText string
 
 	switch r := record.(type) {
 	case ContextDialogue:
@@ -155,7 +183,7 @@ func (e *contextEngine) BuildTranslationContext(ctx context.Context, record inte
 				profile.ToneInstruction = e.toneResolver.Resolve(speaker.Race, speaker.VoiceType, speaker.Gender)
 
 				// Fetch persona if available
-				persona, err := e.personaLookup.FindBySpeakerID(ctx, *r.SpeakerID)
+				persona, err := e.personaLookup.FindBySpeakerID(ctx, r.SourcePlugin, *r.SpeakerID)
 				if err == nil && persona != nil {
 					profile.PersonaText = persona
 				}
@@ -163,16 +191,31 @@ func (e *contextEngine) BuildTranslationContext(ctx context.Context, record inte
 			}
 		}
 
-		// 2. Summary Lookup
+		// 2. Conversation flow
+		pass2Ctx.TopicName = nonEmptyString(r.TopicText)
+		pass2Ctx.PlayerText = nonEmptyString(r.PlayerText)
+		if history := input.dialogueHistory(r); len(history) > 0 {
+			pass2Ctx.PreviousLines = history
+			pass2Ctx.PreviousLine = &history[len(history)-1].Text
+		}
+
+		// 3. Summary Lookup
 		if r.QuestID != nil {
 			summary, err := e.summaryLookup.FindQuestSummary(ctx, *r.QuestID)
 			if err == nil && summary != nil {
 				pass2Ctx.QuestSummary = summary
 			}
 		}
+		if r.GroupID != nil {
+			summary, err := e.summaryLookup.FindDialogueSummary(ctx, *r.GroupID)
+			if err == nil && summary != nil {
+				pass2Ctx.DialogueSummary = summary
+			}
+		}
 
-		// 3. Term Lookup for the source text
+		// 4. Term Lookup for the source text
 		if r.Text != nil {
+			sourceText = *r.Text
 			t, forced, err := e.termLookup.Search(ctx, *r.Text)
 			if err == nil {
 				terms = t
@@ -186,6 +229,19 @@ func (e *contextEngine) BuildTranslationContext(ctx context.Context, record inte
 		if err == nil && summary != nil {
 			pass2Ctx.QuestSummary = summary
 		}
+		sourceText = r.Text
+		t, forced, err := e.termLookup.Search(ctx, r.Text)
+		if err == nil {
+			terms = t
+			forcedTranslation = forced
+		}
+
+	case ContextQuestObjective:
+		summary, err := e.summaryLookup.FindQuestSummary(ctx, r.ParentID)
+		if err == nil && summary != nil {
+			pass2Ctx.QuestSummary = summary
+		}
+		sourceText = r.Text
 		t, forced, err := e.termLookup.Search(ctx, r.Text)
 		if err == nil {
 			terms = t
@@ -193,14 +249,130 @@ func (e *contextEngine) BuildTranslationContext(ctx context.Context, record inte
 		}
 
 	case ContextItem:
+		pass2Ctx.ItemTypeHint = r.TypeHint
 		if r.Name != nil {
+			sourceText = *r.Name
 			t, forced, err := e.termLookup.Search(ctx, *r.Name)
 			if err == nil {
 				terms = t
 				forcedTranslation = forced
 			}
+		} else if r.Text != nil {
+			// Body text only borrows reference terms; a dictionary hit never replaces prose.
+			sourceText = *r.Text
+			t, _, err := e.termLookup.Search(ctx, *r.Text)
+			if err == nil {
+				terms = t
+			}
+		}
+	}
+
+	// 5. Translation memory: an exact match is reused as is, fuzzy matches guide the LLM.
+	// Dictionary hits take precedence.
+	if forcedTranslation == nil && e.memoryLookup != nil && sourceText != "" {
+		matches, err := e.memoryLookup.FindMatches(ctx, sourceText)
+		if err == nil && len(matches) > 0 {
+			if matches[0].Score >= 1 {
+				translated := matches[0].TranslatedText
+				forcedTranslation = &translated
+			} else {
+				pass2Ctx.MemoryMatches = matches
+			}
 		}
 	}
 
 	return pass2Ctx, terms, forcedTranslation, nil
 }
+
+// dialogueIndex looks up dialogue responses by record ID and by owning group.
+type dialogueIndex struct {
+	byID    map[string]ContextDialogue
+	byGroup map[string][]ContextDialogue
+}
+
+func newDialogueIndex(dialogues []ContextDialogue) *dialogueIndex {
+	idx := &dialogueIndex{
+		byID:    make(map[string]ContextDialogue, len(dialogues)),
+		byGroup: make(map[string][]ContextDialogue),
+	}
+	for _, d := range dialogues {
+		if _, exists := idx.byID[d.ID]; !exists {
+			idx.byID[d.ID] = d
+		}
+		if d.GroupID != nil {
+			idx.byGroup[*d.GroupID] = append(idx.byGroup[*d.GroupID], d)
+		}
+	}
+	for _, group := range idx.byGroup {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Order < group[j].Order })
+	}
+	return idx
+}
+
+// previous returns the line spoken before d: the PreviousID link when it resolves,
+// otherwise the response with the nearest lower Order in the same group.
+func (idx *dialogueIndex) previous(d ContextDialogue) (ContextDialogue, bool) {
+	if d.PreviousID != nil {
+		if prev, ok := idx.byID[*d.PreviousID]; ok && prev.ID != d.ID {
+			return prev, true
+		}
+	}
+	if d.GroupID == nil {
+		return ContextDialogue{}, false
+	}
+	group := idx.byGroup[*d.GroupID]
+	pos := sort.Search(len(group), func(i int) bool { return group[i].Order >= d.Order })
+	if pos == 0 {
+		return ContextDialogue{}, false
+	}
+	return group[pos-1], true
+}
+
+// dialogueHistory walks back from d and returns up to DialogueHistoryLines prior lines,
+// oldest first. The index is built on first use and reused for the rest of the input.
+func (input *ContextEngineInput) dialogueHistory(d ContextDialogue) []Pass2DialogueLine {
+	limit := input.DialogueHistoryLines
+	if limit == 0 {
+		limit = defaultDialogueHistoryLines
+	}
+	if limit < 0 || len(input.Dialogues) == 0 {
+		return nil
+	}
+	if input.dialogueIndex == nil {
+		input.dialogueIndex = newDialogueIndex(input.Dialogues)
+	}
+
+	visited := map[string]bool{d.ID: true}
+	lines := make([]Pass2DialogueLine, 0, limit)
+	current := d
+	for len(lines) < limit {
+		prev, ok := input.dialogueIndex.previous(current)
+		if !ok || visited[prev.ID] {
+			break
+		}
+		visited[prev.ID] = true
+		current = prev
+		if prev.Text == nil || *prev.Text == "" {
+			continue
+		}
+		line := Pass2DialogueLine{Text: *prev.Text}
+		if prev.SpeakerID != nil {
+			if npc, ok := input.NPCs[*prev.SpeakerID]; ok {
+				line.Speaker = npc.Name
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
+
+func nonEmptyString(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,195 @@
+package translator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func strPtr(value string) *string {
+	return &value
+}
+
+func buildConversationInput() *ContextEngineInput {
+	group := strPtr("dg-1")
+	return &ContextEngineInput{
+		NPCs: map[string]ContextNPC{
+			"lydia": {ID: "lydia", Name: "Lydia", Race: "Nord", Gender: "Female"},
+		},
+		Dialogues: []ContextDialogue{
+			{ID: "info-1", GroupID: group, SpeakerID: strPtr("lydia"), Text: strPtr("I am sworn to carry your burdens."), Order: 1},
+			{ID: "info-2", GroupID: group, SpeakerID: strPtr("lydia"), Text: strPtr("Lead the way."), Order: 2},
+			{ID: "info-3", GroupID: group, SpeakerID: strPtr("lydia"), Text: strPtr("As you wish."), Order: 3},
+			{ID: "info-4", GroupID: group, SpeakerID: strPtr("lydia"), Text: strPtr("My Thane."), Order: 4, PreviousID: strPtr("info-2"),
+				TopicText: strPtr("Follow me."), PlayerText: strPtr("I need your help.")},
+		},
+	}
+}
+
+type stubSummaryLookup struct {
+	noopSummaryLookup
+}
+
+func (s *stubSummaryLookup) FindDialogueSummary(ctx context.Context, dialogueGroupID string) (*string, error) {
+	return strPtr("summary of " + dialogueGroupID), nil
+}
+
+func TestContextEngine_BuildTranslationContext_DialogueHistory(t *testing.T) {
+	engine := NewContextEngine(NewDefaultToneResolver(), NewPersonaLookupAdapter(), NewTermLookupAdapter(), &stubSummaryLookup{}, NewTranslationMemoryLookupAdapter())
+	input := buildConversationInput()
+
+	tests := []struct {
+		name   string
+		record ContextDialogue
+		limit  int
+		want   []string
+	}{
+		{name: "PreviousID を優先してたどる", record: input.Dialogues[3], want: []string{"I am sworn to carry your burdens.", "Lead the way."}},
+		{name: "PreviousID が無ければ Order の直前をたどる", record: input.Dialogues[2], want: []string{"I am sworn to carry your burdens.", "Lead the way."}},
+		{name: "行数の上限を守る", record: input.Dialogues[2], limit: 1, want: []string{"Lead the way."}},
+		{name: "負の上限で履歴を無効にする", record: input.Dialogues[2], limit: -1},
+		{name: "先頭の行は履歴を持たない", record: input.Dialogues[0]},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			input.DialogueHistoryLines = tc.limit
+			pass2Ctx, _, _, err := engine.BuildTranslationContext(context.Background(), tc.record, input)
+			if err != nil {
+				t.Fatalf("BuildTranslationContext failed: %v", err)
+			}
+			if len(pass2Ctx.PreviousLines) != len(tc.want) {
+				t.Fatalf("PreviousLines = %+v, want %v", pass2Ctx.PreviousLines, tc.want)
+			}
+			for i, line := range pass2Ctx.PreviousLines {
+				if line.Text != tc.want[i] || line.Speaker != "Lydia" {
+					t.Fatalf("PreviousLines[%d] = %+v, want text %q", i, line, tc.want[i])
+				}
+			}
+			if len(tc.want) == 0 {
+				if pass2Ctx.PreviousLine != nil {
+					t.Fatalf("expected no previous line, got %q", *pass2Ctx.PreviousLine)
+				}
+				return
+			}
+			if pass2Ctx.PreviousLine == nil || *pass2Ctx.PreviousLine != tc.want[len(tc.want)-1] {
+				t.Fatalf("PreviousLine must be the latest prior line, got %v", pass2Ctx.PreviousLine)
+			}
+		})
+	}
+}
+
+func TestDefaultPromptBuilder_Build_IncludesConversationContext(t *testing.T) {
+	engine := NewContextEngine(NewDefaultToneResolver(), NewPersonaLookupAdapter(), NewTermLookupAdapter(), &stubSummaryLookup{}, NewTranslationMemoryLookupAdapter())
+	input := buildConversationInput()
+	record := input.Dialogues[3]
+
+	pass2Ctx, _, _, err := engine.BuildTranslationContext(context.Background(), record, input)
+	if err != nil {
+		t.Fatalf("BuildTranslationContext failed: %v", err)
+	}
+	_, userPrompt, err := NewDefaultPromptBuilder().Build(context.Background(), Pass2TranslationRequest{
+		ID:         record.ID,
+		SourceText: *record.Text,
+		Context:    *pass2Ctx,
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"原文: My Thane.",
+		"会話の概要: summary of dg-1",
+		"話題: Follow me.",
+		"プレイヤーの発言: I need your help.",
+		"直前の会話 (古い順):\n- Lydia: I am sworn to carry your burdens.\n- Lydia: Lead the way.\n",
+		"話者: Lydia",
+		"話者の口調: 標準的な女性の話し方 (力強く)",
+	} {
+		if !strings.Contains(userPrompt, want) {
+			t.Fatalf("prompt missing %q:\n%s", want, userPrompt)
+		}
+	}
+}
+
+type stubTranslationMemoryLookup struct {
+	matches map[string][]Pass2MemoryMatch
+	queries []string
+}
+
+func (s *stubTranslationMemoryLookup) FindMatches(ctx context.Context, sourceText string) ([]Pass2MemoryMatch, error) {
+	s.queries = append(s.queries, sourceText)
+	return s.matches[sourceText], nil
+}
+
+type stubForcedTermLookup struct {
+	noopTermLookup
+	forced map[string]string
+}
+
+func (s *stubForcedTermLookup) Search(ctx context.Context, sourceText string) ([]Pass2ReferenceTerm, *string, error) {
+	if forced, ok := s.forced[sourceText]; ok {
+		return nil, &forced, nil
+	}
+	return nil, nil, nil
+}
+
+func TestContextEngine_BuildTranslationContext_TranslationMemory(t *testing.T) {
+	memory := &stubTranslationMemoryLookup{matches: map[string][]Pass2MemoryMatch{
+		"Talk to the Jarl.": {{SourceText: "Talk to the Jarl.", TranslatedText: "首長と話す。", Score: 1}},
+		"Talk to the Jarl of Whiterun.": {
+			{SourceText: "Talk to the Jarl of Riften.", TranslatedText: "リフテンの首長と話す。", Score: 0.85},
+			{SourceText: "Talk to the Jarl.", TranslatedText: "首長と話す。", Score: 0.78},
+		},
+		"Iron Sword": {{SourceText: "Iron Sword", TranslatedText: "鉄の剣 (翻訳メモリ)", Score: 1}},
+	}}
+	terms := &stubForcedTermLookup{forced: map[string]string{"Iron Sword": "鉄の剣"}}
+	engine := NewContextEngine(NewDefaultToneResolver(), NewPersonaLookupAdapter(), terms, NewSummaryLookupAdapter(), memory)
+
+	tests := []struct {
+		name        string
+		record      interface{}
+		wantForced  string
+		wantMatches int
+	}{
+		{name: "完全一致は強制訳になる", record: ContextQuestStage{Text: "Talk to the Jarl.", ParentID: "MQ101"}, wantForced: "首長と話す。"},
+		{name: "あいまい一致はプロンプトの文脈に載る", record: ContextQuestStage{Text: "Talk to the Jarl of Whiterun.", ParentID: "MQ101"}, wantMatches: 2},
+		{name: "辞書の強制訳を優先する", record: ContextItem{Name: strPtr("Iron Sword")}, wantForced: "鉄の剣"},
+		{name: "一致が無ければ何も付与しない", record: ContextQuestStage{Text: "Escape Helgen.", ParentID: "MQ101"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pass2Ctx, _, forced, err := engine.BuildTranslationContext(context.Background(), tc.record, &ContextEngineInput{})
+			if err != nil {
+				t.Fatalf("BuildTranslationContext failed: %v", err)
+			}
+			gotForced := ""
+			if forced != nil {
+				gotForced = *forced
+			}
+			if gotForced != tc.wantForced {
+				t.Fatalf("unexpected forced translation: got=%q want=%q", gotForced, tc.wantForced)
+			}
+			if len(pass2Ctx.MemoryMatches) != tc.wantMatches {
+				t.Fatalf("unexpected memory matches: %+v", pass2Ctx.MemoryMatches)
+			}
+		})
+	}
+	for _, query := range memory.queries {
+		if query == "Iron Sword" {
+			t.Fatalf("translation memory must not be queried after a dictionary hit: %v", memory.queries)
+		}
+	}
+
+	pass2Ctx, _, _, err := engine.BuildTranslationContext(context.Background(), ContextQuestStage{Text: "Talk to the Jarl of Whiterun."}, &ContextEngineInput{})
+	if err != nil {
+		t.Fatalf("BuildTranslationContext failed: %v", err)
+	}
+	_, userPrompt, err := NewDefaultPromptBuilder().Build(context.Background(), Pass2TranslationRequest{SourceText: "Talk to the Jarl of Whiterun.", Context: *pass2Ctx})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	want := "過去の類似訳 (訳語と文体の参考にしてください):\n- [類似度 85%] Talk to the Jarl of Riften. => リフテンの首長と話す。\n- [類似度 78%] Talk to the Jarl. => 首長と話す。\n"
+	if !strings.Contains(userPrompt, want) {
+		t.Fatalf("prompt missing memory matches:\n%s", userPrompt)
+	}
+}
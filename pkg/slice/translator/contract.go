@@ -3,6 +3,7 @@ package translator
 import (
 	"context"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/artifact/translationinput"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
 )
 
@@ -21,6 +22,63 @@ type TranslatorSlice interface {
 	ProposeJobs(ctx context.Context, input TranslatorInput) ([]llmio.Request, error)
 }
 
+// MainTranslator is the task-scoped entry point for the main translation phase.
+type MainTranslator interface {
+	// ID returns the unique identifier of the slice.
+	ID() string
+
+	// PreparePrompts (Phase 1) generates LLM requests from task-scoped artifact input.
+	PreparePrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error)
+
+	// PrepareNextChunks builds the next chunk request of every book row still in progress,
+	// carrying the translation of its previous chunk. It returns no requests once all books are reassembled or failed.
+	PrepareNextChunks(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error)
+
+	// BuildPrompts builds the PreparePrompts requests for dry-run estimation without persisting anything.
+	BuildPrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error)
+
+	// SaveResults (Phase 2) persists LLM responses for one task.
+	SaveResults(ctx context.Context, taskID string, responses []llmio.Response) error
+
+	// GetPhaseSummary returns persisted counts/status for one task.
+	GetPhaseSummary(ctx context.Context, taskID string) (PhaseSummary, error)
+
+	// UpdatePhaseSummary persists workflow-owned phase snapshot updates.
+	UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error
+
+	// ListResults returns one result per current target row, including untranslated rows as pending.
+	ListResults(ctx context.Context, taskID string) ([]TranslationResult, error)
+
+	// CarryForwardResults copies completed results from replaced rows onto their reloaded rows.
+	CarryForwardResults(ctx context.Context, taskID string, carries []ResultCarry) (int, error)
+}
+
+// ResponseRejecter forgets an LLM response whose output failed validation,
+// so re-running the failed rows asks the LLM again instead of replaying a cached copy.
+type ResponseRejecter interface {
+	RejectResponse(ctx context.Context, resp llmio.Response) error
+}
+
+// TranslationInputRepository loads main translation targets from shared artifact storage.
+type TranslationInputRepository interface {
+	LoadMainTranslationInput(ctx context.Context, taskID string) (translationinput.MainTranslationInput, error)
+}
+
+// TaskResultStore persists task-scoped translation results and phase summaries.
+type TaskResultStore interface {
+	InitSchema(ctx context.Context) error
+	SaveResults(ctx context.Context, taskID string, results []TranslationResult) error
+	ListResults(ctx context.Context, taskID string) ([]TranslationResult, error)
+	UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error
+	GetPhaseSummary(ctx context.Context, taskID string) (PhaseSummary, error)
+	// SaveChunk upserts one translated chunk of a book row until the row is reassembled.
+	SaveChunk(ctx context.Context, taskID string, chunk TranslationChunk) error
+	// ListChunks returns the translated chunks of one row ordered by chunk index.
+	ListChunks(ctx context.Context, taskID string, rowID string) ([]TranslationChunk, error)
+	// DeleteChunks drops the chunks of one row once it has been reassembled.
+	DeleteChunks(ctx context.Context, taskID string, rowID string) error
+}
+
 // Internal components
 
 // Translator translates a single record via LLM.
@@ -33,16 +91,16 @@ type PromptBuilder interface {
 	Build(ctx context.Context, request Pass2TranslationRequest) (systemPrompt string, userPrompt string, err error)
 }
 
-// TagProcessor handles HTML tag abstraction and restoration.
+// TagProcessor handles protected token (markup, aliases, printf, MCM keys, ...) abstraction and restoration.
 type TagProcessor interface {
 	Preprocess(text string) (processedText string, tagMap map[string]string)
 	Postprocess(text string, tagMap map[string]string) string
 	Validate(translatedText string, tagMap map[string]string) error
 }
 
-// BookChunker splits long book text into chunks.
+// BookChunker splits long book text into token-bounded chunks that concatenate back to the source.
 type BookChunker interface {
-	Chunk(text string, maxCharsPerChunk int) []string
+	Chunk(text string, maxTokensPerChunk int) []string
 }
 
 // ResultWriter persists translation results.
@@ -15,50 +15,118 @@ type TranslatorConfig struct {
 }
 
 // TranslationResult represents the result of translating a single record.
+// TagIssues keeps the structured tag validation findings behind a failed ErrorMessage.
 type TranslationResult struct {
-	ID             string  `json:"id"`
-	RecordType     string  `json:"type"`
-	SourceText     string  `json:"source_text"`
-	TranslatedText *string `json:"translated_text,omitempty"`
-	Index          *int    `json:"index,omitempty"`
-	Status         string  `json:"status"`
-	ErrorMessage   *string `json:"error_message,omitempty"`
-	SourcePlugin   string  `json:"source_plugin"`
-	SourceFile     string  `json:"source_file"`
-	EditorID       *string `json:"editor_id,omitempty"`
-	ParentID       *string `json:"parent_id,omitempty"`
-	ParentEditorID *string `json:"parent_editor_id,omitempty"`
+	RowID          string     `json:"row_id,omitempty"`
+	ID             string     `json:"id"`
+	RecordType     string     `json:"type"`
+	SourceText     string     `json:"source_text"`
+	TranslatedText *string    `json:"translated_text,omitempty"`
+	Index          *int       `json:"index,omitempty"`
+	Status         string     `json:"status"`
+	ErrorMessage   *string    `json:"error_message,omitempty"`
+	TagIssues      []TagIssue `json:"tag_issues,omitempty"`
+	SourcePlugin   string     `json:"source_plugin"`
+	SourceFile     string     `json:"source_file"`
+	EditorID       *string    `json:"editor_id,omitempty"`
+	ParentID       *string    `json:"parent_id,omitempty"`
+	ParentEditorID *string    `json:"parent_editor_id,omitempty"`
+}
+
+// TranslationChunk is one translated chunk of a book row awaiting reassembly.
+// TranslatedText already has tags restored and the chunk's surrounding whitespace re-applied.
+type TranslationChunk struct {
+	RowID          string `json:"row_id"`
+	Index          int    `json:"index"`
+	Count          int    `json:"count"`
+	SourceText     string `json:"source_text"`
+	TranslatedText string `json:"translated_text"`
+}
+
+// ResultCarry maps a row replaced by a reload to the unchanged row that supersedes it.
+type ResultCarry struct {
+	PreviousRowID string
+	RowID         string
+}
+
+// RequestConfig stores runtime request settings passed from workflow/UI.
+type RequestConfig struct {
+	Provider        string
+	Model           string
+	Endpoint        string
+	APIKey          string
+	Temperature     float32
+	ContextLength   int
+	SyncConcurrency int
+	BulkStrategy    string
+}
+
+// PromptConfig stores runtime prompt settings passed from workflow/UI.
+type PromptConfig struct {
+	UserPrompt   string
+	SystemPrompt string
+}
+
+// PhaseOptions contains the DTO boundary passed from workflow.
+type PhaseOptions struct {
+	Request RequestConfig
+	Prompt  PromptConfig
+}
+
+// PhaseSummary reports the persisted main translation phase state.
+type PhaseSummary struct {
+	TaskID          string `json:"task_id"`
+	Status          string `json:"status"`
+	TargetCount     int    `json:"target_count"`
+	SavedCount      int    `json:"saved_count"`
+	FailedCount     int    `json:"failed_count"`
+	ProgressMode    string `json:"progress_mode"`
+	ProgressCurrent int    `json:"progress_current"`
+	ProgressTotal   int    `json:"progress_total"`
+	ProgressMessage string `json:"progress_message"`
 }
 
 // Pass2TranslationRequest is an internal DTO representing a single translation unit.
 // It is no longer exposed through the slice boundary but kept for internal processing.
 type Pass2TranslationRequest struct {
-	ID                string               `json:"id"`
-	RecordType        string               `json:"record_type"`
-	SourceText        string               `json:"source_text"`
-	Context           Pass2Context         `json:"context"`
-	Index             *int                 `json:"index,omitempty"`
-	ReferenceTerms    []Pass2ReferenceTerm `json:"reference_terms,omitempty"`
-	EditorID          *string              `json:"editor_id,omitempty"`
-	ParentID          *string              `json:"parent_id,omitempty"`
-	ParentEditorID    *string              `json:"parent_editor_id,omitempty"`
-	ForcedTranslation *string              `json:"forced_translation,omitempty"`
-	SourcePlugin      string               `json:"source_plugin"`
-	SourceFile        string               `json:"source_file"`
-	MaxTokens         *int                 `json:"max_tokens,omitempty"`
+	ID         string       `json:"id"`
+	RecordType string       `json:"record_type"`
+	SourceText string       `json:"source_text"`
+	Context    Pass2Context `json:"context"`
+	Index      *int         `json:"index,omitempty"`
+	ChunkCount int          `json:"chunk_count,omitempty"`
+	// PreviousChunkTranslation is the translation of chunk Index-1 of the same book.
+	PreviousChunkTranslation *string              `json:"previous_chunk_translation,omitempty"`
+	ReferenceTerms           []Pass2ReferenceTerm `json:"reference_terms,omitempty"`
+	EditorID                 *string              `json:"editor_id,omitempty"`
+	ParentID                 *string              `json:"parent_id,omitempty"`
+	ParentEditorID           *string              `json:"parent_editor_id,omitempty"`
+	ForcedTranslation        *string              `json:"forced_translation,omitempty"`
+	SourcePlugin             string               `json:"source_plugin"`
+	SourceFile               string               `json:"source_file"`
+	MaxTokens                *int                 `json:"max_tokens,omitempty"`
 }
 
 // Pass2Context holds contextual information needed for high-quality translation.
 type Pass2Context struct {
 	PreviousLine    *string              `json:"previous_line,omitempty"`
+	PreviousLines   []Pass2DialogueLine  `json:"previous_lines,omitempty"`
 	Speaker         *Pass2SpeakerProfile `json:"speaker,omitempty"`
 	TopicName       *string              `json:"topic_name,omitempty"`
+	PlayerText      *string              `json:"player_text,omitempty"`
 	QuestName       *string              `json:"quest_name,omitempty"`
 	QuestSummary    *string              `json:"quest_summary,omitempty"`
 	DialogueSummary *string              `json:"dialogue_summary,omitempty"`
 	ItemTypeHint    *string              `json:"item_type_hint,omitempty"`
 	ModDescription  *string              `json:"mod_description,omitempty"`
 	PlayerTone      *string              `json:"player_tone,omitempty"`
+	MemoryMatches   []Pass2MemoryMatch   `json:"memory_matches,omitempty"`
+}
+
+// Pass2DialogueLine is one prior line of the conversation, oldest first in Pass2Context.PreviousLines.
+type Pass2DialogueLine struct {
+	Speaker string `json:"speaker,omitempty"`
+	Text    string `json:"text"`
 }
 
 // Pass2SpeakerProfile represents NPC speaker attributes for translation context.
@@ -77,6 +145,14 @@ type Pass2ReferenceTerm struct {
 	OriginalJA string `json:"original_ja"`
 }
 
+// Pass2MemoryMatch is a confirmed translation of a similar source text from the translation memory.
+// Score is the source similarity between 0 and 1; 1 means the source matched exactly.
+type Pass2MemoryMatch struct {
+	SourceText     string  `json:"source_text"`
+	TranslatedText string  `json:"translated_text"`
+	Score          float64 `json:"score"`
+}
+
 // BatchConfig holds configuration for batch translation execution and file paths.
 type BatchConfig struct {
 	MaxWorkers     int     `json:"max_workers"`
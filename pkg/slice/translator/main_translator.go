@@ -0,0 +1,721 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/artifact/translationinput"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+)
+
+// mainTranslationStatusInProgress marks a book row whose chunks are only partly translated.
+// Such rows are never exported; they are continued by PrepareNextChunks.
+const mainTranslationStatusInProgress = "in_progress"
+
+// chunkMode selects which chunks of a book row preparePrompts emits.
+type chunkMode int
+
+const (
+	// chunkModeNext emits the next untranslated chunk of every row that is not completed.
+	chunkModeNext chunkMode = iota
+	// chunkModeContinue emits the next chunk of rows already in progress only.
+	chunkModeContinue
+	// chunkModeAll emits every chunk at once without previous-chunk context, for dry-run estimation.
+	chunkModeAll
+)
+
+// MainTranslatorImpl implements MainTranslator on top of the Pass 2 components.
+type MainTranslatorImpl struct {
+	inputRepo     TranslationInputRepository
+	store         TaskResultStore
+	contextEngine ContextEngine
+	promptBuilder PromptBuilder
+	tagProcessor  TagProcessor
+	bookChunker   BookChunker
+	chunkTokens   int
+	rejecter      ResponseRejecter
+	logger        *slog.Logger
+}
+
+// NewMainTranslator creates a new MainTranslatorImpl.
+func NewMainTranslator(
+	inputRepo TranslationInputRepository,
+	store TaskResultStore,
+	contextEngine ContextEngine,
+	promptBuilder PromptBuilder,
+	tagProcessor TagProcessor,
+	bookChunker BookChunker,
+	logger *slog.Logger,
+) *MainTranslatorImpl {
+	return &MainTranslatorImpl{
+		inputRepo:     inputRepo,
+		store:         store,
+		contextEngine: contextEngine,
+		promptBuilder: promptBuilder,
+		tagProcessor:  tagProcessor,
+		bookChunker:   bookChunker,
+		chunkTokens:   defaultBookChunkTokens,
+		logger:        logger.With("component", "MainTranslatorImpl"),
+	}
+}
+
+// SetResponseRejecter registers the hook told about responses that SaveResults rejects.
+func (t *MainTranslatorImpl) SetResponseRejecter(rejecter ResponseRejecter) {
+	t.rejecter = rejecter
+}
+
+// ID returns the unique identifier of the slice.
+func (t *MainTranslatorImpl) ID() string {
+	return "MainTranslation"
+}
+
+// PreparePrompts builds main translation requests and persists the running summary.
+func (t *MainTranslatorImpl) PreparePrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error) {
+	requests, forcedResults, summary, err := t.preparePrompts(ctx, taskID, options, chunkModeNext)
+	if err != nil {
+		return nil, fmt.Errorf("prepare main translation prompts task_id=%s: %w", taskID, err)
+	}
+	if len(forcedResults) > 0 {
+		if err := t.store.SaveResults(ctx, taskID, forcedResults); err != nil {
+			return nil, fmt.Errorf("save forced main translations task_id=%s: %w", taskID, err)
+		}
+	}
+	if err := t.store.UpdatePhaseSummary(ctx, summary); err != nil {
+		return nil, fmt.Errorf("persist main translation phase running summary task_id=%s: %w", taskID, err)
+	}
+	return requests, nil
+}
+
+// PrepareNextChunks builds the next chunk request of every book row left in progress by SaveResults.
+func (t *MainTranslatorImpl) PrepareNextChunks(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error) {
+	requests, _, _, err := t.preparePrompts(ctx, taskID, options, chunkModeContinue)
+	if err != nil {
+		return nil, fmt.Errorf("prepare next main translation chunks task_id=%s: %w", taskID, err)
+	}
+	return requests, nil
+}
+
+// BuildPrompts builds every request of the phase, including all book chunks, without persisting anything.
+func (t *MainTranslatorImpl) BuildPrompts(ctx context.Context, taskID string, options PhaseOptions) ([]llmio.Request, error) {
+	requests, _, _, err := t.preparePrompts(ctx, taskID, options, chunkModeAll)
+	if err != nil {
+		return nil, fmt.Errorf("build main translation prompts task_id=%s: %w", taskID, err)
+	}
+	return requests, nil
+}
+
+func (t *MainTranslatorImpl) preparePrompts(ctx context.Context, taskID string, options PhaseOptions, mode chunkMode) ([]llmio.Request, []TranslationResult, PhaseSummary, error) {
+	t.logger.InfoContext(ctx, "ENTER MainTranslatorImpl.PreparePrompts", "task_id", taskID)
+	defer t.logger.InfoContext(ctx, "EXIT MainTranslatorImpl.PreparePrompts", "task_id", taskID)
+
+	artifactInput, err := t.inputRepo.LoadMainTranslationInput(ctx, taskID)
+	if err != nil {
+		return nil, nil, PhaseSummary{}, fmt.Errorf("load main translation artifact input task_id=%s: %w", taskID, err)
+	}
+	if len(artifactInput.Entries) == 0 {
+		return nil, nil, PhaseSummary{
+			TaskID:          taskID,
+			Status:          "empty",
+			ProgressMode:    "hidden",
+			ProgressMessage: progressMessageForStatus("empty"),
+		}, nil
+	}
+
+	existing, err := t.loadResultsByRowID(ctx, taskID)
+	if err != nil {
+		return nil, nil, PhaseSummary{}, err
+	}
+
+	engineInput := toContextEngineInput(artifactInput)
+	targetCount := len(artifactInput.Entries)
+	savedCount := 0
+	forcedResults := make([]TranslationResult, 0)
+	requests := make([]llmio.Request, 0, targetCount)
+	for _, entry := range artifactInput.Entries {
+		res, ok := existing[entry.RowID]
+		if ok && res.Status == "completed" {
+			savedCount++
+			continue
+		}
+		if mode == chunkModeContinue && (!ok || res.Status != mainTranslationStatusInProgress) {
+			continue
+		}
+
+		pass2Ctx, terms, forced, err := t.contextEngine.BuildTranslationContext(ctx, toContextRecord(entry), &engineInput)
+		if err != nil {
+			return nil, nil, PhaseSummary{}, fmt.Errorf("build translation context row_id=%s: %w", entry.RowID, err)
+		}
+		if forced != nil {
+			result := newMainTranslationResult(entry)
+			result.TranslatedText = forced
+			result.Status = "completed"
+			forcedResults = append(forcedResults, result)
+			savedCount++
+			continue
+		}
+
+		chunks := t.bookChunker.Chunk(entry.SourceText, t.chunkTokens)
+		start, end := 0, len(chunks)
+		var previous *string
+		if len(chunks) > 1 && mode != chunkModeAll {
+			stored, err := t.resumableChunks(ctx, taskID, entry.RowID, chunks)
+			if err != nil {
+				return nil, nil, PhaseSummary{}, err
+			}
+			// Books are translated one chunk per round so each chunk sees the previous translation.
+			start = len(stored)
+			if start == len(chunks) {
+				start--
+			}
+			end = start + 1
+			if start > 0 {
+				previous = &stored[start-1].TranslatedText
+			}
+		}
+		for i := start; i < end; i++ {
+			chunk := chunks[i]
+			prefix, body, suffix := "", chunk, ""
+			if len(chunks) > 1 {
+				prefix, body, suffix = splitChunkSpace(chunk)
+			}
+			processedText, tags := t.tagProcessor.Preprocess(body)
+			req := Pass2TranslationRequest{
+				ID:             entry.ID,
+				RecordType:     entry.RecordType,
+				SourceText:     processedText,
+				Context:        *pass2Ctx,
+				ReferenceTerms: terms,
+				EditorID:       optionalString(entry.EditorID),
+				ParentID:       optionalString(entry.ParentID),
+				ParentEditorID: optionalString(entry.ParentEditorID),
+				SourcePlugin:   entry.SourcePlugin,
+				SourceFile:     entry.SourceFile,
+			}
+			if len(chunks) > 1 {
+				idx := i
+				req.Index = &idx
+				req.ChunkCount = len(chunks)
+				req.PreviousChunkTranslation = previous
+			}
+			systemPrompt, userPrompt, err := t.promptBuilder.Build(ctx, req)
+			if err != nil {
+				return nil, nil, PhaseSummary{}, fmt.Errorf("build main translation prompt row_id=%s chunk=%d: %w", entry.RowID, i, err)
+			}
+			if strings.TrimSpace(options.Prompt.SystemPrompt) != "" {
+				systemPrompt = options.Prompt.SystemPrompt
+			}
+			if strings.TrimSpace(options.Prompt.UserPrompt) != "" {
+				userPrompt = options.Prompt.UserPrompt + "\n\n" + userPrompt
+			}
+			requests = append(requests, llmio.Request{
+				SystemPrompt: systemPrompt,
+				UserPrompt:   userPrompt,
+				Temperature:  options.Request.Temperature,
+				Metadata: map[string]interface{}{
+					"row_id":           entry.RowID,
+					"id":               entry.ID,
+					"editor_id":        entry.EditorID,
+					"record_type":      entry.RecordType,
+					"source_text":      entry.SourceText,
+					"source_plugin":    entry.SourcePlugin,
+					"source_file":      entry.SourceFile,
+					"parent_id":        entry.ParentID,
+					"parent_editor_id": entry.ParentEditorID,
+					"tags":             tags,
+					"chunk_index":      i,
+					"chunk_count":      len(chunks),
+					"chunk_source":     chunk,
+					"chunk_prefix":     prefix,
+					"chunk_suffix":     suffix,
+				},
+			})
+		}
+	}
+
+	status := "running"
+	if len(requests) == 0 {
+		status = "completed"
+	}
+	summary := PhaseSummary{
+		TaskID:          taskID,
+		Status:          status,
+		TargetCount:     targetCount,
+		SavedCount:      savedCount,
+		ProgressMode:    progressModeForStatus(status),
+		ProgressCurrent: savedCount,
+		ProgressTotal:   targetCount,
+		ProgressMessage: progressMessageForStatus(status),
+	}
+	if status == "completed" {
+		summary.ProgressCurrent = targetCount
+	}
+	return requests, forcedResults, summary, nil
+}
+
+// SaveResults stores chunk translations, reassembles finished books and persists the final summary.
+// A book row fails as a whole when any of its chunks fails, so partial books are never exported.
+func (t *MainTranslatorImpl) SaveResults(ctx context.Context, taskID string, responses []llmio.Response) error {
+	t.logger.InfoContext(ctx, "ENTER MainTranslatorImpl.SaveResults", "task_id", taskID, "responses", len(responses))
+	defer t.logger.InfoContext(ctx, "EXIT MainTranslatorImpl.SaveResults", "task_id", taskID)
+
+	grouped := make(map[string][]llmio.Response)
+	rowOrder := make([]string, 0)
+	for _, resp := range responses {
+		rowID, _ := resp.Metadata["row_id"].(string)
+		if strings.TrimSpace(rowID) == "" {
+			t.logger.WarnContext(ctx, "main translation response missing row_id", "task_id", taskID)
+			continue
+		}
+		if _, ok := grouped[rowID]; !ok {
+			rowOrder = append(rowOrder, rowID)
+		}
+		grouped[rowID] = append(grouped[rowID], resp)
+	}
+
+	results := make([]TranslationResult, 0, len(rowOrder))
+	assembled := make([]string, 0)
+	for _, rowID := range rowOrder {
+		result, err := t.mergeRowResponses(ctx, taskID, rowID, grouped[rowID])
+		if err != nil {
+			return err
+		}
+		if result.Status == "completed" && metadataInt(grouped[rowID][0].Metadata, "chunk_count") > 1 {
+			assembled = append(assembled, rowID)
+		}
+		results = append(results, result)
+	}
+	if err := t.store.SaveResults(ctx, taskID, results); err != nil {
+		return fmt.Errorf("save main translation results task_id=%s: %w", taskID, err)
+	}
+	for _, rowID := range assembled {
+		if err := t.store.DeleteChunks(ctx, taskID, rowID); err != nil {
+			return fmt.Errorf("delete reassembled main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+		}
+	}
+
+	summary, err := t.buildFinalSummary(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := t.store.UpdatePhaseSummary(ctx, summary); err != nil {
+		return fmt.Errorf("persist main translation phase summary task_id=%s: %w", taskID, err)
+	}
+	return nil
+}
+
+// GetPhaseSummary returns the persisted main translation phase summary.
+func (t *MainTranslatorImpl) GetPhaseSummary(ctx context.Context, taskID string) (PhaseSummary, error) {
+	summary, err := t.store.GetPhaseSummary(ctx, taskID)
+	if err != nil {
+		return PhaseSummary{}, fmt.Errorf("get main translation phase summary task_id=%s: %w", taskID, err)
+	}
+	return summary, nil
+}
+
+// UpdatePhaseSummary persists a workflow-owned phase snapshot.
+func (t *MainTranslatorImpl) UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error {
+	if err := t.store.UpdatePhaseSummary(ctx, summary); err != nil {
+		return fmt.Errorf("update main translation phase summary task_id=%s: %w", summary.TaskID, err)
+	}
+	return nil
+}
+
+// ListResults returns one result per current target row; rows never translated are reported as pending.
+func (t *MainTranslatorImpl) ListResults(ctx context.Context, taskID string) ([]TranslationResult, error) {
+	artifactInput, err := t.inputRepo.LoadMainTranslationInput(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load main translation artifact input task_id=%s: %w", taskID, err)
+	}
+	existing, err := t.loadResultsByRowID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]TranslationResult, 0, len(artifactInput.Entries))
+	for _, entry := range artifactInput.Entries {
+		if res, ok := existing[entry.RowID]; ok {
+			results = append(results, res)
+			continue
+		}
+		pending := newMainTranslationResult(entry)
+		pending.Status = "pending"
+		results = append(results, pending)
+	}
+	return results, nil
+}
+
+// CarryForwardResults copies completed results onto reloaded rows so unchanged text is not sent to the LLM again.
+func (t *MainTranslatorImpl) CarryForwardResults(ctx context.Context, taskID string, carries []ResultCarry) (int, error) {
+	if len(carries) == 0 {
+		return 0, nil
+	}
+	existing, err := t.loadResultsByRowID(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+	carried := make([]TranslationResult, 0, len(carries))
+	for _, carry := range carries {
+		res, ok := existing[carry.PreviousRowID]
+		if !ok || res.Status != "completed" {
+			continue
+		}
+		if _, exists := existing[carry.RowID]; exists {
+			continue
+		}
+		res.RowID = carry.RowID
+		carried = append(carried, res)
+	}
+	if err := t.store.SaveResults(ctx, taskID, carried); err != nil {
+		return 0, fmt.Errorf("save carried main translation results task_id=%s: %w", taskID, err)
+	}
+	t.logger.InfoContext(ctx, "carried main translation results forward", "task_id", taskID, "carried", len(carried), "candidates", len(carries))
+	return len(carried), nil
+}
+
+func (t *MainTranslatorImpl) loadResultsByRowID(ctx context.Context, taskID string) (map[string]TranslationResult, error) {
+	results, err := t.store.ListResults(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("load existing main translation results task_id=%s: %w", taskID, err)
+	}
+	byRowID := make(map[string]TranslationResult, len(results))
+	for _, result := range results {
+		byRowID[result.RowID] = result
+	}
+	return byRowID, nil
+}
+
+// resumableChunks returns the stored chunks that still match the current split of the row,
+// discarding every stored chunk when the source text or the split changed since they were saved.
+func (t *MainTranslatorImpl) resumableChunks(ctx context.Context, taskID string, rowID string, chunks []string) ([]TranslationChunk, error) {
+	stored, err := t.store.ListChunks(ctx, taskID, rowID)
+	if err != nil {
+		return nil, fmt.Errorf("load main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+	}
+	for i, chunk := range stored {
+		if chunk.Index != i || i >= len(chunks) || chunk.Count != len(chunks) || chunk.SourceText != chunks[i] {
+			if err := t.store.DeleteChunks(ctx, taskID, rowID); err != nil {
+				return nil, fmt.Errorf("discard stale main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+			}
+			return nil, nil
+		}
+	}
+	return stored, nil
+}
+
+func (t *MainTranslatorImpl) mergeRowResponses(ctx context.Context, taskID string, rowID string, responses []llmio.Response) (TranslationResult, error) {
+	sort.SliceStable(responses, func(i, j int) bool {
+		return metadataInt(responses[i].Metadata, "chunk_index") < metadataInt(responses[j].Metadata, "chunk_index")
+	})
+
+	first := responses[0].Metadata
+	result := TranslationResult{
+		RowID:          rowID,
+		ID:             metadataString(first, "id"),
+		RecordType:     metadataString(first, "record_type"),
+		SourceText:     metadataString(first, "source_text"),
+		SourcePlugin:   metadataString(first, "source_plugin"),
+		SourceFile:     metadataString(first, "source_file"),
+		EditorID:       optionalString(metadataString(first, "editor_id")),
+		ParentID:       optionalString(metadataString(first, "parent_id")),
+		ParentEditorID: optionalString(metadataString(first, "parent_editor_id")),
+		Status:         "failed",
+	}
+
+	chunkCount := metadataInt(first, "chunk_count")
+	for _, resp := range responses {
+		chunkIndex := metadataInt(resp.Metadata, "chunk_index")
+		content, err := t.restoreResponse(ctx, rowID, resp)
+		if err != nil {
+			if resp.Success {
+				t.rejectResponse(ctx, rowID, resp)
+			}
+			msg := err.Error()
+			if chunkCount > 1 {
+				msg = fmt.Sprintf("chunk %d/%d: %s", chunkIndex+1, chunkCount, msg)
+			}
+			result.ErrorMessage = &msg
+			result.TagIssues = TagIssuesOf(err)
+			return result, nil
+		}
+		if chunkCount <= 1 {
+			result.TranslatedText = &content
+			result.Status = "completed"
+			return result, nil
+		}
+		if err := t.store.SaveChunk(ctx, taskID, TranslationChunk{
+			RowID:          rowID,
+			Index:          chunkIndex,
+			Count:          chunkCount,
+			SourceText:     metadataString(resp.Metadata, "chunk_source"),
+			TranslatedText: metadataString(resp.Metadata, "chunk_prefix") + content + metadataString(resp.Metadata, "chunk_suffix"),
+		}); err != nil {
+			return TranslationResult{}, fmt.Errorf("save main translation chunk row_id=%s: %w", rowID, err)
+		}
+	}
+	return t.assembleChunks(ctx, taskID, result, chunkCount)
+}
+
+// rejectResponse reports a response that came back but failed validation, so its output is not reused.
+func (t *MainTranslatorImpl) rejectResponse(ctx context.Context, rowID string, resp llmio.Response) {
+	if t.rejecter == nil {
+		return
+	}
+	if err := t.rejecter.RejectResponse(ctx, resp); err != nil {
+		t.logger.WarnContext(ctx, "failed to reject main translation response", "row_id", rowID, "error", err)
+	}
+}
+
+// restoreResponse validates one chunk response and restores its protected tokens.
+func (t *MainTranslatorImpl) restoreResponse(ctx context.Context, rowID string, resp llmio.Response) (string, error) {
+	if !resp.Success {
+		msg := strings.TrimSpace(resp.Error)
+		if msg == "" {
+			msg = "llm request failed"
+		}
+		return "", errors.New(msg)
+	}
+	content := strings.TrimSpace(resp.Content)
+	if content == "" {
+		return "", errors.New("empty llm response")
+	}
+	tags := metadataTags(resp.Metadata)
+	if len(tags) > 0 {
+		if err := t.tagProcessor.Validate(content, tags); err != nil {
+			t.logger.WarnContext(ctx, "main translation tag validation failed", "row_id", rowID, "error", err)
+			return "", err
+		}
+		content = t.tagProcessor.Postprocess(content, tags)
+	}
+	return content, nil
+}
+
+// assembleChunks joins the stored chunks of a book once all of them are translated.
+// Until then the row stays in progress without translated text.
+func (t *MainTranslatorImpl) assembleChunks(ctx context.Context, taskID string, result TranslationResult, chunkCount int) (TranslationResult, error) {
+	stored, err := t.store.ListChunks(ctx, taskID, result.RowID)
+	if err != nil {
+		return TranslationResult{}, fmt.Errorf("load main translation chunks task_id=%s row_id=%s: %w", taskID, result.RowID, err)
+	}
+	if len(stored) < chunkCount {
+		result.Status = mainTranslationStatusInProgress
+		return result, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < chunkCount; i++ {
+		if stored[i].Index != i {
+			result.Status = mainTranslationStatusInProgress
+			return result, nil
+		}
+		sb.WriteString(stored[i].TranslatedText)
+	}
+	translated := sb.String()
+	result.TranslatedText = &translated
+	result.Status = "completed"
+	return result, nil
+}
+
+func (t *MainTranslatorImpl) buildFinalSummary(ctx context.Context, taskID string) (PhaseSummary, error) {
+	artifactInput, err := t.inputRepo.LoadMainTranslationInput(ctx, taskID)
+	if err != nil {
+		return PhaseSummary{}, fmt.Errorf("load main translation artifact input task_id=%s: %w", taskID, err)
+	}
+	existing, err := t.loadResultsByRowID(ctx, taskID)
+	if err != nil {
+		return PhaseSummary{}, err
+	}
+
+	targetCount := len(artifactInput.Entries)
+	savedCount := 0
+	failedCount := 0
+	for _, entry := range artifactInput.Entries {
+		res, ok := existing[entry.RowID]
+		if !ok {
+			continue
+		}
+		switch res.Status {
+		case "completed":
+			savedCount++
+		case "failed":
+			failedCount++
+		}
+	}
+
+	status := "completed"
+	if targetCount == 0 {
+		status = "empty"
+	} else if failedCount > 0 || savedCount < targetCount {
+		status = "completed_partial"
+	}
+	return PhaseSummary{
+		TaskID:          taskID,
+		Status:          status,
+		TargetCount:     targetCount,
+		SavedCount:      savedCount,
+		FailedCount:     failedCount,
+		ProgressMode:    progressModeForStatus(status),
+		ProgressCurrent: targetCount,
+		ProgressTotal:   targetCount,
+		ProgressMessage: progressMessageForStatus(status),
+	}, nil
+}
+
+func toContextEngineInput(input translationinput.MainTranslationInput) ContextEngineInput {
+	npcs := make(map[string]ContextNPC, len(input.NPCs))
+	for key, npc := range input.NPCs {
+		npcs[key] = ContextNPC{
+			ID:        npc.SpeakerID,
+			EditorID:  optionalString(npc.EditorID),
+			Type:      "NPC_",
+			Name:      npc.Name,
+			Race:      npc.Race,
+			Gender:    npc.Sex,
+			VoiceType: npc.VoiceType,
+		}
+	}
+	dialogues := make([]ContextDialogue, 0)
+	for _, entry := range input.Entries {
+		if dialogue, ok := toContextRecord(entry).(ContextDialogue); ok {
+			dialogues = append(dialogues, dialogue)
+		}
+	}
+	return ContextEngineInput{NPCs: npcs, Dialogues: dialogues}
+}
+
+func toContextRecord(entry translationinput.MainTranslationEntry) any {
+	text := entry.SourceText
+	switch entry.Section {
+	case "dialogue_response":
+		return ContextDialogue{
+			ID:               entry.ID,
+			EditorID:         optionalString(entry.EditorID),
+			Type:             entry.RecordType,
+			SpeakerID:        optionalString(entry.SpeakerID),
+			Text:             &text,
+			QuestID:          optionalString(entry.QuestID),
+			IsServicesBranch: entry.IsServicesBranch,
+			Order:            entry.Order,
+			GroupID:          optionalString(entry.ParentID),
+			PreviousID:       optionalString(entry.PreviousID),
+			TopicText:        optionalString(entry.TopicText),
+			PlayerText:       optionalString(entry.PlayerText),
+			SourcePlugin:     entry.SourcePlugin,
+		}
+	case "quest_stage":
+		return ContextQuestStage{
+			StageIndex:     entry.Order,
+			Type:           entry.RecordType,
+			Text:           text,
+			ParentID:       entry.ParentID,
+			ParentEditorID: entry.ParentEditorID,
+		}
+	case "quest_objective":
+		return ContextQuestObjective{
+			Index:          strconv.Itoa(entry.Order),
+			Type:           entry.RecordType,
+			Text:           text,
+			ParentID:       entry.ParentID,
+			ParentEditorID: entry.ParentEditorID,
+		}
+	}
+
+	item := ContextItem{
+		ID:       entry.ID,
+		EditorID: optionalString(entry.EditorID),
+		Type:     entry.RecordType,
+		TypeHint: optionalString(entry.TypeHint),
+	}
+	if strings.HasSuffix(entry.RecordType, " FULL") {
+		item.Name = &text
+	} else {
+		item.Text = &text
+	}
+	return item
+}
+
+func newMainTranslationResult(entry translationinput.MainTranslationEntry) TranslationResult {
+	return TranslationResult{
+		RowID:          entry.RowID,
+		ID:             entry.ID,
+		RecordType:     entry.RecordType,
+		SourceText:     entry.SourceText,
+		SourcePlugin:   entry.SourcePlugin,
+		SourceFile:     entry.SourceFile,
+		EditorID:       optionalString(entry.EditorID),
+		ParentID:       optionalString(entry.ParentID),
+		ParentEditorID: optionalString(entry.ParentEditorID),
+	}
+}
+
+func optionalString(value string) *string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func metadataString(metadata map[string]interface{}, key string) string {
+	value, _ := metadata[key].(string)
+	return value
+}
+
+func metadataInt(metadata map[string]interface{}, key string) int {
+	switch v := metadata[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	default:
+		return 0
+	}
+}
+
+func metadataTags(metadata map[string]interface{}) map[string]string {
+	switch v := metadata["tags"].(type) {
+	case map[string]string:
+		return v
+	case map[string]interface{}:
+		tags := make(map[string]string, len(v))
+		for key, raw := range v {
+			if s, ok := raw.(string); ok {
+				tags[key] = s
+			}
+		}
+		return tags
+	default:
+		return nil
+	}
+}
+
+func progressModeForStatus(status string) string {
+	if status == "running" {
+		return "indeterminate"
+	}
+	return "hidden"
+}
+
+func progressMessageForStatus(status string) string {
+	switch status {
+	case "running":
+		return "本文翻訳を実行中"
+	case "completed":
+		return "本文翻訳完了"
+	case "completed_partial":
+		return "本文翻訳完了（一部失敗あり）"
+	case "run_error":
+		return "本文翻訳の実行に失敗しました"
+	case "empty":
+		return "本文翻訳の対象はありません"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,386 @@
+package translator
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/artifact/translationinput"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	_ "modernc.org/sqlite"
+)
+
+type stubMainTranslationInputRepository struct {
+	input translationinput.MainTranslationInput
+}
+
+func (s *stubMainTranslationInputRepository) LoadMainTranslationInput(_ context.Context, taskID string) (translationinput.MainTranslationInput, error) {
+	input := s.input
+	input.TaskID = taskID
+	return input, nil
+}
+
+func newTestMainTranslator(t *testing.T, dsn string, input translationinput.MainTranslationInput) (*MainTranslatorImpl, *SQLiteTaskResultStore) {
+	t.Helper()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := NewSQLiteTaskResultStore(db, logger)
+	if err := store.InitSchema(context.Background()); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+	translator := NewMainTranslator(
+		&stubMainTranslationInputRepository{input: input},
+		store,
+		NewContextEngine(NewDefaultToneResolver(), NewPersonaLookupAdapter(), NewTermLookupAdapter(), NewSummaryLookupAdapter(), NewTranslationMemoryLookupAdapter()),
+		NewDefaultPromptBuilder(),
+		NewTagProcessor(),
+		NewBookChunker(),
+		logger,
+	)
+	return translator, store
+}
+
+func buildMainTranslationTestInput() translationinput.MainTranslationInput {
+	return translationinput.MainTranslationInput{
+		NPCs: map[string]translationinput.MainTranslationNPC{
+			"npc_1": {SpeakerID: "npc_1", Name: "Lydia", Race: "NordRace", Sex: "female", VoiceType: "FemaleNord"},
+		},
+		Entries: []translationinput.MainTranslationEntry{
+			{
+				RowID:        "dialogue_response:1",
+				Section:      "dialogue_response",
+				ID:           "info-1",
+				RecordType:   "INFO NAM1",
+				SourceText:   "I am sworn to carry your <b>burdens</b>.",
+				SourcePlugin: "Skyrim.esm",
+				SpeakerID:    "npc_1",
+			},
+			{
+				RowID:          "quest_stage:1",
+				Section:        "quest_stage",
+				ID:             "quest-1",
+				RecordType:     "QUST CNAM",
+				SourceText:     "Talk to the Jarl.",
+				SourcePlugin:   "Skyrim.esm",
+				ParentID:       "quest-1",
+				ParentEditorID: "MQ101",
+			},
+		},
+	}
+}
+
+func TestMainTranslator_PreparePrompts_BuildsRequestsPerRow(t *testing.T) {
+	translator, store := newTestMainTranslator(t, "file:main_translation_prepare?mode=memory&cache=shared", buildMainTranslationTestInput())
+	ctx := context.Background()
+
+	requests, err := translator.PreparePrompts(ctx, "task-1", PhaseOptions{
+		Request: RequestConfig{Model: "test-model", Temperature: 0.3},
+		Prompt:  PromptConfig{UserPrompt: "丁寧に訳してください", SystemPrompt: "あなたはスカイリム専門の翻訳者です。"},
+	})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[0].SystemPrompt != "あなたはスカイリム専門の翻訳者です。" {
+		t.Fatalf("expected user system prompt to be honored, got %q", requests[0].SystemPrompt)
+	}
+	if !strings.HasPrefix(requests[0].UserPrompt, "丁寧に訳してください\n\n") {
+		t.Fatalf("expected user prompt to be prepended, got %q", requests[0].UserPrompt)
+	}
+	if requests[0].Metadata["row_id"] != "dialogue_response:1" {
+		t.Fatalf("unexpected row_id metadata: %v", requests[0].Metadata["row_id"])
+	}
+	if requests[0].Temperature != 0.3 {
+		t.Fatalf("expected temperature to be forwarded, got %v", requests[0].Temperature)
+	}
+	tags := metadataTags(requests[0].Metadata)
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 protected tags, got %v", tags)
+	}
+
+	summary, err := store.GetPhaseSummary(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("GetPhaseSummary failed: %v", err)
+	}
+	if summary.Status != "running" || summary.TargetCount != 2 || summary.SavedCount != 0 {
+		t.Fatalf("unexpected running summary: %+v", summary)
+	}
+
+	results, err := translator.ListResults(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("ListResults failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Status != "pending" || results[1].Status != "pending" {
+		t.Fatalf("expected untranslated rows to be listed as pending, got %+v", results)
+	}
+}
+
+func TestMainTranslator_SaveResults_RestoresTagsAndRetriesOnlyFailedRows(t *testing.T) {
+	translator, store := newTestMainTranslator(t, "file:main_translation_save?mode=memory&cache=shared", buildMainTranslationTestInput())
+	ctx := context.Background()
+
+	requests, err := translator.PreparePrompts(ctx, "task-1", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	responses := []llmio.Response{
+		{Content: "あなたの[TAG_0]重荷[TAG_1]を背負います。", Success: true, Metadata: requests[0].Metadata},
+		{Success: false, Error: "timeout", Metadata: requests[1].Metadata},
+	}
+	if err := translator.SaveResults(ctx, "task-1", responses); err != nil {
+		t.Fatalf("SaveResults failed: %v", err)
+	}
+
+	summary, err := store.GetPhaseSummary(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("GetPhaseSummary failed: %v", err)
+	}
+	if summary.Status != "completed_partial" || summary.SavedCount != 1 || summary.FailedCount != 1 {
+		t.Fatalf("unexpected final summary: %+v", summary)
+	}
+
+	results, err := translator.ListResults(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("ListResults failed: %v", err)
+	}
+	byRow := make(map[string]TranslationResult, len(results))
+	for _, result := range results {
+		byRow[result.RowID] = result
+	}
+	dialogue := byRow["dialogue_response:1"]
+	if dialogue.Status != "completed" || dialogue.TranslatedText == nil || *dialogue.TranslatedText != "あなたの<b>重荷</b>を背負います。" {
+		t.Fatalf("unexpected dialogue result: %+v", dialogue)
+	}
+	if byRow["quest_stage:1"].Status != "failed" {
+		t.Fatalf("expected quest stage to be failed, got %+v", byRow["quest_stage:1"])
+	}
+
+	retryRequests, err := translator.PreparePrompts(ctx, "task-1", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("retry PreparePrompts failed: %v", err)
+	}
+	if len(retryRequests) != 1 || retryRequests[0].Metadata["row_id"] != "quest_stage:1" {
+		t.Fatalf("expected only failed row to be retried, got %+v", retryRequests)
+	}
+}
+
+func TestMainTranslator_SaveResults_PersistsTagIssues(t *testing.T) {
+	translator, _ := newTestMainTranslator(t, "file:main_translation_tag_issues?mode=memory&cache=shared", buildMainTranslationTestInput())
+	ctx := context.Background()
+
+	requests, err := translator.PreparePrompts(ctx, "task-1", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	responses := []llmio.Response{
+		{Content: "あなたの重荷[TAG_1]を背負います。", Success: true, Metadata: requests[0].Metadata},
+		{Content: "首長と話す。", Success: true, Metadata: requests[1].Metadata},
+	}
+	if err := translator.SaveResults(ctx, "task-1", responses); err != nil {
+		t.Fatalf("SaveResults failed: %v", err)
+	}
+
+	results, err := translator.ListResults(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("ListResults failed: %v", err)
+	}
+	for _, result := range results {
+		if result.RowID != "dialogue_response:1" {
+			if len(result.TagIssues) != 0 {
+				t.Fatalf("expected no tag issues for %s, got %+v", result.RowID, result.TagIssues)
+			}
+			continue
+		}
+		want := TagIssue{Kind: TagIssueMissing, Class: TagClassMarkup, Placeholder: "[TAG_0]", Token: "<b>"}
+		if result.Status != "failed" || len(result.TagIssues) != 1 || result.TagIssues[0] != want {
+			t.Fatalf("expected the missing tag to be persisted as a structured issue, got %+v", result)
+		}
+	}
+}
+
+type recordingRejecter struct {
+	rejected []llmio.Response
+}
+
+func (r *recordingRejecter) RejectResponse(_ context.Context, resp llmio.Response) error {
+	r.rejected = append(r.rejected, resp)
+	return nil
+}
+
+func TestMainTranslator_SaveResults_RejectsResponsesThatFailValidation(t *testing.T) {
+	translator, _ := newTestMainTranslator(t, "file:main_translation_reject?mode=memory&cache=shared", buildMainTranslationTestInput())
+	rejecter := &recordingRejecter{}
+	translator.SetResponseRejecter(rejecter)
+	ctx := context.Background()
+
+	requests, err := translator.PreparePrompts(ctx, "task-1", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	responses := []llmio.Response{
+		{Content: "あなたの重荷[TAG_1]を背負います。", Success: true, Metadata: requests[0].Metadata},
+		{Success: false, Error: "timeout", Metadata: requests[1].Metadata},
+	}
+	if err := translator.SaveResults(ctx, "task-1", responses); err != nil {
+		t.Fatalf("SaveResults failed: %v", err)
+	}
+
+	if len(rejecter.rejected) != 1 || rejecter.rejected[0].Metadata["row_id"] != "dialogue_response:1" {
+		t.Fatalf("expected only the response with broken tags to be rejected, got %+v", rejecter.rejected)
+	}
+}
+
+func TestMainTranslator_PreparePrompts_EmptyTargetsCompleteAsEmpty(t *testing.T) {
+	translator, store := newTestMainTranslator(t, "file:main_translation_empty?mode=memory&cache=shared", translationinput.MainTranslationInput{})
+	ctx := context.Background()
+
+	requests, err := translator.PreparePrompts(ctx, "task-empty", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected no requests, got %d", len(requests))
+	}
+	summary, err := store.GetPhaseSummary(ctx, "task-empty")
+	if err != nil {
+		t.Fatalf("GetPhaseSummary failed: %v", err)
+	}
+	if summary.Status != "empty" {
+		t.Fatalf("expected empty status, got %+v", summary)
+	}
+}
+
+func TestMainTranslator_CarryForwardResults_CopiesOnlyCompletedRows(t *testing.T) {
+	translator, store := newTestMainTranslator(t, "file:main_translation_carry?mode=memory&cache=shared", buildMainTranslationTestInput())
+	ctx := context.Background()
+	translated := "ジャールと話す"
+	if err := store.SaveResults(ctx, "task-carry", []TranslationResult{
+		{RowID: "quest_stage:old", ID: "quest-1", RecordType: "QUST CNAM", SourceText: "Talk to the Jarl.", TranslatedText: &translated, Status: "completed"},
+		{RowID: "dialogue_response:old", ID: "info-1", RecordType: "INFO NAM1", SourceText: "I am sworn", Status: "failed"},
+	}); err != nil {
+		t.Fatalf("seed results: %v", err)
+	}
+
+	carried, err := translator.CarryForwardResults(ctx, "task-carry", []ResultCarry{
+		{PreviousRowID: "quest_stage:old", RowID: "quest_stage:1"},
+		{PreviousRowID: "dialogue_response:old", RowID: "dialogue_response:1"},
+	})
+	if err != nil {
+		t.Fatalf("CarryForwardResults failed: %v", err)
+	}
+	if carried != 1 {
+		t.Fatalf("unexpected carried count: got=%d want=1", carried)
+	}
+
+	requests, err := translator.PreparePrompts(ctx, "task-carry", PhaseOptions{Request: RequestConfig{Model: "test-model"}})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if len(requests) != 1 || requests[0].Metadata["row_id"] != "dialogue_response:1" {
+		t.Fatalf("expected only the uncarried row to be requested, got %+v", requests)
+	}
+}
+
+func TestMainTranslator_BookChunksAreTranslatedInOrderAndReassembled(t *testing.T) {
+	translator, store := newTestMainTranslator(t, "file:main_translation_book?mode=memory&cache=shared", translationinput.MainTranslationInput{
+		Entries: []translationinput.MainTranslationEntry{
+			{
+				RowID:        "book:1",
+				Section:      "book",
+				ID:           "book-1",
+				RecordType:   "BOOK DESC",
+				SourceText:   testBookText,
+				SourcePlugin: "Skyrim.esm",
+			},
+		},
+	})
+	translator.chunkTokens = 60
+	ctx := context.Background()
+	respond := func(t *testing.T, req llmio.Request, content string) {
+		t.Helper()
+		if err := translator.SaveResults(ctx, "task-book", []llmio.Response{{Content: content, Success: true, Metadata: req.Metadata}}); err != nil {
+			t.Fatalf("SaveResults failed: %v", err)
+		}
+	}
+	bookResult := func(t *testing.T) TranslationResult {
+		t.Helper()
+		results, err := translator.ListResults(ctx, "task-book")
+		if err != nil {
+			t.Fatalf("ListResults failed: %v", err)
+		}
+		return results[0]
+	}
+
+	requests, err := translator.PreparePrompts(ctx, "task-book", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PreparePrompts failed: %v", err)
+	}
+	if len(requests) != 1 || requests[0].Metadata["chunk_index"] != 0 || requests[0].Metadata["chunk_count"] != 3 {
+		t.Fatalf("expected only the first of 3 chunks, got %+v", requests)
+	}
+	respond(t, requests[0], "アルゴニアンの侍女 第一巻\n\n第一幕は厨房で始まる。")
+	if got := bookResult(t); got.Status != mainTranslationStatusInProgress || got.TranslatedText != nil {
+		t.Fatalf("book must stay in progress without text, got %+v", got)
+	}
+
+	next, err := translator.PrepareNextChunks(ctx, "task-book", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("PrepareNextChunks failed: %v", err)
+	}
+	if len(next) != 1 || next[0].Metadata["chunk_index"] != 1 {
+		t.Fatalf("expected the second chunk, got %+v", next)
+	}
+	if !strings.Contains(next[0].UserPrompt, "前の部分の翻訳: アルゴニアンの侍女 第一巻") || !strings.Contains(next[0].UserPrompt, "全3部中の第2部") {
+		t.Fatalf("second chunk must carry the previous translation:\n%s", next[0].UserPrompt)
+	}
+
+	// A damaged chunk fails the whole book and stops the continuation.
+	respond(t, next[0], "尻尾上げ: [TAG_1]とんでもない![TAG_2] 掃除に来ただけです。")
+	failed := bookResult(t)
+	if failed.Status != "failed" || failed.TranslatedText != nil || failed.ErrorMessage == nil || !strings.HasPrefix(*failed.ErrorMessage, "chunk 2/3: ") {
+		t.Fatalf("expected the book to fail without text, got %+v", failed)
+	}
+	if stalled, _ := translator.PrepareNextChunks(ctx, "task-book", PhaseOptions{}); len(stalled) != 0 {
+		t.Fatalf("failed books must not be continued, got %+v", stalled)
+	}
+
+	// A retry resumes at the failed chunk instead of starting over.
+	retry, err := translator.PreparePrompts(ctx, "task-book", PhaseOptions{})
+	if err != nil {
+		t.Fatalf("retry PreparePrompts failed: %v", err)
+	}
+	if len(retry) != 1 || retry[0].Metadata["chunk_index"] != 1 {
+		t.Fatalf("expected the retry to resume at the second chunk, got %+v", retry)
+	}
+	respond(t, retry[0], "[TAG_0]尻尾上げ: [TAG_1]とんでもない![TAG_2] 掃除に来ただけです。\n\nクランティウス: もちろん。")
+
+	last, err := translator.PrepareNextChunks(ctx, "task-book", PhaseOptions{})
+	if err != nil || len(last) != 1 || last[0].Metadata["chunk_index"] != 2 {
+		t.Fatalf("expected the last chunk, got %+v (err=%v)", last, err)
+	}
+	respond(t, last[0], "[TAG_0]第一幕 終わり。")
+
+	done := bookResult(t)
+	want := "アルゴニアンの侍女 第一巻\n\n第一幕は厨房で始まる。" +
+		"[PageBreak]尻尾上げ: <font color='#FFFFFF'>とんでもない!</font> 掃除に来ただけです。\n\nクランティウス: もちろん。" +
+		"[PageBreak]第一幕 終わり。"
+	if done.Status != "completed" || done.TranslatedText == nil || *done.TranslatedText != want {
+		t.Fatalf("unexpected reassembled book: %+v", done)
+	}
+	if chunks, _ := store.ListChunks(ctx, "task-book", "book:1"); len(chunks) != 0 {
+		t.Fatalf("chunks must be dropped after reassembly, got %+v", chunks)
+	}
+	if remaining, _ := translator.PrepareNextChunks(ctx, "task-book", PhaseOptions{}); len(remaining) != 0 {
+		t.Fatalf("no chunk should remain, got %+v", remaining)
+	}
+}
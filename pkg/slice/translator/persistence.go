@@ -66,6 +66,7 @@ func (p *sqlitePersistence) initSchema(db *sql.DB) error {
 		stage_index INTEGER,
 		status TEXT,
 		error_message TEXT,
+		tag_issues TEXT,
 		source_plugin TEXT,
 		editor_id TEXT,
 		parent_form_id TEXT,
@@ -79,6 +80,9 @@ func (p *sqlitePersistence) initSchema(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize schema: %w", err)
 	}
+	if _, err := db.Exec(`ALTER TABLE main_translations ADD COLUMN tag_issues TEXT`); err != nil && !isDuplicateColumnError(err) {
+		return fmt.Errorf("add main_translations tag_issues column: %w", err)
+	}
 	return nil
 }
 
@@ -94,7 +98,7 @@ func (p *sqlitePersistence) LoadCachedResults(pluginName string, baseDir string)
 		return nil, fmt.Errorf("get translation database plugin=%s: %w", pluginName, err)
 	}
 
-	query := `SELECT form_id, record_type, source_text, translated_text, stage_index, status, error_message, source_plugin, editor_id, parent_form_id, parent_editor_id FROM main_translations`
+	query := `SELECT form_id, record_type, source_text, translated_text, stage_index, status, error_message, tag_issues, source_plugin, editor_id, parent_form_id, parent_editor_id FROM main_translations`
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query translations: %w", err)
@@ -105,6 +109,7 @@ func (p *sqlitePersistence) LoadCachedResults(pluginName string, baseDir string)
 	for rows.Next() {
 		var res TranslationResult
 		var stageIndex sql.NullInt64
+		var tagIssues sql.NullString
 		err := rows.Scan(
 			&res.ID,
 			&res.RecordType,
@@ -113,6 +118,7 @@ func (p *sqlitePersistence) LoadCachedResults(pluginName string, baseDir string)
 			&stageIndex,
 			&res.Status,
 			&res.ErrorMessage,
+			&tagIssues,
 			&res.SourcePlugin,
 			&res.EditorID,
 			&res.ParentID,
@@ -121,6 +127,9 @@ func (p *sqlitePersistence) LoadCachedResults(pluginName string, baseDir string)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		if res.TagIssues, err = decodeTagIssues(tagIssues); err != nil {
+			return nil, fmt.Errorf("decode tag issues form_id=%s: %w", res.ID, err)
+		}
 		if stageIndex.Valid {
 			tmp := int(stageIndex.Int64)
 			res.Index = &tmp
@@ -140,12 +149,13 @@ func (p *sqlitePersistence) Write(result TranslationResult) error {
 
 	query := `
 	INSERT INTO main_translations (
-		form_id, record_type, source_text, translated_text, stage_index, status, error_message, source_plugin, editor_id, parent_form_id, parent_editor_id, updated_at
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		form_id, record_type, source_text, translated_text, stage_index, status, error_message, tag_issues, source_plugin, editor_id, parent_form_id, parent_editor_id, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
 	ON CONFLICT(form_id, record_type, stage_index) DO UPDATE SET
 		translated_text = excluded.translated_text,
 		status = excluded.status,
 		error_message = excluded.error_message,
+		tag_issues = excluded.tag_issues,
 		updated_at = CURRENT_TIMESTAMP;
 	`
 
@@ -153,6 +163,10 @@ func (p *sqlitePersistence) Write(result TranslationResult) error {
 	if result.Index != nil {
 		stageIndex = *result.Index
 	}
+	tagIssues, err := encodeTagIssues(result.TagIssues)
+	if err != nil {
+		return fmt.Errorf("encode tag issues for %s: %w", result.ID, err)
+	}
 
 	_, err = db.Exec(query,
 		result.ID,
@@ -162,6 +176,7 @@ func (p *sqlitePersistence) Write(result TranslationResult) error {
 		stageIndex,
 		result.Status,
 		result.ErrorMessage,
+		tagIssues,
 		result.SourcePlugin,
 		result.EditorID,
 		result.ParentID,
@@ -23,8 +23,37 @@ func (b *defaultPromptBuilder) Build(ctx context.Context, req Pass2TranslationRe
 		sb.WriteString(fmt.Sprintf("Mod概要: %s\n", *req.Context.ModDescription))
 	}
 
+	writeOptional(&sb, "クエスト名", req.Context.QuestName)
+	writeOptional(&sb, "クエスト概要", req.Context.QuestSummary)
+	writeOptional(&sb, "会話の概要", req.Context.DialogueSummary)
+	writeOptional(&sb, "話題", req.Context.TopicName)
+	writeOptional(&sb, "プレイヤーの発言", req.Context.PlayerText)
+	writeOptional(&sb, "プレイヤーの口調", req.Context.PlayerTone)
+	writeOptional(&sb, "種別", req.Context.ItemTypeHint)
+
+	if req.Index != nil && req.ChunkCount > 1 {
+		sb.WriteString(fmt.Sprintf("分割翻訳: 全%d部中の第%d部です。前の部分と文体・用語を揃えてください。\n", req.ChunkCount, *req.Index+1))
+	}
+	writeOptional(&sb, "前の部分の翻訳", req.PreviousChunkTranslation)
+
+	if len(req.Context.PreviousLines) > 0 {
+		sb.WriteString("直前の会話 (古い順):\n")
+		for _, line := range req.Context.PreviousLines {
+			speaker := line.Speaker
+			if speaker == "" {
+				speaker = "不明"
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", speaker, line.Text))
+		}
+	} else {
+		writeOptional(&sb, "直前の台詞", req.Context.PreviousLine)
+	}
+
 	if req.Context.Speaker != nil {
 		sb.WriteString(fmt.Sprintf("話者: %s\n", req.Context.Speaker.Name))
+		if req.Context.Speaker.ToneInstruction != "" {
+			sb.WriteString(fmt.Sprintf("話者の口調: %s\n", req.Context.Speaker.ToneInstruction))
+		}
 		if req.Context.Speaker.PersonaText != nil {
 			sb.WriteString(fmt.Sprintf("話者の性格: %s\n", *req.Context.Speaker.PersonaText))
 		}
@@ -37,5 +66,20 @@ func (b *defaultPromptBuilder) Build(ctx context.Context, req Pass2TranslationRe
 		}
 	}
 
+	if len(req.Context.MemoryMatches) > 0 {
+		sb.WriteString("過去の類似訳 (訳語と文体の参考にしてください):\n")
+		for _, match := range req.Context.MemoryMatches {
+			sb.WriteString(fmt.Sprintf("- [類似度 %.0f%%] %s => %s\n", match.Score*100, match.SourceText, match.TranslatedText))
+		}
+	}
+
 	return systemPrompt, sb.String(), nil
 }
+
+// writeOptional writes "label: value" when value is set.
+func writeOptional(sb *strings.Builder, label string, value *string) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%s: %s\n", label, *value))
+}
@@ -104,22 +104,26 @@ func (s *translatorSlice) ProposeJobs(ctx context.Context, input TranslatorInput
 			continue
 		}
 
-		// Tag protection
-		processedText, tags := s.tagProcessor.Preprocess(*dial.Text)
-
-		// Book Chunking (if needed)
-		maxChars := 4000
+		// Book Chunking (if needed), on the raw text so that [PageBreak] boundaries are visible
+		maxTokens := defaultBookChunkTokens
 		if input.OutputConfig.MaxTokens > 0 {
-			maxChars = input.OutputConfig.MaxTokens
+			maxTokens = input.OutputConfig.MaxTokens
 		}
-		chunks := s.bookChunker.Chunk(processedText, maxChars)
+		chunks := s.bookChunker.Chunk(*dial.Text, maxTokens)
 
 		for i, chunk := range chunks {
+			prefix, body, suffix := "", chunk, ""
+			if len(chunks) > 1 {
+				prefix, body, suffix = splitChunkSpace(chunk)
+			}
+			// Tag protection
+			processedText, tags := s.tagProcessor.Preprocess(body)
+
 			// Prepare internal request DTO
 			req := Pass2TranslationRequest{
 				ID:             dial.ID,
 				RecordType:     dial.Type,
-				SourceText:     chunk,
+				SourceText:     processedText,
 				Context:        *pass2Ctx,
 				ReferenceTerms: terms,
 				EditorID:       dial.EditorID,
@@ -130,6 +134,7 @@ func (s *translatorSlice) ProposeJobs(ctx context.Context, input TranslatorInput
 			if len(chunks) > 1 {
 				idx := i
 				req.Index = &idx
+				req.ChunkCount = len(chunks)
 			}
 
 			// Phase 2: Prompt Building
@@ -151,6 +156,9 @@ func (s *translatorSlice) ProposeJobs(ctx context.Context, input TranslatorInput
 					"source_plugin": req.SourcePlugin,
 					"tags":          tags,
 					"chunk_index":   i,
+					"chunk_count":   len(chunks),
+					"chunk_prefix":  prefix,
+					"chunk_suffix":  suffix,
 					"is_chunked":    len(chunks) > 1,
 				},
 			})
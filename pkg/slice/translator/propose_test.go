@@ -50,7 +50,7 @@ func (m *mockTagProcessor) Validate(translatedText string, tagMap map[string]str
 
 type mockBookChunker struct{}
 
-func (m *mockBookChunker) Chunk(text string, maxCharsPerChunk int) []string { return []string{text} }
+func (m *mockBookChunker) Chunk(text string, maxTokensPerChunk int) []string { return []string{text} }
 
 func TestTranslatorSlice_ProposeJobs(t *testing.T) {
 	s := NewTranslatorSlice(
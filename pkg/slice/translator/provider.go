@@ -16,6 +16,7 @@ var ProviderSet = wire.NewSet(
 	NewPersonaLookupAdapter,
 	NewSummaryLookupAdapter,
 	NewTermLookupAdapter,
+	NewTranslationMemoryLookupAdapter,
 	wire.Bind(new(ResultWriter), new(*sqlitePersistence)),
 	wire.Bind(new(ResumeLoader), new(*sqlitePersistence)),
 )
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
@@ -15,6 +17,9 @@ func (s *translatorSlice) SaveResults(ctx context.Context, responses []llmio.Res
 	)
 	start := time.Now()
 
+	// Group chunk responses per record so that books are stitched back in chunk order.
+	grouped := make(map[string][]llmio.Response)
+	order := make([]string, 0)
 	for _, resp := range responses {
 		// Extract metadata
 		id, ok := resp.Metadata["id"].(string)
@@ -22,58 +27,23 @@ func (s *translatorSlice) SaveResults(ctx context.Context, responses []llmio.Res
 			slog.WarnContext(ctx, "response missing record id in metadata", "content", resp.Content)
 			continue
 		}
-
-		recordType, _ := resp.Metadata["record_type"].(string)
-		sourcePlugin, _ := resp.Metadata["source_plugin"].(string)
-		tags, _ := resp.Metadata["tags"].(map[string]string)
-		chunkIndex, _ := resp.Metadata["chunk_index"].(int)
-		isChunked, _ := resp.Metadata["is_chunked"].(bool)
-
-		// 1. Tag Restoration & Validation
-		var restoredText string
-		var status string = "completed"
-		var errMsg *string
-
-		if len(tags) > 0 {
-			// Validate LLM output didn't lose or hallucinate tags
-			if err := s.tagProcessor.Validate(resp.Content, tags); err != nil {
-				slog.WarnContext(ctx, "tag validation failed", "id", id, "error", err)
-				msg := err.Error()
-				errMsg = &msg
-				status = "failed"
-				// We still might want to save the raw content or restored content?
-				// Usually, we want to flag it for human review.
-			}
-			restoredText = s.tagProcessor.Postprocess(resp.Content, tags)
-		} else {
-			restoredText = resp.Content
-		}
-
-		// 2. Prepare Result DTO
-		result := TranslationResult{
-			ID:             id,
-			RecordType:     recordType,
-			TranslatedText: &restoredText,
-			Status:         status,
-			ErrorMessage:   errMsg,
-			SourcePlugin:   sourcePlugin,
+		if _, exists := grouped[id]; !exists {
+			order = append(order, id)
 		}
+		grouped[id] = append(grouped[id], resp)
+	}
 
-		// Handle chunking (if chunked, we might need a more complex merging logic later,
-		// but for now we store each chunk with its index)
-		if isChunked {
-			idx := chunkIndex
-			result.Index = &idx
-		}
+	for _, id := range order {
+		result := s.stitchRecord(ctx, id, grouped[id])
 
-		// 3. Write to persistent storage
+		// Write to persistent storage
 		if err := s.resultWriter.Write(result); err != nil {
 			slog.ErrorContext(ctx, "failed to write result", "id", id, "error", err)
 			return fmt.Errorf("failed to write result for %s: %w", id, err)
 		}
 	}
 
-	// 4. Finalize
+	// Finalize
 	if err := s.resultWriter.Flush(); err != nil {
 		return fmt.Errorf("failed to flush results: %w", err)
 	}
@@ -83,3 +53,75 @@ func (s *translatorSlice) SaveResults(ctx context.Context, responses []llmio.Res
 	)
 	return nil
 }
+
+// stitchRecord restores tags of every chunk and joins them in chunk order.
+// A chunked record fails as a whole, without translated text, when any chunk is missing or failed.
+func (s *translatorSlice) stitchRecord(ctx context.Context, id string, responses []llmio.Response) TranslationResult {
+	sort.SliceStable(responses, func(i, j int) bool {
+		return metadataInt(responses[i].Metadata, "chunk_index") < metadataInt(responses[j].Metadata, "chunk_index")
+	})
+	first := responses[0].Metadata
+	recordType, _ := first["record_type"].(string)
+	sourcePlugin, _ := first["source_plugin"].(string)
+	result := TranslationResult{
+		ID:           id,
+		RecordType:   recordType,
+		Status:       "completed",
+		SourcePlugin: sourcePlugin,
+	}
+
+	chunkCount := metadataInt(first, "chunk_count")
+	isChunked, _ := first["is_chunked"].(bool)
+	if !isChunked {
+		// Single records keep the restored text even when validation fails, for human review.
+		restoredText, err := s.restoreChunk(ctx, id, responses[0])
+		result.TranslatedText = &restoredText
+		if err != nil {
+			msg := err.Error()
+			result.Status = "failed"
+			result.ErrorMessage = &msg
+			result.TagIssues = TagIssuesOf(err)
+		}
+		return result
+	}
+
+	fail := func(msg string) TranslationResult {
+		result.Status = "failed"
+		result.ErrorMessage = &msg
+		return result
+	}
+	if chunkCount > 0 && len(responses) != chunkCount {
+		return fail(fmt.Sprintf("received %d of %d chunks", len(responses), chunkCount))
+	}
+	var sb strings.Builder
+	for i, resp := range responses {
+		if !resp.Success {
+			return fail(fmt.Sprintf("chunk %d/%d: %s", i+1, len(responses), resp.Error))
+		}
+		restoredText, err := s.restoreChunk(ctx, id, resp)
+		if err != nil {
+			result.TagIssues = TagIssuesOf(err)
+			return fail(fmt.Sprintf("chunk %d/%d: %s", i+1, len(responses), err.Error()))
+		}
+		prefix, _ := resp.Metadata["chunk_prefix"].(string)
+		suffix, _ := resp.Metadata["chunk_suffix"].(string)
+		sb.WriteString(prefix + strings.TrimSpace(restoredText) + suffix)
+	}
+	stitched := sb.String()
+	result.TranslatedText = &stitched
+	return result
+}
+
+// restoreChunk validates that the LLM output didn't lose or hallucinate tags and restores them.
+// The restored text is returned even when validation fails so it can be reviewed.
+func (s *translatorSlice) restoreChunk(ctx context.Context, id string, resp llmio.Response) (string, error) {
+	tags := metadataTags(resp.Metadata)
+	if len(tags) == 0 {
+		return resp.Content, nil
+	}
+	err := s.tagProcessor.Validate(resp.Content, tags)
+	if err != nil {
+		slog.WarnContext(ctx, "tag validation failed", "id", id, "error", err)
+	}
+	return s.tagProcessor.Postprocess(resp.Content, tags), err
+}
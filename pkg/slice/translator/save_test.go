@@ -41,3 +41,74 @@ func TestTranslatorSlice_SaveResults(t *testing.T) {
 		t.Errorf("expected 1 written record, got %d", len(writer.writtenRecords))
 	}
 }
+
+func TestTranslatorSlice_SaveResults_StitchesBookChunks(t *testing.T) {
+	chunk := func(index int, content string, success bool) llmio.Response {
+		suffix := ""
+		if index == 0 {
+			suffix = "\n\n"
+		}
+		return llmio.Response{
+			Content: content,
+			Success: success,
+			Metadata: map[string]interface{}{
+				"id":           "book_1",
+				"record_type":  "BOOK DESC",
+				"chunk_index":  index,
+				"chunk_count":  2,
+				"chunk_prefix": "",
+				"chunk_suffix": suffix,
+				"is_chunked":   true,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		responses  []llmio.Response
+		wantStatus string
+		wantText   string
+	}{
+		{
+			name:       "チャンク順に結合する",
+			responses:  []llmio.Response{chunk(1, "第二部", true), chunk(0, "第一部", true)},
+			wantStatus: "completed",
+			wantText:   "第一部\n\n第二部",
+		},
+		{
+			name:       "一つでも失敗すれば本全体を失敗にする",
+			responses:  []llmio.Response{chunk(0, "第一部", true), chunk(1, "", false)},
+			wantStatus: "failed",
+		},
+		{
+			name:       "欠けたチャンクがあれば本全体を失敗にする",
+			responses:  []llmio.Response{chunk(0, "第一部", true)},
+			wantStatus: "failed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := &mockResultWriter{}
+			s := NewTranslatorSlice(&mockContextEngine{}, &mockPromptBuilder{}, &mockResumeLoader{}, writer, NewTagProcessor(), &mockBookChunker{})
+			if err := s.SaveResults(context.Background(), tc.responses); err != nil {
+				t.Fatalf("SaveResults failed: %v", err)
+			}
+			if len(writer.writtenRecords) != 1 {
+				t.Fatalf("expected one stitched record, got %+v", writer.writtenRecords)
+			}
+			got := writer.writtenRecords[0]
+			if got.Status != tc.wantStatus {
+				t.Fatalf("status = %q, want %q (%+v)", got.Status, tc.wantStatus, got)
+			}
+			if tc.wantText == "" {
+				if got.TranslatedText != nil {
+					t.Fatalf("failed books must not carry partial text, got %q", *got.TranslatedText)
+				}
+				return
+			}
+			if got.TranslatedText == nil || *got.TranslatedText != tc.wantText {
+				t.Fatalf("unexpected stitched text: %+v", got)
+			}
+		})
+	}
+}
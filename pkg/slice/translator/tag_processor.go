@@ -1,6 +1,7 @@
 package translator
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -9,37 +10,99 @@ import (
 )
 
 var (
-	// regex to match HTML-like tags: <...>
-	tagRegex = regexp.MustCompile(`<[^>]+>`)
 	// regex to match placeholders: [TAG_N]
 	placeholderRegex = regexp.MustCompile(`\[TAG_(\d+)\]`)
 )
 
-type tagProcessor struct{}
+// Token classes protected by the default Skyrim rule set.
+const (
+	TagClassAlias     = "alias"
+	TagClassGlobal    = "global"
+	TagClassEffect    = "effect"
+	TagClassMarkup    = "markup"
+	TagClassPrintf    = "printf"
+	TagClassMCM       = "mcm"
+	TagClassPageBreak = "pagebreak"
+	TagClassNewline   = "newline"
+)
+
+// TagRule describes one class of tokens that must survive translation byte-for-byte.
+// Rules are evaluated in order, so specific rules must precede generic ones.
+type TagRule struct {
+	Class   string
+	Pattern *regexp.Regexp
+	// CheckOrder reports tokens of this class whose relative order changed.
+	// Leave it false for tokens that translation may legitimately reorder.
+	CheckOrder bool
+}
 
-// NewTagProcessor creates a new TagProcessor instance.
+// DefaultTagRules returns the Skyrim rule set used by NewTagProcessor.
+func DefaultTagRules() []TagRule {
+	return []TagRule{
+		{Class: TagClassAlias, Pattern: regexp.MustCompile(`(?i)<Alias(?:\.[A-Za-z]+)?=[^<>]+>`)},
+		{Class: TagClassGlobal, Pattern: regexp.MustCompile(`(?i)<Global=[^<>]+>`)},
+		{Class: TagClassEffect, Pattern: regexp.MustCompile(`(?i)<(?:mag|dur|area)>`)},
+		// Markup may move with the words it wraps when a translation reorders the sentence.
+		{Class: TagClassMarkup, Pattern: regexp.MustCompile(`<[^<>]+>`)},
+		// Positional printf arguments break when reordered. The space flag is omitted
+		// so that prose such as "50% damage" is not mistaken for "% d".
+		{Class: TagClassPrintf, Pattern: regexp.MustCompile(`%[-+0#]*\d*(?:\.\d+)?[sdfiuxXeEgGc]`), CheckOrder: true},
+		{Class: TagClassMCM, Pattern: regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*`)},
+		{Class: TagClassPageBreak, Pattern: regexp.MustCompile(`(?i)\[PageBreak\]`)},
+		{Class: TagClassNewline, Pattern: regexp.MustCompile(`\\n`)},
+	}
+}
+
+type tagProcessor struct {
+	rules []TagRule
+	// combined matches every rule in a single pass.
+	combined *regexp.Regexp
+}
+
+// NewTagProcessor creates a new TagProcessor instance with the default Skyrim rules.
 func NewTagProcessor() TagProcessor {
-	return &tagProcessor{}
+	return NewTagProcessorWithRules(DefaultTagRules()...)
+}
+
+// NewTagProcessorWithRules creates a TagProcessor protecting the given token classes.
+func NewTagProcessorWithRules(rules ...TagRule) TagProcessor {
+	p := &tagProcessor{rules: rules}
+	if len(rules) == 0 {
+		return p
+	}
+	parts := make([]string, len(rules))
+	for i, rule := range rules {
+		parts[i] = "(?:" + rule.Pattern.String() + ")"
+	}
+	// Go's alternation is leftmost-first, so earlier rules win at the same offset.
+	p.combined = regexp.MustCompile(strings.Join(parts, "|"))
+	return p
 }
 
 // Preprocess identifies tags and replaces them with placeholders [TAG_N].
 func (p *tagProcessor) Preprocess(text string) (string, map[string]string) {
 	tagMap := make(map[string]string)
-	matches := tagRegex.FindAllString(text, -1)
+	if p.combined == nil {
+		return text, tagMap
+	}
+	matches := p.combined.FindAllStringIndex(text, -1)
 	if len(matches) == 0 {
 		return text, tagMap
 	}
 
 	// Use a unique placeholder for every tag encounter to maintain position tracking.
-	count := 0
-	result := tagRegex.ReplaceAllStringFunc(text, func(match string) string {
+	var sb strings.Builder
+	last := 0
+	for count, loc := range matches {
 		placeholder := fmt.Sprintf("[TAG_%d]", count)
-		tagMap[placeholder] = match
-		count++
-		return placeholder
-	})
+		tagMap[placeholder] = text[loc[0]:loc[1]]
+		sb.WriteString(text[last:loc[0]])
+		sb.WriteString(placeholder)
+		last = loc[1]
+	}
+	sb.WriteString(text[last:])
 
-	return result, tagMap
+	return sb.String(), tagMap
 }
 
 // Postprocess restores tags from placeholders.
@@ -63,29 +126,184 @@ func (p *tagProcessor) Postprocess(text string, tagMap map[string]string) string
 	return result
 }
 
-// Validate checks if all placeholders in the original are present in the translated text.
+// TagIssueKind identifies how a protected token was damaged by translation.
+type TagIssueKind string
+
+const (
+	TagIssueMissing      TagIssueKind = "missing"
+	TagIssueHallucinated TagIssueKind = "hallucinated"
+	TagIssueDuplicated   TagIssueKind = "duplicated"
+	TagIssueReordered    TagIssueKind = "reordered"
+)
+
+// TagIssue reports one protected token that was lost, invented, duplicated or moved.
+type TagIssue struct {
+	Kind        TagIssueKind `json:"kind"`
+	Class       string       `json:"class"`
+	Placeholder string       `json:"placeholder"`
+	Token       string       `json:"token,omitempty"`
+}
+
+// TagValidationError is returned by Validate with every issue found in the translation.
+type TagValidationError struct {
+	Issues []TagIssue `json:"issues"`
+}
+
+// TagIssuesOf returns the structured issues carried by a Validate error, or nil for any other error.
+func TagIssuesOf(err error) []TagIssue {
+	var validationErr *TagValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	return validationErr.Issues
+}
+
+// IssuesOf returns the issues of the given kind.
+func (e *TagValidationError) IssuesOf(kind TagIssueKind) []TagIssue {
+	var issues []TagIssue
+	for _, issue := range e.Issues {
+		if issue.Kind == kind {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (e *TagValidationError) Error() string {
+	var parts []string
+	if missing := e.IssuesOf(TagIssueMissing); len(missing) > 0 {
+		parts = append(parts, "missing placeholders in translation: "+formatTagIssues(missing))
+	}
+	if hallucinated := e.IssuesOf(TagIssueHallucinated); len(hallucinated) > 0 {
+		parts = append(parts, "hallucinated placeholder found: "+formatTagIssues(hallucinated))
+	}
+	if duplicated := e.IssuesOf(TagIssueDuplicated); len(duplicated) > 0 {
+		parts = append(parts, "duplicated placeholders in translation: "+formatTagIssues(duplicated))
+	}
+	if reordered := e.IssuesOf(TagIssueReordered); len(reordered) > 0 {
+		parts = append(parts, "reordered placeholders in translation: "+formatTagIssues(reordered))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatTagIssues(issues []TagIssue) string {
+	formatted := make([]string, len(issues))
+	for i, issue := range issues {
+		if issue.Token == "" {
+			formatted[i] = issue.Placeholder
+			continue
+		}
+		formatted[i] = fmt.Sprintf("%s (%s %s)", issue.Placeholder, issue.Class, issue.Token)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// Validate checks per token class that every placeholder appears exactly once,
+// that no unknown placeholder was introduced, and that order-checked classes keep their order.
+// A non-nil error is always a *TagValidationError.
 func (p *tagProcessor) Validate(translatedText string, tagMap map[string]string) error {
-	missing := []string{}
+	var issues []TagIssue
+
+	counts := make(map[string]int, len(tagMap))
+	var seen []string
+	for _, m := range placeholderRegex.FindAllString(translatedText, -1) {
+		original, ok := tagMap[m]
+		if !ok {
+			issues = append(issues, TagIssue{Kind: TagIssueHallucinated, Class: "unknown", Placeholder: m})
+			continue
+		}
+		counts[m]++
+		if counts[m] == 2 {
+			issues = append(issues, TagIssue{Kind: TagIssueDuplicated, Class: p.classify(original), Placeholder: m, Token: original})
+		}
+		if counts[m] == 1 {
+			seen = append(seen, m)
+		}
+	}
+
+	expected := make([]string, 0, len(tagMap))
 	for k := range tagMap {
-		if !strings.Contains(translatedText, k) {
-			missing = append(missing, k)
+		expected = append(expected, k)
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		return placeholderIndex(expected[i]) < placeholderIndex(expected[j])
+	})
+	for _, k := range expected {
+		if counts[k] == 0 {
+			issues = append(issues, TagIssue{Kind: TagIssueMissing, Class: p.classify(tagMap[k]), Placeholder: k, Token: tagMap[k]})
 		}
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing placeholders in translation: %s", strings.Join(missing, ", "))
+	issues = append(issues, p.reordered(expected, seen, tagMap)...)
+
+	if len(issues) > 0 {
+		return &TagValidationError{Issues: issues}
 	}
+	return nil
+}
 
-	// Check for hallucinations (placeholders that were not in tagMap)
-	matches := placeholderRegex.FindAllStringSubmatch(translatedText, -1)
-	for _, m := range matches {
-		placeholder := m[0]
-		if _, ok := tagMap[placeholder]; !ok {
-			return fmt.Errorf("hallucinated placeholder found: %s", placeholder)
+// reordered compares, per order-checked class, the token sequence of the source with the
+// sequence in the translation. Identical tokens swapping places are not reported.
+func (p *tagProcessor) reordered(expected, seen []string, tagMap map[string]string) []TagIssue {
+	byClass := func(placeholders []string) map[string][]string {
+		grouped := make(map[string][]string)
+		for _, k := range placeholders {
+			class := p.classify(tagMap[k])
+			if p.checksOrder(class) {
+				grouped[class] = append(grouped[class], k)
+			}
 		}
+		return grouped
 	}
+	want := byClass(expected)
+	got := byClass(seen)
 
-	return nil
+	classes := make([]string, 0, len(want))
+	for class := range want {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	var issues []TagIssue
+	for _, class := range classes {
+		wantSeq, gotSeq := want[class], got[class]
+		// Missing tokens are reported separately; compare only what survived.
+		present := make(map[string]bool, len(gotSeq))
+		for _, k := range gotSeq {
+			present[k] = true
+		}
+		filtered := wantSeq[:0:0]
+		for _, k := range wantSeq {
+			if present[k] {
+				filtered = append(filtered, k)
+			}
+		}
+		for i := range gotSeq {
+			if tagMap[gotSeq[i]] != tagMap[filtered[i]] {
+				issues = append(issues, TagIssue{Kind: TagIssueReordered, Class: class, Placeholder: gotSeq[i], Token: tagMap[gotSeq[i]]})
+			}
+		}
+	}
+	return issues
+}
+
+// classify returns the class of the first rule matching the whole token.
+func (p *tagProcessor) classify(token string) string {
+	for _, rule := range p.rules {
+		if loc := rule.Pattern.FindStringIndex(token); loc != nil && loc[0] == 0 && loc[1] == len(token) {
+			return rule.Class
+		}
+	}
+	return "unknown"
+}
+
+func (p *tagProcessor) checksOrder(class string) bool {
+	for _, rule := range p.rules {
+		if rule.Class == class {
+			return rule.CheckOrder
+		}
+	}
+	return false
 }
 
 func placeholderIndex(key string) int {
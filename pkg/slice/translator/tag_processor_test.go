@@ -0,0 +1,117 @@
+package translator
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestTagProcessor_PreprocessProtectsSkyrimTokens(t *testing.T) {
+	p := NewTagProcessor()
+	source := `<Alias=Player>, take <font color='#FF0000'><mag></font> gold from %s in %.0f days.\n$MCM_Title[PageBreak]50% damage <Global=GameDaysPassed>`
+
+	processed, tags := p.Preprocess(source)
+
+	want := `[TAG_0], take [TAG_1][TAG_2][TAG_3] gold from [TAG_4] in [TAG_5] days.[TAG_6][TAG_7][TAG_8]50% damage [TAG_9]`
+	if processed != want {
+		t.Fatalf("Preprocess() = %q, want %q", processed, want)
+	}
+	classes := map[string]string{
+		"[TAG_0]": TagClassAlias,
+		"[TAG_1]": TagClassMarkup,
+		"[TAG_2]": TagClassEffect,
+		"[TAG_4]": TagClassPrintf,
+		"[TAG_5]": TagClassPrintf,
+		"[TAG_6]": TagClassNewline,
+		"[TAG_7]": TagClassMCM,
+		"[TAG_8]": TagClassPageBreak,
+		"[TAG_9]": TagClassGlobal,
+	}
+	impl := p.(*tagProcessor)
+	for placeholder, class := range classes {
+		if got := impl.classify(tags[placeholder]); got != class {
+			t.Errorf("classify(%q) = %q, want %q", tags[placeholder], got, class)
+		}
+	}
+	if restored := p.Postprocess(processed, tags); restored != source {
+		t.Fatalf("Postprocess() must restore the source byte-for-byte, got %q", restored)
+	}
+}
+
+func TestTagProcessor_Validate(t *testing.T) {
+	p := NewTagProcessor()
+	_, tags := p.Preprocess(`<Alias=Player> deals <mag> damage for <dur> seconds to %s with %d <b>arrows</b>.`)
+
+	tests := []struct {
+		name       string
+		translated string
+		want       []TagIssue
+	}{
+		{
+			name:       "並べ替えが自然なトークンは順序を問わない",
+			translated: `[TAG_0]は[TAG_3]に[TAG_2]秒間[TAG_1]ダメージを[TAG_4]本の[TAG_5]矢[TAG_6]で与える。`,
+		},
+		{
+			name:       "欠落したトークンを種別付きで報告する",
+			translated: `[TAG_0]は[TAG_3]に[TAG_2]秒間ダメージを[TAG_4]本の[TAG_5]矢[TAG_6]で与える。`,
+			want:       []TagIssue{{Kind: TagIssueMissing, Class: TagClassEffect, Placeholder: "[TAG_1]", Token: "<mag>"}},
+		},
+		{
+			name:       "存在しないプレースホルダを報告する",
+			translated: `[TAG_0]は[TAG_3]に[TAG_2]秒間[TAG_1]ダメージを[TAG_4]本の[TAG_5]矢[TAG_6][TAG_7]で与える。`,
+			want:       []TagIssue{{Kind: TagIssueHallucinated, Class: "unknown", Placeholder: "[TAG_7]"}},
+		},
+		{
+			name:       "重複したトークンを報告する",
+			translated: `[TAG_0]は[TAG_3]に[TAG_2]秒間[TAG_1]ダメージを[TAG_4]本の[TAG_5]矢[TAG_6]で与える。[TAG_0]`,
+			want:       []TagIssue{{Kind: TagIssueDuplicated, Class: TagClassAlias, Placeholder: "[TAG_0]", Token: "<Alias=Player>"}},
+		},
+		{
+			name:       "マークアップの順序入れ替えは報告しない",
+			translated: `[TAG_0]は[TAG_3]に[TAG_2]秒間[TAG_1]ダメージを[TAG_4]本の[TAG_6]矢[TAG_5]で与える。`,
+		},
+		{
+			name:       "printf の順序入れ替えを報告する",
+			translated: `[TAG_0]は[TAG_4]本の[TAG_5]矢[TAG_6]で[TAG_3]に[TAG_2]秒間[TAG_1]ダメージを与える。`,
+			want: []TagIssue{
+				{Kind: TagIssueReordered, Class: TagClassPrintf, Placeholder: "[TAG_4]", Token: "%d"},
+				{Kind: TagIssueReordered, Class: TagClassPrintf, Placeholder: "[TAG_3]", Token: "%s"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.Validate(tc.translated, tags)
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *TagValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected *TagValidationError, got %v", err)
+			}
+			if len(validationErr.Issues) != len(tc.want) {
+				t.Fatalf("Issues = %+v, want %+v", validationErr.Issues, tc.want)
+			}
+			for i, issue := range validationErr.Issues {
+				if issue != tc.want[i] {
+					t.Fatalf("Issues[%d] = %+v, want %+v", i, issue, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTagProcessor_CustomRules(t *testing.T) {
+	p := NewTagProcessorWithRules(TagRule{Class: "brace", Pattern: regexp.MustCompile(`\{[0-9]+\}`), CheckOrder: true})
+
+	processed, tags := p.Preprocess(`{0} <b>{1}</b>`)
+	if processed != `[TAG_0] <b>[TAG_1]</b>` || len(tags) != 2 {
+		t.Fatalf("unexpected preprocess result: %q %v", processed, tags)
+	}
+	if err := p.Validate(`[TAG_1] [TAG_0]`, tags); err == nil {
+		t.Fatal("expected reordered brace tokens to be reported")
+	}
+}
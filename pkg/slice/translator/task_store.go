@@ -0,0 +1,357 @@
+package translator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// SQLiteTaskResultStore persists task-scoped main translation results in SQLite.
+type SQLiteTaskResultStore struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// NewSQLiteTaskResultStore creates a new task-scoped result store.
+func NewSQLiteTaskResultStore(db *sql.DB, logger *slog.Logger) *SQLiteTaskResultStore {
+	return &SQLiteTaskResultStore{
+		db:     db,
+		logger: logger.With("component", "MainTranslationStore"),
+	}
+}
+
+// InitSchema creates result and phase summary tables when missing.
+func (s *SQLiteTaskResultStore) InitSchema(ctx context.Context) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS main_translation_results (
+			task_id TEXT NOT NULL,
+			row_id TEXT NOT NULL,
+			form_id TEXT NOT NULL DEFAULT '',
+			editor_id TEXT,
+			record_type TEXT NOT NULL DEFAULT '',
+			source_text TEXT NOT NULL DEFAULT '',
+			translated_text TEXT,
+			status TEXT NOT NULL,
+			error_message TEXT,
+			tag_issues TEXT,
+			source_plugin TEXT NOT NULL DEFAULT '',
+			source_file TEXT NOT NULL DEFAULT '',
+			parent_form_id TEXT,
+			parent_editor_id TEXT,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (task_id, row_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS main_translation_phase_summary (
+			task_id TEXT PRIMARY KEY,
+			status TEXT NOT NULL,
+			target_count INTEGER NOT NULL DEFAULT 0,
+			saved_count INTEGER NOT NULL DEFAULT 0,
+			failed_count INTEGER NOT NULL DEFAULT 0,
+			progress_mode TEXT NOT NULL DEFAULT 'hidden',
+			progress_current INTEGER NOT NULL DEFAULT 0,
+			progress_total INTEGER NOT NULL DEFAULT 0,
+			progress_message TEXT NOT NULL DEFAULT ''
+		);`,
+		`CREATE TABLE IF NOT EXISTS main_translation_chunks (
+			task_id TEXT NOT NULL,
+			row_id TEXT NOT NULL,
+			chunk_index INTEGER NOT NULL,
+			chunk_count INTEGER NOT NULL,
+			source_text TEXT NOT NULL DEFAULT '',
+			translated_text TEXT NOT NULL DEFAULT '',
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (task_id, row_id, chunk_index)
+		);`,
+	}
+	for _, query := range queries {
+		if _, err := s.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("init main translation schema: %w", err)
+		}
+	}
+	// Tables created before tag issues were persisted lack the column.
+	if _, err := s.db.ExecContext(ctx, `ALTER TABLE main_translation_results ADD COLUMN tag_issues TEXT`); err != nil && !isDuplicateColumnError(err) {
+		return fmt.Errorf("add main translation tag_issues column: %w", err)
+	}
+	return nil
+}
+
+// SaveResults upserts task-scoped translation results keyed by artifact row ID.
+func (s *SQLiteTaskResultStore) SaveResults(ctx context.Context, taskID string, results []TranslationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	if err := s.InitSchema(ctx); err != nil {
+		return fmt.Errorf("init main translation schema before save: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin main translation save task_id=%s: %w", taskID, err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && rollbackErr != sql.ErrTxDone {
+			s.logger.WarnContext(ctx, "rollback main translation save failed", "task_id", taskID, "error", rollbackErr)
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO main_translation_results (
+			task_id, row_id, form_id, editor_id, record_type, source_text, translated_text, status, error_message,
+			tag_issues, source_plugin, source_file, parent_form_id, parent_editor_id, updated_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(task_id, row_id) DO UPDATE SET
+			form_id = excluded.form_id,
+			editor_id = excluded.editor_id,
+			record_type = excluded.record_type,
+			source_text = excluded.source_text,
+			translated_text = excluded.translated_text,
+			status = excluded.status,
+			error_message = excluded.error_message,
+			tag_issues = excluded.tag_issues,
+			source_plugin = excluded.source_plugin,
+			source_file = excluded.source_file,
+			parent_form_id = excluded.parent_form_id,
+			parent_editor_id = excluded.parent_editor_id,
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare main translation upsert task_id=%s: %w", taskID, err)
+	}
+	defer func() {
+		if closeErr := stmt.Close(); closeErr != nil {
+			s.logger.WarnContext(ctx, "close main translation statement failed", "task_id", taskID, "error", closeErr)
+		}
+	}()
+
+	for _, result := range results {
+		tagIssues, err := encodeTagIssues(result.TagIssues)
+		if err != nil {
+			return fmt.Errorf("encode main translation tag issues task_id=%s row_id=%s: %w", taskID, result.RowID, err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			taskID,
+			result.RowID,
+			result.ID,
+			result.EditorID,
+			result.RecordType,
+			result.SourceText,
+			result.TranslatedText,
+			result.Status,
+			result.ErrorMessage,
+			tagIssues,
+			result.SourcePlugin,
+			result.SourceFile,
+			result.ParentID,
+			result.ParentEditorID,
+		); err != nil {
+			return fmt.Errorf("upsert main translation result task_id=%s row_id=%s: %w", taskID, result.RowID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit main translation save task_id=%s: %w", taskID, err)
+	}
+	return nil
+}
+
+// ListResults returns every persisted result for one task ordered by row ID.
+func (s *SQLiteTaskResultStore) ListResults(ctx context.Context, taskID string) ([]TranslationResult, error) {
+	if err := s.InitSchema(ctx); err != nil {
+		return nil, fmt.Errorf("init main translation schema before list: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT row_id, form_id, editor_id, record_type, source_text, translated_text, status, error_message,
+			tag_issues, source_plugin, source_file, parent_form_id, parent_editor_id
+		FROM main_translation_results
+		WHERE task_id = ?
+		ORDER BY row_id ASC
+	`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query main translation results task_id=%s: %w", taskID, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.logger.WarnContext(ctx, "close main translation rows failed", "task_id", taskID, "error", closeErr)
+		}
+	}()
+
+	results := make([]TranslationResult, 0)
+	for rows.Next() {
+		var result TranslationResult
+		var tagIssues sql.NullString
+		if err := rows.Scan(
+			&result.RowID,
+			&result.ID,
+			&result.EditorID,
+			&result.RecordType,
+			&result.SourceText,
+			&result.TranslatedText,
+			&result.Status,
+			&result.ErrorMessage,
+			&tagIssues,
+			&result.SourcePlugin,
+			&result.SourceFile,
+			&result.ParentID,
+			&result.ParentEditorID,
+		); err != nil {
+			return nil, fmt.Errorf("scan main translation result task_id=%s: %w", taskID, err)
+		}
+		if result.TagIssues, err = decodeTagIssues(tagIssues); err != nil {
+			return nil, fmt.Errorf("decode main translation tag issues task_id=%s row_id=%s: %w", taskID, result.RowID, err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate main translation results task_id=%s: %w", taskID, err)
+	}
+	return results, nil
+}
+
+// UpdatePhaseSummary upserts one task-scoped main translation phase summary.
+func (s *SQLiteTaskResultStore) UpdatePhaseSummary(ctx context.Context, summary PhaseSummary) error {
+	if err := s.InitSchema(ctx); err != nil {
+		return fmt.Errorf("init main translation schema before phase summary update: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO main_translation_phase_summary (
+			task_id, status, target_count, saved_count, failed_count, progress_mode, progress_current, progress_total, progress_message
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(task_id) DO UPDATE SET
+			status = excluded.status,
+			target_count = excluded.target_count,
+			saved_count = excluded.saved_count,
+			failed_count = excluded.failed_count,
+			progress_mode = excluded.progress_mode,
+			progress_current = excluded.progress_current,
+			progress_total = excluded.progress_total,
+			progress_message = excluded.progress_message
+	`, summary.TaskID, summary.Status, summary.TargetCount, summary.SavedCount, summary.FailedCount, summary.ProgressMode, summary.ProgressCurrent, summary.ProgressTotal, summary.ProgressMessage); err != nil {
+		return fmt.Errorf("upsert main translation phase summary task_id=%s: %w", summary.TaskID, err)
+	}
+	return nil
+}
+
+// GetPhaseSummary returns one task-scoped main translation phase summary.
+func (s *SQLiteTaskResultStore) GetPhaseSummary(ctx context.Context, taskID string) (PhaseSummary, error) {
+	if err := s.InitSchema(ctx); err != nil {
+		return PhaseSummary{}, fmt.Errorf("init main translation schema before phase summary read: %w", err)
+	}
+	summary := PhaseSummary{TaskID: taskID, Status: "pending", ProgressMode: "hidden"}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT status, target_count, saved_count, failed_count, progress_mode, progress_current, progress_total, progress_message
+		FROM main_translation_phase_summary
+		WHERE task_id = ?
+	`, taskID).Scan(
+		&summary.Status,
+		&summary.TargetCount,
+		&summary.SavedCount,
+		&summary.FailedCount,
+		&summary.ProgressMode,
+		&summary.ProgressCurrent,
+		&summary.ProgressTotal,
+		&summary.ProgressMessage,
+	)
+	if err == sql.ErrNoRows {
+		return summary, nil
+	}
+	if err != nil {
+		return PhaseSummary{}, fmt.Errorf("query main translation phase summary task_id=%s: %w", taskID, err)
+	}
+	return summary, nil
+}
+
+// SaveChunk upserts one translated book chunk keyed by row ID and chunk index.
+func (s *SQLiteTaskResultStore) SaveChunk(ctx context.Context, taskID string, chunk TranslationChunk) error {
+	if err := s.InitSchema(ctx); err != nil {
+		return fmt.Errorf("init main translation schema before chunk save: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO main_translation_chunks (task_id, row_id, chunk_index, chunk_count, source_text, translated_text, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(task_id, row_id, chunk_index) DO UPDATE SET
+			chunk_count = excluded.chunk_count,
+			source_text = excluded.source_text,
+			translated_text = excluded.translated_text,
+			updated_at = CURRENT_TIMESTAMP
+	`, taskID, chunk.RowID, chunk.Index, chunk.Count, chunk.SourceText, chunk.TranslatedText); err != nil {
+		return fmt.Errorf("upsert main translation chunk task_id=%s row_id=%s chunk=%d: %w", taskID, chunk.RowID, chunk.Index, err)
+	}
+	return nil
+}
+
+// ListChunks returns the translated chunks of one row ordered by chunk index.
+func (s *SQLiteTaskResultStore) ListChunks(ctx context.Context, taskID string, rowID string) ([]TranslationChunk, error) {
+	if err := s.InitSchema(ctx); err != nil {
+		return nil, fmt.Errorf("init main translation schema before chunk list: %w", err)
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT row_id, chunk_index, chunk_count, source_text, translated_text
+		FROM main_translation_chunks
+		WHERE task_id = ? AND row_id = ?
+		ORDER BY chunk_index ASC
+	`, taskID, rowID)
+	if err != nil {
+		return nil, fmt.Errorf("query main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.logger.WarnContext(ctx, "close main translation chunk rows failed", "task_id", taskID, "error", closeErr)
+		}
+	}()
+
+	chunks := make([]TranslationChunk, 0)
+	for rows.Next() {
+		var chunk TranslationChunk
+		if err := rows.Scan(&chunk.RowID, &chunk.Index, &chunk.Count, &chunk.SourceText, &chunk.TranslatedText); err != nil {
+			return nil, fmt.Errorf("scan main translation chunk task_id=%s row_id=%s: %w", taskID, rowID, err)
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+	}
+	return chunks, nil
+}
+
+// DeleteChunks removes every stored chunk of one row.
+func (s *SQLiteTaskResultStore) DeleteChunks(ctx context.Context, taskID string, rowID string) error {
+	if err := s.InitSchema(ctx); err != nil {
+		return fmt.Errorf("init main translation schema before chunk delete: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM main_translation_chunks WHERE task_id = ? AND row_id = ?`, taskID, rowID); err != nil {
+		return fmt.Errorf("delete main translation chunks task_id=%s row_id=%s: %w", taskID, rowID, err)
+	}
+	return nil
+}
+
+// encodeTagIssues stores tag issues as a JSON array, or NULL when there are none.
+func encodeTagIssues(issues []TagIssue) (any, error) {
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(issues)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func decodeTagIssues(raw sql.NullString) ([]TagIssue, error) {
+	if !raw.Valid || strings.TrimSpace(raw.String) == "" {
+		return nil, nil
+	}
+	var issues []TagIssue
+	if err := json.Unmarshal([]byte(raw.String), &issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+func isDuplicateColumnError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "duplicate column name")
+}
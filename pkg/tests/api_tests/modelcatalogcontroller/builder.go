@@ -20,7 +20,10 @@ type FakeService struct {
 	LastCtx   context.Context
 	LastInput modelcatalog.ListModelsInput
 	Models    []modelcatalog.ModelOption
+	Status    modelcatalog.ProviderStatus
 	Err       error
+
+	LastHealthInput modelcatalog.ProviderHealthInput
 }
 
 func (s *FakeService) ListModels(ctx context.Context, input modelcatalog.ListModelsInput) ([]modelcatalog.ModelOption, error) {
@@ -29,6 +32,12 @@ func (s *FakeService) ListModels(ctx context.Context, input modelcatalog.ListMod
 	return s.Models, s.Err
 }
 
+func (s *FakeService) ProviderHealth(ctx context.Context, input modelcatalog.ProviderHealthInput) (modelcatalog.ProviderStatus, error) {
+	s.LastCtx = ctx
+	s.LastHealthInput = input
+	return s.Status, s.Err
+}
+
 // Build creates model catalog controller dependencies on shared testenv.
 func Build(t *testing.T, name string) *Env {
 	t.Helper()
@@ -0,0 +1,59 @@
+package translationmemorycontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	translationmemory "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/tests/api_tests/testenv"
+)
+
+// Env bundles translation memory controller test dependencies.
+type Env struct {
+	Service *FakeService
+	TestEnv *testenv.Env
+}
+
+// FakeService is a minimal stub for TranslationMemoryController API tests.
+type FakeService struct {
+	LastCtx context.Context
+
+	Matches        []translationmemory.Match
+	LookupErr      error
+	ImportID       string
+	StartImportErr error
+	LastLookupText string
+	LastImportPath string
+}
+
+func (f *FakeService) Lookup(ctx context.Context, sourceText string) ([]translationmemory.Match, error) {
+	f.LastCtx = ctx
+	f.LastLookupText = sourceText
+	return f.Matches, f.LookupErr
+}
+
+func (f *FakeService) StartImport(ctx context.Context, filePath string) (string, error) {
+	f.LastCtx = ctx
+	f.LastImportPath = filePath
+	return f.ImportID, f.StartImportErr
+}
+
+// Build creates translation memory controller dependencies on shared testenv.
+func Build(t *testing.T, name string) *Env {
+	t.Helper()
+
+	base := testenv.NewFileSQLiteEnv(t, name)
+	return &Env{
+		Service: &FakeService{},
+		TestEnv: base,
+	}
+}
+
+// String returns a short summary useful in failures.
+func (e *Env) String() string {
+	if e == nil || e.TestEnv == nil {
+		return "<nil translationmemorycontroller env>"
+	}
+	return fmt.Sprintf("db=%s trace_id=%s", e.TestEnv.DBPath, testenv.TraceIDValue(e.TestEnv.Ctx))
+}
@@ -16,8 +16,8 @@ The user message will contain:
 
 Use the User Request as the variable instruction, then analyze the NPC Profile and Dialogue History.
 Generate a concise persona summary.
-Your response MUST be formatted strictly as: TL: |...|
-Inside the pipes, include these sections in plain text:
+Your response MUST be a single JSON object of the form {"persona": "..."}.
+In the persona value, include these sections in plain text:
 - Personality Traits: ...
 - Speaking Habits: ...
 - Background: ...
@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+)
+
+// DictionaryEmbeddingIndexJob fills in missing dictionary vectors in the background.
+// It is triggered after dictionary imports and edits so terminology phases never wait on indexing.
+// Triggers that arrive while a pass is running are coalesced into one follow-up pass.
+type DictionaryEmbeddingIndexJob struct {
+	indexer terminologyslice.EmbeddingIndexer
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	running bool
+	rerun   bool
+	done    chan struct{}
+}
+
+// NewDictionaryEmbeddingIndexJob creates a background indexing job for the given indexer.
+func NewDictionaryEmbeddingIndexJob(indexer terminologyslice.EmbeddingIndexer, logger *slog.Logger) *DictionaryEmbeddingIndexJob {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DictionaryEmbeddingIndexJob{
+		indexer: indexer,
+		logger:  logger.With("component", "DictionaryEmbeddingIndexJob"),
+	}
+}
+
+// Trigger starts an indexing pass unless one is already running, in which case it schedules another pass.
+// The pass outlives the caller's request; only values such as request IDs are inherited from ctx.
+func (j *DictionaryEmbeddingIndexJob) Trigger(ctx context.Context) {
+	if j == nil || j.indexer == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		j.rerun = true
+		return
+	}
+	j.running = true
+	j.done = make(chan struct{})
+	go j.run(context.WithoutCancel(ctx), j.done)
+}
+
+// Wait blocks until the current indexing pass, including coalesced reruns, has finished.
+func (j *DictionaryEmbeddingIndexJob) Wait() {
+	j.mu.Lock()
+	done := j.done
+	j.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+func (j *DictionaryEmbeddingIndexJob) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		indexed, err := j.indexer.IndexEmbeddings(ctx)
+		if err != nil {
+			j.logger.WarnContext(ctx, "dictionary embedding index is incomplete; semantic references may be missing",
+				slog.Int("indexed", indexed), slog.String("error", err.Error()))
+		}
+		j.mu.Lock()
+		if !j.rerun {
+			j.running = false
+			j.mu.Unlock()
+			return
+		}
+		j.rerun = false
+		j.mu.Unlock()
+	}
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type blockingEmbeddingIndexer struct {
+	mu      sync.Mutex
+	calls   int
+	release chan struct{}
+	err     error
+}
+
+func (i *blockingEmbeddingIndexer) IndexEmbeddings(context.Context) (int, error) {
+	i.mu.Lock()
+	i.calls++
+	first := i.calls == 1
+	i.mu.Unlock()
+	if first && i.release != nil {
+		<-i.release
+	}
+	return 0, i.err
+}
+
+func TestDictionaryEmbeddingIndexJob_CoalescesTriggersWhileRunning(t *testing.T) {
+	indexer := &blockingEmbeddingIndexer{release: make(chan struct{})}
+	job := NewDictionaryEmbeddingIndexJob(indexer, nil)
+
+	job.Trigger(context.Background())
+	job.Trigger(context.Background())
+	job.Trigger(context.Background())
+	close(indexer.release)
+	job.Wait()
+
+	if indexer.calls != 2 {
+		t.Fatalf("実行中のトリガーは1回の再実行にまとめられるべき: calls=%d", indexer.calls)
+	}
+}
+
+func TestDictionaryEmbeddingIndexJob_SurvivesIndexErrors(t *testing.T) {
+	indexer := &blockingEmbeddingIndexer{err: errors.New("embedding provider down")}
+	job := NewDictionaryEmbeddingIndexJob(indexer, nil)
+
+	job.Trigger(context.Background())
+	job.Wait()
+	job.Trigger(context.Background())
+	job.Wait()
+
+	if indexer.calls != 2 {
+		t.Fatalf("エラー後も次のトリガーで再実行されるべき: calls=%d", indexer.calls)
+	}
+}
@@ -9,12 +9,18 @@ import (
 
 // XMLExportService is a workflow adapter that delegates XML generation to format/exporter contract.
 type XMLExportService struct {
-	exporter formatexporter.Exporter
+	exporter              formatexporter.Exporter
+	stringTables          formatexporter.StringTableExporter
+	interfaceTranslations formatexporter.InterfaceTranslationExporter
 }
 
-// NewXMLExportService constructs a workflow service bound to Exporter contract.
-func NewXMLExportService(exporter formatexporter.Exporter) *XMLExportService {
-	return &XMLExportService{exporter: exporter}
+// NewXMLExportService constructs a workflow service bound to the Exporter, StringTableExporter and InterfaceTranslationExporter contracts.
+func NewXMLExportService(
+	exporter formatexporter.Exporter,
+	stringTables formatexporter.StringTableExporter,
+	interfaceTranslations formatexporter.InterfaceTranslationExporter,
+) *XMLExportService {
+	return &XMLExportService{exporter: exporter, stringTables: stringTables, interfaceTranslations: interfaceTranslations}
 }
 
 // GenerateXTranslatorXML exports workflow output into xTranslator XML through the Exporter contract.
@@ -24,3 +30,19 @@ func (s *XMLExportService) GenerateXTranslatorXML(ctx context.Context, input for
 	}
 	return s.exporter.GenerateXML(ctx, input)
 }
+
+// GenerateStringTables exports translated plugin string tables through the StringTableExporter contract.
+func (s *XMLExportService) GenerateStringTables(ctx context.Context, input formatexporter.StringTableExportInput) ([]string, error) {
+	if s.stringTables == nil {
+		return nil, fmt.Errorf("string table exporter is not configured")
+	}
+	return s.stringTables.GenerateStringTables(ctx, input)
+}
+
+// GenerateInterfaceTranslation exports one translated MCM translation file through the InterfaceTranslationExporter contract.
+func (s *XMLExportService) GenerateInterfaceTranslation(ctx context.Context, input formatexporter.InterfaceTranslationExportInput) (string, error) {
+	if s.interfaceTranslations == nil {
+		return "", fmt.Errorf("interface translation exporter is not configured")
+	}
+	return s.interfaceTranslations.GenerateInterfaceTranslation(ctx, input)
+}
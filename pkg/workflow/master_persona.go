@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
 	runtimequeue "github.com/ishibata91/ai-translation-engine-2/pkg/runtime/queue"
 )
 
@@ -39,6 +40,7 @@ type PersonaRuntimeEntry struct {
 type MasterPersona interface {
 	StartMasterPersona(ctx context.Context, input StartMasterPersonaInput) (string, error)
 	RunPersonaPhase(ctx context.Context, input PersonaExecutionInput) error
+	PreviewPersonaRequests(ctx context.Context, input PersonaExecutionInput) ([]llmio.Request, error)
 	ListPersonaRuntime(ctx context.Context, taskID string) ([]PersonaRuntimeEntry, error)
 	ResumeMasterPersona(ctx context.Context, taskID string) error
 	CancelMasterPersona(ctx context.Context, taskID string) error
@@ -144,6 +144,56 @@ func (s *MasterPersonaService) RunPersonaPhase(ctx context.Context, input Person
 	return nil
 }
 
+// PreviewPersonaRequests returns the requests RunPersonaPhase would send without enqueueing or persisting them.
+// Already queued tasks return their unfinished requests; otherwise requests are built from the source JSON as a dry run.
+func (s *MasterPersonaService) PreviewPersonaRequests(ctx context.Context, input PersonaExecutionInput) ([]llmio.Request, error) {
+	trimmedTaskID := strings.TrimSpace(input.TaskID)
+	if trimmedTaskID == "" {
+		return nil, fmt.Errorf("task_id is required")
+	}
+	if s.queue == nil {
+		return nil, fmt.Errorf("request queue is not configured")
+	}
+
+	jobs, err := s.queue.GetTaskRequests(ctx, trimmedTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task requests task_id=%s: %w", trimmedTaskID, err)
+	}
+	if len(jobs) > 0 {
+		requests := make([]llmio.Request, 0, len(jobs))
+		for _, job := range jobs {
+			if job.Status == runtimequeue.StatusCompleted {
+				continue
+			}
+			var req llmio.Request
+			if err := json.Unmarshal([]byte(job.RequestJSON), &req); err != nil {
+				return nil, fmt.Errorf("decode queued persona request job=%s: %w", job.ID, err)
+			}
+			requests = append(requests, req)
+		}
+		return requests, nil
+	}
+
+	sourceJSONPath := strings.TrimSpace(input.SourceJSONPath)
+	if sourceJSONPath == "" {
+		return nil, fmt.Errorf("source_json_path is required for persona bootstrap task_id=%s", trimmedTaskID)
+	}
+	runCtx := persona.WithTaskID(ctx, trimmedTaskID)
+	parsed, err := s.parser.LoadExtractedJSON(runCtx, sourceJSONPath)
+	if err != nil {
+		return nil, fmt.Errorf("load extracted json source_json_path=%s: %w", sourceJSONPath, err)
+	}
+	personaInput := pipeline.ToPersonaGenInput(parsed)
+	personaInput.SourceJSONPath = sourceJSONPath
+	personaInput.OverwriteExisting = input.OverwriteExisting
+	personaInput.DryRun = true
+	requests, err := s.personaGenerator.PreparePrompts(runCtx, personaInput)
+	if err != nil {
+		return nil, fmt.Errorf("build persona prompts task_id=%s: %w", trimmedTaskID, err)
+	}
+	return applyPersonaExecutionOverrides(requests, input.Request, input.Prompt), nil
+}
+
 // ListPersonaRuntime returns task-scoped runtime snapshot without exposing queue internals.
 func (s *MasterPersonaService) ListPersonaRuntime(ctx context.Context, taskID string) ([]PersonaRuntimeEntry, error) {
 	trimmedTaskID := strings.TrimSpace(taskID)
@@ -289,7 +339,7 @@ func (s *MasterPersonaService) runPersonaRuntime(ctx context.Context, input Pers
 		return fmt.Errorf("task_id is required")
 	}
 
-	metadata := personaExecutionConfigMetadata(input.Request, input.Prompt)
+	metadata := phaseExecutionConfigMetadata(input.Request, input.Prompt)
 	metadata["entrypoint"] = "translation_flow_persona_phase"
 	metadata["phase"] = phaseRequestEnqueued
 	if strings.TrimSpace(input.SourceJSONPath) != "" {
@@ -543,7 +593,7 @@ func (s *MasterPersonaService) executeRequestPreparation(ctx context.Context, ta
 		"request_count":      len(requests),
 	}
 	if hasExecutionOverrides {
-		taskMetadata = mergeTaskMetadata(taskMetadata, personaExecutionConfigMetadata(requestCfg, promptCfg))
+		taskMetadata = mergeTaskMetadata(taskMetadata, phaseExecutionConfigMetadata(requestCfg, promptCfg))
 	}
 
 	if s.queue == nil {
@@ -898,7 +948,7 @@ func mergeMetadata(primary map[string]interface{}, fallback map[string]interface
 	return merged
 }
 
-func personaExecutionConfigMetadata(request TranslationRequestConfig, prompt TranslationPromptConfig) task2.TaskMetadata {
+func phaseExecutionConfigMetadata(request TranslationRequestConfig, prompt TranslationPromptConfig) task2.TaskMetadata {
 	requestMetadata := map[string]interface{}{
 		"provider":         strings.TrimSpace(request.Provider),
 		"model":            strings.TrimSpace(request.Model),
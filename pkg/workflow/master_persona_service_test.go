@@ -382,6 +382,38 @@ func (s *stubMasterPersonaParser) LoadExtractedJSON(ctx context.Context, path st
 	return s.output, nil
 }
 
+func (s *stubMasterPersonaParser) LoadPlugin(ctx context.Context, path string) (*skyrim.ParserOutput, error) {
+	_ = ctx
+	_ = path
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.output == nil {
+		return &skyrim.ParserOutput{}, nil
+	}
+	return s.output, nil
+}
+
+func (s *stubMasterPersonaParser) LoadInterfaceTranslation(ctx context.Context, path string) (*skyrim.ParserOutput, error) {
+	_ = ctx
+	_ = path
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.output == nil {
+		return &skyrim.ParserOutput{}, nil
+	}
+	return s.output, nil
+}
+
+func (s *stubMasterPersonaParser) StreamExtractedJSON(ctx context.Context, path string, sink skyrim.ParserOutputSink) error {
+	output, err := s.LoadExtractedJSON(ctx, path)
+	if err != nil {
+		return err
+	}
+	return sink(output)
+}
+
 type stubMasterPersonaGenerator struct {
 	prepareRequests []llmio.Request
 	prepareErr      error
@@ -412,6 +444,26 @@ func (s *stubMasterPersona) RunPersonaPhase(ctx context.Context, input PersonaEx
 	return s.ResumeMasterPersona(withPersonaPhaseRunConfig(ctx, input.Request, input.Prompt), input.TaskID)
 }
 
+// PreviewPersonaRequests decodes the unfinished queued requests of the stubbed task.
+func (s *stubMasterPersona) PreviewPersonaRequests(ctx context.Context, input PersonaExecutionInput) ([]llmio.Request, error) {
+	jobs, err := s.GetTaskRequests(ctx, input.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]llmio.Request, 0, len(jobs))
+	for _, job := range jobs {
+		if job.Status == runtimequeue.StatusCompleted {
+			continue
+		}
+		var req llmio.Request
+		if err := json.Unmarshal([]byte(job.RequestJSON), &req); err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
 // ListPersonaRuntime keeps legacy translation-flow tests compiling after MasterPersona contract expansion.
 func (s *stubMasterPersona) ListPersonaRuntime(ctx context.Context, taskID string) ([]PersonaRuntimeEntry, error) {
 	requests, err := s.GetTaskRequests(ctx, taskID)
@@ -458,9 +510,9 @@ func (s *stubMasterPersonaLLMManager) GetBatchClient(ctx context.Context, config
 	return nil, fmt.Errorf("batch client is not configured")
 }
 
-func (s *stubMasterPersonaLLMManager) ResolveBulkStrategy(ctx context.Context, strategy gatewayllm.BulkStrategy, provider string) gatewayllm.BulkStrategy {
+func (s *stubMasterPersonaLLMManager) ResolveBulkStrategy(ctx context.Context, strategy gatewayllm.BulkStrategy, config gatewayllm.LLMConfig) gatewayllm.BulkStrategy {
 	_ = ctx
-	_ = provider
+	_ = config
 	if strings.TrimSpace(string(strategy)) == "" {
 		return gatewayllm.BulkStrategySync
 	}
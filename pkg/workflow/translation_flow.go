@@ -6,6 +6,8 @@ import "context"
 type LoadTranslationFlowInput struct {
 	TaskID    string   `json:"task_id"`
 	FilePaths []string `json:"file_paths"`
+	// Incremental replaces previously loaded files of the same name and carries unchanged translations forward.
+	Incremental bool `json:"incremental"`
 }
 
 // TranslationPreviewRow is one row shown in load-phase preview tables.
@@ -40,6 +42,19 @@ type TranslationLoadedFile struct {
 type TranslationLoadResult struct {
 	TaskID string                  `json:"task_id"`
 	Files  []TranslationLoadedFile `json:"files"`
+	Diffs  []TranslationFileDiff   `json:"diffs,omitempty"`
+}
+
+// TranslationFileDiff reports how an incrementally reloaded file differs from its previous load.
+type TranslationFileDiff struct {
+	FilePath       string `json:"file_path"`
+	FileName       string `json:"file_name"`
+	HasPrevious    bool   `json:"has_previous"`
+	Added          int    `json:"added"`
+	Changed        int    `json:"changed"`
+	Removed        int    `json:"removed"`
+	Unchanged      int    `json:"unchanged"`
+	CarriedForward int    `json:"carried_forward"`
 }
 
 // TranslationRequestConfig is the workflow DTO for terminology request settings.
@@ -100,6 +115,100 @@ type TerminologyPhaseResult struct {
 	ProgressMessage string `json:"progress_message"`
 }
 
+// RunSummaryPhaseInput contains the request payload for task-scoped story summary generation.
+type RunSummaryPhaseInput struct {
+	TaskID  string                   `json:"task_id"`
+	Request TranslationRequestConfig `json:"request"`
+}
+
+// SummaryPhaseResult is the aggregate response for one summary phase run.
+type SummaryPhaseResult struct {
+	TaskID         string `json:"task_id"`
+	Status         string `json:"status"`
+	DialogueGroups int    `json:"dialogue_groups"`
+	Quests         int    `json:"quests"`
+	RequestCount   int    `json:"request_count"`
+	SavedCount     int    `json:"saved_count"`
+	FailedCount    int    `json:"failed_count"`
+}
+
+// RunMainTranslationPhaseInput contains the request payload for task-scoped main translation execution.
+type RunMainTranslationPhaseInput struct {
+	TaskID  string                   `json:"task_id"`
+	Request TranslationRequestConfig `json:"request"`
+	Prompt  TranslationPromptConfig  `json:"prompt"`
+}
+
+// MainTranslationPhaseResult is the aggregate response for main translation phase status.
+type MainTranslationPhaseResult struct {
+	TaskID          string `json:"task_id"`
+	Status          string `json:"status"`
+	TargetCount     int    `json:"target_count"`
+	SavedCount      int    `json:"saved_count"`
+	FailedCount     int    `json:"failed_count"`
+	ProgressMode    string `json:"progress_mode"`
+	ProgressCurrent int    `json:"progress_current"`
+	ProgressTotal   int    `json:"progress_total"`
+	ProgressMessage string `json:"progress_message"`
+}
+
+// RunExportPhaseInput contains the request payload for task-scoped xTranslator XML and string table export.
+type RunExportPhaseInput struct {
+	TaskID         string `json:"task_id"`
+	OutputDir      string `json:"output_dir"`
+	SourceLanguage string `json:"source_language"`
+	DestLanguage   string `json:"dest_language"`
+	// IncludeStringTables also writes translated Strings/*.STRINGS files for localized plugin inputs.
+	IncludeStringTables bool `json:"include_string_tables"`
+}
+
+// ExportedFile describes one SSTXML written for a source plugin.
+type ExportedFile struct {
+	PluginName     string `json:"plugin_name"`
+	OutputFilePath string `json:"output_file_path"`
+	TermCount      int    `json:"term_count"`
+	MainCount      int    `json:"main_count"`
+}
+
+// ExportSkippedRow is one target row that was left out of the XML because it has no usable translation.
+// Failed rows carry the saved error message and, for tag validation failures, every damaged token.
+type ExportSkippedRow struct {
+	Phase        string           `json:"phase"`
+	RowID        string           `json:"row_id"`
+	FormID       string           `json:"form_id"`
+	EditorID     string           `json:"editor_id"`
+	RecordType   string           `json:"record_type"`
+	SourceText   string           `json:"source_text"`
+	SourcePlugin string           `json:"source_plugin"`
+	Reason       string           `json:"reason"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+	TagIssues    []ExportTagIssue `json:"tag_issues,omitempty"`
+}
+
+// ExportTagIssue is one protected token that a failed translation lost, invented, duplicated or moved.
+type ExportTagIssue struct {
+	Kind        string `json:"kind"`
+	Class       string `json:"class"`
+	Placeholder string `json:"placeholder"`
+	Token       string `json:"token,omitempty"`
+}
+
+// ExportPhaseResult is the aggregate response for one export run.
+type ExportPhaseResult struct {
+	TaskID        string             `json:"task_id"`
+	Status        string             `json:"status"`
+	ExportedCount int                `json:"exported_count"`
+	SkippedCount  int                `json:"skipped_count"`
+	Files         []ExportedFile     `json:"files"`
+	SkippedRows   []ExportSkippedRow `json:"skipped_rows"`
+	// StringTableFiles lists the translated string table paths written for localized plugins.
+	StringTableFiles []string `json:"string_table_files"`
+	// InterfaceTranslationFiles lists the translated MCM Interface/Translations files.
+	InterfaceTranslationFiles []string `json:"interface_translation_files"`
+	// MemoryRecordedCount is the number of exported main translations stored in the translation memory.
+	MemoryRecordedCount int `json:"memory_recorded_count"`
+}
+
 // PersonaDialogueView is one dialogue excerpt rendered in persona detail panes.
 type PersonaDialogueView struct {
 	RecordType       string `json:"record_type"`
@@ -163,8 +272,16 @@ type TranslationFlow interface {
 	ListPreviewRows(ctx context.Context, fileID int64, page int, pageSize int) (TranslationPreviewPage, error)
 	ListTerminologyTargets(ctx context.Context, taskID string, page int, pageSize int) (TerminologyTargetPreviewPage, error)
 	RunTerminologyPhase(ctx context.Context, input RunTerminologyPhaseInput) (TerminologyPhaseResult, error)
+	EstimateTerminologyPhase(ctx context.Context, input RunTerminologyPhaseInput) (PhaseCostEstimate, error)
 	GetTerminologyPhase(ctx context.Context, taskID string) (TerminologyPhaseResult, error)
 	ListTranslationFlowPersonaTargets(ctx context.Context, taskID string, page int, pageSize int) (PersonaTargetPreviewPage, error)
 	RunTranslationFlowPersonaPhase(ctx context.Context, input RunTranslationFlowPersonaPhaseInput) (PersonaPhaseResult, error)
+	EstimateTranslationFlowPersonaPhase(ctx context.Context, input RunTranslationFlowPersonaPhaseInput) (PhaseCostEstimate, error)
 	GetTranslationFlowPersonaPhase(ctx context.Context, taskID string) (PersonaPhaseResult, error)
+	RunSummaryPhase(ctx context.Context, input RunSummaryPhaseInput) (SummaryPhaseResult, error)
+	EstimateSummaryPhase(ctx context.Context, input RunSummaryPhaseInput) (PhaseCostEstimate, error)
+	RunMainTranslationPhase(ctx context.Context, input RunMainTranslationPhaseInput) (MainTranslationPhaseResult, error)
+	EstimateMainTranslationPhase(ctx context.Context, input RunMainTranslationPhaseInput) (PhaseCostEstimate, error)
+	GetMainTranslationPhase(ctx context.Context, taskID string) (MainTranslationPhaseResult, error)
+	RunExportPhase(ctx context.Context, input RunExportPhaseInput) (ExportPhaseResult, error)
 }
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+type personaFinalFinder interface {
+	FindPersonaFinal(ctx context.Context, key translationflow.PersonaLookupKey) (translationflow.PersonaFinalSummary, bool, error)
+}
+
+type personaLookup struct {
+	store personaFinalFinder
+}
+
+// NewPersonaLookup exposes the persona phase's final personas to the main translation context builder.
+func NewPersonaLookup(store personaFinalFinder) translatorslice.PersonaLookup {
+	return &personaLookup{store: store}
+}
+
+func (l *personaLookup) FindBySpeakerID(ctx context.Context, sourcePlugin string, speakerID string) (*string, error) {
+	trimmedSpeakerID := strings.TrimSpace(speakerID)
+	if l.store == nil || trimmedSpeakerID == "" {
+		return nil, nil
+	}
+	key := translationflow.PersonaLookupKey{
+		SourcePlugin: normalizePersonaSourcePlugin(sourcePlugin, ""),
+		SpeakerID:    trimmedSpeakerID,
+	}
+	final, ok, err := l.store.FindPersonaFinal(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("find final persona source_plugin=%s speaker_id=%s: %w", key.SourcePlugin, key.SpeakerID, err)
+	}
+	if !ok || strings.TrimSpace(final.PersonaText) == "" {
+		return nil, nil
+	}
+	text := final.PersonaText
+	return &text, nil
+}
+
+type termTextSearcher interface {
+	SearchTermsInText(ctx context.Context, text string) ([]terminologyslice.ReferenceTerm, error)
+}
+
+type termLookup struct {
+	store termTextSearcher
+}
+
+// NewTermLookup exposes the terminology phase's translated terms to the main translation context builder.
+// A source text that is itself a translated term is returned as the forced translation.
+func NewTermLookup(store termTextSearcher) translatorslice.TermLookup {
+	return &termLookup{store: store}
+}
+
+func (l *termLookup) Search(ctx context.Context, sourceText string) ([]translatorslice.Pass2ReferenceTerm, *string, error) {
+	trimmed := strings.TrimSpace(sourceText)
+	if l.store == nil || trimmed == "" {
+		return nil, nil, nil
+	}
+	terms, err := l.store.SearchTermsInText(ctx, trimmed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("search terminology terms: %w", err)
+	}
+	var forced *string
+	references := make([]translatorslice.Pass2ReferenceTerm, 0, len(terms))
+	for _, term := range terms {
+		if term.Source == trimmed {
+			translation := term.Translation
+			forced = &translation
+		}
+		references = append(references, translatorslice.Pass2ReferenceTerm{
+			OriginalEN: term.Source,
+			OriginalJA: term.Translation,
+		})
+	}
+	return references, forced, nil
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+)
+
+type stubPersonaFinalFinder struct {
+	finals map[translationflow.PersonaLookupKey]translationflow.PersonaFinalSummary
+	keys   []translationflow.PersonaLookupKey
+}
+
+func (s *stubPersonaFinalFinder) FindPersonaFinal(ctx context.Context, key translationflow.PersonaLookupKey) (translationflow.PersonaFinalSummary, bool, error) {
+	_ = ctx
+	s.keys = append(s.keys, key)
+	final, ok := s.finals[key]
+	return final, ok, nil
+}
+
+type stubTermTextSearcher struct {
+	terms []terminologyslice.ReferenceTerm
+	err   error
+}
+
+func (s *stubTermTextSearcher) SearchTermsInText(ctx context.Context, text string) ([]terminologyslice.ReferenceTerm, error) {
+	_ = ctx
+	_ = text
+	return s.terms, s.err
+}
+
+func TestNewPersonaLookup(t *testing.T) {
+	store := &stubPersonaFinalFinder{finals: map[translationflow.PersonaLookupKey]translationflow.PersonaFinalSummary{
+		{SourcePlugin: "Skyrim.esm", SpeakerID: "0x0A2C94"}: {PersonaText: "忠実な従士。"},
+		{SourcePlugin: "Skyrim.esm", SpeakerID: "0x000001"}: {PersonaText: " "},
+	}}
+	lookup := NewPersonaLookup(store)
+
+	got, err := lookup.FindBySpeakerID(context.Background(), "Skyrim.esm", " 0x0A2C94 ")
+	if err != nil || got == nil || *got != "忠実な従士。" {
+		t.Fatalf("unexpected persona: %v %v", got, err)
+	}
+	for _, speakerID := range []string{"0x000001", "0xMISSING", ""} {
+		got, err := lookup.FindBySpeakerID(context.Background(), "Skyrim.esm", speakerID)
+		if err != nil || got != nil {
+			t.Fatalf("expected no persona for %q, got %v %v", speakerID, got, err)
+		}
+	}
+	if _, err := lookup.FindBySpeakerID(context.Background(), "", "0x0A2C94"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := store.keys[len(store.keys)-1]
+	if last.SourcePlugin != "UNKNOWN" {
+		t.Fatalf("expected unresolved plugin to be normalized, got %+v", last)
+	}
+}
+
+func TestNewTermLookup(t *testing.T) {
+	t.Run("本文に含まれる用語を参照訳として返す", func(t *testing.T) {
+		lookup := NewTermLookup(&stubTermTextSearcher{terms: []terminologyslice.ReferenceTerm{
+			{Source: "Whiterun", Translation: "ホワイトラン"},
+		}})
+		terms, forced, err := lookup.Search(context.Background(), "Go to Whiterun.")
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if forced != nil {
+			t.Fatalf("unexpected forced translation: %v", *forced)
+		}
+		if len(terms) != 1 || terms[0].OriginalEN != "Whiterun" || terms[0].OriginalJA != "ホワイトラン" {
+			t.Fatalf("unexpected terms: %+v", terms)
+		}
+	})
+
+	t.Run("本文全体が用語なら強制訳を返す", func(t *testing.T) {
+		lookup := NewTermLookup(&stubTermTextSearcher{terms: []terminologyslice.ReferenceTerm{
+			{Source: "Whiterun", Translation: "ホワイトラン"},
+		}})
+		_, forced, err := lookup.Search(context.Background(), " Whiterun ")
+		if err != nil || forced == nil || *forced != "ホワイトラン" {
+			t.Fatalf("unexpected forced translation: %v %v", forced, err)
+		}
+	})
+
+	t.Run("検索エラーを返す", func(t *testing.T) {
+		lookup := NewTermLookup(&stubTermTextSearcher{err: errors.New("db closed")})
+		if _, _, err := lookup.Search(context.Background(), "Whiterun"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
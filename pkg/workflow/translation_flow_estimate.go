@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+// PhaseCostEstimate is the dry-run forecast of one translation-flow phase.
+type PhaseCostEstimate = llmusage.PhaseEstimate
+
+type phaseCostEstimator interface {
+	EstimatePhase(ctx context.Context, config llmio.ExecutionConfig, requests []llmio.Request) llmusage.PhaseEstimate
+}
+
+// SetCostEstimator injects the dry-run token/time/price estimator.
+func (s *TranslationFlowService) SetCostEstimator(estimator phaseCostEstimator) {
+	s.estimator = estimator
+}
+
+// EstimateTerminologyPhase builds the terminology requests without running them and forecasts their cost.
+func (s *TranslationFlowService) EstimateTerminologyPhase(ctx context.Context, input RunTerminologyPhaseInput) (PhaseCostEstimate, error) {
+	trimmedTaskID, err := s.validateEstimateInput(input.TaskID, input.Request)
+	if err != nil {
+		return PhaseCostEstimate{}, err
+	}
+	requests, err := s.terminology.BuildPrompts(ctx, trimmedTaskID, terminologyslice.PhaseOptions{
+		Request: terminologyslice.RequestConfig{
+			Provider:        input.Request.Provider,
+			Model:           input.Request.Model,
+			Endpoint:        input.Request.Endpoint,
+			APIKey:          input.Request.APIKey,
+			Temperature:     input.Request.Temperature,
+			ContextLength:   input.Request.ContextLength,
+			SyncConcurrency: input.Request.SyncConcurrency,
+			BulkStrategy:    input.Request.BulkStrategy,
+		},
+		Prompt: terminologyslice.PromptConfig{
+			UserPrompt:   input.Prompt.UserPrompt,
+			SystemPrompt: input.Prompt.SystemPrompt,
+		},
+	})
+	if err != nil {
+		return PhaseCostEstimate{}, fmt.Errorf("build terminology prompts task_id=%s: %w", trimmedTaskID, err)
+	}
+	return s.estimator.EstimatePhase(ctx, estimateExecutionConfig(trimmedTaskID, terminologyProgressPhase, terminologyLLMNamespace, input.Request), requests), nil
+}
+
+// EstimateTranslationFlowPersonaPhase forecasts the cost of generating the remaining personas.
+func (s *TranslationFlowService) EstimateTranslationFlowPersonaPhase(ctx context.Context, input RunTranslationFlowPersonaPhaseInput) (PhaseCostEstimate, error) {
+	trimmedTaskID, err := s.validateEstimateInput(input.TaskID, input.Request)
+	if err != nil {
+		return PhaseCostEstimate{}, err
+	}
+	if s.personaWorkflow == nil {
+		return PhaseCostEstimate{}, fmt.Errorf("persona workflow is not configured")
+	}
+	config := estimateExecutionConfig(trimmedTaskID, personaProgressPhase, translationFlowLLMNS, input.Request)
+
+	plan, err := s.planTranslationFlowPersonaPhase(ctx, trimmedTaskID)
+	if err != nil {
+		return PhaseCostEstimate{}, fmt.Errorf("plan translation flow persona phase task_id=%s: %w", trimmedTaskID, err)
+	}
+	if plan.RetryableCount == 0 {
+		return s.estimator.EstimatePhase(ctx, config, nil), nil
+	}
+
+	executionInput := PersonaExecutionInput{
+		TaskID:  trimmedTaskID,
+		Request: input.Request,
+		Prompt:  input.Prompt,
+	}
+	if plan.RuntimeCount == 0 {
+		sourceJSONPath, err := s.resolvePersonaBootstrapSourceJSONPath(ctx, trimmedTaskID)
+		if err != nil {
+			return PhaseCostEstimate{}, fmt.Errorf("resolve persona bootstrap source_json_path task_id=%s: %w", trimmedTaskID, err)
+		}
+		executionInput.SourceJSONPath = sourceJSONPath
+	}
+	requests, err := s.personaWorkflow.PreviewPersonaRequests(ctx, executionInput)
+	if err != nil {
+		return PhaseCostEstimate{}, fmt.Errorf("preview persona requests task_id=%s: %w", trimmedTaskID, err)
+	}
+	return s.estimator.EstimatePhase(ctx, config, requests), nil
+}
+
+// EstimateMainTranslationPhase builds the main translation requests without running them and forecasts their cost.
+func (s *TranslationFlowService) EstimateMainTranslationPhase(ctx context.Context, input RunMainTranslationPhaseInput) (PhaseCostEstimate, error) {
+	trimmedTaskID, err := s.validateEstimateInput(input.TaskID, input.Request)
+	if err != nil {
+		return PhaseCostEstimate{}, err
+	}
+	if s.mainTranslation == nil {
+		return PhaseCostEstimate{}, fmt.Errorf("main translation slice is not configured")
+	}
+	requests, err := s.mainTranslation.BuildPrompts(ctx, trimmedTaskID, translatorslice.PhaseOptions{
+		Request: translatorslice.RequestConfig{
+			Provider:        input.Request.Provider,
+			Model:           input.Request.Model,
+			Endpoint:        input.Request.Endpoint,
+			APIKey:          input.Request.APIKey,
+			Temperature:     input.Request.Temperature,
+			ContextLength:   input.Request.ContextLength,
+			SyncConcurrency: input.Request.SyncConcurrency,
+			BulkStrategy:    input.Request.BulkStrategy,
+		},
+		Prompt: translatorslice.PromptConfig{
+			UserPrompt:   input.Prompt.UserPrompt,
+			SystemPrompt: input.Prompt.SystemPrompt,
+		},
+	})
+	if err != nil {
+		return PhaseCostEstimate{}, fmt.Errorf("build main translation prompts task_id=%s: %w", trimmedTaskID, err)
+	}
+	return s.estimator.EstimatePhase(ctx, estimateExecutionConfig(trimmedTaskID, mainTranslationProgressPhase, mainTranslationLLMNamespace, input.Request), requests), nil
+}
+
+func (s *TranslationFlowService) validateEstimateInput(taskID string, request TranslationRequestConfig) (string, error) {
+	trimmedTaskID := strings.TrimSpace(taskID)
+	if trimmedTaskID == "" {
+		return "", fmt.Errorf("task_id is required")
+	}
+	if strings.TrimSpace(request.Model) == "" {
+		return "", fmt.Errorf("request.model is required")
+	}
+	if s.estimator == nil {
+		return "", fmt.Errorf("cost estimator is not configured")
+	}
+	return trimmedTaskID, nil
+}
+
+func estimateExecutionConfig(taskID string, phase string, namespace string, request TranslationRequestConfig) llmio.ExecutionConfig {
+	return llmio.ExecutionConfig{
+		Provider:        request.Provider,
+		Model:           request.Model,
+		Endpoint:        request.Endpoint,
+		Temperature:     request.Temperature,
+		ContextLength:   request.ContextLength,
+		SyncConcurrency: request.SyncConcurrency,
+		BulkStrategy:    request.BulkStrategy,
+		ConfigNamespace: namespace,
+		TaskID:          taskID,
+		Phase:           phase,
+	}
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/runtime/llmusage"
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+type stubCostEstimator struct {
+	config   llmio.ExecutionConfig
+	requests []llmio.Request
+}
+
+func (s *stubCostEstimator) EstimatePhase(_ context.Context, config llmio.ExecutionConfig, requests []llmio.Request) llmusage.PhaseEstimate {
+	s.config = config
+	s.requests = requests
+	return llmusage.PhaseEstimate{TaskID: config.TaskID, Phase: config.Phase, Requests: len(requests)}
+}
+
+func TestTranslationFlowServiceEstimateMainTranslationPhaseDoesNotExecute(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{
+			{Metadata: map[string]interface{}{"row_id": "dialogue_response:1"}},
+			{Metadata: map[string]interface{}{"row_id": "quest_stage:1"}},
+		},
+		summary: translatorslice.PhaseSummary{TaskID: "task-main", Status: "pending"},
+	}
+	estimator := &stubCostEstimator{}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor:        &stubTerminologyExecutor{err: errors.New("executor must not run")},
+		notifier:        &stubWorkflowProgressNotifier{},
+	}
+	service.SetCostEstimator(estimator)
+
+	got, err := service.EstimateMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  " task-main ",
+		Request: TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.5-flash", BulkStrategy: "batch", SyncConcurrency: 4},
+	})
+	if err != nil {
+		t.Fatalf("EstimateMainTranslationPhase failed: %v", err)
+	}
+	if got.Requests != 2 || got.Phase != mainTranslationProgressPhase || got.TaskID != "task-main" {
+		t.Fatalf("unexpected estimate: %+v", got)
+	}
+	if estimator.config.ConfigNamespace != mainTranslationLLMNamespace || estimator.config.BulkStrategy != "batch" || estimator.config.SyncConcurrency != 4 {
+		t.Fatalf("unexpected execution config: %+v", estimator.config)
+	}
+	if mainTranslation.updatedSummary.Status != "" || len(mainTranslation.savedResponses) != 0 {
+		t.Fatalf("estimate must not persist phase state: summary=%+v saved=%d", mainTranslation.updatedSummary, len(mainTranslation.savedResponses))
+	}
+}
+
+func TestTranslationFlowServiceEstimateTerminologyPhaseUsesTerminologyNamespace(t *testing.T) {
+	terminology := &stubTerminology{
+		preparePromptsResult: []llmio.Request{{Metadata: map[string]interface{}{"source_text": "Whiterun"}}},
+		summary:              terminologyslice.PhaseSummary{TaskID: "task-term", Status: "pending"},
+	}
+	estimator := &stubCostEstimator{}
+	service := &TranslationFlowService{terminology: terminology, estimator: estimator}
+
+	got, err := service.EstimateTerminologyPhase(context.Background(), RunTerminologyPhaseInput{
+		TaskID:  "task-term",
+		Request: TranslationRequestConfig{Provider: "lmstudio", Model: "qwen"},
+	})
+	if err != nil {
+		t.Fatalf("EstimateTerminologyPhase failed: %v", err)
+	}
+	if got.Requests != 1 || estimator.config.ConfigNamespace != terminologyLLMNamespace || estimator.config.Phase != terminologyProgressPhase {
+		t.Fatalf("unexpected estimate: %+v config=%+v", got, estimator.config)
+	}
+	if len(terminology.updatedSummaries) != 0 {
+		t.Fatalf("estimate must not persist terminology summary: %+v", terminology.updatedSummaries)
+	}
+}
+
+func TestTranslationFlowServiceEstimateRequiresEstimatorAndModel(t *testing.T) {
+	service := &TranslationFlowService{terminology: &stubTerminology{}}
+	if _, err := service.EstimateTerminologyPhase(context.Background(), RunTerminologyPhaseInput{
+		TaskID:  "task-1",
+		Request: TranslationRequestConfig{Model: "m"},
+	}); err == nil {
+		t.Fatalf("expected error without estimator")
+	}
+	service.SetCostEstimator(&stubCostEstimator{})
+	if _, err := service.EstimateTerminologyPhase(context.Background(), RunTerminologyPhaseInput{TaskID: "task-1"}); err == nil {
+		t.Fatalf("expected error without model")
+	}
+}
@@ -0,0 +1,322 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+const defaultExportSourceLanguage = "english"
+const defaultExportDestLanguage = "japanese"
+
+type translationExporter interface {
+	GenerateXTranslatorXML(ctx context.Context, input formatexporter.ExportInput) error
+	GenerateStringTables(ctx context.Context, input formatexporter.StringTableExportInput) ([]string, error)
+	GenerateInterfaceTranslation(ctx context.Context, input formatexporter.InterfaceTranslationExportInput) (string, error)
+}
+
+type exportPluginBucket struct {
+	termResults []formatexporter.ExportRecord
+	mainResults []formatexporter.ExportRecord
+}
+
+// RunExportPhase writes one xTranslator SSTXML per source plugin from terminology and main translation results.
+// MCM keys are written to "<Mod>_<LANGUAGE>.txt" Interface/Translations files instead of the SSTXML.
+// When requested, it also rewrites the string tables of localized plugin inputs with the same translations.
+// Completed main translations are then recorded into the translation memory as confirmed translations.
+func (s *TranslationFlowService) RunExportPhase(ctx context.Context, input RunExportPhaseInput) (ExportPhaseResult, error) {
+	trimmedTaskID := strings.TrimSpace(input.TaskID)
+	if trimmedTaskID == "" {
+		return ExportPhaseResult{}, fmt.Errorf("task_id is required")
+	}
+	outputDir := strings.TrimSpace(input.OutputDir)
+	if outputDir == "" {
+		return ExportPhaseResult{}, fmt.Errorf("output_dir is required")
+	}
+	if s.exporter == nil {
+		return ExportPhaseResult{}, fmt.Errorf("xml exporter is not configured")
+	}
+	if s.mainTranslation == nil {
+		return ExportPhaseResult{}, fmt.Errorf("main translation slice is not configured")
+	}
+	if err := s.recordResumeEntrypoint(ctx, trimmedTaskID, exportEntrypoint, exportConfigMetadata(input)); err != nil {
+		return ExportPhaseResult{}, err
+	}
+	sourceLanguage := strings.ToLower(strings.TrimSpace(input.SourceLanguage))
+	if sourceLanguage == "" {
+		sourceLanguage = defaultExportSourceLanguage
+	}
+	destLanguage := strings.ToLower(strings.TrimSpace(input.DestLanguage))
+	if destLanguage == "" {
+		destLanguage = defaultExportDestLanguage
+	}
+
+	termRows, err := s.terminology.ListTranslations(ctx, trimmedTaskID)
+	if err != nil {
+		return ExportPhaseResult{}, fmt.Errorf("list terminology translations task_id=%s: %w", trimmedTaskID, err)
+	}
+	mainRows, err := s.mainTranslation.ListResults(ctx, trimmedTaskID)
+	if err != nil {
+		return ExportPhaseResult{}, fmt.Errorf("list main translation results task_id=%s: %w", trimmedTaskID, err)
+	}
+
+	result := ExportPhaseResult{
+		TaskID:                    trimmedTaskID,
+		Files:                     make([]ExportedFile, 0),
+		SkippedRows:               make([]ExportSkippedRow, 0),
+		StringTableFiles:          make([]string, 0),
+		InterfaceTranslationFiles: make([]string, 0),
+	}
+	buckets := make(map[string]*exportPluginBucket)
+	bucketFor := func(plugin string) *exportPluginBucket {
+		bucket, ok := buckets[plugin]
+		if !ok {
+			bucket = &exportPluginBucket{}
+			buckets[plugin] = bucket
+		}
+		return bucket
+	}
+
+	for _, row := range termRows {
+		plugin := resolveExportPlugin("", row.ID, row.SourceFile)
+		if row.TranslationState != "translated" || strings.TrimSpace(row.TranslatedText) == "" {
+			result.SkippedRows = append(result.SkippedRows, ExportSkippedRow{
+				Phase:        terminologyProgressPhase,
+				RowID:        row.ID,
+				FormID:       row.ID,
+				EditorID:     row.EditorID,
+				RecordType:   row.RecordType,
+				SourceText:   row.SourceText,
+				SourcePlugin: plugin,
+				Reason:       "untranslated",
+			})
+			continue
+		}
+		bucket := bucketFor(plugin)
+		bucket.termResults = append(bucket.termResults, formatexporter.ExportRecord{
+			FormID:         row.ID,
+			EditorID:       row.EditorID,
+			RecordType:     row.RecordType,
+			SourceText:     row.SourceText,
+			TranslatedText: row.TranslatedText,
+		})
+	}
+
+	interfaceBuckets := make(map[string][]formatexporter.ExportRecord)
+	for _, row := range mainRows {
+		plugin := resolveExportPlugin(row.SourcePlugin, row.ID, row.SourceFile)
+		editorID := ""
+		if row.EditorID != nil {
+			editorID = *row.EditorID
+		}
+		translatedText := ""
+		if row.TranslatedText != nil {
+			translatedText = *row.TranslatedText
+		}
+		completed := row.Status == "completed" && strings.TrimSpace(translatedText) != ""
+		if row.RecordType == skyrim.InterfaceTranslationRecordType {
+			// Untranslated MCM keys are still written with their source text so the menu stays complete.
+			modName := ""
+			if row.ParentID != nil {
+				modName = *row.ParentID
+			}
+			interfaceBuckets[modName] = append(interfaceBuckets[modName], formatexporter.ExportRecord{
+				FormID:         row.ID,
+				EditorID:       editorID,
+				RecordType:     row.RecordType,
+				SourceText:     row.SourceText,
+				TranslatedText: translatedText,
+			})
+			if completed {
+				result.ExportedCount++
+			}
+		}
+		if !completed {
+			reason := "untranslated"
+			if row.Status == "failed" {
+				reason = "failed"
+			}
+			errorMessage := ""
+			if row.ErrorMessage != nil {
+				errorMessage = *row.ErrorMessage
+			}
+			result.SkippedRows = append(result.SkippedRows, ExportSkippedRow{
+				Phase:        mainTranslationProgressPhase,
+				RowID:        row.RowID,
+				FormID:       row.ID,
+				EditorID:     editorID,
+				RecordType:   row.RecordType,
+				SourceText:   row.SourceText,
+				SourcePlugin: plugin,
+				Reason:       reason,
+				ErrorMessage: errorMessage,
+				TagIssues:    toExportTagIssues(row.TagIssues),
+			})
+			continue
+		}
+		if row.RecordType == skyrim.InterfaceTranslationRecordType {
+			continue
+		}
+		bucket := bucketFor(plugin)
+		bucket.mainResults = append(bucket.mainResults, formatexporter.ExportRecord{
+			FormID:         row.ID,
+			EditorID:       editorID,
+			RecordType:     row.RecordType,
+			SourceText:     row.SourceText,
+			TranslatedText: translatedText,
+		})
+	}
+
+	plugins := make([]string, 0, len(buckets))
+	for plugin := range buckets {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+
+	for _, plugin := range plugins {
+		bucket := buckets[plugin]
+		outputPath := filepath.Join(outputDir, buildExportFileName(plugin, sourceLanguage, destLanguage))
+		if err := s.exporter.GenerateXTranslatorXML(ctx, formatexporter.ExportInput{
+			PluginName:     plugin,
+			SourceLanguage: sourceLanguage,
+			DestLanguage:   destLanguage,
+			TermResults:    bucket.termResults,
+			MainResults:    bucket.mainResults,
+			OutputFilePath: outputPath,
+		}); err != nil {
+			return ExportPhaseResult{}, fmt.Errorf("generate xtranslator xml task_id=%s plugin=%s: %w", trimmedTaskID, plugin, err)
+		}
+		result.Files = append(result.Files, ExportedFile{
+			PluginName:     plugin,
+			OutputFilePath: outputPath,
+			TermCount:      len(bucket.termResults),
+			MainCount:      len(bucket.mainResults),
+		})
+		result.ExportedCount += len(bucket.termResults) + len(bucket.mainResults)
+	}
+
+	modNames := make([]string, 0, len(interfaceBuckets))
+	for modName := range interfaceBuckets {
+		modNames = append(modNames, modName)
+	}
+	sort.Strings(modNames)
+	for _, modName := range modNames {
+		outputPath, err := s.exporter.GenerateInterfaceTranslation(ctx, formatexporter.InterfaceTranslationExportInput{
+			ModName:      modName,
+			DestLanguage: destLanguage,
+			Records:      interfaceBuckets[modName],
+			OutputDir:    outputDir,
+		})
+		if err != nil {
+			return ExportPhaseResult{}, fmt.Errorf("generate interface translation task_id=%s mod=%s: %w", trimmedTaskID, modName, err)
+		}
+		result.InterfaceTranslationFiles = append(result.InterfaceTranslationFiles, outputPath)
+	}
+
+	if input.IncludeStringTables {
+		stringTableFiles, err := s.exportStringTables(ctx, trimmedTaskID, outputDir, sourceLanguage, destLanguage, buckets)
+		if err != nil {
+			return ExportPhaseResult{}, err
+		}
+		result.StringTableFiles = stringTableFiles
+	}
+
+	recorded, err := s.recordTranslationMemory(ctx, trimmedTaskID, mainRows)
+	if err != nil {
+		return ExportPhaseResult{}, err
+	}
+	result.MemoryRecordedCount = recorded
+
+	result.SkippedCount = len(result.SkippedRows)
+	switch {
+	case result.ExportedCount == 0 && result.SkippedCount == 0:
+		result.Status = "empty"
+	case result.SkippedCount > 0:
+		result.Status = "completed_partial"
+	default:
+		result.Status = "completed"
+	}
+	return result, nil
+}
+
+// exportStringTables writes translated string tables for every loaded plugin file of the task.
+func (s *TranslationFlowService) exportStringTables(
+	ctx context.Context,
+	taskID string,
+	outputDir string,
+	sourceLanguage string,
+	destLanguage string,
+	buckets map[string]*exportPluginBucket,
+) ([]string, error) {
+	files, err := s.store.ListFiles(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list translation-flow files task_id=%s: %w", taskID, err)
+	}
+	written := make([]string, 0)
+	for _, file := range files {
+		if !skyrim.IsPluginFile(file.SourceFilePath) {
+			continue
+		}
+		plugin := resolveExportPlugin("", "", file.SourceFilePath)
+		records := make([]formatexporter.ExportRecord, 0)
+		if bucket, ok := buckets[plugin]; ok {
+			records = append(records, bucket.termResults...)
+			records = append(records, bucket.mainResults...)
+		}
+		paths, err := s.exporter.GenerateStringTables(ctx, formatexporter.StringTableExportInput{
+			PluginPath:     file.SourceFilePath,
+			SourceLanguage: sourceLanguage,
+			DestLanguage:   destLanguage,
+			Records:        records,
+			OutputDir:      outputDir,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("generate string tables task_id=%s plugin=%s: %w", taskID, plugin, err)
+		}
+		written = append(written, paths...)
+	}
+	return written, nil
+}
+
+func toExportTagIssues(issues []translatorslice.TagIssue) []ExportTagIssue {
+	if len(issues) == 0 {
+		return nil
+	}
+	converted := make([]ExportTagIssue, len(issues))
+	for i, issue := range issues {
+		converted[i] = ExportTagIssue{
+			Kind:        string(issue.Kind),
+			Class:       issue.Class,
+			Placeholder: issue.Placeholder,
+			Token:       issue.Token,
+		}
+	}
+	return converted
+}
+
+// resolveExportPlugin picks the owning plugin from explicit metadata, the "0x...|Plugin.esp" form ID suffix, or the source file name.
+func resolveExportPlugin(sourcePlugin string, formID string, sourceFile string) string {
+	candidates := []string{sourcePlugin}
+	if _, pluginPart, ok := strings.Cut(formID, "|"); ok {
+		candidates = append(candidates, pluginPart)
+	}
+	candidates = append(candidates, sourceFile)
+	for _, candidate := range candidates {
+		match := personaSourcePluginPattern.FindString(strings.TrimSpace(candidate))
+		if match != "" {
+			return match
+		}
+	}
+	return "UNKNOWN"
+}
+
+func buildExportFileName(plugin string, sourceLanguage string, destLanguage string) string {
+	base := strings.TrimSuffix(plugin, filepath.Ext(plugin))
+	return fmt.Sprintf("%s_%s_%s.xml", base, sourceLanguage, destLanguage)
+}
@@ -0,0 +1,218 @@
+package workflow
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	formatexporter "github.com/ishibata91/ai-translation-engine-2/pkg/format/exporter"
+	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+func TestTranslationFlowServiceRunExportPhaseWritesOneXMLPerPluginAndReportsSkippedRows(t *testing.T) {
+	exporter := &stubTranslationExporter{}
+	completedText := "あなたの重荷を背負います。"
+	dialogueEditorID := "DialogueEDID"
+	tagErrorMessage := "missing placeholders in translation: [TAG_0] (alias <Alias=Jarl>)"
+	service := &TranslationFlowService{
+		terminology: &stubTerminology{
+			translatedEntries: []terminologyslice.TranslatedEntry{
+				{
+					TerminologyEntry: terminologyslice.TerminologyEntry{ID: "0x000001|Skyrim.esm", EditorID: "IronSword", RecordType: "WEAP:FULL", SourceText: "Iron Sword", SourceFile: "Skyrim.esm.json"},
+					TranslatedText:   "鉄の剣",
+					TranslationState: "translated",
+				},
+				{
+					TerminologyEntry: terminologyslice.TerminologyEntry{ID: "0x000002|MyMod.esp", EditorID: "MyArmor", RecordType: "ARMO:FULL", SourceText: "My Armor", SourceFile: "MyMod.esp.json"},
+					TranslationState: "missing",
+				},
+			},
+		},
+		mainTranslation: &stubMainTranslator{
+			results: []translatorslice.TranslationResult{
+				{RowID: "dialogue_response:1", ID: "0x000010", EditorID: &dialogueEditorID, RecordType: "INFO NAM1", SourceText: "I am sworn to carry your burdens.", TranslatedText: &completedText, Status: "completed", SourcePlugin: "Skyrim.esm"},
+				{RowID: "quest_stage:1", ID: "0x000020", RecordType: "QUST CNAM", SourceText: "Talk to <Alias=Jarl>.", Status: "failed", ErrorMessage: &tagErrorMessage, TagIssues: []translatorslice.TagIssue{
+					{Kind: translatorslice.TagIssueMissing, Class: translatorslice.TagClassAlias, Placeholder: "[TAG_0]", Token: "<Alias=Jarl>"},
+				}, SourcePlugin: "MyMod.esp"},
+				{RowID: "item_description:1", ID: "0x000030", RecordType: "BOOK DESC", SourceText: "A book.", Status: "pending", SourcePlugin: "MyMod.esp"},
+			},
+		},
+		exporter: exporter,
+	}
+
+	outputDir := filepath.Join("out", "xml")
+	result, err := service.RunExportPhase(context.Background(), RunExportPhaseInput{
+		TaskID:    "task-export",
+		OutputDir: outputDir,
+	})
+	if err != nil {
+		t.Fatalf("RunExportPhase failed: %v", err)
+	}
+
+	if len(exporter.inputs) != 1 {
+		t.Fatalf("unexpected export call count: got=%d want=%d", len(exporter.inputs), 1)
+	}
+	exported := exporter.inputs[0]
+	if exported.PluginName != "Skyrim.esm" {
+		t.Fatalf("unexpected plugin name: got=%q want=%q", exported.PluginName, "Skyrim.esm")
+	}
+	wantPath := filepath.Join(outputDir, "Skyrim_english_japanese.xml")
+	if exported.OutputFilePath != wantPath {
+		t.Fatalf("unexpected output path: got=%q want=%q", exported.OutputFilePath, wantPath)
+	}
+	if len(exported.TermResults) != 1 || len(exported.MainResults) != 1 {
+		t.Fatalf("unexpected exported records: term=%d main=%d", len(exported.TermResults), len(exported.MainResults))
+	}
+	if exported.MainResults[0].EditorID != dialogueEditorID || exported.MainResults[0].TranslatedText != completedText {
+		t.Fatalf("unexpected main export record: %+v", exported.MainResults[0])
+	}
+
+	if result.Status != "completed_partial" {
+		t.Fatalf("unexpected status: got=%q want=%q", result.Status, "completed_partial")
+	}
+	if result.ExportedCount != 2 || result.SkippedCount != 3 {
+		t.Fatalf("unexpected counts: exported=%d skipped=%d", result.ExportedCount, result.SkippedCount)
+	}
+	reasons := map[string]string{}
+	for _, row := range result.SkippedRows {
+		reasons[row.RowID] = row.Reason
+		if row.SourcePlugin != "MyMod.esp" {
+			t.Fatalf("unexpected skipped row plugin: %+v", row)
+		}
+	}
+	if reasons["0x000002|MyMod.esp"] != "untranslated" || reasons["quest_stage:1"] != "failed" || reasons["item_description:1"] != "untranslated" {
+		t.Fatalf("unexpected skipped reasons: %+v", reasons)
+	}
+	for _, row := range result.SkippedRows {
+		if row.RowID != "quest_stage:1" {
+			continue
+		}
+		wantIssues := []ExportTagIssue{{Kind: "missing", Class: "alias", Placeholder: "[TAG_0]", Token: "<Alias=Jarl>"}}
+		if row.ErrorMessage != tagErrorMessage || !reflect.DeepEqual(row.TagIssues, wantIssues) {
+			t.Fatalf("expected failed row to expose its tag issues, got %+v", row)
+		}
+	}
+}
+
+func TestTranslationFlowServiceRunExportPhaseWritesStringTablesForPluginInputs(t *testing.T) {
+	exporter := &stubTranslationExporter{}
+	service := &TranslationFlowService{
+		store: &stubTranslationFlowStore{
+			loadedFiles: []translationflow.LoadedFile{
+				{ID: 1, SourceFilePath: filepath.Join("mods", "MyMod.esp")},
+				{ID: 2, SourceFilePath: filepath.Join("mods", "Other.esp.json")},
+			},
+		},
+		terminology: &stubTerminology{
+			translatedEntries: []terminologyslice.TranslatedEntry{
+				{
+					TerminologyEntry: terminologyslice.TerminologyEntry{ID: "0x000002|MyMod.esp", EditorID: "MyArmor", RecordType: "ARMO:FULL", SourceText: "My Armor", SourceFile: "MyMod.esp"},
+					TranslatedText:   "私の鎧",
+					TranslationState: "translated",
+				},
+			},
+		},
+		mainTranslation: &stubMainTranslator{},
+		exporter:        exporter,
+	}
+
+	result, err := service.RunExportPhase(context.Background(), RunExportPhaseInput{
+		TaskID:              "task-export",
+		OutputDir:           "out",
+		IncludeStringTables: true,
+	})
+	if err != nil {
+		t.Fatalf("RunExportPhase failed: %v", err)
+	}
+
+	if len(exporter.stringTableInputs) != 1 {
+		t.Fatalf("unexpected string table export count: got=%d want=%d", len(exporter.stringTableInputs), 1)
+	}
+	input := exporter.stringTableInputs[0]
+	if input.PluginPath != filepath.Join("mods", "MyMod.esp") || input.SourceLanguage != "english" || input.DestLanguage != "japanese" {
+		t.Fatalf("unexpected string table input: %+v", input)
+	}
+	if len(input.Records) != 1 || input.Records[0].TranslatedText != "私の鎧" {
+		t.Fatalf("unexpected string table records: %+v", input.Records)
+	}
+	if len(result.StringTableFiles) != 1 {
+		t.Fatalf("unexpected string table files: %+v", result.StringTableFiles)
+	}
+}
+
+func TestTranslationFlowServiceRunExportPhaseWritesInterfaceTranslationsPerMod(t *testing.T) {
+	exporter := &stubTranslationExporter{}
+	translated := "機能を有効化"
+	modName := "MyMod"
+	service := &TranslationFlowService{
+		terminology: &stubTerminology{},
+		mainTranslation: &stubMainTranslator{
+			results: []translatorslice.TranslationResult{
+				{RowID: "interface_text:1", ID: "$MYMOD_ENABLE", RecordType: "MCM TEXT", SourceText: "Enable feature", TranslatedText: &translated, Status: "completed", ParentID: &modName},
+				{RowID: "interface_text:2", ID: "$MYMOD_HELP", RecordType: "MCM TEXT", SourceText: "Help", Status: "pending", ParentID: &modName},
+			},
+		},
+		exporter: exporter,
+	}
+
+	result, err := service.RunExportPhase(context.Background(), RunExportPhaseInput{TaskID: "task-export", OutputDir: "out"})
+	if err != nil {
+		t.Fatalf("RunExportPhase failed: %v", err)
+	}
+
+	if len(exporter.inputs) != 0 {
+		t.Fatalf("MCM keys must not be written to SSTXML: %+v", exporter.inputs)
+	}
+	if len(exporter.interfaceTranslationInputs) != 1 {
+		t.Fatalf("unexpected interface translation export count: got=%d want=%d", len(exporter.interfaceTranslationInputs), 1)
+	}
+	input := exporter.interfaceTranslationInputs[0]
+	if input.ModName != "MyMod" || input.DestLanguage != "japanese" || len(input.Records) != 2 {
+		t.Fatalf("unexpected interface translation input: %+v", input)
+	}
+	if len(result.InterfaceTranslationFiles) != 1 {
+		t.Fatalf("unexpected interface translation files: %+v", result.InterfaceTranslationFiles)
+	}
+	if result.ExportedCount != 1 || result.SkippedCount != 1 || result.Status != "completed_partial" {
+		t.Fatalf("unexpected export summary: exported=%d skipped=%d status=%s", result.ExportedCount, result.SkippedCount, result.Status)
+	}
+}
+
+func TestTranslationFlowServiceRunExportPhaseRequiresOutputDir(t *testing.T) {
+	service := &TranslationFlowService{
+		terminology:     &stubTerminology{},
+		mainTranslation: &stubMainTranslator{},
+		exporter:        &stubTranslationExporter{},
+	}
+
+	if _, err := service.RunExportPhase(context.Background(), RunExportPhaseInput{TaskID: "task-export"}); err == nil {
+		t.Fatalf("RunExportPhase unexpectedly succeeded without output_dir")
+	}
+}
+
+type stubTranslationExporter struct {
+	inputs                     []formatexporter.ExportInput
+	stringTableInputs          []formatexporter.StringTableExportInput
+	interfaceTranslationInputs []formatexporter.InterfaceTranslationExportInput
+}
+
+func (s *stubTranslationExporter) GenerateXTranslatorXML(ctx context.Context, input formatexporter.ExportInput) error {
+	_ = ctx
+	s.inputs = append(s.inputs, input)
+	return nil
+}
+
+func (s *stubTranslationExporter) GenerateStringTables(ctx context.Context, input formatexporter.StringTableExportInput) ([]string, error) {
+	_ = ctx
+	s.stringTableInputs = append(s.stringTableInputs, input)
+	return []string{filepath.Join(input.OutputDir, "Strings", "stub.STRINGS")}, nil
+}
+
+func (s *stubTranslationExporter) GenerateInterfaceTranslation(ctx context.Context, input formatexporter.InterfaceTranslationExportInput) (string, error) {
+	_ = ctx
+	s.interfaceTranslationInputs = append(s.interfaceTranslationInputs, input)
+	return filepath.Join(input.OutputDir, "Interface", "Translations", input.ModName+"_JAPANESE.txt"), nil
+}
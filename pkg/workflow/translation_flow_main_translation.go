@@ -0,0 +1,287 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+	taskworkflow "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
+)
+
+const mainTranslationProgressPhase = "main_translation"
+
+// RunMainTranslationPhase executes the main translation phase synchronously and returns the persisted summary.
+func (s *TranslationFlowService) RunMainTranslationPhase(ctx context.Context, input RunMainTranslationPhaseInput) (MainTranslationPhaseResult, error) {
+	trimmedTaskID := strings.TrimSpace(input.TaskID)
+	if trimmedTaskID == "" {
+		return MainTranslationPhaseResult{}, fmt.Errorf("task_id is required")
+	}
+	if strings.TrimSpace(input.Request.Model) == "" {
+		return MainTranslationPhaseResult{}, fmt.Errorf("request.model is required")
+	}
+	if s.mainTranslation == nil {
+		return MainTranslationPhaseResult{}, fmt.Errorf("main translation slice is not configured")
+	}
+	if err := s.recordResumeEntrypoint(ctx, trimmedTaskID, mainTranslationEntrypoint, phaseExecutionConfigMetadata(input.Request, input.Prompt)); err != nil {
+		return MainTranslationPhaseResult{}, err
+	}
+	// Dialogue and quest summaries feed the translation context, so bring them up to date first.
+	// The summary slice caches by content, so a rerun only reaches the LLM for changed groups.
+	if s.summary != nil {
+		if _, err := s.runSummaryPhase(ctx, trimmedTaskID, input.Request); err != nil {
+			return MainTranslationPhaseResult{}, fmt.Errorf("run summary phase before main translation task_id=%s: %w", trimmedTaskID, err)
+		}
+	}
+
+	options := translatorslice.PhaseOptions{
+		Request: translatorslice.RequestConfig{
+			Provider:        input.Request.Provider,
+			Model:           input.Request.Model,
+			Endpoint:        input.Request.Endpoint,
+			APIKey:          input.Request.APIKey,
+			Temperature:     input.Request.Temperature,
+			ContextLength:   input.Request.ContextLength,
+			SyncConcurrency: input.Request.SyncConcurrency,
+			BulkStrategy:    input.Request.BulkStrategy,
+		},
+		Prompt: translatorslice.PromptConfig{
+			UserPrompt:   input.Prompt.UserPrompt,
+			SystemPrompt: input.Prompt.SystemPrompt,
+		},
+	}
+	requests, err := s.mainTranslation.PreparePrompts(ctx, trimmedTaskID, options)
+	if err != nil {
+		return MainTranslationPhaseResult{}, fmt.Errorf("prepare main translation prompts task_id=%s: %w", trimmedTaskID, err)
+	}
+	if len(requests) > 0 {
+		baseSummary, err := s.mainTranslation.GetPhaseSummary(ctx, trimmedTaskID)
+		if err != nil {
+			return MainTranslationPhaseResult{}, fmt.Errorf("get prepared main translation summary task_id=%s: %w", trimmedTaskID, err)
+		}
+		startCurrent := baseSummary.SavedCount
+		if startCurrent < 0 {
+			startCurrent = 0
+		}
+		// Rows may be split into several chunk requests, so runtime progress is counted per request.
+		// Later book chunks are requested in subsequent rounds but counted up front.
+		rounds := 0
+		pendingRequests := 0
+		for _, req := range requests {
+			remaining := remainingChunkRequests(req)
+			pendingRequests += remaining
+			if remaining > rounds {
+				rounds = remaining
+			}
+		}
+		progressTotal := startCurrent + pendingRequests
+		startSummary := translatorslice.PhaseSummary{
+			TaskID:          trimmedTaskID,
+			Status:          "running",
+			TargetCount:     baseSummary.TargetCount,
+			SavedCount:      baseSummary.SavedCount,
+			FailedCount:     baseSummary.FailedCount,
+			ProgressMode:    "determinate",
+			ProgressCurrent: startCurrent,
+			ProgressTotal:   progressTotal,
+			ProgressMessage: buildTerminologyProgressMessage(startCurrent, progressTotal),
+		}
+		if err := s.mainTranslation.UpdatePhaseSummary(ctx, startSummary); err != nil {
+			return MainTranslationPhaseResult{}, fmt.Errorf("update running main translation summary task_id=%s: %w", trimmedTaskID, err)
+		}
+		s.reportMainTranslationProgress(ctx, startSummary)
+		executionConfig := llmio.ExecutionConfig{
+			Provider:        input.Request.Provider,
+			Model:           input.Request.Model,
+			Endpoint:        input.Request.Endpoint,
+			APIKey:          input.Request.APIKey,
+			Temperature:     input.Request.Temperature,
+			ContextLength:   input.Request.ContextLength,
+			SyncConcurrency: input.Request.SyncConcurrency,
+			BulkStrategy:    input.Request.BulkStrategy,
+			ConfigNamespace: mainTranslationLLMNamespace,
+			TaskID:          trimmedTaskID,
+			Phase:           mainTranslationProgressPhase,
+		}
+		// Each round advances every book by one chunk so that the next chunk sees the previous translation.
+		// The round bound stops books whose chunk responses never came back from running forever.
+		for round := 0; len(requests) > 0 && round < rounds; round++ {
+			if round > 0 {
+				if startSummary, err = s.continueMainTranslationSummary(ctx, startSummary); err != nil {
+					return MainTranslationPhaseResult{}, err
+				}
+			}
+			responses, err := s.executeMainTranslationWithProgress(ctx, startSummary, executionConfig, requests)
+			if err != nil {
+				summary, summaryErr := s.mainTranslation.GetPhaseSummary(ctx, trimmedTaskID)
+				if summaryErr == nil {
+					runErrorSummary := translatorslice.PhaseSummary{
+						TaskID:          trimmedTaskID,
+						Status:          "run_error",
+						TargetCount:     summary.TargetCount,
+						SavedCount:      summary.SavedCount,
+						FailedCount:     summary.FailedCount,
+						ProgressMode:    "hidden",
+						ProgressCurrent: summary.ProgressCurrent,
+						ProgressTotal:   summary.ProgressTotal,
+						ProgressMessage: "本文翻訳の実行に失敗しました",
+					}
+					_ = s.mainTranslation.UpdatePhaseSummary(ctx, runErrorSummary)
+					s.reportMainTranslationProgress(ctx, runErrorSummary)
+				}
+				return MainTranslationPhaseResult{}, fmt.Errorf("execute main translation llm requests task_id=%s: %w", trimmedTaskID, err)
+			}
+			if err := s.mainTranslation.SaveResults(ctx, trimmedTaskID, responses); err != nil {
+				return MainTranslationPhaseResult{}, fmt.Errorf("save main translation results task_id=%s: %w", trimmedTaskID, err)
+			}
+			startSummary.ProgressCurrent += len(requests)
+			requests, err = s.mainTranslation.PrepareNextChunks(ctx, trimmedTaskID, options)
+			if err != nil {
+				return MainTranslationPhaseResult{}, fmt.Errorf("prepare next main translation chunks task_id=%s: %w", trimmedTaskID, err)
+			}
+		}
+	}
+	if summary, summaryErr := s.mainTranslation.GetPhaseSummary(ctx, trimmedTaskID); summaryErr == nil {
+		s.reportMainTranslationProgress(ctx, summary)
+	}
+
+	return s.GetMainTranslationPhase(ctx, trimmedTaskID)
+}
+
+// GetMainTranslationPhase returns the current main translation phase summary.
+func (s *TranslationFlowService) GetMainTranslationPhase(ctx context.Context, taskID string) (MainTranslationPhaseResult, error) {
+	trimmedTaskID := strings.TrimSpace(taskID)
+	if trimmedTaskID == "" {
+		return MainTranslationPhaseResult{}, fmt.Errorf("task_id is required")
+	}
+	if s.mainTranslation == nil {
+		return MainTranslationPhaseResult{}, fmt.Errorf("main translation slice is not configured")
+	}
+	summary, err := s.mainTranslation.GetPhaseSummary(ctx, trimmedTaskID)
+	if err != nil {
+		return MainTranslationPhaseResult{}, fmt.Errorf("get main translation phase summary task_id=%s: %w", trimmedTaskID, err)
+	}
+	return MainTranslationPhaseResult{
+		TaskID:          summary.TaskID,
+		Status:          summary.Status,
+		TargetCount:     summary.TargetCount,
+		SavedCount:      summary.SavedCount,
+		FailedCount:     summary.FailedCount,
+		ProgressMode:    summary.ProgressMode,
+		ProgressCurrent: summary.ProgressCurrent,
+		ProgressTotal:   summary.ProgressTotal,
+		ProgressMessage: summary.ProgressMessage,
+	}, nil
+}
+
+// continueMainTranslationSummary marks the phase running again for the next chunk round,
+// taking the saved and failed counts persisted by the previous round.
+func (s *TranslationFlowService) continueMainTranslationSummary(ctx context.Context, previous translatorslice.PhaseSummary) (translatorslice.PhaseSummary, error) {
+	saved, err := s.mainTranslation.GetPhaseSummary(ctx, previous.TaskID)
+	if err != nil {
+		return translatorslice.PhaseSummary{}, fmt.Errorf("get main translation summary between chunk rounds task_id=%s: %w", previous.TaskID, err)
+	}
+	next := previous
+	next.Status = "running"
+	next.SavedCount = saved.SavedCount
+	next.FailedCount = saved.FailedCount
+	next.ProgressMode = "determinate"
+	next.ProgressMessage = buildTerminologyProgressMessage(next.ProgressCurrent, next.ProgressTotal)
+	if err := s.mainTranslation.UpdatePhaseSummary(ctx, next); err != nil {
+		return translatorslice.PhaseSummary{}, fmt.Errorf("update running main translation summary task_id=%s: %w", previous.TaskID, err)
+	}
+	s.reportMainTranslationProgress(ctx, next)
+	return next, nil
+}
+
+// remainingChunkRequests counts the request itself and the later chunks of the same book.
+func remainingChunkRequests(req llmio.Request) int {
+	count, _ := req.Metadata["chunk_count"].(int)
+	index, _ := req.Metadata["chunk_index"].(int)
+	if count <= index {
+		return 1
+	}
+	return count - index
+}
+
+func (s *TranslationFlowService) executeMainTranslationWithProgress(
+	ctx context.Context,
+	startSummary translatorslice.PhaseSummary,
+	config llmio.ExecutionConfig,
+	requests []llmio.Request,
+) ([]llmio.Response, error) {
+	executorWithProgress, ok := s.executor.(terminologyPhaseExecutorWithProgress)
+	if !ok {
+		return s.executor.Execute(ctx, config, requests)
+	}
+
+	requestCount := len(requests)
+	persistStride := terminologyProgressPersistStride(requestCount)
+	lastPersisted := 0
+	currentSummary := startSummary
+	var progressMu sync.Mutex
+	progress := func(completed, _ int) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+
+		safeCompleted := completed
+		if safeCompleted < 0 {
+			safeCompleted = 0
+		}
+		if safeCompleted > requestCount {
+			safeCompleted = requestCount
+		}
+		runningSummary := startSummary
+		runningSummary.ProgressCurrent = startSummary.ProgressCurrent + safeCompleted
+		runningSummary.ProgressMessage = buildTerminologyProgressMessage(runningSummary.ProgressCurrent, runningSummary.ProgressTotal)
+
+		if shouldPersistTerminologyProgress(safeCompleted, requestCount, lastPersisted, persistStride) {
+			_ = s.mainTranslation.UpdatePhaseSummary(ctx, runningSummary)
+			lastPersisted = safeCompleted
+		}
+		currentSummary = runningSummary
+		s.reportMainTranslationProgress(ctx, runningSummary)
+	}
+	streamingExecutor, ok := s.executor.(phaseExecutorWithStreamProgress)
+	if !ok {
+		return executorWithProgress.ExecuteWithProgress(ctx, config, requests, progress)
+	}
+	return streamingExecutor.ExecuteWithStreamProgress(ctx, config, requests, progress, func(receivedChars int) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+
+		streamingSummary := currentSummary
+		streamingSummary.ProgressMessage = fmt.Sprintf("書籍本文を生成中... %d 文字受信", receivedChars)
+		s.reportMainTranslationProgress(ctx, streamingSummary)
+	})
+}
+
+func (s *TranslationFlowService) reportMainTranslationProgress(ctx context.Context, summary translatorslice.PhaseSummary) {
+	if s.notifier == nil || strings.TrimSpace(summary.TaskID) == "" {
+		return
+	}
+
+	status := runtimeprogress.StatusInProgress
+	switch summary.Status {
+	case "completed", "empty":
+		status = runtimeprogress.StatusCompleted
+	case "completed_partial", "run_error":
+		status = runtimeprogress.StatusFailed
+	}
+
+	s.notifier.OnProgress(ctx, runtimeprogress.ProgressEvent{
+		CorrelationID: summary.TaskID,
+		TaskID:        summary.TaskID,
+		TaskType:      string(taskworkflow.TypeTranslationProject),
+		Phase:         mainTranslationProgressPhase,
+		Current:       summary.ProgressCurrent,
+		Total:         summary.ProgressTotal,
+		Completed:     summary.ProgressCurrent,
+		Failed:        summary.FailedCount,
+		Status:        status,
+		Message:       summary.ProgressMessage,
+	})
+}
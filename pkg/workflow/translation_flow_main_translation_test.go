@@ -0,0 +1,354 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+func TestTranslationFlowServiceRunMainTranslationPhaseSavesResponses(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{
+			{Metadata: map[string]interface{}{"row_id": "dialogue_response:1"}},
+			{Metadata: map[string]interface{}{"row_id": "quest_stage:1"}},
+		},
+		summary: translatorslice.PhaseSummary{
+			TaskID:      "task-main",
+			Status:      "running",
+			TargetCount: 3,
+			SavedCount:  1,
+		},
+		finalSummary: translatorslice.PhaseSummary{
+			TaskID:          "task-main",
+			Status:          "completed",
+			TargetCount:     3,
+			SavedCount:      3,
+			ProgressMode:    "hidden",
+			ProgressCurrent: 3,
+			ProgressTotal:   3,
+			ProgressMessage: "本文翻訳完了",
+		},
+	}
+	notifier := &stubWorkflowProgressNotifier{}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor: &stubTerminologyExecutor{
+			responses: []llmio.Response{{Success: true}, {Success: true}},
+			steps:     []int{1, 2},
+		},
+		notifier: notifier,
+	}
+
+	result, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  " task-main ",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	})
+	if err != nil {
+		t.Fatalf("RunMainTranslationPhase failed: %v", err)
+	}
+	if len(mainTranslation.savedResponses) != 2 {
+		t.Fatalf("unexpected saved response count: got=%d want=%d", len(mainTranslation.savedResponses), 2)
+	}
+	if result.Status != "completed" || result.TargetCount != 3 || result.SavedCount != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(notifier.events) < 3 {
+		t.Fatalf("unexpected event count: got=%d want>=%d", len(notifier.events), 3)
+	}
+	first := notifier.events[0]
+	if first.Phase != mainTranslationProgressPhase || first.Current != 1 || first.Total != 3 {
+		t.Fatalf("unexpected first event: %+v", first)
+	}
+	last := notifier.events[len(notifier.events)-1]
+	if last.Status != runtimeprogress.StatusCompleted {
+		t.Fatalf("unexpected last event status: got=%q want=%q", last.Status, runtimeprogress.StatusCompleted)
+	}
+}
+
+type stubStreamingExecutor struct {
+	stubTerminologyExecutor
+	streamed []int
+}
+
+func (s *stubStreamingExecutor) ExecuteWithStreamProgress(
+	ctx context.Context,
+	config llmio.ExecutionConfig,
+	requests []llmio.Request,
+	progress func(completed, total int),
+	streamProgress func(receivedChars int),
+) ([]llmio.Response, error) {
+	for _, receivedChars := range s.streamed {
+		streamProgress(receivedChars)
+	}
+	return s.ExecuteWithProgress(ctx, config, requests, progress)
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseReportsBookStreaming(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{
+			{Metadata: map[string]interface{}{"row_id": "book_body:1", "record_type": "BOOK DESC"}},
+		},
+		summary: translatorslice.PhaseSummary{TaskID: "task-main", Status: "running", TargetCount: 1},
+	}
+	notifier := &stubWorkflowProgressNotifier{}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor: &stubStreamingExecutor{
+			stubTerminologyExecutor: stubTerminologyExecutor{responses: []llmio.Response{{Success: true}}},
+			streamed:                []int{200, 400},
+		},
+		notifier: notifier,
+	}
+
+	if _, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-main",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	}); err != nil {
+		t.Fatalf("RunMainTranslationPhase failed: %v", err)
+	}
+	streamMessages := 0
+	for _, event := range notifier.events {
+		if event.Message == "書籍本文を生成中... 400 文字受信" && event.Status == runtimeprogress.StatusInProgress {
+			streamMessages++
+		}
+	}
+	if streamMessages != 1 {
+		t.Fatalf("expected book streaming progress to be reported, events=%+v", notifier.events)
+	}
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseRunsSummaryFirst(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{
+			{Metadata: map[string]interface{}{"row_id": "dialogue_response:1"}},
+		},
+		summary:      translatorslice.PhaseSummary{TaskID: "task-main", Status: "running", TargetCount: 1},
+		finalSummary: translatorslice.PhaseSummary{TaskID: "task-main", Status: "completed", TargetCount: 1, SavedCount: 1},
+	}
+	summary := &stubSummarySlice{requests: []llmio.Request{{UserPrompt: "summarize"}}}
+	service := &TranslationFlowService{
+		store:           &stubTranslationFlowStore{summarySource: newSummarySource()},
+		summary:         summary,
+		mainTranslation: mainTranslation,
+		executor:        &stubTerminologyExecutor{responses: []llmio.Response{{Success: true}}},
+		notifier:        &stubWorkflowProgressNotifier{},
+	}
+
+	if _, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-main",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	}); err != nil {
+		t.Fatalf("RunMainTranslationPhase failed: %v", err)
+	}
+	if len(summary.preparedInput.DialogueItems) != 2 || len(summary.savedResponses) != 1 {
+		t.Fatalf("summary phase must run before main translation: input=%+v saved=%d", summary.preparedInput, len(summary.savedResponses))
+	}
+	if len(mainTranslation.savedResponses) != 1 {
+		t.Fatalf("unexpected main translation saved count: %d", len(mainTranslation.savedResponses))
+	}
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseStopsWhenSummaryFails(t *testing.T) {
+	mainTranslation := &stubMainTranslator{}
+	service := &TranslationFlowService{
+		store:           &stubTranslationFlowStore{summarySource: newSummarySource()},
+		summary:         &stubSummarySlice{requests: []llmio.Request{{UserPrompt: "summarize"}}},
+		mainTranslation: mainTranslation,
+		executor:        &stubTerminologyExecutor{err: errors.New("executor failed")},
+		notifier:        &stubWorkflowProgressNotifier{},
+	}
+
+	if _, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-main",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	}); err == nil {
+		t.Fatal("RunMainTranslationPhase must fail when the summary phase fails")
+	}
+	if mainTranslation.preparePromptsCalls != 0 {
+		t.Fatalf("main translation prompts must not be prepared after a summary failure")
+	}
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseMarksRunError(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{
+			{Metadata: map[string]interface{}{"row_id": "dialogue_response:1"}},
+		},
+		summary: translatorslice.PhaseSummary{TaskID: "task-main", Status: "running", TargetCount: 1},
+	}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor:        &stubTerminologyExecutor{err: errors.New("executor failed")},
+		notifier:        &stubWorkflowProgressNotifier{},
+	}
+
+	_, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-main",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	})
+	if err == nil {
+		t.Fatalf("RunMainTranslationPhase unexpectedly succeeded")
+	}
+	if mainTranslation.updatedSummary.Status != "run_error" {
+		t.Fatalf("unexpected updated status: got=%q want=%q", mainTranslation.updatedSummary.Status, "run_error")
+	}
+	if len(mainTranslation.savedResponses) != 0 {
+		t.Fatalf("responses must not be saved after executor failure")
+	}
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseSkipsRuntimeWhenEmpty(t *testing.T) {
+	mainTranslation := &stubMainTranslator{
+		summary: translatorslice.PhaseSummary{TaskID: "task-empty", Status: "empty", ProgressMode: "hidden"},
+	}
+	executor := &stubTerminologyExecutor{err: errors.New("executor must not run")}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor:        executor,
+	}
+
+	result, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-empty",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	})
+	if err != nil {
+		t.Fatalf("RunMainTranslationPhase failed: %v", err)
+	}
+	if result.Status != "empty" {
+		t.Fatalf("unexpected status: got=%q want=%q", result.Status, "empty")
+	}
+}
+
+func TestTranslationFlowServiceLoadFilesResetsMainTranslationSummary(t *testing.T) {
+	mainTranslation := &stubMainTranslator{}
+	service := &TranslationFlowService{
+		parser:          &stubSkyrimParser{output: &skyrim.ParserOutput{}},
+		store:           &stubTranslationFlowStore{},
+		terminology:     &stubTerminology{},
+		mainTranslation: mainTranslation,
+	}
+
+	if _, err := service.LoadFiles(context.Background(), LoadTranslationFlowInput{
+		TaskID:    "task-reset",
+		FilePaths: []string{"example.json"},
+	}); err != nil {
+		t.Fatalf("LoadFiles failed: %v", err)
+	}
+	if mainTranslation.updatedSummary.TaskID != "task-reset" || mainTranslation.updatedSummary.Status != "pending" {
+		t.Fatalf("unexpected reset summary: %+v", mainTranslation.updatedSummary)
+	}
+}
+
+type stubMainTranslator struct {
+	preparePromptsResult []llmio.Request
+	summary              translatorslice.PhaseSummary
+	finalSummary         translatorslice.PhaseSummary
+	updatedSummary       translatorslice.PhaseSummary
+	savedResponses       []llmio.Response
+	results              []translatorslice.TranslationResult
+	carries              []translatorslice.ResultCarry
+	nextChunkRounds      [][]llmio.Request
+	saveCalls            int
+	preparePromptsCalls  int
+}
+
+func (s *stubMainTranslator) ID() string {
+	return "MainTranslation"
+}
+
+func (s *stubMainTranslator) PreparePrompts(ctx context.Context, taskID string, options translatorslice.PhaseOptions) ([]llmio.Request, error) {
+	_ = ctx
+	_ = taskID
+	_ = options
+	s.preparePromptsCalls++
+	return s.preparePromptsResult, nil
+}
+
+func (s *stubMainTranslator) PrepareNextChunks(ctx context.Context, taskID string, options translatorslice.PhaseOptions) ([]llmio.Request, error) {
+	_ = ctx
+	_ = taskID
+	_ = options
+	if len(s.nextChunkRounds) == 0 {
+		return nil, nil
+	}
+	next := s.nextChunkRounds[0]
+	s.nextChunkRounds = s.nextChunkRounds[1:]
+	return next, nil
+}
+
+func (s *stubMainTranslator) BuildPrompts(ctx context.Context, taskID string, options translatorslice.PhaseOptions) ([]llmio.Request, error) {
+	return s.PreparePrompts(ctx, taskID, options)
+}
+
+func (s *stubMainTranslator) SaveResults(ctx context.Context, taskID string, responses []llmio.Response) error {
+	_ = ctx
+	_ = taskID
+	s.savedResponses = append([]llmio.Response(nil), responses...)
+	s.saveCalls++
+	if s.finalSummary.TaskID != "" {
+		s.summary = s.finalSummary
+	}
+	return nil
+}
+
+func (s *stubMainTranslator) GetPhaseSummary(ctx context.Context, taskID string) (translatorslice.PhaseSummary, error) {
+	_ = ctx
+	_ = taskID
+	return s.summary, nil
+}
+
+func (s *stubMainTranslator) UpdatePhaseSummary(ctx context.Context, summary translatorslice.PhaseSummary) error {
+	_ = ctx
+	s.updatedSummary = summary
+	s.summary = summary
+	return nil
+}
+
+func (s *stubMainTranslator) CarryForwardResults(ctx context.Context, taskID string, carries []translatorslice.ResultCarry) (int, error) {
+	_ = ctx
+	_ = taskID
+	s.carries = append(s.carries, carries...)
+	return len(carries), nil
+}
+
+func (s *stubMainTranslator) ListResults(ctx context.Context, taskID string) ([]translatorslice.TranslationResult, error) {
+	_ = ctx
+	_ = taskID
+	return append([]translatorslice.TranslationResult(nil), s.results...), nil
+}
+
+func TestTranslationFlowServiceRunMainTranslationPhaseContinuesBookChunks(t *testing.T) {
+	chunk := func(index int) llmio.Request {
+		return llmio.Request{Metadata: map[string]interface{}{"row_id": "book:1", "chunk_index": index, "chunk_count": 3}}
+	}
+	mainTranslation := &stubMainTranslator{
+		preparePromptsResult: []llmio.Request{chunk(0), {Metadata: map[string]interface{}{"row_id": "quest_stage:1", "chunk_index": 0, "chunk_count": 1}}},
+		nextChunkRounds:      [][]llmio.Request{{chunk(1)}, {chunk(2)}, {chunk(2)}},
+		summary:              translatorslice.PhaseSummary{TaskID: "task-book", Status: "running", TargetCount: 2},
+	}
+	notifier := &stubWorkflowProgressNotifier{}
+	service := &TranslationFlowService{
+		mainTranslation: mainTranslation,
+		executor:        &stubTerminologyExecutor{responses: []llmio.Response{{Success: true}}},
+		notifier:        notifier,
+	}
+
+	if _, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+		TaskID:  "task-book",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	}); err != nil {
+		t.Fatalf("RunMainTranslationPhase failed: %v", err)
+	}
+	// Three rounds cover the three chunks; a chunk that keeps coming back must not loop forever.
+	if mainTranslation.saveCalls != 3 {
+		t.Fatalf("unexpected round count: got=%d want=%d", mainTranslation.saveCalls, 3)
+	}
+	if first := notifier.events[0]; first.Total != 4 {
+		t.Fatalf("progress total must count every book chunk up front: %+v", first)
+	}
+}
@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	translationmemoryslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+// TranslationMemory stores confirmed sentence-level translations across tasks and finds exact or fuzzy matches.
+type TranslationMemory interface {
+	Lookup(ctx context.Context, sourceText string) ([]translationmemoryslice.Match, error)
+	Record(ctx context.Context, entries []translationmemoryslice.Entry) (int, error)
+}
+
+// SetTranslationMemory injects the translation memory that exported main translations are recorded into.
+func (s *TranslationFlowService) SetTranslationMemory(memory TranslationMemory) {
+	s.memory = memory
+}
+
+// recordTranslationMemory stores completed main translations of an export as confirmed translations.
+// Exporting is the point where the user accepts the task's translations, so later tasks may reuse them.
+func (s *TranslationFlowService) recordTranslationMemory(ctx context.Context, taskID string, rows []translatorslice.TranslationResult) (int, error) {
+	if s.memory == nil {
+		return 0, nil
+	}
+	entries := make([]translationmemoryslice.Entry, 0, len(rows))
+	for _, row := range rows {
+		if row.Status != "completed" || row.TranslatedText == nil || strings.TrimSpace(*row.TranslatedText) == "" {
+			continue
+		}
+		editorID := ""
+		if row.EditorID != nil {
+			editorID = *row.EditorID
+		}
+		entries = append(entries, translationmemoryslice.Entry{
+			SourceText:   row.SourceText,
+			DestText:     *row.TranslatedText,
+			RecordType:   row.RecordType,
+			EDID:         editorID,
+			SourcePlugin: row.SourcePlugin,
+			Origin:       translationmemoryslice.OriginTask,
+			OriginRef:    taskID,
+		})
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	saved, err := s.memory.Record(ctx, entries)
+	if err != nil {
+		return 0, fmt.Errorf("record translation memory task_id=%s: %w", taskID, err)
+	}
+	return saved, nil
+}
+
+type translationMemoryLookup struct {
+	memory TranslationMemory
+}
+
+// NewTranslationMemoryLookup exposes TranslationMemory.Lookup to the main translation context builder.
+func NewTranslationMemoryLookup(memory TranslationMemory) translatorslice.TranslationMemoryLookup {
+	return &translationMemoryLookup{memory: memory}
+}
+
+func (l *translationMemoryLookup) FindMatches(ctx context.Context, sourceText string) ([]translatorslice.Pass2MemoryMatch, error) {
+	if l.memory == nil || strings.TrimSpace(sourceText) == "" {
+		return nil, nil
+	}
+	matches, err := l.memory.Lookup(ctx, sourceText)
+	if err != nil {
+		return nil, fmt.Errorf("find translation memory matches: %w", err)
+	}
+	out := make([]translatorslice.Pass2MemoryMatch, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, translatorslice.Pass2MemoryMatch{
+			SourceText:     match.SourceText,
+			TranslatedText: match.DestText,
+			Score:          match.Score,
+		})
+	}
+	return out, nil
+}
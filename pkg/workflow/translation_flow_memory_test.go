@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	translationmemoryslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationmemory"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+)
+
+type stubTranslationMemory struct {
+	recorded  []translationmemoryslice.Entry
+	recordErr error
+	matches   []translationmemoryslice.Match
+	lookups   []string
+}
+
+func (s *stubTranslationMemory) Lookup(ctx context.Context, sourceText string) ([]translationmemoryslice.Match, error) {
+	_ = ctx
+	s.lookups = append(s.lookups, sourceText)
+	return s.matches, nil
+}
+
+func (s *stubTranslationMemory) Record(ctx context.Context, entries []translationmemoryslice.Entry) (int, error) {
+	_ = ctx
+	if s.recordErr != nil {
+		return 0, s.recordErr
+	}
+	s.recorded = append(s.recorded, entries...)
+	return len(entries), nil
+}
+
+func TestTranslationFlowServiceRunExportPhaseRecordsTranslationMemory(t *testing.T) {
+	completedText := "あなたの重荷を背負います。"
+	editorID := "DialogueEDID"
+	newService := func(memory *stubTranslationMemory) *TranslationFlowService {
+		service := &TranslationFlowService{
+			terminology: &stubTerminology{},
+			mainTranslation: &stubMainTranslator{
+				results: []translatorslice.TranslationResult{
+					{RowID: "dialogue_response:1", ID: "0x000010", EditorID: &editorID, RecordType: "INFO NAM1", SourceText: "I am sworn to carry your burdens.", TranslatedText: &completedText, Status: "completed", SourcePlugin: "Skyrim.esm"},
+					{RowID: "quest_stage:1", ID: "0x000020", RecordType: "QUST CNAM", SourceText: "Talk to the Jarl.", Status: "failed", SourcePlugin: "Skyrim.esm"},
+				},
+			},
+			exporter: &stubTranslationExporter{},
+		}
+		service.SetTranslationMemory(memory)
+		return service
+	}
+
+	t.Run("完了した本文翻訳だけを確定訳として記録する", func(t *testing.T) {
+		memory := &stubTranslationMemory{}
+		result, err := newService(memory).RunExportPhase(context.Background(), RunExportPhaseInput{TaskID: "task-export", OutputDir: "out"})
+		if err != nil {
+			t.Fatalf("RunExportPhase failed: %v", err)
+		}
+		if result.MemoryRecordedCount != 1 || len(memory.recorded) != 1 {
+			t.Fatalf("unexpected recorded entries: count=%d entries=%+v", result.MemoryRecordedCount, memory.recorded)
+		}
+		got := memory.recorded[0]
+		if got.SourceText != "I am sworn to carry your burdens." || got.DestText != completedText || got.RecordType != "INFO NAM1" ||
+			got.EDID != editorID || got.SourcePlugin != "Skyrim.esm" || got.Origin != translationmemoryslice.OriginTask || got.OriginRef != "task-export" {
+			t.Fatalf("unexpected recorded entry: %+v", got)
+		}
+	})
+
+	t.Run("記録に失敗したらエクスポートを失敗にする", func(t *testing.T) {
+		memory := &stubTranslationMemory{recordErr: errors.New("disk full")}
+		if _, err := newService(memory).RunExportPhase(context.Background(), RunExportPhaseInput{TaskID: "task-export", OutputDir: "out"}); err == nil {
+			t.Fatal("RunExportPhase must fail when the translation memory cannot be recorded")
+		}
+	})
+}
+
+func TestNewTranslationMemoryLookup(t *testing.T) {
+	memory := &stubTranslationMemory{matches: []translationmemoryslice.Match{
+		{Entry: translationmemoryslice.Entry{SourceText: "Talk to the Jarl.", DestText: "首長と話す。"}, Score: 0.8},
+	}}
+	lookup := NewTranslationMemoryLookup(memory)
+
+	matches, err := lookup.FindMatches(context.Background(), "Talk to the Jarl of Whiterun.")
+	if err != nil {
+		t.Fatalf("FindMatches failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].SourceText != "Talk to the Jarl." || matches[0].TranslatedText != "首長と話す。" || matches[0].Score != 0.8 {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if got, err := lookup.FindMatches(context.Background(), "  "); err != nil || got != nil {
+		t.Fatalf("blank source must not be looked up: %v %v", got, err)
+	}
+	if len(memory.lookups) != 1 {
+		t.Fatalf("unexpected lookups: %v", memory.lookups)
+	}
+}
@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	taskworkflow "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
+)
+
+const (
+	mainTranslationEntrypoint = "translation_flow_main_translation_phase"
+	summaryEntrypoint         = "translation_flow_summary_phase"
+	exportEntrypoint          = "translation_flow_export_phase"
+	exportProgressPhase       = "export"
+)
+
+// translationFlowTaskMetadata persists the entrypoint and run config Run needs to resume a phase.
+type translationFlowTaskMetadata interface {
+	GetMetadata(ctx context.Context, id string) (taskworkflow.TaskMetadata, error)
+	SaveMetadata(ctx context.Context, id string, metadata taskworkflow.TaskMetadata) error
+}
+
+// SetTaskMetadataStore injects the task metadata store that records which phase a translation project last started.
+// Without it the summary, main translation and export phases still run, but cannot be resumed through Run.
+func (s *TranslationFlowService) SetTaskMetadataStore(store translationFlowTaskMetadata) {
+	s.taskMetadata = store
+}
+
+// recordResumeEntrypoint stores the phase entrypoint and its run config on the translation project task.
+// API keys are not recorded; a resumed run resolves them from the config namespace like the persona phase does.
+func (s *TranslationFlowService) recordResumeEntrypoint(ctx context.Context, taskID string, entrypoint string, metadata taskworkflow.TaskMetadata) error {
+	if s.taskMetadata == nil {
+		return nil
+	}
+	metadata["entrypoint"] = entrypoint
+	if existing, err := s.taskMetadata.GetMetadata(ctx, taskID); err == nil {
+		metadata = mergeTaskMetadata(existing, metadata)
+	}
+	if err := s.taskMetadata.SaveMetadata(ctx, taskID, metadata); err != nil {
+		return fmt.Errorf("save resume metadata task_id=%s entrypoint=%s: %w", taskID, entrypoint, err)
+	}
+	return nil
+}
+
+// resumeMainTranslationPhase re-runs the main translation phase (and the summaries it depends on) from task metadata.
+func (s *TranslationFlowService) resumeMainTranslationPhase(ctx context.Context, currentTask *taskworkflow.Task, update func(phase string, progress float64)) error {
+	requestConfig, promptConfig, err := translationFlowPhaseConfigFromMetadata(currentTask.Metadata)
+	if err != nil {
+		return fmt.Errorf("resolve main translation config task_id=%s: %w", currentTask.ID, err)
+	}
+	reportResumePhase(update, mainTranslationProgressPhase, currentTask.Progress)
+	if _, err := s.RunMainTranslationPhase(ctx, RunMainTranslationPhaseInput{
+		TaskID:  currentTask.ID,
+		Request: requestConfig,
+		Prompt:  promptConfig,
+	}); err != nil {
+		return fmt.Errorf("resume main translation phase task_id=%s: %w", currentTask.ID, err)
+	}
+	return nil
+}
+
+// resumeSummaryPhase re-runs a summary phase that was started on its own; cached summaries are not requested again.
+func (s *TranslationFlowService) resumeSummaryPhase(ctx context.Context, currentTask *taskworkflow.Task, update func(phase string, progress float64)) error {
+	requestConfig, _, err := translationFlowPhaseConfigFromMetadata(currentTask.Metadata)
+	if err != nil {
+		return fmt.Errorf("resolve summary config task_id=%s: %w", currentTask.ID, err)
+	}
+	reportResumePhase(update, summaryProgressPhase, currentTask.Progress)
+	if _, err := s.RunSummaryPhase(ctx, RunSummaryPhaseInput{TaskID: currentTask.ID, Request: requestConfig}); err != nil {
+		return fmt.Errorf("resume summary phase task_id=%s: %w", currentTask.ID, err)
+	}
+	return nil
+}
+
+// resumeExportPhase re-runs the export into the recorded output directory; files are rewritten as a whole.
+func (s *TranslationFlowService) resumeExportPhase(ctx context.Context, currentTask *taskworkflow.Task, update func(phase string, progress float64)) error {
+	input, err := exportInputFromMetadata(currentTask.ID, currentTask.Metadata)
+	if err != nil {
+		return fmt.Errorf("resolve export config task_id=%s: %w", currentTask.ID, err)
+	}
+	reportResumePhase(update, exportProgressPhase, currentTask.Progress)
+	if _, err := s.RunExportPhase(ctx, input); err != nil {
+		return fmt.Errorf("resume export phase task_id=%s: %w", currentTask.ID, err)
+	}
+	return nil
+}
+
+func reportResumePhase(update func(phase string, progress float64), phase string, progress float64) {
+	if update != nil {
+		update(phase, progress)
+	}
+}
+
+func exportConfigMetadata(input RunExportPhaseInput) taskworkflow.TaskMetadata {
+	return taskworkflow.TaskMetadata{
+		"export_config": map[string]interface{}{
+			"output_dir":            strings.TrimSpace(input.OutputDir),
+			"source_language":       strings.TrimSpace(input.SourceLanguage),
+			"dest_language":         strings.TrimSpace(input.DestLanguage),
+			"include_string_tables": input.IncludeStringTables,
+		},
+	}
+}
+
+func exportInputFromMetadata(taskID string, metadata taskworkflow.TaskMetadata) (RunExportPhaseInput, error) {
+	exportMetadata := metadataMap(map[string]any(metadata), "export_config")
+	input := RunExportPhaseInput{
+		TaskID:         taskID,
+		OutputDir:      metadataString(exportMetadata, "output_dir"),
+		SourceLanguage: metadataString(exportMetadata, "source_language"),
+		DestLanguage:   metadataString(exportMetadata, "dest_language"),
+	}
+	input.IncludeStringTables, _ = exportMetadata["include_string_tables"].(bool)
+	if strings.TrimSpace(input.OutputDir) == "" {
+		return RunExportPhaseInput{}, fmt.Errorf("export_config.output_dir is required")
+	}
+	return input, nil
+}
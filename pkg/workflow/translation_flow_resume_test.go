@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	taskworkflow "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
+)
+
+// stubTaskMetadataStore round-trips metadata through JSON like the task store does.
+type stubTaskMetadataStore struct {
+	saved map[string][]byte
+}
+
+func (s *stubTaskMetadataStore) GetMetadata(ctx context.Context, id string) (taskworkflow.TaskMetadata, error) {
+	_ = ctx
+	metadata := taskworkflow.TaskMetadata{}
+	if raw, ok := s.saved[id]; ok {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+func (s *stubTaskMetadataStore) SaveMetadata(ctx context.Context, id string, metadata taskworkflow.TaskMetadata) error {
+	_ = ctx
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if s.saved == nil {
+		s.saved = map[string][]byte{}
+	}
+	s.saved[id] = raw
+	return nil
+}
+
+func TestTranslationFlowServiceRunResumesRecordedPhase(t *testing.T) {
+	tests := []struct {
+		name           string
+		runPhase       func(service *TranslationFlowService) error
+		wantEntrypoint string
+		wantPhase      string
+		assertResumed  func(t *testing.T, mainTranslation *stubMainTranslator, summary *stubSummarySlice, metadata taskworkflow.TaskMetadata)
+	}{
+		{
+			name: "本文翻訳フェーズを再開する",
+			runPhase: func(service *TranslationFlowService) error {
+				_, err := service.RunMainTranslationPhase(context.Background(), RunMainTranslationPhaseInput{
+					TaskID:  "task-resume",
+					Request: TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.5-flash", SyncConcurrency: 2},
+					Prompt:  TranslationPromptConfig{SystemPrompt: "main system prompt"},
+				})
+				return err
+			},
+			wantEntrypoint: mainTranslationEntrypoint,
+			wantPhase:      mainTranslationProgressPhase,
+			assertResumed: func(t *testing.T, mainTranslation *stubMainTranslator, _ *stubSummarySlice, metadata taskworkflow.TaskMetadata) {
+				if mainTranslation.preparePromptsCalls != 2 {
+					t.Fatalf("main translation must run again on resume: got=%d", mainTranslation.preparePromptsCalls)
+				}
+				requestConfig, promptConfig, err := translationFlowPhaseConfigFromMetadata(metadata)
+				if err != nil || requestConfig.SyncConcurrency != 2 || promptConfig.SystemPrompt != "main system prompt" {
+					t.Fatalf("unexpected recorded config: request=%+v prompt=%+v err=%v", requestConfig, promptConfig, err)
+				}
+			},
+		},
+		{
+			name: "要約フェーズを再開する",
+			runPhase: func(service *TranslationFlowService) error {
+				_, err := service.RunSummaryPhase(context.Background(), RunSummaryPhaseInput{
+					TaskID:  "task-resume",
+					Request: TranslationRequestConfig{Provider: "gemini", Model: "gemini-2.5-flash"},
+				})
+				return err
+			},
+			wantEntrypoint: summaryEntrypoint,
+			wantPhase:      summaryProgressPhase,
+			assertResumed: func(t *testing.T, mainTranslation *stubMainTranslator, summary *stubSummarySlice, _ taskworkflow.TaskMetadata) {
+				if mainTranslation.preparePromptsCalls != 0 {
+					t.Fatalf("summary resume must not start main translation: got=%d", mainTranslation.preparePromptsCalls)
+				}
+				if summary.prepareCalls != 2 {
+					t.Fatalf("summary must run again on resume: got=%d", summary.prepareCalls)
+				}
+			},
+		},
+		{
+			name: "エクスポートフェーズを再開する",
+			runPhase: func(service *TranslationFlowService) error {
+				_, err := service.RunExportPhase(context.Background(), RunExportPhaseInput{
+					TaskID:              "task-resume",
+					OutputDir:           "out",
+					DestLanguage:        "japanese",
+					IncludeStringTables: true,
+				})
+				return err
+			},
+			wantEntrypoint: exportEntrypoint,
+			wantPhase:      exportProgressPhase,
+			assertResumed: func(t *testing.T, _ *stubMainTranslator, _ *stubSummarySlice, metadata taskworkflow.TaskMetadata) {
+				resumed, err := exportInputFromMetadata("task-resume", metadata)
+				if err != nil || resumed.OutputDir != "out" || resumed.DestLanguage != "japanese" || !resumed.IncludeStringTables {
+					t.Fatalf("unexpected recorded export input: %+v err=%v", resumed, err)
+				}
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			metadataStore := &stubTaskMetadataStore{}
+			mainTranslation := &stubMainTranslator{}
+			summary := &stubSummarySlice{}
+			service := &TranslationFlowService{
+				store:           &stubTranslationFlowStore{summarySource: newSummarySource()},
+				terminology:     &stubTerminology{},
+				mainTranslation: mainTranslation,
+				exporter:        &stubTranslationExporter{},
+				executor:        &stubTerminologyExecutor{},
+				summary:         summary,
+				taskMetadata:    metadataStore,
+			}
+
+			if err := tc.runPhase(service); err != nil {
+				t.Fatalf("run phase failed: %v", err)
+			}
+			metadata, err := metadataStore.GetMetadata(context.Background(), "task-resume")
+			if err != nil {
+				t.Fatalf("GetMetadata failed: %v", err)
+			}
+			if got := metadataString(map[string]any(metadata), "entrypoint"); got != tc.wantEntrypoint {
+				t.Fatalf("unexpected recorded entrypoint: got=%q want=%q", got, tc.wantEntrypoint)
+			}
+			if _, ok := metadataMap(map[string]any(metadata), "request_config")["api_key"]; ok {
+				t.Fatalf("api key must not be recorded: %v", metadata)
+			}
+
+			var phases []string
+			err = service.Run(context.Background(), &taskworkflow.Task{
+				ID:       "task-resume",
+				Type:     taskworkflow.TypeTranslationProject,
+				Status:   taskworkflow.StatusFailed,
+				Metadata: metadata,
+			}, func(phase string, _ float64) {
+				phases = append(phases, phase)
+			})
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if len(phases) != 1 || phases[0] != tc.wantPhase {
+				t.Fatalf("unexpected resumed phase: got=%v want=%q", phases, tc.wantPhase)
+			}
+			tc.assertResumed(t, mainTranslation, summary, metadata)
+		})
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/ishibata91/ai-translation-engine-2/pkg/format/parser/skyrim"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
 	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	summaryslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/summary"
 	terminologyslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/terminology"
 	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
 	taskworkflow "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
 )
 
@@ -20,6 +22,8 @@ const defaultTranslationPreviewPageSize = 50
 const terminologyProgressPhase = "terminology"
 const personaProgressPhase = "persona"
 const terminologyProgressPersistMaxUpdates = 20
+const terminologyLLMNamespace = "translation_flow.terminology.llm"
+const mainTranslationLLMNamespace = "translation_flow.translation"
 
 var personaSourcePluginPattern = regexp.MustCompile(`(?i)[^\\/:*?"<>|]+\.(esm|esl|esp)`)
 
@@ -29,8 +33,14 @@ type TranslationFlowService struct {
 	store           translationflow.Service
 	terminology     terminologyslice.Terminology
 	personaWorkflow MasterPersona
+	mainTranslation translatorslice.MainTranslator
+	exporter        translationExporter
 	executor        terminologyPhaseExecutor
 	notifier        runtimeprogress.ProgressNotifier
+	estimator       phaseCostEstimator
+	summary         summaryslice.Summary
+	memory          TranslationMemory
+	taskMetadata    translationFlowTaskMetadata
 }
 
 type terminologyPhaseExecutor interface {
@@ -46,12 +56,25 @@ type terminologyPhaseExecutorWithProgress interface {
 	) ([]llmio.Response, error)
 }
 
+// phaseExecutorWithStreamProgress also reports partial output of streamed long book bodies.
+type phaseExecutorWithStreamProgress interface {
+	ExecuteWithStreamProgress(
+		ctx context.Context,
+		config llmio.ExecutionConfig,
+		requests []llmio.Request,
+		progress func(completed, total int),
+		streamProgress func(receivedChars int),
+	) ([]llmio.Response, error)
+}
+
 // NewTranslationFlowService constructs a translation-flow workflow implementation.
 func NewTranslationFlowService(
 	parser skyrim.Parser,
 	store translationflow.Service,
 	terminology terminologyslice.Terminology,
 	personaWorkflow MasterPersona,
+	mainTranslation translatorslice.MainTranslator,
+	exporter translationExporter,
 	executor terminologyPhaseExecutor,
 	notifier runtimeprogress.ProgressNotifier,
 ) *TranslationFlowService {
@@ -60,6 +83,8 @@ func NewTranslationFlowService(
 		store:           store,
 		terminology:     terminology,
 		personaWorkflow: personaWorkflow,
+		mainTranslation: mainTranslation,
+		exporter:        exporter,
 		executor:        executor,
 		notifier:        notifier,
 	}
@@ -77,7 +102,7 @@ func (s *TranslationFlowService) Run(ctx context.Context, currentTask *taskworkf
 	entrypoint := metadataString(map[string]any(currentTask.Metadata), "entrypoint")
 	switch entrypoint {
 	case "translation_flow_persona_phase", "master_persona":
-		requestConfig, promptConfig, err := translationFlowPersonaConfigFromMetadata(currentTask.Metadata)
+		requestConfig, promptConfig, err := translationFlowPhaseConfigFromMetadata(currentTask.Metadata)
 		if err != nil {
 			return fmt.Errorf("resolve translation flow persona config task_id=%s: %w", currentTask.ID, err)
 		}
@@ -92,6 +117,12 @@ func (s *TranslationFlowService) Run(ctx context.Context, currentTask *taskworkf
 			return fmt.Errorf("resume translation flow persona phase task_id=%s: %w", currentTask.ID, err)
 		}
 		return nil
+	case mainTranslationEntrypoint:
+		return s.resumeMainTranslationPhase(ctx, currentTask, update)
+	case summaryEntrypoint:
+		return s.resumeSummaryPhase(ctx, currentTask, update)
+	case exportEntrypoint:
+		return s.resumeExportPhase(ctx, currentTask, update)
 	default:
 		return fmt.Errorf("unsupported translation project entrypoint=%q", entrypoint)
 	}
@@ -111,19 +142,25 @@ func (s *TranslationFlowService) LoadFiles(ctx context.Context, input LoadTransl
 		return TranslationLoadResult{}, fmt.Errorf("ensure translation-flow task task_id=%s: %w", trimmedTaskID, err)
 	}
 
-	for _, sourcePath := range input.FilePaths {
-		trimmedPath := strings.TrimSpace(sourcePath)
-		if trimmedPath == "" {
+	filePaths, err := skyrim.ExpandPluginInputPaths(input.FilePaths)
+	if err != nil {
+		return TranslationLoadResult{}, fmt.Errorf("expand input paths task_id=%s: %w", trimmedTaskID, err)
+	}
+
+	diffs := make([]TranslationFileDiff, 0)
+	for _, trimmedPath := range filePaths {
+		stream := s.parsedOutputStream(ctx, trimmedTaskID, trimmedPath)
+		if !input.Incremental {
+			if _, err := s.store.SaveParsedOutputStream(ctx, trimmedTaskID, trimmedPath, stream); err != nil {
+				return TranslationLoadResult{}, fmt.Errorf("save parsed output task_id=%s file=%s: %w", trimmedTaskID, trimmedPath, err)
+			}
 			continue
 		}
-
-		parsed, err := s.parser.LoadExtractedJSON(ctx, trimmedPath)
+		diff, err := s.reloadParsedOutput(ctx, trimmedTaskID, trimmedPath, stream)
 		if err != nil {
-			return TranslationLoadResult{}, fmt.Errorf("parse source json task_id=%s file=%s: %w", trimmedTaskID, trimmedPath, err)
-		}
-		if _, err := s.store.SaveParsedOutput(ctx, trimmedTaskID, trimmedPath, parsed); err != nil {
-			return TranslationLoadResult{}, fmt.Errorf("save parsed output task_id=%s file=%s: %w", trimmedTaskID, trimmedPath, err)
+			return TranslationLoadResult{}, err
 		}
+		diffs = append(diffs, diff)
 	}
 
 	if err := s.terminology.UpdatePhaseSummary(ctx, terminologyslice.PhaseSummary{
@@ -133,8 +170,79 @@ func (s *TranslationFlowService) LoadFiles(ctx context.Context, input LoadTransl
 	}); err != nil {
 		return TranslationLoadResult{}, fmt.Errorf("reset terminology phase summary task_id=%s: %w", trimmedTaskID, err)
 	}
+	if s.mainTranslation != nil {
+		if err := s.mainTranslation.UpdatePhaseSummary(ctx, translatorslice.PhaseSummary{
+			TaskID:       trimmedTaskID,
+			Status:       "pending",
+			ProgressMode: "hidden",
+		}); err != nil {
+			return TranslationLoadResult{}, fmt.Errorf("reset main translation phase summary task_id=%s: %w", trimmedTaskID, err)
+		}
+	}
+
+	result, err := s.ListFiles(ctx, trimmedTaskID)
+	if err != nil {
+		return TranslationLoadResult{}, err
+	}
+	if input.Incremental {
+		result.Diffs = diffs
+	}
+	return result, nil
+}
+
+// parsedOutputStream picks the parser for one input path; xEdit JSON is streamed, other formats emit one chunk.
+func (s *TranslationFlowService) parsedOutputStream(ctx context.Context, taskID string, path string) func(sink skyrim.ParserOutputSink) error {
+	return func(sink skyrim.ParserOutputSink) error {
+		switch {
+		case skyrim.IsPluginFile(path):
+			parsed, err := s.parser.LoadPlugin(ctx, path)
+			if err != nil {
+				return fmt.Errorf("parse source plugin task_id=%s file=%s: %w", taskID, path, err)
+			}
+			return sink(parsed)
+		case skyrim.IsInterfaceTranslationFile(path):
+			parsed, err := s.parser.LoadInterfaceTranslation(ctx, path)
+			if err != nil {
+				return fmt.Errorf("parse interface translation task_id=%s file=%s: %w", taskID, path, err)
+			}
+			return sink(parsed)
+		default:
+			if err := s.parser.StreamExtractedJSON(ctx, path, sink); err != nil {
+				return fmt.Errorf("parse source json task_id=%s file=%s: %w", taskID, path, err)
+			}
+			return nil
+		}
+	}
+}
 
-	return s.ListFiles(ctx, trimmedTaskID)
+// reloadParsedOutput replaces the previous load of the same file and carries unchanged main translations forward.
+func (s *TranslationFlowService) reloadParsedOutput(ctx context.Context, taskID string, path string, stream func(sink skyrim.ParserOutputSink) error) (TranslationFileDiff, error) {
+	loaded, diff, err := s.store.ReloadParsedOutputStream(ctx, taskID, path, stream)
+	if err != nil {
+		return TranslationFileDiff{}, fmt.Errorf("reload parsed output task_id=%s file=%s: %w", taskID, path, err)
+	}
+	result := TranslationFileDiff{
+		FilePath:    loaded.SourceFilePath,
+		FileName:    loaded.SourceFileName,
+		HasPrevious: diff.HasPrevious,
+		Added:       diff.Added,
+		Changed:     diff.Changed,
+		Removed:     diff.Removed,
+		Unchanged:   diff.Unchanged,
+	}
+	if s.mainTranslation == nil || len(diff.Carries) == 0 {
+		return result, nil
+	}
+	carries := make([]translatorslice.ResultCarry, 0, len(diff.Carries))
+	for _, carry := range diff.Carries {
+		carries = append(carries, translatorslice.ResultCarry{PreviousRowID: carry.PreviousRowID, RowID: carry.RowID})
+	}
+	carried, err := s.mainTranslation.CarryForwardResults(ctx, taskID, carries)
+	if err != nil {
+		return TranslationFileDiff{}, fmt.Errorf("carry forward main translations task_id=%s file=%s: %w", taskID, path, err)
+	}
+	result.CarriedForward = carried
+	return result, nil
 }
 
 // ListFiles returns loaded files with first preview page for each file.
@@ -429,6 +537,9 @@ func (s *TranslationFlowService) RunTerminologyPhase(ctx context.Context, input
 			ContextLength:   input.Request.ContextLength,
 			SyncConcurrency: input.Request.SyncConcurrency,
 			BulkStrategy:    input.Request.BulkStrategy,
+			ConfigNamespace: terminologyLLMNamespace,
+			TaskID:          trimmedTaskID,
+			Phase:           terminologyProgressPhase,
 		}
 		responses, err := s.executeTerminologyWithProgress(
 			ctx,
@@ -829,7 +940,7 @@ func metadataFloat32Value(metadata map[string]any, key string) float32 {
 	}
 }
 
-func translationFlowPersonaConfigFromMetadata(metadata taskworkflow.TaskMetadata) (TranslationRequestConfig, TranslationPromptConfig, error) {
+func translationFlowPhaseConfigFromMetadata(metadata taskworkflow.TaskMetadata) (TranslationRequestConfig, TranslationPromptConfig, error) {
 	root := map[string]any(metadata)
 	requestMetadata := metadataMap(root, "request_config")
 	promptMetadata := metadataMap(root, "prompt_config")
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
@@ -142,6 +144,105 @@ func TestTranslationFlowServiceLoadFilesResetsTerminologySummary(t *testing.T) {
 	}
 }
 
+func TestTranslationFlowServiceLoadFilesReadsPluginFilesNatively(t *testing.T) {
+	parser := &stubSkyrimParser{output: &skyrim.ParserOutput{}}
+	store := &stubTranslationFlowStore{}
+	service := &TranslationFlowService{
+		parser:      parser,
+		store:       store,
+		terminology: &stubTerminology{},
+	}
+
+	if _, err := service.LoadFiles(context.Background(), LoadTranslationFlowInput{
+		TaskID:    "task-plugin",
+		FilePaths: []string{"example.json", "MyMod.ESP", "MyMod_ENGLISH.txt"},
+	}); err != nil {
+		t.Fatalf("LoadFiles failed: %v", err)
+	}
+	if len(parser.pluginPaths) != 1 || parser.pluginPaths[0] != "MyMod.ESP" {
+		t.Fatalf("unexpected plugin paths: %+v", parser.pluginPaths)
+	}
+	if len(parser.interfaceTranslationPaths) != 1 || parser.interfaceTranslationPaths[0] != "MyMod_ENGLISH.txt" {
+		t.Fatalf("unexpected interface translation paths: %+v", parser.interfaceTranslationPaths)
+	}
+	if len(parser.streamedPaths) != 1 || parser.streamedPaths[0] != "example.json" || store.streamedChunks != 3 {
+		t.Fatalf("unexpected streamed json: paths=%+v chunks=%d", parser.streamedPaths, store.streamedChunks)
+	}
+}
+
+func TestTranslationFlowServiceLoadFilesExpandsModFolders(t *testing.T) {
+	modDir := t.TempDir()
+	for _, name := range []string{"MyMod.esp", "MyMod.bsa"} {
+		if err := os.WriteFile(filepath.Join(modDir, name), []byte{}, 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	parser := &stubSkyrimParser{output: &skyrim.ParserOutput{}}
+	service := &TranslationFlowService{
+		parser:      parser,
+		store:       &stubTranslationFlowStore{},
+		terminology: &stubTerminology{},
+	}
+
+	if _, err := service.LoadFiles(context.Background(), LoadTranslationFlowInput{
+		TaskID:    "task-folder",
+		FilePaths: []string{modDir},
+	}); err != nil {
+		t.Fatalf("LoadFiles failed: %v", err)
+	}
+	if len(parser.pluginPaths) != 1 || parser.pluginPaths[0] != filepath.Join(modDir, "MyMod.esp") {
+		t.Fatalf("unexpected plugin paths: %+v", parser.pluginPaths)
+	}
+}
+
+func TestTranslationFlowServiceLoadFilesIncrementalCarriesUnchangedRows(t *testing.T) {
+	store := &stubTranslationFlowStore{
+		reloadDiff: translationflow.FileDiff{
+			HasPrevious: true,
+			Added:       1,
+			Changed:     2,
+			Removed:     1,
+			Unchanged:   1,
+			Carries:     []translationflow.RowCarry{{PreviousRowID: "item_text:1", RowID: "item_text:9"}},
+		},
+	}
+	mainTranslation := &stubMainTranslator{}
+	service := &TranslationFlowService{
+		parser:          &stubSkyrimParser{output: &skyrim.ParserOutput{}},
+		store:           store,
+		terminology:     &stubTerminology{},
+		mainTranslation: mainTranslation,
+	}
+
+	result, err := service.LoadFiles(context.Background(), LoadTranslationFlowInput{
+		TaskID:      "task-incremental",
+		FilePaths:   []string{"MyMod.esp"},
+		Incremental: true,
+	})
+	if err != nil {
+		t.Fatalf("LoadFiles failed: %v", err)
+	}
+	if len(store.reloadedPaths) != 1 || store.reloadedPaths[0] != "MyMod.esp" {
+		t.Fatalf("unexpected reloaded paths: %+v", store.reloadedPaths)
+	}
+	if len(mainTranslation.carries) != 1 || mainTranslation.carries[0].PreviousRowID != "item_text:1" || mainTranslation.carries[0].RowID != "item_text:9" {
+		t.Fatalf("unexpected carries: %+v", mainTranslation.carries)
+	}
+	want := TranslationFileDiff{
+		FilePath:       "MyMod.esp",
+		FileName:       "MyMod.esp",
+		HasPrevious:    true,
+		Added:          1,
+		Changed:        2,
+		Removed:        1,
+		Unchanged:      1,
+		CarriedForward: 1,
+	}
+	if len(result.Diffs) != 1 || result.Diffs[0] != want {
+		t.Fatalf("unexpected diffs: %+v", result.Diffs)
+	}
+}
+
 func TestTranslationFlowServiceRunTerminologyPhaseMarksRunError(t *testing.T) {
 	terminology := &stubTerminology{
 		preparePromptsResult: []llmio.Request{
@@ -399,9 +500,9 @@ func TestTranslationFlowServiceRunTerminologyPhaseRestoresFinalSummaryAfterRunni
 		{
 			name: "completed",
 			responses: []scriptedTerminologyResponse{
-				{success: true, content: "TL: |鋼鉄の鎧|"},
-				{success: true, content: "TL: |銀の盾|"},
-				{success: true, content: "TL: |黒檀の弓|"},
+				{success: true, content: `{"translation": "鋼鉄の鎧"}`},
+				{success: true, content: `{"translation": "銀の盾"}`},
+				{success: true, content: `{"translation": "黒檀の弓"}`},
 			},
 			wantStatus: "completed",
 			wantSaved:  4,
@@ -410,9 +511,9 @@ func TestTranslationFlowServiceRunTerminologyPhaseRestoresFinalSummaryAfterRunni
 		{
 			name: "completed_partial",
 			responses: []scriptedTerminologyResponse{
-				{success: true, content: "TL: |鋼鉄の鎧|"},
+				{success: true, content: `{"translation": "鋼鉄の鎧"}`},
 				{success: false, err: "provider timeout"},
-				{success: true, content: "TL: |黒檀の弓|"},
+				{success: true, content: `{"translation": "黒檀の弓"}`},
 			},
 			wantStatus: "completed_partial",
 			wantSaved:  3,
@@ -1181,11 +1282,24 @@ type stubTranslationFlowStore struct {
 	personaInput          translationflow.PersonaCandidateInput
 	finalPersonasByLookup map[string]translationflow.PersonaFinalSummary
 	loadedFiles           []translationflow.LoadedFile
+	streamedChunks        int
+	reloadDiff            translationflow.FileDiff
+	reloadedPaths         []string
+	summarySource         translationflow.SummarySourceInput
+}
+
+func (s *stubTranslationFlowStore) LoadSummarySource(ctx context.Context, taskID string) (translationflow.SummarySourceInput, error) {
+	_ = ctx
+	s.summarySource.TaskID = taskID
+	return s.summarySource, nil
 }
 
 type stubSkyrimParser struct {
-	output *skyrim.ParserOutput
-	err    error
+	output                    *skyrim.ParserOutput
+	err                       error
+	pluginPaths               []string
+	interfaceTranslationPaths []string
+	streamedPaths             []string
 }
 
 func (s *stubSkyrimParser) LoadExtractedJSON(ctx context.Context, path string) (*skyrim.ParserOutput, error) {
@@ -1197,6 +1311,33 @@ func (s *stubSkyrimParser) LoadExtractedJSON(ctx context.Context, path string) (
 	return s.output, nil
 }
 
+func (s *stubSkyrimParser) LoadPlugin(ctx context.Context, path string) (*skyrim.ParserOutput, error) {
+	_ = ctx
+	s.pluginPaths = append(s.pluginPaths, path)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.output, nil
+}
+
+func (s *stubSkyrimParser) LoadInterfaceTranslation(ctx context.Context, path string) (*skyrim.ParserOutput, error) {
+	_ = ctx
+	s.interfaceTranslationPaths = append(s.interfaceTranslationPaths, path)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.output, nil
+}
+
+func (s *stubSkyrimParser) StreamExtractedJSON(ctx context.Context, path string, sink skyrim.ParserOutputSink) error {
+	_ = ctx
+	s.streamedPaths = append(s.streamedPaths, path)
+	if s.err != nil {
+		return s.err
+	}
+	return sink(s.output)
+}
+
 func (s *stubTranslationFlowStore) EnsureTask(ctx context.Context, taskID string) error {
 	_ = ctx
 	_ = taskID
@@ -1211,6 +1352,29 @@ func (s *stubTranslationFlowStore) SaveParsedOutput(ctx context.Context, taskID
 	return translationflow.LoadedFile{}, nil
 }
 
+func (s *stubTranslationFlowStore) SaveParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (translationflow.LoadedFile, error) {
+	_ = ctx
+	_ = taskID
+	_ = sourceFilePath
+	if err := stream(func(chunk *skyrim.ParserOutput) error {
+		_ = chunk
+		s.streamedChunks++
+		return nil
+	}); err != nil {
+		return translationflow.LoadedFile{}, err
+	}
+	return translationflow.LoadedFile{}, nil
+}
+
+func (s *stubTranslationFlowStore) ReloadParsedOutputStream(ctx context.Context, taskID string, sourceFilePath string, stream translationinput.ParsedOutputStream) (translationflow.LoadedFile, translationflow.FileDiff, error) {
+	_ = taskID
+	s.reloadedPaths = append(s.reloadedPaths, sourceFilePath)
+	if _, err := s.SaveParsedOutputStream(ctx, taskID, sourceFilePath, stream); err != nil {
+		return translationflow.LoadedFile{}, translationflow.FileDiff{}, err
+	}
+	return translationflow.LoadedFile{SourceFilePath: sourceFilePath, SourceFileName: filepath.Base(sourceFilePath)}, s.reloadDiff, nil
+}
+
 func (s *stubTranslationFlowStore) ListFiles(ctx context.Context, taskID string) ([]translationflow.LoadedFile, error) {
 	_ = ctx
 	_ = taskID
@@ -1295,6 +1459,7 @@ type stubTerminology struct {
 	updatedSummary       terminologyslice.PhaseSummary
 	updatedSummaries     []terminologyslice.PhaseSummary
 	savedResponses       []llmio.Response
+	translatedEntries    []terminologyslice.TranslatedEntry
 }
 
 func (s *stubTerminology) ID() string {
@@ -1308,6 +1473,10 @@ func (s *stubTerminology) PreparePrompts(ctx context.Context, taskID string, opt
 	return s.preparePromptsResult, nil
 }
 
+func (s *stubTerminology) BuildPrompts(ctx context.Context, taskID string, options terminologyslice.PhaseOptions) ([]llmio.Request, error) {
+	return s.PreparePrompts(ctx, taskID, options)
+}
+
 func (s *stubTerminology) SaveResults(ctx context.Context, taskID string, responses []llmio.Response) error {
 	_ = ctx
 	_ = taskID
@@ -1333,6 +1502,12 @@ func (s *stubTerminology) ListTargets(ctx context.Context, taskID string) ([]ter
 	return append([]terminologyslice.TerminologyEntry(nil), s.listTargetsResult...), nil
 }
 
+func (s *stubTerminology) ListTranslations(ctx context.Context, taskID string) ([]terminologyslice.TranslatedEntry, error) {
+	_ = ctx
+	_ = taskID
+	return append([]terminologyslice.TranslatedEntry(nil), s.translatedEntries...), nil
+}
+
 func (s *stubTerminology) UpdatePhaseSummary(ctx context.Context, summary terminologyslice.PhaseSummary) error {
 	_ = ctx
 	s.updatedSummary = summary
@@ -0,0 +1,216 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	summaryslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/summary"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+	translatorslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/translator"
+	taskworkflow "github.com/ishibata91/ai-translation-engine-2/pkg/workflow/task"
+)
+
+const summaryProgressPhase = "summary"
+const summaryLLMNamespace = "translation_flow.summary.llm"
+
+// SetSummary injects the dialogue/quest summary slice run before main translation.
+func (s *TranslationFlowService) SetSummary(summary summaryslice.Summary) {
+	s.summary = summary
+}
+
+// RunSummaryPhase generates dialogue-group and quest "story so far" summaries for the task.
+// Cached summaries are reused by the slice, so only changed groups and stages reach the LLM.
+// RunMainTranslationPhase calls it first. Unlike persona generation it runs on the shared sync
+// executor rather than the job queue, because main translation must wait for the summaries
+// before building its prompts.
+func (s *TranslationFlowService) RunSummaryPhase(ctx context.Context, input RunSummaryPhaseInput) (SummaryPhaseResult, error) {
+	trimmedTaskID := strings.TrimSpace(input.TaskID)
+	if trimmedTaskID == "" {
+		return SummaryPhaseResult{}, fmt.Errorf("task_id is required")
+	}
+	if strings.TrimSpace(input.Request.Model) == "" {
+		return SummaryPhaseResult{}, fmt.Errorf("request.model is required")
+	}
+	if err := s.recordResumeEntrypoint(ctx, trimmedTaskID, summaryEntrypoint, phaseExecutionConfigMetadata(input.Request, TranslationPromptConfig{})); err != nil {
+		return SummaryPhaseResult{}, err
+	}
+	return s.runSummaryPhase(ctx, trimmedTaskID, input.Request)
+}
+
+// runSummaryPhase runs the summaries without recording a resume entrypoint, so main translation keeps its own.
+func (s *TranslationFlowService) runSummaryPhase(ctx context.Context, taskID string, request TranslationRequestConfig) (SummaryPhaseResult, error) {
+	summaryInput, requests, err := s.prepareSummaryPrompts(ctx, taskID)
+	if err != nil {
+		return SummaryPhaseResult{}, err
+	}
+	result := SummaryPhaseResult{
+		TaskID:         taskID,
+		Status:         "completed",
+		DialogueGroups: len(summaryInput.DialogueItems),
+		Quests:         len(summaryInput.QuestItems),
+		RequestCount:   len(requests),
+	}
+	if len(summaryInput.DialogueItems) == 0 && len(summaryInput.QuestItems) == 0 {
+		result.Status = "empty"
+		s.reportSummaryProgress(ctx, taskID, runtimeprogress.StatusCompleted, 0, 0, 0, "要約対象なし")
+		return result, nil
+	}
+	if len(requests) == 0 {
+		s.reportSummaryProgress(ctx, taskID, runtimeprogress.StatusCompleted, 0, 0, 0, "要約はすべてキャッシュ済み")
+		return result, nil
+	}
+
+	s.reportSummaryProgress(ctx, taskID, runtimeprogress.StatusInProgress, 0, len(requests), 0, buildSummaryProgressMessage(0, len(requests)))
+	config := estimateExecutionConfig(taskID, summaryProgressPhase, summaryLLMNamespace, request)
+	config.APIKey = request.APIKey
+	responses, err := s.executeSummaryWithProgress(ctx, taskID, config, requests)
+	if err != nil {
+		s.reportSummaryProgress(ctx, taskID, runtimeprogress.StatusFailed, 0, len(requests), 0, "要約の実行に失敗しました")
+		return SummaryPhaseResult{}, fmt.Errorf("execute summary llm requests task_id=%s: %w", taskID, err)
+	}
+	if err := s.summary.SaveResults(ctx, responses); err != nil {
+		return SummaryPhaseResult{}, fmt.Errorf("save summary results task_id=%s: %w", taskID, err)
+	}
+
+	for _, resp := range responses {
+		if resp.Success {
+			result.SavedCount++
+		} else {
+			result.FailedCount++
+		}
+	}
+	status := runtimeprogress.StatusCompleted
+	if result.FailedCount > 0 {
+		result.Status = "completed_partial"
+		status = runtimeprogress.StatusFailed
+	}
+	s.reportSummaryProgress(ctx, taskID, status, len(requests), len(requests), result.FailedCount, buildSummaryProgressMessage(len(requests), len(requests)))
+	return result, nil
+}
+
+// EstimateSummaryPhase builds the uncached summary requests without running them and forecasts their cost.
+func (s *TranslationFlowService) EstimateSummaryPhase(ctx context.Context, input RunSummaryPhaseInput) (PhaseCostEstimate, error) {
+	trimmedTaskID, err := s.validateEstimateInput(input.TaskID, input.Request)
+	if err != nil {
+		return PhaseCostEstimate{}, err
+	}
+	_, requests, err := s.prepareSummaryPrompts(ctx, trimmedTaskID)
+	if err != nil {
+		return PhaseCostEstimate{}, err
+	}
+	return s.estimator.EstimatePhase(ctx, estimateExecutionConfig(trimmedTaskID, summaryProgressPhase, summaryLLMNamespace, input.Request), requests), nil
+}
+
+func (s *TranslationFlowService) prepareSummaryPrompts(ctx context.Context, taskID string) (summaryslice.SummaryInput, []llmio.Request, error) {
+	if s.summary == nil {
+		return summaryslice.SummaryInput{}, nil, fmt.Errorf("summary slice is not configured")
+	}
+	source, err := s.store.LoadSummarySource(ctx, taskID)
+	if err != nil {
+		return summaryslice.SummaryInput{}, nil, fmt.Errorf("load summary source task_id=%s: %w", taskID, err)
+	}
+	summaryInput := toSummaryInput(source)
+	requests, err := s.summary.PreparePrompts(ctx, summaryInput)
+	if err != nil {
+		return summaryslice.SummaryInput{}, nil, fmt.Errorf("prepare summary prompts task_id=%s: %w", taskID, err)
+	}
+	return summaryInput, requests, nil
+}
+
+func toSummaryInput(source translationflow.SummarySourceInput) summaryslice.SummaryInput {
+	input := summaryslice.SummaryInput{
+		DialogueItems: make([]summaryslice.DialogueItem, 0, len(source.DialogueGroups)),
+		QuestItems:    make([]summaryslice.QuestItem, 0, len(source.Quests)),
+	}
+	for _, group := range source.DialogueGroups {
+		item := summaryslice.DialogueItem{GroupID: group.GroupID, Lines: group.Lines}
+		if strings.TrimSpace(group.PlayerText) != "" {
+			playerText := group.PlayerText
+			item.PlayerText = &playerText
+		}
+		input.DialogueItems = append(input.DialogueItems, item)
+	}
+	for _, quest := range source.Quests {
+		item := summaryslice.QuestItem{QuestID: quest.QuestID, StageTexts: make([]summaryslice.QuestStage, 0, len(quest.Stages))}
+		for _, stage := range quest.Stages {
+			item.StageTexts = append(item.StageTexts, summaryslice.QuestStage{Index: stage.Index, Text: stage.Text})
+		}
+		input.QuestItems = append(input.QuestItems, item)
+	}
+	return input
+}
+
+func (s *TranslationFlowService) executeSummaryWithProgress(ctx context.Context, taskID string, config llmio.ExecutionConfig, requests []llmio.Request) ([]llmio.Response, error) {
+	executorWithProgress, ok := s.executor.(terminologyPhaseExecutorWithProgress)
+	if !ok {
+		return s.executor.Execute(ctx, config, requests)
+	}
+	total := len(requests)
+	return executorWithProgress.ExecuteWithProgress(ctx, config, requests, func(completed, _ int) {
+		if completed < 0 {
+			completed = 0
+		}
+		if completed > total {
+			completed = total
+		}
+		s.reportSummaryProgress(ctx, taskID, runtimeprogress.StatusInProgress, completed, total, 0, buildSummaryProgressMessage(completed, total))
+	})
+}
+
+func (s *TranslationFlowService) reportSummaryProgress(ctx context.Context, taskID string, status string, current int, total int, failed int, message string) {
+	if s.notifier == nil {
+		return
+	}
+	s.notifier.OnProgress(ctx, runtimeprogress.ProgressEvent{
+		CorrelationID: taskID,
+		TaskID:        taskID,
+		TaskType:      string(taskworkflow.TypeTranslationProject),
+		Phase:         summaryProgressPhase,
+		Current:       current,
+		Total:         total,
+		Completed:     current,
+		Failed:        failed,
+		Status:        status,
+		Message:       message,
+	})
+}
+
+func buildSummaryProgressMessage(current int, total int) string {
+	return "要約: " + buildTerminologyProgressMessage(current, total)
+}
+
+// summaryLookup adapts the summary slice to the translator context builder.
+type summaryLookup struct {
+	summary summaryslice.Summary
+}
+
+// NewSummaryLookup exposes Summary.GetSummary to the main translation context builder.
+func NewSummaryLookup(summary summaryslice.Summary) translatorslice.SummaryLookup {
+	return &summaryLookup{summary: summary}
+}
+
+func (l *summaryLookup) FindDialogueSummary(ctx context.Context, dialogueGroupID string) (*string, error) {
+	return l.find(ctx, dialogueGroupID, summaryslice.TypeDialogue)
+}
+
+func (l *summaryLookup) FindQuestSummary(ctx context.Context, questID string) (*string, error) {
+	return l.find(ctx, questID, summaryslice.TypeQuest)
+}
+
+func (l *summaryLookup) find(ctx context.Context, recordID string, summaryType string) (*string, error) {
+	if l.summary == nil || strings.TrimSpace(recordID) == "" {
+		return nil, nil
+	}
+	result, err := l.summary.GetSummary(ctx, recordID, summaryType)
+	if err != nil {
+		return nil, fmt.Errorf("find %s summary record_id=%s: %w", strings.ToLower(summaryType), recordID, err)
+	}
+	if result == nil || strings.TrimSpace(result.SummaryText) == "" {
+		return nil, nil
+	}
+	text := result.SummaryText
+	return &text, nil
+}
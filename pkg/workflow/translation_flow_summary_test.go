@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ishibata91/ai-translation-engine-2/pkg/foundation/llmio"
+	runtimeprogress "github.com/ishibata91/ai-translation-engine-2/pkg/foundation/progress"
+	summaryslice "github.com/ishibata91/ai-translation-engine-2/pkg/slice/summary"
+	"github.com/ishibata91/ai-translation-engine-2/pkg/slice/translationflow"
+)
+
+type stubSummarySlice struct {
+	preparedInput  summaryslice.SummaryInput
+	requests       []llmio.Request
+	savedResponses []llmio.Response
+	results        map[string]*summaryslice.SummaryResult
+	lookups        []string
+	prepareCalls   int
+}
+
+func (s *stubSummarySlice) ID() string { return "Summary" }
+
+func (s *stubSummarySlice) PreparePrompts(ctx context.Context, input any) ([]llmio.Request, error) {
+	_ = ctx
+	s.preparedInput = input.(summaryslice.SummaryInput)
+	s.prepareCalls++
+	return s.requests, nil
+}
+
+func (s *stubSummarySlice) SaveResults(ctx context.Context, responses []llmio.Response) error {
+	_ = ctx
+	s.savedResponses = responses
+	return nil
+}
+
+func (s *stubSummarySlice) GetSummary(ctx context.Context, recordID string, summaryType string) (*summaryslice.SummaryResult, error) {
+	_ = ctx
+	s.lookups = append(s.lookups, summaryType+":"+recordID)
+	return s.results[recordID], nil
+}
+
+func newSummarySource() translationflow.SummarySourceInput {
+	return translationflow.SummarySourceInput{
+		DialogueGroups: []translationflow.SummaryDialogueGroup{
+			{GroupID: "dg-1", PlayerText: "Who are you?", Lines: []string{"I am Lydia.", "I am sworn to carry your burdens."}},
+			{GroupID: "dg-2", Lines: []string{"Hail, Dragonborn."}},
+		},
+		Quests: []translationflow.SummaryQuest{
+			{QuestID: "MQ101", Stages: []translationflow.SummaryQuestStage{{Index: 10, Text: "Escape Helgen."}, {Index: 20, Text: "Reach Riverwood."}}},
+		},
+	}
+}
+
+func TestTranslationFlowServiceRunSummaryPhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     translationflow.SummarySourceInput
+		requests   []llmio.Request
+		responses  []llmio.Response
+		wantStatus string
+		wantSaved  int
+		wantFailed int
+		wantLast   string
+	}{
+		{
+			name:       "未キャッシュの要約を実行して保存する",
+			source:     newSummarySource(),
+			requests:   []llmio.Request{{}, {}, {}},
+			responses:  []llmio.Response{{Success: true}, {Success: true}, {Success: true}},
+			wantStatus: "completed",
+			wantSaved:  3,
+			wantLast:   runtimeprogress.StatusCompleted,
+		},
+		{
+			name:       "一部失敗は completed_partial として報告する",
+			source:     newSummarySource(),
+			requests:   []llmio.Request{{}, {}},
+			responses:  []llmio.Response{{Success: true}, {Success: false, Error: "boom"}},
+			wantStatus: "completed_partial",
+			wantSaved:  1,
+			wantFailed: 1,
+			wantLast:   runtimeprogress.StatusFailed,
+		},
+		{
+			name:       "すべてキャッシュ済みなら LLM を呼ばない",
+			source:     newSummarySource(),
+			wantStatus: "completed",
+			wantLast:   runtimeprogress.StatusCompleted,
+		},
+		{
+			name:       "要約対象が無ければ empty を返す",
+			wantStatus: "empty",
+			wantLast:   runtimeprogress.StatusCompleted,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := &stubSummarySlice{requests: tc.requests}
+			notifier := &stubWorkflowProgressNotifier{}
+			executor := &stubTerminologyExecutor{responses: tc.responses}
+			if len(tc.requests) == 0 {
+				executor.err = errors.New("executor must not run")
+			}
+			service := &TranslationFlowService{
+				store:    &stubTranslationFlowStore{summarySource: tc.source},
+				summary:  summary,
+				executor: executor,
+				notifier: notifier,
+			}
+
+			result, err := service.RunSummaryPhase(context.Background(), RunSummaryPhaseInput{
+				TaskID:  " task-summary ",
+				Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+			})
+			if err != nil {
+				t.Fatalf("RunSummaryPhase failed: %v", err)
+			}
+			if result.TaskID != "task-summary" || result.Status != tc.wantStatus || result.SavedCount != tc.wantSaved || result.FailedCount != tc.wantFailed {
+				t.Fatalf("unexpected result: %+v", result)
+			}
+			if len(summary.savedResponses) != len(tc.responses) {
+				t.Fatalf("unexpected saved response count: got=%d want=%d", len(summary.savedResponses), len(tc.responses))
+			}
+			if len(notifier.events) == 0 {
+				t.Fatal("progress events must be reported")
+			}
+			last := notifier.events[len(notifier.events)-1]
+			if last.Phase != summaryProgressPhase || last.Status != tc.wantLast {
+				t.Fatalf("unexpected last event: %+v", last)
+			}
+		})
+	}
+}
+
+func TestTranslationFlowServiceRunSummaryPhaseBuildsSliceInput(t *testing.T) {
+	summary := &stubSummarySlice{}
+	service := &TranslationFlowService{
+		store:    &stubTranslationFlowStore{summarySource: newSummarySource()},
+		summary:  summary,
+		executor: &stubTerminologyExecutor{},
+	}
+
+	result, err := service.RunSummaryPhase(context.Background(), RunSummaryPhaseInput{
+		TaskID:  "task-summary",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	})
+	if err != nil {
+		t.Fatalf("RunSummaryPhase failed: %v", err)
+	}
+	if result.DialogueGroups != 2 || result.Quests != 1 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	dialogues := summary.preparedInput.DialogueItems
+	if dialogues[0].PlayerText == nil || *dialogues[0].PlayerText != "Who are you?" || len(dialogues[0].Lines) != 2 {
+		t.Fatalf("unexpected first dialogue item: %+v", dialogues[0])
+	}
+	if dialogues[1].PlayerText != nil {
+		t.Fatalf("empty player text must stay nil: %+v", dialogues[1])
+	}
+	stages := summary.preparedInput.QuestItems[0].StageTexts
+	if len(stages) != 2 || stages[1].Index != 20 || stages[1].Text != "Reach Riverwood." {
+		t.Fatalf("unexpected quest stages: %+v", stages)
+	}
+}
+
+func TestTranslationFlowServiceRunSummaryPhaseRequiresSlice(t *testing.T) {
+	service := &TranslationFlowService{store: &stubTranslationFlowStore{summarySource: newSummarySource()}}
+
+	_, err := service.RunSummaryPhase(context.Background(), RunSummaryPhaseInput{
+		TaskID:  "task-summary",
+		Request: TranslationRequestConfig{Model: "gemini-2.5-flash"},
+	})
+	if err == nil {
+		t.Fatal("RunSummaryPhase must fail without a summary slice")
+	}
+}
+
+func TestNewSummaryLookup(t *testing.T) {
+	summary := &stubSummarySlice{results: map[string]*summaryslice.SummaryResult{
+		"dg-1":  {SummaryText: "Lydia swears to serve."},
+		"MQ101": {SummaryText: "The player escaped Helgen."},
+		"dg-2":  {SummaryText: "  "},
+	}}
+	lookup := NewSummaryLookup(summary)
+
+	dialogue, err := lookup.FindDialogueSummary(context.Background(), "dg-1")
+	if err != nil || dialogue == nil || *dialogue != "Lydia swears to serve." {
+		t.Fatalf("unexpected dialogue summary: %v %v", dialogue, err)
+	}
+	quest, err := lookup.FindQuestSummary(context.Background(), "MQ101")
+	if err != nil || quest == nil || *quest != "The player escaped Helgen." {
+		t.Fatalf("unexpected quest summary: %v %v", quest, err)
+	}
+	for _, id := range []string{"dg-2", "dg-missing", ""} {
+		got, err := lookup.FindDialogueSummary(context.Background(), id)
+		if err != nil || got != nil {
+			t.Fatalf("expected no summary for %q, got %v %v", id, got, err)
+		}
+	}
+	want := []string{"Dialogue:dg-1", "Quest:MQ101", "Dialogue:dg-2", "Dialogue:dg-missing"}
+	if len(summary.lookups) != len(want) {
+		t.Fatalf("unexpected lookups: %v", summary.lookups)
+	}
+	for i := range want {
+		if summary.lookups[i] != want[i] {
+			t.Fatalf("unexpected lookups: %v", summary.lookups)
+		}
+	}
+}